@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -42,7 +41,6 @@ func main() {
 
 	// Create gRPC configuration for CLI
 	config := agenthub.NewGRPCConfig("chat_cli")
-	config.HealthPort = "8087" // Unique port for CLI health
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
@@ -65,7 +63,7 @@ func main() {
 	}
 
 	// Generate session ID for this CLI session
-	sessionID := fmt.Sprintf("cli_session_%d", time.Now().Unix())
+	sessionID := client.NewID("cli_session")
 
 	fmt.Println("╔════════════════════════════════════════════════════╗")
 	fmt.Println("║         Cortex Chat CLI - POC Demo                ║")
@@ -73,85 +71,39 @@ func main() {
 	fmt.Printf("\nSession ID: %s\n", sessionID)
 	fmt.Println("\nType your messages and press Enter.")
 	fmt.Println("Type 'exit' or 'quit' to end the session.")
-	fmt.Println("Press Ctrl+C to shutdown.\n")
+	fmt.Print("Press Ctrl+C to shutdown.\n\n")
 
-	// Subscribe to response messages
-	responseChan := make(chan *pb.Message, 10)
+	// Collect the chat responses and task results of this session
+	correlator := agenthub.NewResponseCorrelator(client, cliAgentID)
+	correlator.Accept = func(msg *pb.Message) bool {
+		taskType, _ := agenthub.MetadataString(msg.GetMetadata(), "task_type")
+		return taskType == "chat_response" || taskType == "task_result"
+	}
+	if err := correlator.Start(ctx); err != nil {
+		client.Logger.ErrorContext(ctx, "Failed to subscribe to messages", "error", err)
+		return
+	}
 
+	// Display incoming responses in a separate goroutine
 	go func() {
-		stream, err := client.Client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{
-			AgentId: cliAgentID,
-		})
-
-		if err != nil {
-			client.Logger.ErrorContext(ctx, "Failed to subscribe to messages", "error", err)
-			return
-		}
-
 		for {
-			event, err := stream.Recv()
+			msg, err := correlator.WaitFor(ctx, sessionID, 0)
 			if err != nil {
-				if err == io.EOF {
-					return
+				if ctx.Err() == nil {
+					client.Logger.ErrorContext(ctx, "Error receiving message", "error", err)
 				}
-				client.Logger.ErrorContext(ctx, "Error receiving message", "error", err)
 				return
 			}
-
-			// Process message events
-			if messageEvent := event.GetMessage(); messageEvent != nil {
-				// Only show messages for our session (or task results from any session)
-				isTaskResult := false
-				if messageEvent.Metadata != nil && messageEvent.Metadata.Fields != nil {
-					if taskType, exists := messageEvent.Metadata.Fields["task_type"]; exists {
-						if taskType.GetStringValue() == "task_result" {
-							isTaskResult = true
-						}
-					}
-				}
-
-				if (messageEvent.ContextId == sessionID || isTaskResult) && messageEvent.Role == pb.Role_ROLE_AGENT {
-					// Check if this is a chat response or task result
-					if messageEvent.Metadata != nil {
-						if taskType, exists := messageEvent.Metadata.Fields["task_type"]; exists {
-							taskTypeValue := taskType.GetStringValue()
-							if taskTypeValue == "chat_response" || taskTypeValue == "task_result" {
-								responseChan <- messageEvent
-							}
-						}
-					}
-				}
+			if len(msg.Content) == 0 {
+				continue
 			}
-		}
-	}()
+			responseText := msg.Content[0].GetText()
 
-	// Handle incoming responses in a separate goroutine
-	go func() {
-		for {
-			select {
-			case msg := <-responseChan:
-				if len(msg.Content) > 0 {
-					responseText := msg.Content[0].GetText()
-
-					// Check if this is a task result message
-					isTaskResult := false
-					if msg.Metadata != nil && msg.Metadata.Fields != nil {
-						if taskType, exists := msg.Metadata.Fields["task_type"]; exists {
-							if taskType.GetStringValue() == "task_result" {
-								isTaskResult = true
-							}
-						}
-					}
-
-					// Display task results in cyan color
-					if isTaskResult {
-						fmt.Printf("\n%s🤖 [Task Result] %s%s\n\n> ", colorCyan, responseText, colorReset)
-					} else {
-						fmt.Printf("\n🤖 Cortex: %s\n\n> ", responseText)
-					}
-				}
-			case <-ctx.Done():
-				return
+			// Display task results in cyan color
+			if taskType, _ := agenthub.MetadataString(msg.GetMetadata(), "task_type"); taskType == "task_result" {
+				fmt.Printf("\n%s🤖 [Task Result] %s%s\n\n> ", colorCyan, responseText, colorReset)
+			} else {
+				fmt.Printf("\n🤖 Cortex: %s\n\n> ", responseText)
 			}
 		}
 	}()
@@ -182,7 +134,7 @@ func main() {
 
 		// Create and send chat request with tracing
 		message := &pb.Message{
-			MessageId: fmt.Sprintf("cli_msg_%d", time.Now().UnixNano()),
+			MessageId: client.NewID("cli_msg"),
 			ContextId: sessionID,
 			Role:      pb.Role_ROLE_USER,
 			Content: []*pb.Part{
@@ -3,8 +3,8 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -45,7 +45,6 @@ func main() {
 
 	// Create gRPC configuration for REPL agent
 	config := agenthub.NewGRPCConfig("chat_repl")
-	config.HealthPort = "8083" // Unique port for REPL agent health
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
@@ -67,47 +66,12 @@ func main() {
 		panic(err)
 	}
 
-	// Channel to receive chat responses
-	responseChan := make(chan *pb.Message, 10)
-
-	// Start subscribing to messages in a goroutine
-	go func() {
-		// Subscribe to messages for this agent
-		stream, err := client.Client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{
-			AgentId: replAgentID,
-		})
-
-		if err != nil {
-			client.Logger.ErrorContext(ctx, "Failed to subscribe to messages", "error", err)
-			return
-		}
-
-		for {
-			event, err := stream.Recv()
-			if err != nil {
-				if err == io.EOF {
-					client.Logger.InfoContext(ctx, "Message stream ended")
-					break
-				}
-				client.Logger.ErrorContext(ctx, "Error receiving message", "error", err)
-				break
-			}
-
-			// Check if this is a message event from our chat responder
-			if messageEvent := event.GetMessage(); messageEvent != nil {
-				// Check if this is a response to our request
-				if messageEvent.Role == pb.Role_ROLE_AGENT {
-					select {
-					case responseChan <- messageEvent:
-					case <-ctx.Done():
-						return
-					default:
-						// Channel full, skip this message
-					}
-				}
-			}
-		}
-	}()
+	// Collect the responses sent to this agent
+	correlator := agenthub.NewResponseCorrelator(client, replAgentID)
+	if err := correlator.Start(ctx); err != nil {
+		client.Logger.ErrorContext(ctx, "Failed to subscribe to messages", "error", err)
+		return
+	}
 
 	client.Logger.InfoContext(ctx, "Chat REPL started")
 	fmt.Println("=== A2A-Compliant Chat REPL ===")
@@ -141,11 +105,11 @@ func main() {
 			}
 
 			// Create A2A-compliant context ID
-			contextID := fmt.Sprintf("chat_conversation_%d", time.Now().Unix())
+			contextID := client.NewID("chat_conversation")
 
 			// Create A2A-compliant message
 			message := &pb.Message{
-				MessageId: fmt.Sprintf("msg_chat_request_%d", time.Now().Unix()),
+				MessageId: client.NewID("msg_chat_request"),
 				ContextId: contextID,
 				Role:      pb.Role_ROLE_USER, // A2A spec: USER role for requests
 				Content: []*pb.Part{
@@ -222,89 +186,75 @@ func main() {
 
 			// Wait for response with timeout
 			fmt.Print("Waiting for response...")
-			select {
-			case response := <-responseChan:
-				// Start tracing for response processing
-				respCtx, respSpan := client.TraceManager.StartA2AMessageSpan(
-					ctx,
-					"process_chat_response",
-					response.GetMessageId(),
-					response.GetRole().String(),
-				)
-				defer respSpan.End()
-
-				// Add A2A attributes for response processing
-				client.TraceManager.AddA2AMessageAttributes(
-					respSpan,
-					response.GetMessageId(),
-					response.GetContextId(),
-					response.GetRole().String(),
-					"chat_response",
-					len(response.GetContent()),
-					response.GetMetadata() != nil,
-				)
-				client.TraceManager.AddComponentAttribute(respSpan, "chat_repl")
-
-				// Check if this is a task result message
-				isTaskResult := false
-				if response.Metadata != nil && response.Metadata.Fields != nil {
-					if taskType, exists := response.Metadata.Fields["task_type"]; exists {
-						if taskType.GetStringValue() == "task_result" {
-							isTaskResult = true
-						}
-					}
+			response, err := correlator.WaitFor(ctx, contextID, 30*time.Second)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
 				}
+				fmt.Print("\r")
+				if errors.Is(err, agenthub.ErrResponseTimeout) {
+					fmt.Printf("< [Timeout - no response received]\n\n")
+				} else {
+					fmt.Printf("< [Error: %v]\n\n", err)
+				}
+				continue
+			}
 
-				// Check if this response matches our context, or is a task result
-				if response.ContextId == contextID || isTaskResult {
-					client.TraceManager.AddSpanEvent(respSpan, "context_matched",
-						attribute.String("expected_context", contextID),
-						attribute.String("received_context", response.ContextId),
-						attribute.Bool("is_task_result", isTaskResult),
-					)
-					fmt.Print("\r")
-					if len(response.Content) > 0 && response.Content[0].GetText() != "" {
-						// Display task results in cyan color
-						if isTaskResult {
-							fmt.Printf("%s< [Task Result] %s%s\n\n", colorCyan, response.Content[0].GetText(), colorReset)
-							client.TraceManager.AddSpanEvent(respSpan, "task_result_displayed",
-								attribute.String("response_text", response.Content[0].GetText()),
-							)
-						} else {
-							fmt.Printf("< %s\n\n", response.Content[0].GetText())
-							client.TraceManager.AddSpanEvent(respSpan, "response_displayed",
-								attribute.String("response_text", response.Content[0].GetText()),
-							)
-						}
-					} else {
-						fmt.Printf("< [Empty response]\n\n")
-						client.TraceManager.AddSpanEvent(respSpan, "empty_response_received")
-					}
-					client.TraceManager.SetSpanSuccess(respSpan)
-					client.Logger.InfoContext(respCtx, "Processed chat response",
-						"response_message_id", response.GetMessageId(),
-						"context_id", response.GetContextId(),
-						"is_task_result", isTaskResult,
-						"trace_id", respSpan.SpanContext().TraceID().String(),
+			// Start tracing for response processing
+			respCtx, respSpan := client.TraceManager.StartA2AMessageSpan(
+				ctx,
+				"process_chat_response",
+				response.GetMessageId(),
+				response.GetRole().String(),
+			)
+			defer respSpan.End()
+
+			// Add A2A attributes for response processing
+			client.TraceManager.AddA2AMessageAttributes(
+				respSpan,
+				response.GetMessageId(),
+				response.GetContextId(),
+				response.GetRole().String(),
+				"chat_response",
+				len(response.GetContent()),
+				response.GetMetadata() != nil,
+			)
+			client.TraceManager.AddComponentAttribute(respSpan, "chat_repl")
+
+			// Check if this is a task result message
+			taskType, _ := agenthub.MetadataString(response.GetMetadata(), "task_type")
+			isTaskResult := taskType == "task_result"
+
+			client.TraceManager.AddSpanEvent(respSpan, "context_matched",
+				attribute.String("expected_context", contextID),
+				attribute.String("received_context", response.ContextId),
+				attribute.Bool("is_task_result", isTaskResult),
+			)
+			fmt.Print("\r")
+			if len(response.Content) > 0 && response.Content[0].GetText() != "" {
+				// Display task results in cyan color
+				if isTaskResult {
+					fmt.Printf("%s< [Task Result] %s%s\n\n", colorCyan, response.Content[0].GetText(), colorReset)
+					client.TraceManager.AddSpanEvent(respSpan, "task_result_displayed",
+						attribute.String("response_text", response.Content[0].GetText()),
 					)
 				} else {
-					client.TraceManager.AddSpanEvent(respSpan, "context_mismatch",
-						attribute.String("expected_context", contextID),
-						attribute.String("received_context", response.ContextId),
+					fmt.Printf("< %s\n\n", response.Content[0].GetText())
+					client.TraceManager.AddSpanEvent(respSpan, "response_displayed",
+						attribute.String("response_text", response.Content[0].GetText()),
 					)
-					// Put it back if it doesn't match (though this shouldn't happen with proper filtering)
-					select {
-					case responseChan <- response:
-					default:
-					}
-					fmt.Print(".")
 				}
-			case <-time.After(30 * time.Second):
-				fmt.Print("\r")
-				fmt.Printf("< [Timeout - no response received]\n\n")
-			case <-ctx.Done():
-				return
+			} else {
+				fmt.Printf("< [Empty response]\n\n")
+				client.TraceManager.AddSpanEvent(respSpan, "empty_response_received")
 			}
+			client.TraceManager.SetSpanSuccess(respSpan)
+			client.Logger.InfoContext(respCtx, "Processed chat response",
+				"response_message_id", response.GetMessageId(),
+				"context_id", response.GetContextId(),
+				"is_task_result", isTaskResult,
+				"trace_id", respSpan.SpanContext().TraceID().String(),
+			)
 		}
 	}
 }
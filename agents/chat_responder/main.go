@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/signal"
 	"syscall"
@@ -93,7 +92,6 @@ func main() {
 
 	// Create gRPC configuration for responder
 	config := agenthub.NewGRPCConfig("chat_responder")
-	config.HealthPort = "8084" // Unique port for responder agent health
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
@@ -115,48 +113,26 @@ func main() {
 		panic(err)
 	}
 
-	// Subscribe to messages for ChatCompletionRequest
-	go func() {
-		stream, err := client.Client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{
-			AgentId: responderAgentID,
-		})
-
-		if err != nil {
-			client.Logger.ErrorContext(ctx, "Failed to subscribe to messages", "error", err)
+	// Subscribe to messages for ChatCompletionRequest, re-subscribing if the broker restarts
+	subscription := agenthub.NewMessageSubscription(client, responderAgentID)
+	go subscription.Run(ctx, func(ctx context.Context, event *pb.AgentEvent) {
+		// Check if this is a message event from USER role (A2A spec)
+		messageEvent := event.GetMessage()
+		// A2A compliance: Only process messages with USER role
+		if messageEvent == nil || messageEvent.Role != pb.Role_ROLE_USER {
 			return
 		}
-
-		for {
-			event, err := stream.Recv()
-			if err != nil {
-				if err == io.EOF {
-					client.Logger.InfoContext(ctx, "Message stream ended")
-					break
-				}
-				client.Logger.ErrorContext(ctx, "Error receiving message", "error", err)
-				break
-			}
-
-			// Check if this is a message event from USER role (A2A spec)
-			if messageEvent := event.GetMessage(); messageEvent != nil {
-				// A2A compliance: Only process messages with USER role
-				if messageEvent.Role == pb.Role_ROLE_USER {
-					if messageEvent.Metadata != nil {
-						if taskType, exists := messageEvent.Metadata.Fields["task_type"]; exists {
-							if taskType.GetStringValue() == "chat_request" {
-								// Validate A2A message before processing
-								if err := validateA2AMessage(messageEvent); err != nil {
-									client.Logger.ErrorContext(ctx, "Invalid A2A message", "error", err)
-									continue
-								}
-								handleChatRequest(ctx, client, messageEvent)
-							}
-						}
-					}
-				}
-			}
+		if taskType, _ := agenthub.MetadataString(messageEvent.GetMetadata(), "task_type"); taskType != "chat_request" {
+			return
 		}
-	}()
+		// Validate A2A message before processing
+		if err := validateA2AMessage(messageEvent); err != nil {
+			client.Logger.ErrorContext(ctx, "Invalid A2A message", "error", err)
+			return
+		}
+		// The response carries the headers of the request
+		handleChatRequest(agenthub.ContextWithEvent(ctx, event), client, messageEvent)
+	})
 
 	client.Logger.InfoContext(ctx, "Starting Chat Responder")
 	client.Logger.InfoContext(ctx, "Subscribing to A2A chat_request messages from USER role")
@@ -242,7 +218,7 @@ func handleChatRequest(ctx context.Context, client *agenthub.AgentHubClient, mes
 
 	// Create A2A-compliant response message
 	responseMessage := &pb.Message{
-		MessageId: fmt.Sprintf("msg_chat_response_%d", time.Now().Unix()),
+		MessageId: client.NewID("msg_chat_response"),
 		ContextId: message.GetContextId(), // A2A spec: Same context for correlation
 		Role:      pb.Role_ROLE_AGENT,     // A2A spec: AGENT role for responses
 		Content: []*pb.Part{
@@ -297,6 +273,7 @@ func handleChatRequest(ctx context.Context, client *agenthub.AgentHubClient, mes
 			ToAgentId:   "", // Broadcast response for correlation matching
 			EventType:   "a2a.message.chat_response",
 			Priority:    pb.Priority_PRIORITY_MEDIUM,
+			Headers:     agenthub.HeadersFromContext(ctx),
 		},
 	})
 
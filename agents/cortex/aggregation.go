@@ -0,0 +1,78 @@
+package cortex
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// AggregationPolicy controls how the artifacts of a delegated task are delivered back to the user.
+type AggregationPolicy int
+
+const (
+	// AggregationStream forwards each artifact to the user as soon as it arrives.
+	AggregationStream AggregationPolicy = iota
+	// AggregationConcatenate buffers artifacts and sends their ordered concatenation once the task completes.
+	AggregationConcatenate
+	// AggregationSynthesize buffers artifacts and lets the LLM compose a response from all of them once the task completes.
+	AggregationSynthesize
+)
+
+// String returns the configuration name of the policy.
+func (p AggregationPolicy) String() string {
+	switch p {
+	case AggregationStream:
+		return "stream"
+	case AggregationConcatenate:
+		return "concatenate"
+	case AggregationSynthesize:
+		return "synthesize"
+	default:
+		return fmt.Sprintf("AggregationPolicy(%d)", int(p))
+	}
+}
+
+// ParseAggregationPolicy parses a policy name ("stream", "concatenate" or "synthesize").
+func ParseAggregationPolicy(name string) (AggregationPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "stream":
+		return AggregationStream, nil
+	case "concatenate":
+		return AggregationConcatenate, nil
+	case "synthesize":
+		return AggregationSynthesize, nil
+	default:
+		return AggregationStream, fmt.Errorf("unknown aggregation policy: %s", name)
+	}
+}
+
+// artifactText extracts the user-facing text of an artifact, prefixed by its
+// name when the artifact is described. It returns "" for artifacts without text.
+func artifactText(artifact *pb.Artifact) string {
+	var textParts []string
+	for _, part := range artifact.GetParts() {
+		if textPart := part.GetText(); textPart != "" {
+			textParts = append(textParts, textPart)
+		}
+	}
+
+	if len(textParts) == 0 {
+		return ""
+	}
+	if artifact.GetName() != "" && artifact.GetDescription() != "" {
+		return fmt.Sprintf("%s: %s", artifact.GetName(), strings.Join(textParts, "\n"))
+	}
+	return strings.Join(textParts, "\n")
+}
+
+// aggregateArtifacts concatenates the text of the artifacts in arrival order.
+func aggregateArtifacts(artifacts []*pb.Artifact) string {
+	var texts []string
+	for _, artifact := range artifacts {
+		if text := artifactText(artifact); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
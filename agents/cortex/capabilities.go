@@ -0,0 +1,92 @@
+package cortex
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// FindAgentsForTaskType returns the registered agents with a skill serving the task
+// type, ordered by agent ID. A skill serves a task type when its ID, name or one of
+// its tags equals the task type, ignoring case.
+func (c *Cortex) FindAgentsForTaskType(taskType string) []*pb.AgentCard {
+	c.agentsMu.RLock()
+	defer c.agentsMu.RUnlock()
+
+	agentIDs := c.agentIDsForTaskType(taskType)
+	cards := make([]*pb.AgentCard, 0, len(agentIDs))
+	for _, agentID := range agentIDs {
+		cards = append(cards, c.registeredAgents[agentID])
+	}
+	return cards
+}
+
+// agentIDsForTaskType returns the sorted IDs of the agents serving the task type.
+// The caller must hold agentsMu.
+func (c *Cortex) agentIDsForTaskType(taskType string) []string {
+	var agentIDs []string
+	for agentID, card := range c.registeredAgents {
+		if cardServesTaskType(card, taskType) {
+			agentIDs = append(agentIDs, agentID)
+		}
+	}
+	sort.Strings(agentIDs)
+	return agentIDs
+}
+
+// cardServesTaskType reports whether one of the card's skills serves the task type
+func cardServesTaskType(card *pb.AgentCard, taskType string) bool {
+	for _, skill := range card.GetSkills() {
+		if strings.EqualFold(skill.GetId(), taskType) || strings.EqualFold(skill.GetName(), taskType) {
+			return true
+		}
+		for _, tag := range skill.GetTags() {
+			if strings.EqualFold(tag, taskType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveTaskTarget checks that the agent chosen by the LLM serves the task type.
+// It returns the chosen agent when it does, otherwise the first agent that does,
+// and an error when no registered agent serves the task type.
+func (c *Cortex) resolveTaskTarget(targetAgent, taskType string) (string, error) {
+	c.agentsMu.RLock()
+	defer c.agentsMu.RUnlock()
+
+	if card, ok := c.registeredAgents[targetAgent]; ok && cardServesTaskType(card, taskType) {
+		return targetAgent, nil
+	}
+	if agentIDs := c.agentIDsForTaskType(taskType); len(agentIDs) > 0 {
+		return agentIDs[0], nil
+	}
+	return "", fmt.Errorf("no registered agent serves task type %q (requested agent: %q)", taskType, targetAgent)
+}
+
+// UpdateAgent registers the new card of an agent that registered again and returns
+// the task types of the skills it dropped that no registered agent serves anymore,
+// so that the caller can warn about them. Skills are identified by their ID, or
+// their name when they have none.
+func (c *Cortex) UpdateAgent(agentID string, card *pb.AgentCard, diff *pb.AgentCardDiff) []string {
+	c.agentsMu.Lock()
+	defer c.agentsMu.Unlock()
+
+	c.registeredAgents[agentID] = card
+
+	var orphaned []string
+	for _, skill := range diff.GetRemovedSkills() {
+		taskType := skill.GetId()
+		if taskType == "" {
+			taskType = skill.GetName()
+		}
+		if taskType != "" && len(c.agentIDsForTaskType(taskType)) == 0 {
+			orphaned = append(orphaned, taskType)
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned
+}
@@ -3,14 +3,18 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/owulveryck/agenthub/agents/cortex"
 	"github.com/owulveryck/agenthub/agents/cortex/llm"
+	"github.com/owulveryck/agenthub/agents/cortex/llm/anthropic"
+	"github.com/owulveryck/agenthub/agents/cortex/llm/openai"
 	"github.com/owulveryck/agenthub/agents/cortex/llm/vertexai"
 	"github.com/owulveryck/agenthub/agents/cortex/state"
 	pb "github.com/owulveryck/agenthub/events/a2a"
@@ -51,7 +55,6 @@ func main() {
 
 	// Create gRPC configuration for Cortex
 	config := agenthub.NewGRPCConfig("cortex")
-	config.HealthPort = "8086" // Unique port for Cortex health
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
@@ -87,144 +90,65 @@ func main() {
 	messagePublisher := &AgentHubMessagePublisher{client: client}
 
 	// Create Cortex instance
-	cortexInstance := cortex.NewCortex(stateManager, llmClient, messagePublisher, client.Logger)
+	cortexInstance := cortex.NewCortex(stateManager, llmClient, messagePublisher, client.Logger, llmOptionsFromEnv(ctx, client.Logger)...)
+	cortexInstance.SetMetricsManager(client.MetricsManager)
 
-	llmType := "mock"
-	if os.Getenv("GCP_PROJECT") != "" && os.Getenv("GCP_PROJECT") != "your-project" {
-		llmType = "vertexai"
+	aggregationPolicy, err := cortex.ParseAggregationPolicy(os.Getenv("CORTEX_AGGREGATION_POLICY"))
+	if err != nil {
+		client.Logger.ErrorContext(ctx, "Invalid aggregation policy, streaming artifacts", "error", err)
 	}
+	cortexInstance.SetAggregationPolicy(aggregationPolicy)
+	cortexInstance.SetStreamResponses(os.Getenv("CORTEX_STREAM_RESPONSES") == "true")
 
 	client.Logger.InfoContext(ctx, "Cortex initialized",
 		"agent_id", cortexAgentID,
-		"llm_client", llmType,
+		"llm_client", llmProvider(),
 		"state_manager", "in-memory",
 	)
 
-	// Subscribe to all messages to orchestrate
-	go func() {
-		stream, err := client.Client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{
-			AgentId: cortexAgentID,
-		})
-
-		if err != nil {
-			client.Logger.ErrorContext(ctx, "Failed to subscribe to messages", "error", err)
-			return
-		}
-
-		for {
-			event, err := stream.Recv()
-			if err != nil {
-				if err == io.EOF {
-					client.Logger.InfoContext(ctx, "Message stream ended")
-					break
-				}
-				client.Logger.ErrorContext(ctx, "Error receiving message", "error", err)
-				break
-			}
-
-			// Process message events
-			if messageEvent := event.GetMessage(); messageEvent != nil {
-				// Skip messages from Cortex itself to prevent infinite loops
-				// Cortex should only process USER messages and AGENT task results
-				if messageEvent.Metadata != nil && messageEvent.Metadata.Fields != nil {
-					if fromAgent, exists := messageEvent.Metadata.Fields["from_agent"]; exists {
-						if fromAgent.GetStringValue() == cortexAgentID {
-							// This is a message Cortex published, ignore it
-							continue
-						}
-					}
-				}
+	// Seed the registry with the agents that registered before Cortex started;
+	// later registrations arrive as agent events
+	seedAgentRegistry(ctx, client, cortexInstance)
 
-				// Extract parent trace context from the event for distributed tracing
-				eventCtx := ctx
-				if event.GetTraceId() != "" && event.GetSpanId() != "" {
-					// Create W3C traceparent header format: version-trace_id-span_id-flags
-					headers := map[string]string{
-						"traceparent": fmt.Sprintf("00-%s-%s-01", event.GetTraceId(), event.GetSpanId()),
-					}
-					eventCtx = client.TraceManager.ExtractTraceContext(ctx, headers)
-				}
+	// The subscriptions below re-subscribe with backoff when the broker restarts
 
-				handleMessage(eventCtx, client, cortexInstance, messageEvent)
-			}
-		}
-	}()
-
-	// Subscribe to agent events (including agent card registrations)
-	go func() {
-		stream, err := client.Client.SubscribeToAgentEvents(ctx, &pb.SubscribeToAgentEventsRequest{
-			AgentId:    cortexAgentID,
-			EventTypes: []string{"agent.registered", "agent.updated"},
-		})
-
-		if err != nil {
-			client.Logger.ErrorContext(ctx, "Failed to subscribe to agent events", "error", err)
+	// Subscribe to all messages to orchestrate
+	messages := agenthub.NewMessageSubscription(client, cortexAgentID)
+	go messages.Run(ctx, func(ctx context.Context, event *pb.AgentEvent) {
+		messageEvent := event.GetMessage()
+		if messageEvent == nil {
 			return
 		}
 
-		client.Logger.InfoContext(ctx, "Subscribed to agent registration events")
+		// Tasks dispatched for the message inherit its priority and headers
+		eventCtx := agenthub.ContextWithEvent(eventContext(ctx, client, event), event)
+		handleMessage(eventCtx, client, cortexInstance, messageEvent)
+	})
 
-		for {
-			event, err := stream.Recv()
-			if err != nil {
-				if err == io.EOF {
-					client.Logger.InfoContext(ctx, "Agent event stream ended")
-					break
-				}
-				client.Logger.ErrorContext(ctx, "Error receiving agent event", "error", err)
-				break
-			}
-
-			// Process agent card events
-			if agentCardEvent := event.GetAgentCard(); agentCardEvent != nil {
-				handleAgentCardEvent(ctx, client, cortexInstance, agentCardEvent)
-			}
+	// Subscribe to agent events (including agent card registrations)
+	agentEvents := agenthub.NewAgentEventSubscription(client, cortexAgentID, "agent.registered", "agent.updated")
+	go agentEvents.Run(ctx, func(ctx context.Context, event *pb.AgentEvent) {
+		// Process agent card events
+		if agentCardEvent := event.GetAgentCard(); agentCardEvent != nil {
+			handleAgentCardEvent(ctx, client, cortexInstance, agentCardEvent)
 		}
-	}()
+	})
 
 	// Subscribe to task updates to receive completions from delegated agents
-	go func() {
-		stream, err := client.Client.SubscribeToTasks(ctx, &pb.SubscribeToTasksRequest{
-			AgentId: cortexAgentID,
-		})
-
-		if err != nil {
-			client.Logger.ErrorContext(ctx, "Failed to subscribe to tasks", "error", err)
-			return
+	taskEvents := agenthub.NewTaskEventSubscription(client, cortexAgentID)
+	go taskEvents.Run(ctx, func(ctx context.Context, event *pb.AgentEvent) {
+		// Follow-up tasks keep the priority and headers of the completed one
+		eventCtx := agenthub.ContextWithEvent(eventContext(ctx, client, event), event)
+
+		// Process task completion events
+		if statusUpdate := event.GetStatusUpdate(); statusUpdate != nil {
+			handleTaskStatusUpdate(eventCtx, client, cortexInstance, statusUpdate)
 		}
 
-		client.Logger.InfoContext(ctx, "Subscribed to task updates")
-
-		for {
-			event, err := stream.Recv()
-			if err != nil {
-				if err == io.EOF {
-					client.Logger.InfoContext(ctx, "Task event stream ended")
-					break
-				}
-				client.Logger.ErrorContext(ctx, "Error receiving task event", "error", err)
-				break
-			}
-
-			// Extract trace context from event
-			eventCtx := ctx
-			if event.GetTraceId() != "" && event.GetSpanId() != "" {
-				headers := map[string]string{
-					"traceparent": fmt.Sprintf("00-%s-%s-01", event.GetTraceId(), event.GetSpanId()),
-				}
-				eventCtx = client.TraceManager.ExtractTraceContext(ctx, headers)
-			}
-
-			// Process task completion events
-			if statusUpdate := event.GetStatusUpdate(); statusUpdate != nil {
-				handleTaskStatusUpdate(eventCtx, client, cortexInstance, statusUpdate)
-			}
-
-			if artifactUpdate := event.GetArtifactUpdate(); artifactUpdate != nil {
-				handleTaskArtifactUpdate(eventCtx, client, cortexInstance, artifactUpdate)
-			}
+		if artifactUpdate := event.GetArtifactUpdate(); artifactUpdate != nil {
+			handleTaskArtifactUpdate(eventCtx, client, cortexInstance, artifactUpdate)
 		}
-	}()
+	})
 
 	client.Logger.InfoContext(ctx, "Starting Cortex Orchestrator")
 	client.Logger.InfoContext(ctx, "Cortex is ready to orchestrate conversations and tasks")
@@ -239,6 +163,18 @@ func main() {
 }
 
 // handleMessage processes incoming messages through Cortex
+// eventContext continues the trace of the event, for distributed tracing
+func eventContext(ctx context.Context, client *agenthub.AgentHubClient, event *pb.AgentEvent) context.Context {
+	if event.GetTraceId() == "" || event.GetSpanId() == "" {
+		return ctx
+	}
+	// Create W3C traceparent header format: version-trace_id-span_id-flags
+	headers := map[string]string{
+		"traceparent": fmt.Sprintf("00-%s-%s-01", event.GetTraceId(), event.GetSpanId()),
+	}
+	return client.TraceManager.ExtractTraceContext(ctx, headers)
+}
+
 func handleMessage(ctx context.Context, client *agenthub.AgentHubClient, cortexInstance *cortex.Cortex, message *pb.Message) {
 	// Start tracing for Cortex message handling
 	handlerCtx, handlerSpan := client.TraceManager.StartA2AMessageSpan(
@@ -250,12 +186,7 @@ func handleMessage(ctx context.Context, client *agenthub.AgentHubClient, cortexI
 	defer handlerSpan.End()
 
 	// Add comprehensive A2A attributes for message handling
-	taskType := ""
-	if message.Metadata != nil && message.Metadata.Fields != nil {
-		if taskTypeValue, exists := message.Metadata.Fields["task_type"]; exists {
-			taskType = taskTypeValue.GetStringValue()
-		}
-	}
+	taskType, _ := agenthub.MetadataString(message.GetMetadata(), "task_type")
 	client.TraceManager.AddA2AMessageAttributes(
 		handlerSpan,
 		message.GetMessageId(),
@@ -276,16 +207,12 @@ func handleMessage(ctx context.Context, client *agenthub.AgentHubClient, cortexI
 	)
 
 	// Check if this is an agent card registration
-	if message.Metadata != nil {
-		if msgType, exists := message.Metadata.Fields["message_type"]; exists {
-			if msgType.GetStringValue() == "agent_card" {
-				// TODO: Extract agent card from message and register
-				client.Logger.InfoContext(handlerCtx, "Agent card registration received (not yet implemented)")
-				client.TraceManager.AddSpanEvent(handlerSpan, "agent_card_registration_skipped")
-				client.TraceManager.SetSpanSuccess(handlerSpan)
-				return
-			}
-		}
+	if msgType, _ := agenthub.MetadataString(message.GetMetadata(), "message_type"); msgType == "agent_card" {
+		// TODO: Extract agent card from message and register
+		client.Logger.InfoContext(handlerCtx, "Agent card registration received (not yet implemented)")
+		client.TraceManager.AddSpanEvent(handlerSpan, "agent_card_registration_skipped")
+		client.TraceManager.SetSpanSuccess(handlerSpan)
+		return
 	}
 
 	// Process the message through Cortex
@@ -308,7 +235,23 @@ func handleMessage(ctx context.Context, client *agenthub.AgentHubClient, cortexI
 	)
 }
 
-// handleAgentCardEvent processes agent registration/update events
+// seedAgentRegistry registers with Cortex the agents already known to the broker
+func seedAgentRegistry(ctx context.Context, client *agenthub.AgentHubClient, cortexInstance *cortex.Cortex) {
+	agents, err := client.ListAgents(ctx)
+	if err != nil {
+		client.Logger.WarnContext(ctx, "Failed to list registered agents, relying on agent events", "error", err)
+		return
+	}
+
+	for _, card := range agents {
+		cortexInstance.RegisterAgent(card.GetName(), card)
+	}
+	client.Logger.InfoContext(ctx, "Seeded agent registry from broker",
+		"total_agents", len(agents),
+	)
+}
+
+// handleAgentCardEvent processes agent registration/update/deregistration events
 func handleAgentCardEvent(ctx context.Context, client *agenthub.AgentHubClient, cortexInstance *cortex.Cortex, cardEvent *pb.AgentCardEvent) {
 	agentID := cardEvent.GetAgentId()
 	agentCard := cardEvent.GetAgentCard()
@@ -322,8 +265,36 @@ func handleAgentCardEvent(ctx context.Context, client *agenthub.AgentHubClient,
 		"skills_count", len(agentCard.GetSkills()),
 	)
 
+	// Departed agents must no longer be offered to the LLM
+	if eventType == "deregistered" {
+		cortexInstance.DeregisterAgent(agentID)
+		client.Logger.InfoContext(ctx, "Agent removed from Cortex orchestrator",
+			"agent_id", agentID,
+			"total_agents", len(cortexInstance.GetAvailableAgents()),
+		)
+		return
+	}
+
 	// Register the agent with Cortex
-	cortexInstance.RegisterAgent(agentID, agentCard)
+	if eventType == "updated" {
+		diff := cardEvent.GetDiff()
+		orphaned := cortexInstance.UpdateAgent(agentID, agentCard, diff)
+		client.Logger.InfoContext(ctx, "Agent card updated",
+			"agent_id", agentID,
+			"added_skills", skillNames(diff.GetAddedSkills()),
+			"removed_skills", skillNames(diff.GetRemovedSkills()),
+			"changed_skills", skillNames(diff.GetChangedSkills()),
+			"changed_fields", diff.GetChangedFields(),
+		)
+		if len(orphaned) > 0 {
+			client.Logger.WarnContext(ctx, "Task types no longer served by any agent",
+				"agent_id", agentID,
+				"task_types", orphaned,
+			)
+		}
+	} else {
+		cortexInstance.RegisterAgent(agentID, agentCard)
+	}
 
 	// Log the skills for visibility
 	if len(agentCard.GetSkills()) > 0 {
@@ -345,6 +316,15 @@ func handleAgentCardEvent(ctx context.Context, client *agenthub.AgentHubClient,
 	)
 }
 
+// skillNames returns the names of skills, for logging
+func skillNames(skills []*pb.AgentSkill) []string {
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		names = append(names, skill.GetName())
+	}
+	return names
+}
+
 // handleTaskStatusUpdate processes task status completion events
 func handleTaskStatusUpdate(ctx context.Context, client *agenthub.AgentHubClient, cortexInstance *cortex.Cortex, statusUpdate *pb.TaskStatusUpdateEvent) {
 	taskID := statusUpdate.GetTaskId()
@@ -381,13 +361,23 @@ func handleTaskArtifactUpdate(ctx context.Context, client *agenthub.AgentHubClie
 	cortexInstance.HandleTaskArtifact(ctx, taskID, contextID, artifact)
 }
 
-// createLLMClient creates the LLM client based on configuration
-// Uses VertexAI when GCP_PROJECT is set, otherwise falls back to mock
+// llmProvider returns the LLM provider selected by LLM_PROVIDER: mock, vertexai,
+// openai or anthropic. When unset, VertexAI is used if GCP_PROJECT is set and
+// the mock otherwise.
+func llmProvider() string {
+	if provider := strings.ToLower(os.Getenv("LLM_PROVIDER")); provider != "" {
+		return provider
+	}
+	if gcpProject := os.Getenv("GCP_PROJECT"); gcpProject != "" && gcpProject != "your-project" {
+		return "vertexai"
+	}
+	return "mock"
+}
+
+// createLLMClient creates the LLM client of the provider selected by llmProvider
 func createLLMClient(ctx context.Context) (llm.Client, error) {
-	// Check if VertexAI configuration is available
-	gcpProject := os.Getenv("GCP_PROJECT")
-	if gcpProject != "" && gcpProject != "your-project" {
-		// Create VertexAI client
+	switch provider := llmProvider(); provider {
+	case "vertexai":
 		config := vertexai.NewConfigFromEnv()
 		fmt.Printf("Initializing VertexAI client (project: %s, location: %s, model: %s)\n",
 			config.Project, config.Location, config.Model)
@@ -398,9 +388,81 @@ func createLLMClient(ctx context.Context) (llm.Client, error) {
 		}
 		fmt.Println("VertexAI client initialized successfully")
 		return client, nil
+
+	case "openai":
+		config := openai.NewConfigFromEnv()
+		fmt.Printf("Initializing OpenAI client (model: %s, base URL: %s)\n", config.Model, config.BaseURL)
+
+		client, err := openai.NewClient(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OpenAI client: %w", err)
+		}
+		return client, nil
+
+	case "anthropic":
+		config := anthropic.NewConfigFromEnv()
+		fmt.Printf("Initializing Anthropic client (model: %s, base URL: %s)\n", config.Model, config.BaseURL)
+
+		client, err := anthropic.NewClient(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Anthropic client: %w", err)
+		}
+		return client, nil
+
+	case "mock":
+		// Mock for development
+		fmt.Println("Using mock LLM client (set LLM_PROVIDER to vertexai, openai or anthropic to use a real LLM)")
+		return llm.NewMockClientWithFunc(llm.IntelligentDecider()), nil
+
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER %q: expected mock, vertexai, openai or anthropic", provider)
 	}
+}
 
-	// Fall back to mock for development
-	fmt.Println("Using mock LLM client (set GCP_PROJECT to use VertexAI)")
-	return llm.NewMockClientWithFunc(llm.IntelligentDecider()), nil
+// llmOptionsFromEnv reads the LLM timeout, retry and history summarization
+// settings, keeping the Cortex defaults for unset or malformed values.
+func llmOptionsFromEnv(ctx context.Context, logger *slog.Logger) []cortex.Option {
+	timeout, maxRetries, backoff := cortex.DefaultLLMTimeout, cortex.DefaultLLMMaxRetries, cortex.DefaultLLMRetryBackoff
+	if value := os.Getenv("CORTEX_LLM_TIMEOUT"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			timeout = parsed
+		} else {
+			logger.ErrorContext(ctx, "Invalid CORTEX_LLM_TIMEOUT, using default", "value", value, "error", err)
+		}
+	}
+	if value := os.Getenv("CORTEX_LLM_MAX_RETRIES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			maxRetries = parsed
+		} else {
+			logger.ErrorContext(ctx, "Invalid CORTEX_LLM_MAX_RETRIES, using default", "value", value, "error", err)
+		}
+	}
+	if value := os.Getenv("CORTEX_LLM_RETRY_BACKOFF"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			backoff = parsed
+		} else {
+			logger.ErrorContext(ctx, "Invalid CORTEX_LLM_RETRY_BACKOFF, using default", "value", value, "error", err)
+		}
+	}
+	maxMessages, maxTokens, keepRecent := cortex.DefaultSummarizeMaxMessages, cortex.DefaultSummarizeMaxTokens, cortex.DefaultSummarizeKeepRecent
+	for name, setting := range map[string]*int{
+		"CORTEX_SUMMARIZE_MAX_MESSAGES": &maxMessages,
+		"CORTEX_SUMMARIZE_MAX_TOKENS":   &maxTokens,
+		"CORTEX_SUMMARIZE_KEEP_RECENT":  &keepRecent,
+	} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			*setting = parsed
+		} else {
+			logger.ErrorContext(ctx, "Invalid "+name+", using default", "value", value, "error", err)
+		}
+	}
+	return []cortex.Option{
+		cortex.WithLLMTimeout(timeout),
+		cortex.WithLLMRetry(maxRetries, backoff),
+		cortex.WithSummarization(maxMessages, maxTokens, keepRecent),
+	}
 }
@@ -4,13 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/owulveryck/agenthub/agents/cortex/llm"
 	"github.com/owulveryck/agenthub/agents/cortex/state"
 	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
 	"github.com/owulveryck/agenthub/internal/observability"
 	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -34,26 +34,82 @@ type Cortex struct {
 	llmClient        llm.Client
 	messagePublisher MessagePublisher
 	logger           *slog.Logger
+	metricsManager   *observability.MetricsManager // Optional, nil disables metrics
+	aggregation      AggregationPolicy
+	streamResponses  bool
+	llmTimeout       time.Duration
+	llmMaxRetries    int
+	llmRetryBackoff  time.Duration
 	registeredAgents map[string]*pb.AgentCard
 	agentsMu         sync.RWMutex
+	ids              agenthub.IDGenerator
+
+	// History summarization thresholds, see WithSummarization
+	summarizeMaxMessages int
+	summarizeMaxTokens   int
+	summarizeKeepRecent  int
 }
 
 // NewCortex creates a new Cortex instance.
+// LLM calls are bounded by DefaultLLMTimeout and retried DefaultLLMMaxRetries
+// times unless WithLLMTimeout or WithLLMRetry say otherwise. Long conversations
+// are summarized with the default thresholds unless WithSummarization says otherwise.
 func NewCortex(
 	stateManager state.StateManager,
 	llmClient llm.Client,
 	messagePublisher MessagePublisher,
 	logger *slog.Logger,
+	opts ...Option,
 ) *Cortex {
-	return &Cortex{
+	c := &Cortex{
 		stateManager:     stateManager,
 		llmClient:        llmClient,
 		messagePublisher: messagePublisher,
 		logger:           logger,
+		llmTimeout:       DefaultLLMTimeout,
+		llmMaxRetries:    DefaultLLMMaxRetries,
+		llmRetryBackoff:  DefaultLLMRetryBackoff,
 		registeredAgents: make(map[string]*pb.AgentCard),
+		ids:              agenthub.DefaultIDGenerator,
+
+		summarizeMaxMessages: DefaultSummarizeMaxMessages,
+		summarizeMaxTokens:   DefaultSummarizeMaxTokens,
+		summarizeKeepRecent:  DefaultSummarizeKeepRecent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithIDGenerator sets the generator of the task and message IDs Cortex mints,
+// instead of agenthub.DefaultIDGenerator
+func WithIDGenerator(ids agenthub.IDGenerator) Option {
+	return func(c *Cortex) {
+		if ids != nil {
+			c.ids = ids
+		}
 	}
 }
 
+// SetMetricsManager enables emission of orchestration metrics such as
+// cortex_actions_total. Passing nil disables them.
+func (c *Cortex) SetMetricsManager(mm *observability.MetricsManager) {
+	c.metricsManager = mm
+}
+
+// SetAggregationPolicy selects how task artifacts are delivered to the user.
+// The default is AggregationStream.
+func (c *Cortex) SetAggregationPolicy(policy AggregationPolicy) {
+	c.aggregation = policy
+}
+
+// SetStreamResponses enables publishing the chat response text as the LLM
+// generates it, as chat_response_delta messages preceding the final response.
+func (c *Cortex) SetStreamResponses(enabled bool) {
+	c.streamResponses = enabled
+}
+
 // RegisterAgent registers an agent's capabilities with Cortex.
 // This is called when an AgentCard is received.
 func (c *Cortex) RegisterAgent(agentID string, card *pb.AgentCard) {
@@ -63,6 +119,15 @@ func (c *Cortex) RegisterAgent(agentID string, card *pb.AgentCard) {
 	c.registeredAgents[agentID] = card
 }
 
+// DeregisterAgent forgets an agent, so that it is no longer offered to the LLM.
+// This is called when an agent deregisters from the broker.
+func (c *Cortex) DeregisterAgent(agentID string) {
+	c.agentsMu.Lock()
+	defer c.agentsMu.Unlock()
+
+	delete(c.registeredAgents, agentID)
+}
+
 // GetAvailableAgents returns a list of all registered agents.
 func (c *Cortex) GetAvailableAgents() []*pb.AgentCard {
 	c.agentsMu.RLock()
@@ -94,7 +159,8 @@ func (c *Cortex) HandleMessage(ctx context.Context, traceManager *observability.
 	// Use WithLock to ensure thread-safe state updates
 	return c.stateManager.WithLock(sessionID, func(conversationState *state.ConversationState) error {
 		// Add the incoming message to conversation history
-		conversationState.Messages = append(conversationState.Messages, msg)
+		conversationState.AppendMessage(msg)
+		c.summarizeIfNeeded(ctx, traceManager, conversationState)
 
 		// Check if this is a task result
 		if msg.TaskId != "" && msg.Role == pb.Role_ROLE_AGENT {
@@ -112,7 +178,7 @@ func (c *Cortex) handleChatRequest(ctx context.Context, traceManager *observabil
 	reqCtx, reqSpan := traceManager.StartSpan(ctx, "cortex.chat_request",
 		attribute.String("session_id", conversationState.SessionID),
 		attribute.String("message_id", msg.GetMessageId()),
-		attribute.Int("message_history_count", len(conversationState.Messages)),
+		attribute.Int("message_history_count", conversationState.MessageCount()),
 	)
 	defer reqSpan.End()
 
@@ -128,12 +194,12 @@ func (c *Cortex) handleChatRequest(ctx context.Context, traceManager *observabil
 	llmCtx, llmSpan := traceManager.StartSpan(reqCtx, "cortex.llm_decide",
 		attribute.String("message_id", msg.GetMessageId()),
 		attribute.Int("available_agents", len(availableAgents)),
-		attribute.Int("conversation_history_length", len(conversationState.Messages)),
+		attribute.Int("conversation_history_length", conversationState.MessageCount()),
 	)
 
 	// Log LLM input details
 	traceManager.AddSpanEvent(llmSpan, "llm_input_prepared",
-		attribute.Int("history_messages", len(conversationState.Messages)),
+		attribute.Int("history_messages", conversationState.MessageCount()),
 		attribute.Int("available_agents", len(availableAgents)),
 		attribute.String("new_message_role", msg.GetRole().String()),
 	)
@@ -150,7 +216,7 @@ func (c *Cortex) handleChatRequest(ctx context.Context, traceManager *observabil
 		)
 	}
 
-	decision, err := c.llmClient.Decide(llmCtx, conversationState.Messages, availableAgents, msg)
+	decision, err := c.decideWithRetry(llmCtx, traceManager, llmSpan, LLMOperationDecide, conversationState, availableAgents, msg)
 	if err != nil {
 		traceManager.RecordError(llmSpan, err)
 		traceManager.RecordError(reqSpan, err)
@@ -158,7 +224,7 @@ func (c *Cortex) handleChatRequest(ctx context.Context, traceManager *observabil
 			attribute.String("error", err.Error()),
 		)
 		llmSpan.End()
-		return fmt.Errorf("LLM decision failed: %w", err)
+		return c.sendFallbackResponse(reqCtx, traceManager, conversationState, msg, err)
 	}
 
 	// Log detailed LLM decision output
@@ -216,10 +282,10 @@ func (c *Cortex) handleTaskResult(ctx context.Context, traceManager *observabili
 	traceManager.AddComponentAttribute(resSpan, "cortex_orchestrator")
 
 	// Remove the task from pending tasks
-	delete(conversationState.PendingTasks, msg.TaskId)
+	conversationState.CompletePendingTask(msg.TaskId)
 	traceManager.AddSpanEvent(resSpan, "task_completed",
 		attribute.String("task_id", msg.GetTaskId()),
-		attribute.Int("remaining_tasks", len(conversationState.PendingTasks)),
+		attribute.Int("remaining_tasks", conversationState.PendingTaskCount()),
 	)
 
 	// Get available agents
@@ -229,15 +295,15 @@ func (c *Cortex) handleTaskResult(ctx context.Context, traceManager *observabili
 	llmCtx, llmSpan := traceManager.StartSpan(resCtx, "cortex.llm_synthesize",
 		attribute.String("task_id", msg.GetTaskId()),
 		attribute.Int("available_agents", len(availableAgents)),
-		attribute.Int("conversation_history_length", len(conversationState.Messages)),
-		attribute.Int("remaining_pending_tasks", len(conversationState.PendingTasks)),
+		attribute.Int("conversation_history_length", conversationState.MessageCount()),
+		attribute.Int("remaining_pending_tasks", conversationState.PendingTaskCount()),
 	)
 
 	// Log LLM synthesis input details
 	traceManager.AddSpanEvent(llmSpan, "llm_synthesis_input_prepared",
 		attribute.String("task_id", msg.GetTaskId()),
-		attribute.Int("history_messages", len(conversationState.Messages)),
-		attribute.Int("remaining_tasks", len(conversationState.PendingTasks)),
+		attribute.Int("history_messages", conversationState.MessageCount()),
+		attribute.Int("remaining_tasks", conversationState.PendingTaskCount()),
 		attribute.String("result_role", msg.GetRole().String()),
 	)
 
@@ -251,7 +317,7 @@ func (c *Cortex) handleTaskResult(ctx context.Context, traceManager *observabili
 		)
 	}
 
-	decision, err := c.llmClient.Decide(llmCtx, conversationState.Messages, availableAgents, msg)
+	decision, err := c.decideWithRetry(llmCtx, traceManager, llmSpan, LLMOperationSynthesize, conversationState, availableAgents, msg)
 	if err != nil {
 		traceManager.RecordError(llmSpan, err)
 		traceManager.RecordError(resSpan, err)
@@ -260,7 +326,7 @@ func (c *Cortex) handleTaskResult(ctx context.Context, traceManager *observabili
 			attribute.String("error", err.Error()),
 		)
 		llmSpan.End()
-		return fmt.Errorf("LLM decision failed: %w", err)
+		return c.sendFallbackResponse(resCtx, traceManager, conversationState, msg, err)
 	}
 
 	// Log detailed LLM synthesis output
@@ -305,22 +371,101 @@ func (c *Cortex) handleTaskResult(ctx context.Context, traceManager *observabili
 	return nil
 }
 
-// executeActions executes the actions decided by the LLM.
+// decide asks the LLM what to do about msg. When response streaming is enabled,
+// the chat response text is published to the session while it is generated.
+func (c *Cortex) decide(ctx context.Context, conversationState *state.ConversationState, availableAgents []*pb.AgentCard, msg *pb.Message) (*llm.Decision, error) {
+	if !c.streamResponses {
+		return c.llmClient.Decide(ctx, conversationState.Messages(), availableAgents, msg)
+	}
+
+	chunks, err := c.llmClient.DecideStream(ctx, conversationState.Messages(), availableAgents, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	streamID := c.ids.NewID("cortex_stream")
+	sequence := 0
+	var decision *llm.Decision
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			if sequence > 0 {
+				return nil, fmt.Errorf("%w (%d deltas of stream %s): %w", errResponseStreamed, sequence, streamID, chunk.Err)
+			}
+			return nil, chunk.Err
+		}
+		if chunk.Text != "" {
+			// Deltas are best effort, the final response is always published
+			if err := c.publishChatDelta(ctx, conversationState, msg, streamID, sequence, chunk.Text); err != nil {
+				c.logger.WarnContext(ctx, "Failed to publish chat response delta",
+					"stream_id", streamID,
+					"error", err,
+				)
+			}
+			sequence++
+		}
+		if chunk.Decision != nil {
+			decision = chunk.Decision
+		}
+	}
+	if decision == nil {
+		if sequence > 0 {
+			return nil, fmt.Errorf("%w: stream %s ended without a decision", errResponseStreamed, streamID)
+		}
+		return nil, fmt.Errorf("decision stream ended without a decision")
+	}
+	return decision, nil
+}
+
+// publishChatDelta publishes a fragment of a chat response being generated.
+// Deltas are not recorded in the conversation history.
+func (c *Cortex) publishChatDelta(ctx context.Context, conversationState *state.ConversationState, triggeringMsg *pb.Message, streamID string, sequence int, text string) error {
+	deltaMsg := &pb.Message{
+		MessageId: fmt.Sprintf("%s_%d", streamID, sequence),
+		ContextId: conversationState.SessionID,
+		Role:      pb.Role_ROLE_AGENT,
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: text}},
+		},
+		Metadata: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"task_type":           structpb.NewStringValue("chat_response_delta"),
+				"from_agent":          structpb.NewStringValue(CortexAgentID),
+				"original_message_id": structpb.NewStringValue(triggeringMsg.MessageId),
+				"stream_id":           structpb.NewStringValue(streamID),
+				"sequence":            structpb.NewNumberValue(float64(sequence)),
+			},
+		},
+	}
+
+	routing := &pb.AgentEventMetadata{
+		FromAgentId: CortexAgentID,
+		EventType:   "a2a.message.chat_response.delta",
+		Priority:    pb.Priority_PRIORITY_MEDIUM,
+		ExcludeSelf: true,
+		Headers:     agenthub.HeadersFromContext(ctx),
+	}
+
+	return c.messagePublisher.PublishMessage(ctx, deltaMsg, routing)
+}
+
+// executeActions validates, then executes the actions decided by the LLM.
 func (c *Cortex) executeActions(ctx context.Context, traceManager *observability.TraceManager, conversationState *state.ConversationState, actions []llm.Action, triggeringMsg *pb.Message) error {
 	actCtx, actSpan := traceManager.StartSpan(ctx, "cortex.execute_actions",
 		attribute.Int("action_count", len(actions)),
 		attribute.String("session_id", conversationState.SessionID),
 		attribute.String("triggering_message_id", triggeringMsg.GetMessageId()),
-		attribute.Int("pending_tasks_count", len(conversationState.PendingTasks)),
+		attribute.Int("pending_tasks_count", conversationState.PendingTaskCount()),
 	)
 	defer actSpan.End()
 
 	traceManager.AddComponentAttribute(actSpan, "cortex_orchestrator")
 
+	actions = c.validateActions(actCtx, traceManager, actSpan, actions)
+
 	// Log execution plan
 	traceManager.AddSpanEvent(actSpan, "execution_plan_started",
 		attribute.Int("total_actions", len(actions)),
-		attribute.Int("current_pending_tasks", len(conversationState.PendingTasks)),
+		attribute.Int("current_pending_tasks", conversationState.PendingTaskCount()),
 	)
 
 	for i, action := range actions {
@@ -344,7 +489,11 @@ func (c *Cortex) executeActions(ctx context.Context, traceManager *observability
 
 		traceManager.AddSpanEvent(actSpan, "executing_action", actionAttrs...)
 
-		// Execute the action
+		if c.metricsManager != nil {
+			c.metricsManager.IncrementCortexActions(actCtx, action.Type, action.TargetAgent)
+		}
+
+		// Execute the action, of a type validateActions accepts
 		switch action.Type {
 		case "chat.response":
 			if err := c.executeChatResponse(actCtx, traceManager, conversationState, action, triggeringMsg); err != nil {
@@ -380,21 +529,13 @@ func (c *Cortex) executeActions(ctx context.Context, traceManager *observability
 				attribute.String("target_agent", action.TargetAgent),
 			)
 
-		default:
-			err := fmt.Errorf("unknown action type: %s", action.Type)
-			traceManager.RecordError(actSpan, err)
-			traceManager.AddSpanEvent(actSpan, "unknown_action_type",
-				attribute.Int("action_index", i),
-				attribute.String("action_type", action.Type),
-			)
-			return err
 		}
 	}
 
 	// Log execution completion
 	traceManager.AddSpanEvent(actSpan, "execution_plan_completed",
 		attribute.Int("actions_executed", len(actions)),
-		attribute.Int("final_pending_tasks", len(conversationState.PendingTasks)),
+		attribute.Int("final_pending_tasks", conversationState.PendingTaskCount()),
 	)
 
 	traceManager.SetSpanSuccess(actSpan)
@@ -414,7 +555,7 @@ func (c *Cortex) executeChatResponse(ctx context.Context, traceManager *observab
 
 	// Create response message
 	responseMsg := &pb.Message{
-		MessageId: fmt.Sprintf("cortex_response_%d", time.Now().UnixNano()),
+		MessageId: c.ids.NewID("cortex_response"),
 		ContextId: conversationState.SessionID,
 		Role:      pb.Role_ROLE_AGENT,
 		Content: []*pb.Part{
@@ -435,13 +576,15 @@ func (c *Cortex) executeChatResponse(ctx context.Context, traceManager *observab
 	)
 
 	// Add to conversation history
-	conversationState.Messages = append(conversationState.Messages, responseMsg)
+	conversationState.AppendMessage(responseMsg)
 
 	// Publish the message (trace context automatically propagated via respCtx)
 	routing := &pb.AgentEventMetadata{
 		FromAgentId: CortexAgentID,
 		EventType:   "a2a.message.chat_response",
 		Priority:    pb.Priority_PRIORITY_MEDIUM,
+		ExcludeSelf: true,
+		Headers:     agenthub.HeadersFromContext(ctx),
 	}
 
 	err := c.messagePublisher.PublishMessage(respCtx, responseMsg, routing)
@@ -458,9 +601,10 @@ func (c *Cortex) executeChatResponse(ctx context.Context, traceManager *observab
 	return nil
 }
 
-// executeTaskRequest dispatches a task request to an agent.
+// executeTaskRequest dispatches a task request to an agent. Its target has been
+// checked, and re-routed if needed, by validateActions.
 func (c *Cortex) executeTaskRequest(ctx context.Context, traceManager *observability.TraceManager, conversationState *state.ConversationState, action llm.Action, triggeringMsg *pb.Message) error {
-	taskID := fmt.Sprintf("task_%d", time.Now().UnixNano())
+	taskID := c.ids.NewID("task")
 
 	// Start tracing for task request execution
 	taskCtx, taskSpan := traceManager.StartSpan(ctx, "cortex.dispatch_task",
@@ -475,7 +619,7 @@ func (c *Cortex) executeTaskRequest(ctx context.Context, traceManager *observabi
 
 	// Create task request message
 	taskMsg := &pb.Message{
-		MessageId: fmt.Sprintf("task_request_%d", time.Now().UnixNano()),
+		MessageId: c.ids.NewID("task_request"),
 		ContextId: conversationState.SessionID,
 		TaskId:    taskID,
 		Role:      pb.Role_ROLE_AGENT,
@@ -490,6 +634,24 @@ func (c *Cortex) executeTaskRequest(ctx context.Context, traceManager *observabi
 			},
 		},
 	}
+	// Arguments of a structured tool call travel with the task
+	if len(action.TaskPayload) > 0 {
+		payload, err := structpb.NewStruct(action.TaskPayload)
+		if err != nil {
+			traceManager.RecordError(taskSpan, err)
+			return fmt.Errorf("invalid task payload: %w", err)
+		}
+		taskMsg.Metadata.Fields["task_payload"] = structpb.NewStructValue(payload)
+	}
+	// Input of skills declaring a schema travels as a data part, which they validate
+	if len(action.TaskData) > 0 {
+		data, err := structpb.NewStruct(action.TaskData)
+		if err != nil {
+			traceManager.RecordError(taskSpan, err)
+			return fmt.Errorf("invalid task data: %w", err)
+		}
+		taskMsg.Content = append(taskMsg.Content, &pb.Part{Part: &pb.Part_Data{Data: &pb.DataPart{Data: data}}})
+	}
 
 	traceManager.AddSpanEvent(taskSpan, "task_request_created",
 		attribute.String("task_id", taskID),
@@ -498,16 +660,19 @@ func (c *Cortex) executeTaskRequest(ctx context.Context, traceManager *observabi
 	)
 
 	// Track this task as pending
-	conversationState.PendingTasks[taskID] = &state.TaskContext{
+	if err := conversationState.AddPendingTask(&state.TaskContext{
 		TaskID:        taskID,
 		TaskType:      action.TaskType,
 		RequestedAt:   time.Now().Unix(),
 		OriginalInput: triggeringMsg,
 		UserNotified:  true, // We assume we've already sent an acknowledgment
+	}); err != nil {
+		traceManager.RecordError(taskSpan, err)
+		return err
 	}
 
 	traceManager.AddSpanEvent(taskSpan, "task_tracked_as_pending",
-		attribute.Int("total_pending_tasks", len(conversationState.PendingTasks)),
+		attribute.Int("total_pending_tasks", conversationState.PendingTaskCount()),
 	)
 
 	// Publish the task request (trace context automatically propagated via taskCtx)
@@ -515,7 +680,9 @@ func (c *Cortex) executeTaskRequest(ctx context.Context, traceManager *observabi
 		FromAgentId: CortexAgentID,
 		ToAgentId:   action.TargetAgent,
 		EventType:   fmt.Sprintf("a2a.task.%s", action.TaskType),
-		Priority:    pb.Priority_PRIORITY_MEDIUM,
+		// Delegated work keeps the priority and headers of the request that triggered it
+		Priority: agenthub.PriorityFromContext(ctx),
+		Headers:  agenthub.HeadersFromContext(ctx),
 	}
 
 	err := c.messagePublisher.PublishMessage(taskCtx, taskMsg, routing)
@@ -535,40 +702,83 @@ func (c *Cortex) executeTaskRequest(ctx context.Context, traceManager *observabi
 
 // HandleTaskCompletion processes task completion notifications from delegated agents
 func (c *Cortex) HandleTaskCompletion(ctx context.Context, taskID, contextID string, status *pb.TaskStatus) {
+	var aggregated string
+	var completed bool
+
 	// Use WithLock to ensure thread-safe state access
 	_ = c.stateManager.WithLock(contextID, func(conversationState *state.ConversationState) error {
-		// Check if this task is pending
-		taskContext, pending := conversationState.PendingTasks[taskID]
+		// Store the task result and update completion time if the task is pending
+		conversationState.UpdatePendingTask(taskID, func(taskContext *state.TaskContext) {
+			taskContext.CompletedAt = time.Now().Unix()
+			taskContext.Result = status
+		})
+
+		if c.aggregation == AggregationStream {
+			// Note: We don't complete the pending task yet - keep it for potential
+			// use in responding to the user with the task results
+			return nil
+		}
+
+		// Buffered policies deliver everything at once, then forget the task
+		taskContext, pending := conversationState.CompletePendingTask(taskID)
 		if !pending {
-			// Task not found or already processed
 			return nil
 		}
+		completed = true
+		aggregated = aggregateArtifacts(taskContext.Artifacts)
+		return nil
+	})
 
-		// Store the task result and update completion time
-		taskContext.CompletedAt = time.Now().Unix()
-		taskContext.Result = status
+	if !completed {
+		return
+	}
 
-		// Note: We don't delete from PendingTasks yet - keep it for potential
-		// use in responding to the user with the task results
+	switch c.aggregation {
+	case AggregationConcatenate:
+		if aggregated == "" {
+			c.logger.DebugContext(ctx, "Task completed without text artifacts", "task_id", taskID)
+			return
+		}
+		c.sendTaskResultToUser(ctx, contextID, taskID, aggregated)
 
-		return nil
-	})
+	case AggregationSynthesize:
+		if aggregated == "" {
+			aggregated = fmt.Sprintf("Task finished with state %s and produced no text output.", status.GetState().String())
+		}
+		resultMsg := &pb.Message{
+			MessageId: c.ids.NewID("cortex_task_aggregate"),
+			ContextId: contextID,
+			TaskId:    taskID,
+			Role:      pb.Role_ROLE_AGENT,
+			Content: []*pb.Part{
+				{Part: &pb.Part_Text{Text: aggregated}},
+			},
+		}
+		// Let the LLM compose the response from the whole result
+		traceManager := observability.NewTraceManager(CortexAgentID)
+		if err := c.HandleMessage(ctx, traceManager, resultMsg); err != nil {
+			c.logger.ErrorContext(ctx, "Failed to synthesize task result",
+				"task_id", taskID,
+				"error", err)
+		}
+	}
 }
 
-// HandleTaskArtifact processes task artifact notifications from delegated agents
+// HandleTaskArtifact processes task artifact notifications from delegated agents.
+// With AggregationStream the artifact is forwarded to the user immediately;
+// other policies only record it until the task completes.
 func (c *Cortex) HandleTaskArtifact(ctx context.Context, taskID, contextID string, artifact *pb.Artifact) {
 	c.logger.DebugContext(ctx, "HandleTaskArtifact called",
 		"task_id", taskID,
 		"context_id", contextID,
 		"artifact_id", artifact.GetArtifactId())
 
-	var shouldRespond bool
 	var responseText string
 
 	// Use WithLock to ensure thread-safe state access
 	_ = c.stateManager.WithLock(contextID, func(conversationState *state.ConversationState) error {
 		// Check if this task is pending
-		taskContext, pending := conversationState.PendingTasks[taskID]
+		taskContext, pending := conversationState.PendingTask(taskID)
 		if !pending {
 			c.logger.DebugContext(ctx, "Task not found in pending tasks", "task_id", taskID)
 			// Task not found or already processed
@@ -576,50 +786,27 @@ func (c *Cortex) HandleTaskArtifact(ctx context.Context, taskID, contextID strin
 		}
 
 		// Store the artifact with the task context
-		if taskContext.Artifacts == nil {
-			taskContext.Artifacts = make([]*pb.Artifact, 0)
-		}
 		taskContext.Artifacts = append(taskContext.Artifacts, artifact)
 
-		// Extract text content from artifact for response
-		var textParts []string
-		for _, part := range artifact.GetParts() {
-			if textPart := part.GetText(); textPart != "" {
-				textParts = append(textParts, textPart)
-			}
+		if c.aggregation == AggregationStream {
+			responseText = artifactText(artifact)
 		}
-
-		c.logger.DebugContext(ctx, "Extracted text parts from artifact",
-			"part_count", len(textParts))
-
-		// By default, send artifact results back to the user
-		if len(textParts) > 0 {
-			shouldRespond = true
-			if artifact.GetName() != "" && artifact.GetDescription() != "" {
-				responseText = fmt.Sprintf("%s: %s", artifact.GetName(), strings.Join(textParts, "\n"))
-			} else {
-				responseText = strings.Join(textParts, "\n")
-			}
-			c.logger.DebugContext(ctx, "Will send response to user", "response_text", responseText)
-		}
-
 		return nil
 	})
 
-	// Send response to user if we have content
-	if shouldRespond && responseText != "" {
+	// Stream the artifact to the user if it has content
+	if responseText != "" {
 		c.logger.DebugContext(ctx, "Calling sendTaskResultToUser", "context_id", contextID)
 		c.sendTaskResultToUser(ctx, contextID, taskID, responseText)
 	} else {
 		c.logger.DebugContext(ctx, "Not sending response",
-			"should_respond", shouldRespond,
-			"has_text", responseText != "")
+			"aggregation", c.aggregation.String())
 	}
 }
 
 // sendTaskResultToUser sends task results back to the user
 func (c *Cortex) sendTaskResultToUser(ctx context.Context, contextID, taskID, resultText string) {
-	messageID := fmt.Sprintf("cortex_task_result_%d", time.Now().UnixNano())
+	messageID := c.ids.NewID("cortex_task_result")
 
 	c.logger.DebugContext(ctx, "sendTaskResultToUser called",
 		"message_id", messageID,
@@ -645,18 +832,20 @@ func (c *Cortex) sendTaskResultToUser(ctx context.Context, contextID, taskID, re
 
 	// Update conversation state with the response
 	_ = c.stateManager.WithLock(contextID, func(conversationState *state.ConversationState) error {
-		conversationState.Messages = append(conversationState.Messages, responseMsg)
+		conversationState.AppendMessage(responseMsg)
 		c.logger.DebugContext(ctx, "Added response to conversation history",
-			"total_messages", len(conversationState.Messages))
+			"total_messages", conversationState.MessageCount())
 		return nil
 	})
 
 	// Publish the response - broadcast to all message subscribers (including REPL)
 	routing := &pb.AgentEventMetadata{
 		FromAgentId: CortexAgentID,
-		// No ToAgentId - broadcast to all
-		EventType: "a2a.message.task_result",
-		Priority:  pb.Priority_PRIORITY_MEDIUM,
+		// No ToAgentId - broadcast to all but Cortex itself
+		EventType:   "a2a.message.task_result",
+		Priority:    pb.Priority_PRIORITY_MEDIUM,
+		ExcludeSelf: true,
+		Headers:     agenthub.HeadersFromContext(ctx),
 	}
 
 	c.logger.DebugContext(ctx, "Publishing message",
@@ -2,18 +2,25 @@ package cortex
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/owulveryck/agenthub/agents/cortex/llm"
 	"github.com/owulveryck/agenthub/agents/cortex/state"
 	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
 	"github.com/owulveryck/agenthub/internal/observability"
+	"github.com/owulveryck/agenthub/internal/observability/observabilitytest"
 )
 
 // MockAgentHubClient is a mock of the AgentHub client for testing
 type MockAgentHubClient struct {
 	PublishedMessages []*pb.Message
+	PublishedRoutings []*pb.AgentEventMetadata
 	PublishError      error
 }
 
@@ -22,6 +29,7 @@ func (m *MockAgentHubClient) PublishMessage(ctx context.Context, msg *pb.Message
 		return m.PublishError
 	}
 	m.PublishedMessages = append(m.PublishedMessages, msg)
+	m.PublishedRoutings = append(m.PublishedRoutings, routing)
 	return nil
 }
 
@@ -30,7 +38,7 @@ func TestCortex_RegisterAgent(t *testing.T) {
 	llmClient := llm.NewMockClient()
 	mockClient := &MockAgentHubClient{}
 
-	cortex := NewCortex(sm, llmClient, mockClient)
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default())
 
 	// Register an agent
 	agentCard := &pb.AgentCard{
@@ -60,6 +68,11 @@ func TestCortex_RegisterAgent(t *testing.T) {
 	if retrieved.Name != "test-agent" {
 		t.Errorf("Expected agent name 'test-agent', got '%s'", retrieved.Name)
 	}
+
+	cortex.DeregisterAgent("test-agent")
+	if agents := cortex.GetAvailableAgents(); len(agents) != 0 {
+		t.Errorf("Expected no agents after deregistration, got %d", len(agents))
+	}
 }
 
 func TestCortex_HandleChatRequest(t *testing.T) {
@@ -79,7 +92,7 @@ func TestCortex_HandleChatRequest(t *testing.T) {
 	})
 
 	mockClient := &MockAgentHubClient{}
-	cortex := NewCortex(sm, llmClient, mockClient)
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default())
 
 	// Create a chat request
 	chatRequest := &pb.Message{
@@ -105,8 +118,8 @@ func TestCortex_HandleChatRequest(t *testing.T) {
 	}
 
 	// Should have 2 messages: user request + cortex response
-	if len(sessionState.Messages) != 2 {
-		t.Errorf("Expected 2 messages in state, got %d", len(sessionState.Messages))
+	if sessionState.MessageCount() != 2 {
+		t.Errorf("Expected 2 messages in state, got %d", sessionState.MessageCount())
 	}
 
 	// Verify a message was published
@@ -144,13 +157,9 @@ func TestCortex_HandleTaskResult(t *testing.T) {
 		UserNotified: true,
 	}
 
-	initialState := &state.ConversationState{
-		SessionID: "session-1",
-		Messages:  []*pb.Message{},
-		PendingTasks: map[string]*state.TaskContext{
-			"task-123": taskContext,
-		},
-		RegisteredAgents: make(map[string]*pb.AgentCard),
+	initialState := state.NewConversationState("session-1")
+	if err := initialState.AddPendingTask(taskContext); err != nil {
+		t.Fatalf("AddPendingTask failed: %v", err)
 	}
 
 	sm.Set("session-1", initialState)
@@ -169,7 +178,7 @@ func TestCortex_HandleTaskResult(t *testing.T) {
 	})
 
 	mockClient := &MockAgentHubClient{}
-	cortex := NewCortex(sm, llmClient, mockClient)
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default())
 
 	// Create a task result message
 	taskResult := &pb.Message{
@@ -195,8 +204,8 @@ func TestCortex_HandleTaskResult(t *testing.T) {
 		t.Fatalf("Failed to get state: %v", err)
 	}
 
-	if len(sessionState.PendingTasks) != 0 {
-		t.Errorf("Expected pending task to be removed, but %d tasks remain", len(sessionState.PendingTasks))
+	if sessionState.PendingTaskCount() != 0 {
+		t.Errorf("Expected pending task to be removed, but %d tasks remain", sessionState.PendingTaskCount())
 	}
 
 	// Verify response was published
@@ -205,12 +214,120 @@ func TestCortex_HandleTaskResult(t *testing.T) {
 	}
 }
 
+func TestCortex_StreamResponses(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	llmClient := llm.NewMockClientWithFunc(llm.SimpleEchoDecider())
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default())
+	cortex.SetStreamResponses(true)
+
+	chatRequest := &pb.Message{
+		MessageId: "msg-1",
+		ContextId: "session-1",
+		Role:      pb.Role_ROLE_USER,
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: "hello world"}},
+		},
+	}
+
+	traceManager := observability.NewTraceManager("cortex_test")
+	if err := cortex.HandleMessage(context.Background(), traceManager, chatRequest); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+
+	// "Echo: hello world" streams as three deltas, then the final response
+	if len(mockClient.PublishedMessages) != 4 {
+		t.Fatalf("Expected 4 published messages, got %d", len(mockClient.PublishedMessages))
+	}
+
+	var streamed string
+	for _, msg := range mockClient.PublishedMessages[:3] {
+		if taskType := msg.Metadata.Fields["task_type"].GetStringValue(); taskType != "chat_response_delta" {
+			t.Errorf("Expected chat_response_delta, got %s", taskType)
+		}
+		streamed += msg.Content[0].GetText()
+	}
+
+	final := mockClient.PublishedMessages[3]
+	if final.Metadata.Fields["task_type"].GetStringValue() != "chat_response" {
+		t.Errorf("Expected final message to be a chat_response")
+	}
+	if streamed != final.Content[0].GetText() {
+		t.Errorf("Streamed text %q does not match final response %q", streamed, final.Content[0].GetText())
+	}
+
+	// Deltas are not part of the conversation history
+	sessionState, err := sm.Get("session-1")
+	if err != nil {
+		t.Fatalf("Failed to get state: %v", err)
+	}
+	if sessionState.MessageCount() != 2 {
+		t.Errorf("Expected 2 messages in state, got %d", sessionState.MessageCount())
+	}
+}
+
+func TestCortex_LLMRetry(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	llmClient := llm.NewScriptedMockClient(llm.MockResponse{Err: errors.New("model overloaded")})
+	llmClient.DecideFunc = llm.SimpleEchoDecider()
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default(), WithLLMRetry(2, time.Millisecond))
+
+	chatRequest := &pb.Message{
+		MessageId: "msg-1",
+		ContextId: "session-1",
+		Role:      pb.Role_ROLE_USER,
+		Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+	}
+
+	traceManager := observability.NewTraceManager("cortex_test")
+	if err := cortex.HandleMessage(context.Background(), traceManager, chatRequest); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	if llmClient.CallCount != 2 {
+		t.Errorf("Expected 2 LLM calls, got %d", llmClient.CallCount)
+	}
+	if len(mockClient.PublishedMessages) != 1 || mockClient.PublishedMessages[0].Content[0].GetText() != "Echo: hello" {
+		t.Errorf("Expected the echo response after a retry, got %v", mockClient.PublishedMessages)
+	}
+}
+
+func TestCortex_LLMFallbackResponse(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	llmClient := llm.NewMockClient()
+	// Every call times out before the model answers
+	llmClient.Delay = time.Minute
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default(),
+		WithLLMTimeout(10*time.Millisecond),
+		WithLLMRetry(1, time.Millisecond),
+	)
+
+	chatRequest := &pb.Message{
+		MessageId: "msg-1",
+		ContextId: "session-1",
+		Role:      pb.Role_ROLE_USER,
+		Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+	}
+
+	traceManager := observability.NewTraceManager("cortex_test")
+	if err := cortex.HandleMessage(context.Background(), traceManager, chatRequest); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	if llmClient.CallCount != 2 {
+		t.Errorf("Expected 2 LLM calls, got %d", llmClient.CallCount)
+	}
+	if len(mockClient.PublishedMessages) != 1 || mockClient.PublishedMessages[0].Content[0].GetText() != LLMFallbackResponse {
+		t.Errorf("Expected the fallback response, got %v", mockClient.PublishedMessages)
+	}
+}
+
 func TestCortex_GetAvailableAgents(t *testing.T) {
 	sm := state.NewInMemoryStateManager()
 	llmClient := llm.NewMockClient()
 	mockClient := &MockAgentHubClient{}
 
-	cortex := NewCortex(sm, llmClient, mockClient)
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default())
 
 	// Register multiple agents
 	cortex.RegisterAgent("agent-1", &pb.AgentCard{Name: "agent-1", Description: "First agent"})
@@ -221,3 +338,375 @@ func TestCortex_GetAvailableAgents(t *testing.T) {
 		t.Errorf("Expected 2 available agents, got %d", len(agents))
 	}
 }
+
+func TestCortex_HandleTaskArtifact_Concatenate(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	initialState := state.NewConversationState("session-1")
+	if err := initialState.AddPendingTask(&state.TaskContext{TaskID: "task-1", TaskType: "research"}); err != nil {
+		t.Fatalf("AddPendingTask failed: %v", err)
+	}
+	sm.Set("session-1", initialState)
+
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(sm, llm.NewMockClient(), mockClient, slog.Default())
+	cortex.SetAggregationPolicy(AggregationConcatenate)
+
+	ctx := context.Background()
+	for _, text := range []string{"first chunk", "second chunk"} {
+		cortex.HandleTaskArtifact(ctx, "task-1", "session-1", &pb.Artifact{
+			Parts: []*pb.Part{{Part: &pb.Part_Text{Text: text}}},
+		})
+	}
+
+	if len(mockClient.PublishedMessages) != 0 {
+		t.Fatalf("Expected artifacts to be buffered, got %d published messages", len(mockClient.PublishedMessages))
+	}
+
+	cortex.HandleTaskCompletion(ctx, "task-1", "session-1", &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED})
+
+	if len(mockClient.PublishedMessages) != 1 {
+		t.Fatalf("Expected 1 aggregated message, got %d", len(mockClient.PublishedMessages))
+	}
+	if got := mockClient.PublishedMessages[0].GetContent()[0].GetText(); got != "first chunk\n\nsecond chunk" {
+		t.Errorf("Unexpected aggregated text: %q", got)
+	}
+
+	sessionState, _ := sm.Get("session-1")
+	if sessionState.PendingTaskCount() != 0 {
+		t.Errorf("Expected task to be completed, %d pending", sessionState.PendingTaskCount())
+	}
+}
+
+func TestCortex_FindAgentsForTaskType(t *testing.T) {
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), &MockAgentHubClient{}, slog.Default())
+	cortex.RegisterAgent("translator", &pb.AgentCard{Name: "translator", Skills: []*pb.AgentSkill{
+		{Id: "skill_0", Name: "Translate Text", Tags: []string{"translation"}},
+	}})
+	cortex.RegisterAgent("echo_agent", &pb.AgentCard{Name: "echo_agent", Skills: []*pb.AgentSkill{
+		{Id: "skill_0", Name: "Echo Messages", Tags: []string{"Echo Messages"}},
+	}})
+	cortex.RegisterAgent("echo_backup", &pb.AgentCard{Name: "echo_backup", Skills: []*pb.AgentSkill{
+		{Id: "echo messages", Name: "Echo"},
+	}})
+
+	names := func(cards []*pb.AgentCard) []string {
+		var result []string
+		for _, card := range cards {
+			result = append(result, card.GetName())
+		}
+		return result
+	}
+	if got := names(cortex.FindAgentsForTaskType("translation")); len(got) != 1 || got[0] != "translator" {
+		t.Errorf("Expected translator for translation, got %v", got)
+	}
+	if got := names(cortex.FindAgentsForTaskType("echo messages")); len(got) != 2 || got[0] != "echo_agent" || got[1] != "echo_backup" {
+		t.Errorf("Expected both echo agents by ID order, got %v", got)
+	}
+	if got := cortex.FindAgentsForTaskType("weather"); len(got) != 0 {
+		t.Errorf("Expected no agent for weather, got %v", names(got))
+	}
+}
+
+func TestCortex_ValidateAction_ResolvesTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		taskType   string
+		wantTarget string
+		wantErr    bool
+	}{
+		{name: "capable target", target: "translator", taskType: "translation", wantTarget: "translator"},
+		{name: "re-routed", target: "echo_agent", taskType: "translation", wantTarget: "translator"},
+		{name: "unknown target re-routed", target: "translate_bot", taskType: "translation", wantTarget: "translator"},
+		{name: "no capable agent", target: "echo_agent", taskType: "weather", wantErr: true},
+		{name: "broadcast", target: "", taskType: "weather", wantTarget: ""},
+	}
+
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), &MockAgentHubClient{}, slog.Default())
+	cortex.RegisterAgent("translator", &pb.AgentCard{Name: "translator", Skills: []*pb.AgentSkill{{Name: "translation"}}})
+	cortex.RegisterAgent("echo_agent", &pb.AgentCard{Name: "echo_agent", Skills: []*pb.AgentSkill{{Name: "echo"}}})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, problem := cortex.validateAction(llm.Action{Type: "task.request", TaskType: tt.taskType, TargetAgent: tt.target})
+			if tt.wantErr {
+				if problem == "" {
+					t.Fatal("Expected the task request to be rejected")
+				}
+				return
+			}
+			if problem != "" {
+				t.Fatalf("Expected a valid task request, got %q", problem)
+			}
+			if action.TargetAgent != tt.wantTarget {
+				t.Errorf("Expected task routed to %q, got %q", tt.wantTarget, action.TargetAgent)
+			}
+		})
+	}
+}
+
+func TestCortex_ExecuteActions_Validates(t *testing.T) {
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), mockClient, slog.Default())
+	cortex.RegisterAgent("translator", &pb.AgentCard{Name: "translator", Skills: []*pb.AgentSkill{{Name: "translation"}}})
+	cortex.RegisterAgent("echo_agent", &pb.AgentCard{Name: "echo_agent", Skills: []*pb.AgentSkill{{Name: "echo"}}})
+
+	actions := []llm.Action{
+		{Type: "chat.response", ResponseText: "  "},
+		{Type: "task.request", TaskType: "weather", TargetAgent: "echo_agent"},
+		{Type: "task.request", TaskType: "please translate this for me"},
+		{Type: "task.request", TaskType: "translation", TargetAgent: "echo_agent"},
+		{Type: "agent.dance"},
+	}
+	err := cortex.executeActions(context.Background(), observability.NewTraceManager("cortex_test"),
+		state.NewConversationState("session-1"), actions, &pb.Message{MessageId: "msg-1"})
+	if err != nil {
+		t.Fatalf("executeActions failed: %v", err)
+	}
+
+	// The repairable task is re-routed, and the user is told about the rest
+	if len(mockClient.PublishedMessages) != 2 {
+		t.Fatalf("Expected a task and a chat response, got %d messages", len(mockClient.PublishedMessages))
+	}
+	if got := mockClient.PublishedRoutings[0].GetToAgentId(); got != "translator" {
+		t.Errorf("Expected the translation task re-routed to translator, got %q", got)
+	}
+	text := mockClient.PublishedMessages[1].GetContent()[0].GetText()
+	if !strings.HasPrefix(text, InvalidActionsResponse) {
+		t.Fatalf("Expected an invalid actions response, got %q", text)
+	}
+	if got := strings.Count(text, "\n- "); got != 4 {
+		t.Errorf("Expected 4 rejected actions listed, got %d in %q", got, text)
+	}
+}
+
+func TestCortex_ExecuteTaskRequest_Priority(t *testing.T) {
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), mockClient, slog.Default())
+	action := llm.Action{Type: "task.request", TaskType: "translation"}
+	traceManager := observability.NewTraceManager("cortex_test")
+
+	// Tasks dispatched for a HIGH priority request are HIGH too
+	ctx := agenthub.ContextWithPriority(context.Background(), pb.Priority_PRIORITY_HIGH)
+	if err := cortex.executeTaskRequest(ctx, traceManager, state.NewConversationState("session-1"), action, &pb.Message{MessageId: "msg-1"}); err != nil {
+		t.Fatalf("executeTaskRequest failed: %v", err)
+	}
+	// Without a known priority the broker default applies
+	if err := cortex.executeTaskRequest(context.Background(), traceManager, state.NewConversationState("session-2"), action, &pb.Message{MessageId: "msg-2"}); err != nil {
+		t.Fatalf("executeTaskRequest failed: %v", err)
+	}
+
+	if len(mockClient.PublishedRoutings) != 2 {
+		t.Fatalf("Expected 2 published tasks, got %d", len(mockClient.PublishedRoutings))
+	}
+	if got := mockClient.PublishedRoutings[0].GetPriority(); got != pb.Priority_PRIORITY_HIGH {
+		t.Errorf("Expected the request priority to propagate, got %s", got)
+	}
+	if got := mockClient.PublishedRoutings[1].GetPriority(); got != pb.Priority_PRIORITY_UNSPECIFIED {
+		t.Errorf("Expected an unspecified priority, got %s", got)
+	}
+}
+
+func TestCortex_ExecuteTaskRequest_Headers(t *testing.T) {
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), mockClient, slog.Default())
+	action := llm.Action{Type: "task.request", TaskType: "translation"}
+
+	ctx := agenthub.ContextWithHeaders(context.Background(), map[string]string{agenthub.HeaderTenantID: "acme"})
+	if err := cortex.executeTaskRequest(ctx, observability.NewTraceManager("cortex_test"), state.NewConversationState("session-1"), action, &pb.Message{MessageId: "msg-1"}); err != nil {
+		t.Fatalf("executeTaskRequest failed: %v", err)
+	}
+	if got := mockClient.PublishedRoutings[0].GetHeaders()[agenthub.HeaderTenantID]; got != "acme" {
+		t.Errorf("Expected the request headers to propagate, got tenant %q", got)
+	}
+}
+
+func TestCortex_ExecuteTaskRequest_Payload(t *testing.T) {
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), mockClient, slog.Default())
+	cortex.RegisterAgent("translator", &pb.AgentCard{Name: "translator", Skills: []*pb.AgentSkill{{Id: "translation"}}})
+
+	err := cortex.executeTaskRequest(context.Background(), observability.NewTraceManager("cortex_test"),
+		state.NewConversationState("session-1"),
+		llm.Action{
+			Type:        "task.request",
+			TaskType:    "translation",
+			TargetAgent: "translator",
+			TaskPayload: map[string]interface{}{"request": "Translate hello to French"},
+		},
+		&pb.Message{MessageId: "msg-1"})
+	if err != nil {
+		t.Fatalf("executeTaskRequest failed: %v", err)
+	}
+	if len(mockClient.PublishedMessages) != 1 {
+		t.Fatalf("Expected 1 published task, got %d", len(mockClient.PublishedMessages))
+	}
+	payload := mockClient.PublishedMessages[0].GetMetadata().GetFields()["task_payload"].GetStructValue()
+	if got := payload.GetFields()["request"].GetStringValue(); got != "Translate hello to French" {
+		t.Errorf("Expected the payload in the task metadata, got %q", got)
+	}
+}
+
+func TestCortex_SummarizesLongHistory(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	llmClient := llm.NewMockClient()
+	var summarized []*pb.Message
+	llmClient.SummarizeFunc = func(ctx context.Context, messages []*pb.Message) (string, error) {
+		summarized = messages
+		return "the user said hello several times", nil
+	}
+	cortex := NewCortex(sm, llmClient, &MockAgentHubClient{}, slog.Default(), WithSummarization(6, 0, 2))
+	traceManager, exporter := observabilitytest.NewInMemoryTraceManager(t)
+
+	// Each chat request adds the request and the response to the history
+	for i := 0; i < 4; i++ {
+		err := cortex.HandleMessage(context.Background(), traceManager, &pb.Message{
+			MessageId: fmt.Sprintf("msg-%d", i),
+			ContextId: "session-1",
+			Role:      pb.Role_ROLE_USER,
+			Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "Hello"}}},
+		})
+		if err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+
+	if llmClient.SummarizeCount != 1 {
+		t.Fatalf("Expected 1 summarization, got %d", llmClient.SummarizeCount)
+	}
+	// The 4th request makes 7 messages: 5 are summarized, 2 kept
+	if len(summarized) != 5 {
+		t.Errorf("Expected 5 summarized messages, got %d", len(summarized))
+	}
+
+	sessionState, _ := sm.Get("session-1")
+	messages := sessionState.Messages()
+	if len(messages) != 4 {
+		t.Fatalf("Expected summary, 2 kept messages and the response, got %d messages", len(messages))
+	}
+	if !messages[0].GetMetadata().GetFields()[SummaryMetadataKey].GetBoolValue() {
+		t.Error("Expected the first message to be the summary")
+	}
+	if got := messages[0].GetContent()[0].GetText(); !strings.HasSuffix(got, "the user said hello several times") {
+		t.Errorf("Unexpected summary text %q", got)
+	}
+	if messages[2].GetMessageId() != "msg-3" {
+		t.Errorf("Expected the triggering message kept verbatim, got %q", messages[2].GetMessageId())
+	}
+
+	spans := observabilitytest.FindSpans(exporter, "cortex.summarize_history")
+	if len(spans) != 1 || len(spans[0].Events) == 0 || spans[0].Events[0].Name != "conversation_summarization_triggered" {
+		t.Errorf("Expected a summarization span with its trigger event, got %+v", spans)
+	}
+}
+
+func TestCortex_SummarizationSkipsOversizedTail(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	llmClient := llm.NewMockClient()
+	llmClient.SummarizeFunc = func(ctx context.Context, messages []*pb.Message) (string, error) {
+		return "summary", nil
+	}
+	cortex := NewCortex(sm, llmClient, &MockAgentHubClient{}, slog.Default(), WithSummarization(0, 100, 2))
+	traceManager, _ := observabilitytest.NewInMemoryTraceManager(t)
+
+	// Each message alone is about the token budget: the kept messages always exceed it
+	for i := 0; i < 5; i++ {
+		err := cortex.HandleMessage(context.Background(), traceManager, &pb.Message{
+			MessageId: fmt.Sprintf("msg-%d", i),
+			ContextId: "session-1",
+			Role:      pb.Role_ROLE_USER,
+			Content:   []*pb.Part{{Part: &pb.Part_Text{Text: strings.Repeat("word ", 80)}}},
+		})
+		if err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+
+	if llmClient.SummarizeCount != 0 {
+		t.Errorf("Expected no summarization while the kept messages exceed the budget, got %d", llmClient.SummarizeCount)
+	}
+}
+
+func TestCortex_UpdateAgent(t *testing.T) {
+	cortex := NewCortex(state.NewInMemoryStateManager(), llm.NewMockClient(), &MockAgentHubClient{}, slog.Default())
+	cortex.RegisterAgent("translator", &pb.AgentCard{Name: "translator", Skills: []*pb.AgentSkill{
+		{Id: "translation", Name: "Translate"},
+		{Id: "summary", Name: "Summarize"},
+	}})
+	cortex.RegisterAgent("summarizer", &pb.AgentCard{Name: "summarizer", Skills: []*pb.AgentSkill{
+		{Id: "summary", Name: "Summarize"},
+	}})
+
+	card := &pb.AgentCard{Name: "translator"}
+	orphaned := cortex.UpdateAgent("translator", card, &pb.AgentCardDiff{RemovedSkills: []*pb.AgentSkill{
+		{Id: "translation", Name: "Translate"},
+		{Id: "summary", Name: "Summarize"},
+	}})
+	if len(orphaned) != 1 || orphaned[0] != "translation" {
+		t.Errorf("Expected only translation to be left unserved, got %v", orphaned)
+	}
+	if got := cortex.FindAgentsForTaskType("translation"); len(got) != 0 {
+		t.Errorf("Expected the updated card to be registered, got %v", got)
+	}
+}
+
+func TestCortex_LLMRetry_NotAfterStreaming(t *testing.T) {
+	sm := state.NewInMemoryStateManager()
+	llmClient := llm.NewScriptedMockClient(llm.MockResponse{PartialText: "Let me", Err: errors.New("connection reset")})
+	llmClient.DecideFunc = llm.SimpleEchoDecider()
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(sm, llmClient, mockClient, slog.Default(), WithLLMRetry(2, time.Millisecond))
+	cortex.SetStreamResponses(true)
+
+	chatRequest := &pb.Message{
+		MessageId: "msg-1",
+		ContextId: "session-1",
+		Role:      pb.Role_ROLE_USER,
+		Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+	}
+
+	traceManager := observability.NewTraceManager("cortex_test")
+	if err := cortex.HandleMessage(context.Background(), traceManager, chatRequest); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	if llmClient.CallCount != 1 {
+		t.Errorf("Expected no retry once deltas were published, got %d LLM calls", llmClient.CallCount)
+	}
+
+	// The two deltas of the failed stream are followed by the fallback response, not by a second stream
+	if len(mockClient.PublishedMessages) != 3 {
+		t.Fatalf("Expected 2 deltas and a fallback response, got %d messages", len(mockClient.PublishedMessages))
+	}
+	for _, msg := range mockClient.PublishedMessages[:2] {
+		if taskType := msg.Metadata.Fields["task_type"].GetStringValue(); taskType != "chat_response_delta" {
+			t.Errorf("Expected chat_response_delta, got %s", taskType)
+		}
+	}
+	if got := mockClient.PublishedMessages[2].Content[0].GetText(); got != LLMFallbackResponse {
+		t.Errorf("Expected the fallback response, got %q", got)
+	}
+}
+
+func TestCortex_LLMRetry_StreamFailingBeforeDeltas(t *testing.T) {
+	llmClient := llm.NewScriptedMockClient(llm.MockResponse{Err: errors.New("model overloaded")})
+	llmClient.DecideFunc = llm.SimpleEchoDecider()
+	mockClient := &MockAgentHubClient{}
+	cortex := NewCortex(state.NewInMemoryStateManager(), llmClient, mockClient, slog.Default(), WithLLMRetry(2, time.Millisecond))
+	cortex.SetStreamResponses(true)
+
+	chatRequest := &pb.Message{
+		MessageId: "msg-1",
+		ContextId: "session-1",
+		Role:      pb.Role_ROLE_USER,
+		Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+	}
+	if err := cortex.HandleMessage(context.Background(), observability.NewTraceManager("cortex_test"), chatRequest); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	if llmClient.CallCount != 2 {
+		t.Errorf("Expected a retry when nothing was streamed, got %d LLM calls", llmClient.CallCount)
+	}
+	if final := mockClient.PublishedMessages[len(mockClient.PublishedMessages)-1]; final.Content[0].GetText() != "Echo: hello" {
+		t.Errorf("Expected the echo response after a retry, got %q", final.Content[0].GetText())
+	}
+}
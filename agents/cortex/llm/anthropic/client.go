@@ -0,0 +1,320 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/owulveryck/agenthub/agents/cortex/llm"
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// apiVersion is the version of the Anthropic Messages API the client speaks
+const apiVersion = "2023-06-01"
+
+// Config holds the configuration for the Anthropic client
+type Config struct {
+	APIKey    string
+	Model     string
+	BaseURL   string
+	MaxTokens int
+}
+
+// NewConfigFromEnv creates an Anthropic config from environment variables
+func NewConfigFromEnv() *Config {
+	maxTokens, err := strconv.Atoi(os.Getenv("ANTHROPIC_MAX_TOKENS"))
+	if err != nil || maxTokens <= 0 {
+		maxTokens = 1024
+	}
+	return &Config{
+		APIKey:    os.Getenv("ANTHROPIC_API_KEY"),
+		Model:     getEnvOrDefault("ANTHROPIC_MODEL", "claude-3-5-haiku-latest"),
+		BaseURL:   getEnvOrDefault("ANTHROPIC_BASE_URL", "https://api.anthropic.com/v1"),
+		MaxTokens: maxTokens,
+	}
+}
+
+// getEnvOrDefault returns environment variable value or default if not set
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Client implements the llm.Client interface using the Anthropic Messages API
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewClient creates a new Anthropic client for Cortex orchestration
+func NewClient(config *Config) (*Client, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("ANTHROPIC_API_KEY is required")
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: http.DefaultClient,
+		logger:     llm.NewLogger(),
+	}, nil
+}
+
+// Decide implements the llm.Client interface
+func (c *Client) Decide(
+	ctx context.Context,
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	newEvent *pb.Message,
+) (*llm.Decision, error) {
+	chunks, err := c.DecideStream(ctx, conversationHistory, availableAgents, newEvent)
+	if err != nil {
+		return nil, err
+	}
+	return llm.CollectDecision(chunks)
+}
+
+// message is a message of the Messages API
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// tool is a tool the model can use
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+// messagesRequest is the body of a Messages API request
+type messagesRequest struct {
+	Model     string    `json:"model"`
+	MaxTokens int       `json:"max_tokens"`
+	System    string    `json:"system,omitempty"`
+	Messages  []message `json:"messages"`
+	Tools     []tool    `json:"tools,omitempty"`
+	Stream    bool      `json:"stream"`
+}
+
+// streamEvent is one streamed Messages API event. Only text, tool use and
+// errors are of interest; tool inputs are streamed as JSON fragments.
+type streamEvent struct {
+	Type         string `json:"type"`
+	Index        int    `json:"index"`
+	ContentBlock struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"content_block"`
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// buildRequest translates the conversation into a Messages API request: the
+// orchestration instructions and agents go in the system prompt, the
+// conversation in alternating user and assistant messages and the agent skills
+// in tools
+func (c *Client) buildRequest(
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	tools []llm.Tool,
+	newEvent *pb.Message,
+) messagesRequest {
+	var messages []message
+	for _, turn := range llm.ChatTurns(conversationHistory, newEvent) {
+		messages = append(messages, message{Role: turn.Role, Content: turn.Text})
+	}
+	request := messagesRequest{
+		Model:     c.config.Model,
+		MaxTokens: c.config.MaxTokens,
+		System:    llm.SystemPrompt(availableAgents, tools),
+		Messages:  messages,
+		Stream:    true,
+	}
+	for _, t := range tools {
+		request.Tools = append(request.Tools, tool{
+			Name:        t.Name,
+			Description: t.Description,
+			InputSchema: t.Parameters(),
+		})
+	}
+	return request
+}
+
+// DecideStream implements the llm.Client interface
+// It streams the chat response text while Anthropic generates the decision
+func (c *Client) DecideStream(
+	ctx context.Context,
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	newEvent *pb.Message,
+) (<-chan llm.DecisionChunk, error) {
+	if newEvent == nil {
+		return llm.NoEventDecision(), nil
+	}
+
+	tools := llm.ToolsForAgents(availableAgents)
+	resp, err := c.post(ctx, c.buildRequest(conversationHistory, availableAgents, tools, newEvent))
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := func(yield func(llm.ResponseDelta, error) bool) {
+		defer resp.Body.Close()
+		toolUses := make(map[int]*toolUseBuilder)
+		for data, err := range llm.ServerSentData(resp.Body) {
+			if err != nil {
+				yield(llm.ResponseDelta{}, err)
+				return
+			}
+			var event streamEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				yield(llm.ResponseDelta{}, fmt.Errorf("invalid stream event: %w", err))
+				return
+			}
+			switch event.Type {
+			case "content_block_start":
+				if event.ContentBlock.Type == "tool_use" {
+					toolUses[event.Index] = &toolUseBuilder{name: event.ContentBlock.Name}
+				}
+			case "content_block_delta":
+				switch event.Delta.Type {
+				case "text_delta":
+					if !yield(llm.ResponseDelta{Text: event.Delta.Text}, nil) {
+						return
+					}
+				case "input_json_delta":
+					if toolUse, ok := toolUses[event.Index]; ok {
+						toolUse.input.WriteString(event.Delta.PartialJSON)
+					}
+				}
+			case "content_block_stop":
+				toolUse, ok := toolUses[event.Index]
+				if !ok {
+					continue
+				}
+				delete(toolUses, event.Index)
+				call, err := toolUse.toolCall()
+				if err != nil {
+					yield(llm.ResponseDelta{}, err)
+					return
+				}
+				if !yield(llm.ResponseDelta{ToolCalls: []llm.ToolCall{call}}, nil) {
+					return
+				}
+			case "error":
+				yield(llm.ResponseDelta{}, fmt.Errorf("%s: %s", event.Error.Type, event.Error.Message))
+				return
+			case "message_stop":
+				return
+			}
+		}
+	}
+	return llm.StreamDecision(ctx, "Anthropic", c.logger, tools, deltas), nil
+}
+
+// toolUseBuilder accumulates the input of a streamed tool use
+type toolUseBuilder struct {
+	name  string
+	input strings.Builder
+}
+
+// toolCall returns the completed tool use
+func (b *toolUseBuilder) toolCall() (llm.ToolCall, error) {
+	call := llm.ToolCall{Name: b.name}
+	if b.input.Len() > 0 {
+		if err := json.Unmarshal([]byte(b.input.String()), &call.Arguments); err != nil {
+			return call, fmt.Errorf("invalid input for tool %s: %w", b.name, err)
+		}
+	}
+	return call, nil
+}
+
+// post sends a request to the Anthropic API and returns the successful response,
+// whose body the caller must close
+func (c *Client) post(ctx context.Context, request any) (*http.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Anthropic request: %w", err)
+	}
+	c.logger.DebugContext(ctx, "Sending request to Anthropic",
+		"model", c.config.Model,
+		"request_length", len(body),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.config.BaseURL, "/")+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Anthropic: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", c.config.APIKey)
+	req.Header.Set("Anthropic-Version", apiVersion)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "Anthropic query failed", "error", err)
+		return nil, fmt.Errorf("failed to query Anthropic: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		c.logger.ErrorContext(ctx, "Anthropic query failed", "status", resp.StatusCode, "response", string(message))
+		return nil, fmt.Errorf("failed to query Anthropic: status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// messagesResponse is the body of a Messages API response
+type messagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+// Summarize implements the llm.Summarizer interface
+func (c *Client) Summarize(ctx context.Context, messages []*pb.Message) (string, error) {
+	resp, err := c.post(ctx, messagesRequest{
+		Model:     c.config.Model,
+		MaxTokens: c.config.MaxTokens,
+		Messages:  []message{{Role: "user", Content: llm.SummaryPrompt(messages)}},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response messagesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("invalid Anthropic response: %w", err)
+	}
+	var summary strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			summary.WriteString(block.Text)
+		}
+	}
+	if summary.Len() == 0 {
+		return "", fmt.Errorf("no summary from Anthropic")
+	}
+	return strings.TrimSpace(summary.String()), nil
+}
@@ -0,0 +1,128 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestClient_Decide(t *testing.T) {
+	var got messagesRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if key := r.Header.Get("X-Api-Key"); key != "test-key" {
+			t.Errorf("Unexpected X-Api-Key header %q", key)
+		}
+		if version := r.Header.Get("Anthropic-Version"); version != apiVersion {
+			t.Errorf("Unexpected Anthropic-Version header %q", version)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: message_start\ndata: {\"type\":\"message_start\"}\n\n")
+		decision := "```json\n" + `{"reasoning": "needs echo", "actions": [{"type": "task.request", "taskType": "echo", "targetAgent": "echo_agent"}]}` + "\n```"
+		for _, part := range []string{decision[:30], decision[30:]} {
+			text, _ := json.Marshal(part)
+			fmt.Fprintf(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":%s}}\n\n", text)
+		}
+		fmt.Fprint(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{APIKey: "test-key", Model: "test-model", BaseURL: srv.URL, MaxTokens: 256})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	result := &pb.Message{Role: pb.Role_ROLE_AGENT, TaskId: "task_1", Content: []*pb.Part{{Part: &pb.Part_Text{Text: "echoed"}}}}
+	decision, err := client.Decide(context.Background(), []*pb.Message{result}, nil, result)
+	if err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if len(decision.Actions) != 1 || decision.Actions[0].Type != "task.request" || decision.Actions[0].TargetAgent != "echo_agent" {
+		t.Errorf("Unexpected decision %+v", decision)
+	}
+
+	if got.MaxTokens != 256 || !got.Stream {
+		t.Errorf("Unexpected max_tokens %d or stream %v", got.MaxTokens, got.Stream)
+	}
+	if !strings.Contains(got.System, "No agents are currently available") {
+		t.Errorf("Expected orchestration instructions in system prompt, got %q", got.System)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Role != "user" || got.Messages[0].Content != "New task result: echoed" {
+		t.Errorf("Unexpected messages %+v", got.Messages)
+	}
+}
+
+func TestClient_DecideStreamError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{APIKey: "test-key", Model: "test-model", BaseURL: srv.URL, MaxTokens: 256})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	msg := &pb.Message{Role: pb.Role_ROLE_USER, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "Hi"}}}}
+	if _, err := client.Decide(context.Background(), []*pb.Message{msg}, nil, msg); err == nil || !strings.Contains(err.Error(), "overloaded_error") {
+		t.Errorf("Expected overloaded error, got %v", err)
+	}
+}
+
+func TestClient_DecideToolUse(t *testing.T) {
+	var got messagesRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		events := []string{
+			`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"{\"reasoning\": \"translate\", \"actions\": [{\"type\": \"chat.response\", \"responseText\": \"On it\"}]}"}}`,
+			`{"type":"content_block_stop","index":0}`,
+			`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"translator__translation","input":{}}}`,
+			`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"request\": \"hel"}}`,
+			`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"lo\"}"}}`,
+			`{"type":"content_block_stop","index":1}`,
+			`{"type":"message_stop"}`,
+		}
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{APIKey: "test-key", Model: "test-model", BaseURL: srv.URL, MaxTokens: 256})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	msg := &pb.Message{Role: pb.Role_ROLE_USER, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "Translate hello"}}}}
+	agents := []*pb.AgentCard{{Name: "translator", Skills: []*pb.AgentSkill{{Id: "translation", Description: "Translates text"}}}}
+
+	decision, err := client.Decide(context.Background(), []*pb.Message{msg}, agents, msg)
+	if err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if len(got.Tools) != 1 || got.Tools[0].Name != "translator__translation" {
+		t.Errorf("Expected the skill offered as a tool, got %+v", got.Tools)
+	}
+	if len(decision.Actions) != 2 || decision.Actions[0].ResponseText != "On it" {
+		t.Fatalf("Unexpected actions %+v", decision.Actions)
+	}
+	action := decision.Actions[1]
+	if action.Type != "task.request" || action.TargetAgent != "translator" || action.TaskType != "translation" || action.TaskPayload["request"] != "hello" {
+		t.Errorf("Unexpected action %+v", action)
+	}
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"fmt"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
 )
@@ -18,6 +19,9 @@ type Action struct {
 	TaskPayload map[string]interface{}
 	TargetAgent string // If empty, broadcast
 
+	// TaskData is the input of skills declaring an input schema, sent as a data part
+	TaskData map[string]interface{}
+
 	// Correlation
 	CorrelationID string
 }
@@ -28,6 +32,15 @@ type Decision struct {
 	Actions   []Action // The actions to take
 }
 
+// DecisionChunk is one increment of a streamed decision.
+// Intermediate chunks carry newly generated chat response text in Text.
+// The last chunk carries the complete Decision, or Err if generation failed.
+type DecisionChunk struct {
+	Text     string
+	Decision *Decision
+	Err      error
+}
+
 // Client is the interface for interacting with an LLM.
 // The LLM is used by Cortex to decide what actions to take based on:
 // - Conversation history
@@ -46,4 +59,32 @@ type Client interface {
 		availableAgents []*pb.AgentCard,
 		newEvent *pb.Message,
 	) (*Decision, error)
+
+	// DecideStream is the streaming variant of Decide. The returned channel
+	// emits the response text as it is generated and is closed after the
+	// final chunk holding the Decision (or the error).
+	DecideStream(
+		ctx context.Context,
+		conversationHistory []*pb.Message,
+		availableAgents []*pb.AgentCard,
+		newEvent *pb.Message,
+	) (<-chan DecisionChunk, error)
+}
+
+// CollectDecision drains a decision stream and returns its final Decision.
+// Clients use it to implement Decide on top of DecideStream.
+func CollectDecision(chunks <-chan DecisionChunk) (*Decision, error) {
+	var decision *Decision
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			return nil, chunk.Err
+		}
+		if chunk.Decision != nil {
+			decision = chunk.Decision
+		}
+	}
+	if decision == nil {
+		return nil, fmt.Errorf("decision stream ended without a decision")
+	}
+	return decision, nil
 }
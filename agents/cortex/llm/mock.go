@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
 )
 
 // MockClient is a mock LLM client for testing.
-// It allows you to define custom decision logic via a DecideFunc.
+// It allows you to define custom decision logic via a DecideFunc, or a Script
+// of responses returned in order. Calls honor the cancellation of their context.
 type MockClient struct {
+	// Script lists the responses of successive Decide calls.
+	// Once it is exhausted, DecideFunc (or the echo response) is used.
+	Script []MockResponse
+
 	// DecideFunc is called when Decide is invoked.
 	// If nil, returns a simple echo response.
 	DecideFunc func(
@@ -20,11 +27,33 @@ type MockClient struct {
 		newEvent *pb.Message,
 	) (*Decision, error)
 
+	// SummarizeFunc is called when Summarize is invoked.
+	// If nil, the messages are concatenated and truncated.
+	SummarizeFunc func(ctx context.Context, messages []*pb.Message) (string, error)
+
+	// Delay is waited before each Decide and Summarize call returns, like the
+	// latency of a real model. Calls whose context ends first return its error.
+	Delay time.Duration
+
+	mu sync.Mutex
+
 	// Track calls for testing
-	CallCount int
-	LastEvent *pb.Message
+	CallCount      int
+	LastEvent      *pb.Message
+	SummarizeCount int
 }
 
+// MockResponse is a scripted response of a MockClient Decide call
+type MockResponse struct {
+	Decision *Decision
+	Err      error
+	// PartialText is streamed before Err, like a model failing mid-response
+	PartialText string
+}
+
+// mockSummaryLength bounds the default mock summary
+const mockSummaryLength = 500
+
 // NewMockClient creates a new mock LLM client.
 func NewMockClient() *MockClient {
 	return &MockClient{}
@@ -42,16 +71,130 @@ func NewMockClientWithFunc(fn func(
 	}
 }
 
-// Decide implements the Client interface.
+// NewScriptedMockClient creates a mock client returning the given responses on
+// successive Decide calls, then echoing user messages.
+func NewScriptedMockClient(responses ...MockResponse) *MockClient {
+	return &MockClient{
+		Script: responses,
+	}
+}
+
+// Decide implements the Client interface by collecting DecideStream.
 func (m *MockClient) Decide(
 	ctx context.Context,
 	conversationHistory []*pb.Message,
 	availableAgents []*pb.AgentCard,
 	newEvent *pb.Message,
 ) (*Decision, error) {
+	chunks, err := m.DecideStream(ctx, conversationHistory, availableAgents, newEvent)
+	if err != nil {
+		return nil, err
+	}
+	return CollectDecision(chunks)
+}
+
+// DecideStream implements the Client interface.
+// It emits the chat response text of the decision word by word, then the decision itself.
+func (m *MockClient) DecideStream(
+	ctx context.Context,
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	newEvent *pb.Message,
+) (<-chan DecisionChunk, error) {
+	m.mu.Lock()
 	m.CallCount++
 	m.LastEvent = newEvent
+	var scripted *MockResponse
+	if len(m.Script) > 0 {
+		scripted = &m.Script[0]
+		m.Script = m.Script[1:]
+	}
+	m.mu.Unlock()
+
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	var decision *Decision
+	var err error
+	if scripted != nil {
+		decision, err = scripted.Decision, scripted.Err
+	} else {
+		decision, err = m.decide(ctx, conversationHistory, availableAgents, newEvent)
+	}
+
+	var chunks []DecisionChunk
+	streamText := func(text string) {
+		for _, word := range strings.SplitAfter(text, " ") {
+			if word != "" {
+				chunks = append(chunks, DecisionChunk{Text: word})
+			}
+		}
+	}
+	if err == nil && decision != nil {
+		for _, action := range decision.Actions {
+			if action.Type == "chat.response" {
+				streamText(action.ResponseText)
+			}
+		}
+	} else if scripted != nil {
+		streamText(scripted.PartialText)
+	}
+	chunks = append(chunks, DecisionChunk{Decision: decision, Err: err})
 
+	stream := make(chan DecisionChunk, len(chunks))
+	for _, chunk := range chunks {
+		stream <- chunk
+	}
+	close(stream)
+	return stream, nil
+}
+
+// Summarize implements the Summarizer interface.
+func (m *MockClient) Summarize(ctx context.Context, messages []*pb.Message) (string, error) {
+	m.mu.Lock()
+	m.SummarizeCount++
+	m.mu.Unlock()
+
+	if err := m.wait(ctx); err != nil {
+		return "", err
+	}
+	if m.SummarizeFunc != nil {
+		return m.SummarizeFunc(ctx, messages)
+	}
+
+	texts := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		texts = append(texts, MessageText(msg))
+	}
+	summary := strings.Join(texts, " / ")
+	if len(summary) > mockSummaryLength {
+		summary = summary[:mockSummaryLength] + "..."
+	}
+	return fmt.Sprintf("%d earlier messages: %s", len(messages), summary), nil
+}
+
+// wait waits for the configured delay, returning the context error if ctx ends first
+func (m *MockClient) wait(ctx context.Context) error {
+	if m.Delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(m.Delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *MockClient) decide(
+	ctx context.Context,
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	newEvent *pb.Message,
+) (*Decision, error) {
 	// Use custom function if provided
 	if m.DecideFunc != nil {
 		return m.DecideFunc(ctx, conversationHistory, availableAgents, newEvent)
@@ -2,7 +2,9 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
 )
@@ -148,3 +150,131 @@ func TestTaskDispatcherDecider(t *testing.T) {
 		t.Errorf("Expected target agent 'transcriber-agent', got '%s'", taskAction.TargetAgent)
 	}
 }
+
+func TestMockClient_DecideStream(t *testing.T) {
+	client := NewMockClientWithFunc(SimpleEchoDecider())
+
+	event := &pb.Message{
+		MessageId: "test-msg",
+		Role:      pb.Role_ROLE_USER,
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: "Hello there"}},
+		},
+	}
+
+	chunks, err := client.DecideStream(context.Background(), nil, nil, event)
+	if err != nil {
+		t.Fatalf("DecideStream failed: %v", err)
+	}
+
+	var text string
+	var textChunks int
+	var decision *Decision
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			t.Fatalf("Unexpected chunk error: %v", chunk.Err)
+		}
+		if chunk.Text != "" {
+			text += chunk.Text
+			textChunks++
+		}
+		if chunk.Decision != nil {
+			decision = chunk.Decision
+		}
+	}
+
+	if textChunks < 2 {
+		t.Errorf("Expected several text chunks, got %d", textChunks)
+	}
+	if decision == nil {
+		t.Fatal("Expected the stream to end with a decision")
+	}
+	if text != decision.Actions[0].ResponseText {
+		t.Errorf("Expected streamed text %q to match response %q", text, decision.Actions[0].ResponseText)
+	}
+	if client.CallCount != 1 {
+		t.Errorf("Expected CallCount to be 1, got %d", client.CallCount)
+	}
+}
+
+func TestMockClient_Script(t *testing.T) {
+	scriptErr := errors.New("model overloaded")
+	client := NewScriptedMockClient(
+		MockResponse{Err: scriptErr},
+		MockResponse{Decision: &Decision{Reasoning: "scripted", Actions: []Action{{Type: "chat.response", ResponseText: "first"}}}},
+	)
+
+	event := &pb.Message{
+		MessageId: "test",
+		Role:      pb.Role_ROLE_USER,
+		Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "Hello"}}},
+	}
+
+	if _, err := client.Decide(context.Background(), nil, nil, event); !errors.Is(err, scriptErr) {
+		t.Errorf("Expected the scripted error, got %v", err)
+	}
+
+	decision, err := client.Decide(context.Background(), nil, nil, event)
+	if err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if decision.Reasoning != "scripted" || decision.Actions[0].ResponseText != "first" {
+		t.Errorf("Expected the scripted decision, got %+v", decision)
+	}
+
+	// The exhausted script falls back to the default echo
+	decision, err = client.Decide(context.Background(), nil, nil, event)
+	if err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if decision.Actions[0].ResponseText != "I received your message: Hello" {
+		t.Errorf("Expected the default response, got %q", decision.Actions[0].ResponseText)
+	}
+	if client.CallCount != 3 {
+		t.Errorf("Expected CallCount to be 3, got %d", client.CallCount)
+	}
+}
+
+func TestMockClient_Delay(t *testing.T) {
+	client := NewMockClient()
+	client.Delay = 20 * time.Millisecond
+
+	event := &pb.Message{
+		MessageId: "test",
+		Role:      pb.Role_ROLE_USER,
+		Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "Hello"}}},
+	}
+
+	start := time.Now()
+	if _, err := client.Decide(context.Background(), nil, nil, event); err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < client.Delay {
+		t.Errorf("Expected Decide to take at least %s, took %s", client.Delay, elapsed)
+	}
+
+	// A call whose deadline ends before the delay returns the context error
+	client.Delay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.Decide(ctx, nil, nil, event); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := client.Summarize(ctx, []*pb.Message{event}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Summarize to return context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMockClient_CanceledContext(t *testing.T) {
+	client := NewScriptedMockClient(MockResponse{Decision: &Decision{Reasoning: "scripted"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.Decide(ctx, nil, nil, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	// The canceled call still consumes its scripted response
+	if len(client.Script) != 0 {
+		t.Errorf("Expected the script to be consumed, %d responses left", len(client.Script))
+	}
+}
@@ -0,0 +1,286 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/owulveryck/agenthub/agents/cortex/llm"
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// Config holds the configuration for the OpenAI client
+type Config struct {
+	APIKey  string
+	Model   string
+	BaseURL string // Also allows OpenAI-compatible servers
+}
+
+// NewConfigFromEnv creates an OpenAI config from environment variables
+func NewConfigFromEnv() *Config {
+	return &Config{
+		APIKey:  os.Getenv("OPENAI_API_KEY"),
+		Model:   getEnvOrDefault("OPENAI_MODEL", "gpt-4o-mini"),
+		BaseURL: getEnvOrDefault("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+	}
+}
+
+// getEnvOrDefault returns environment variable value or default if not set
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Client implements the llm.Client interface using the OpenAI chat completions API
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewClient creates a new OpenAI client for Cortex orchestration
+func NewClient(config *Config) (*Client, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY is required")
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: http.DefaultClient,
+		logger:     llm.NewLogger(),
+	}, nil
+}
+
+// Decide implements the llm.Client interface
+func (c *Client) Decide(
+	ctx context.Context,
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	newEvent *pb.Message,
+) (*llm.Decision, error) {
+	chunks, err := c.DecideStream(ctx, conversationHistory, availableAgents, newEvent)
+	if err != nil {
+		return nil, err
+	}
+	return llm.CollectDecision(chunks)
+}
+
+// chatMessage is a message of the chat completions API
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatTool is a function the model can call
+type chatTool struct {
+	Type     string       `json:"type"`
+	Function chatFunction `json:"function"`
+}
+
+// chatFunction declares a function of a chatTool
+type chatFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+// chatRequest is the body of a chat completions request
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Tools    []chatTool    `json:"tools,omitempty"`
+	Stream   bool          `json:"stream"`
+}
+
+// chatChunk is one streamed chat completions chunk. Tool calls are streamed
+// in fragments identified by their index.
+type chatChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Index    int `json:"index"`
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// toolCallBuilder accumulates the fragments of a streamed tool call
+type toolCallBuilder struct {
+	name      string
+	arguments strings.Builder
+}
+
+// buildRequest translates the conversation into a chat completions request:
+// the orchestration instructions and agents go in the system message, the
+// conversation in user and assistant messages and the agent skills in tools
+func (c *Client) buildRequest(
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	tools []llm.Tool,
+	newEvent *pb.Message,
+) chatRequest {
+	messages := []chatMessage{{
+		Role:    "system",
+		Content: llm.SystemPrompt(availableAgents, tools),
+	}}
+	for _, turn := range llm.ChatTurns(conversationHistory, newEvent) {
+		messages = append(messages, chatMessage{Role: turn.Role, Content: turn.Text})
+	}
+	request := chatRequest{Model: c.config.Model, Messages: messages, Stream: true}
+	for _, tool := range tools {
+		request.Tools = append(request.Tools, chatTool{
+			Type: "function",
+			Function: chatFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Parameters(),
+			},
+		})
+	}
+	return request
+}
+
+// DecideStream implements the llm.Client interface
+// It streams the chat response text while OpenAI generates the decision
+func (c *Client) DecideStream(
+	ctx context.Context,
+	conversationHistory []*pb.Message,
+	availableAgents []*pb.AgentCard,
+	newEvent *pb.Message,
+) (<-chan llm.DecisionChunk, error) {
+	if newEvent == nil {
+		return llm.NoEventDecision(), nil
+	}
+
+	tools := llm.ToolsForAgents(availableAgents)
+	resp, err := c.post(ctx, c.buildRequest(conversationHistory, availableAgents, tools, newEvent))
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := func(yield func(llm.ResponseDelta, error) bool) {
+		defer resp.Body.Close()
+		var calls []*toolCallBuilder
+		for data, err := range llm.ServerSentData(resp.Body) {
+			if err != nil {
+				yield(llm.ResponseDelta{}, err)
+				return
+			}
+			if data == "[DONE]" {
+				break
+			}
+			var chunk chatChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				yield(llm.ResponseDelta{}, fmt.Errorf("invalid stream chunk: %w", err))
+				return
+			}
+			for _, choice := range chunk.Choices {
+				for _, fragment := range choice.Delta.ToolCalls {
+					for len(calls) <= fragment.Index {
+						calls = append(calls, &toolCallBuilder{})
+					}
+					calls[fragment.Index].name += fragment.Function.Name
+					calls[fragment.Index].arguments.WriteString(fragment.Function.Arguments)
+				}
+				if !yield(llm.ResponseDelta{Text: choice.Delta.Content}, nil) {
+					return
+				}
+			}
+		}
+
+		var delta llm.ResponseDelta
+		for _, call := range calls {
+			var arguments map[string]any
+			if call.arguments.Len() > 0 {
+				if err := json.Unmarshal([]byte(call.arguments.String()), &arguments); err != nil {
+					yield(llm.ResponseDelta{}, fmt.Errorf("invalid arguments for tool %s: %w", call.name, err))
+					return
+				}
+			}
+			delta.ToolCalls = append(delta.ToolCalls, llm.ToolCall{Name: call.name, Arguments: arguments})
+		}
+		if len(delta.ToolCalls) > 0 {
+			yield(delta, nil)
+		}
+	}
+	return llm.StreamDecision(ctx, "OpenAI", c.logger, tools, deltas), nil
+}
+
+// post sends a request to the OpenAI API and returns the successful response,
+// whose body the caller must close
+func (c *Client) post(ctx context.Context, request any) (*http.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAI request: %w", err)
+	}
+	c.logger.DebugContext(ctx, "Sending request to OpenAI",
+		"model", c.config.Model,
+		"request_length", len(body),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.config.BaseURL, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenAI: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "OpenAI query failed", "error", err)
+		return nil, fmt.Errorf("failed to query OpenAI: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		c.logger.ErrorContext(ctx, "OpenAI query failed", "status", resp.StatusCode, "response", string(message))
+		return nil, fmt.Errorf("failed to query OpenAI: status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// chatResponse is the body of a chat completions response
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// Summarize implements the llm.Summarizer interface
+func (c *Client) Summarize(ctx context.Context, messages []*pb.Message) (string, error) {
+	resp, err := c.post(ctx, chatRequest{
+		Model:    c.config.Model,
+		Messages: []chatMessage{{Role: "user", Content: llm.SummaryPrompt(messages)}},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("invalid OpenAI response: %w", err)
+	}
+	if len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("no summary from OpenAI")
+	}
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
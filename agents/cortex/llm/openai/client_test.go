@@ -0,0 +1,138 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestClient_DecideStream(t *testing.T) {
+	var got chatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("Unexpected Authorization header %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		decision := `{"reasoning": "greeting", "actions": [{"type": "chat.response", "responseText": "Hello there"}]}`
+		for _, part := range []string{decision[:40], decision[40:70], decision[70:]} {
+			content, _ := json.Marshal(part)
+			fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":%s}}]}\n\n", content)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{APIKey: "test-key", Model: "test-model", BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	history := []*pb.Message{
+		{Role: pb.Role_ROLE_USER, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "Hi"}}}},
+		{Role: pb.Role_ROLE_AGENT, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "Hello!"}}}},
+		{Role: pb.Role_ROLE_USER, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "How are you?"}}}},
+	}
+	agents := []*pb.AgentCard{{Name: "echo_agent", Description: "Echoes messages"}}
+
+	chunks, err := client.DecideStream(context.Background(), history, agents, history[2])
+	if err != nil {
+		t.Fatalf("DecideStream failed: %v", err)
+	}
+	var text strings.Builder
+	var last string
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			t.Fatalf("Stream failed: %v", chunk.Err)
+		}
+		text.WriteString(chunk.Text)
+		if chunk.Decision != nil {
+			last = chunk.Decision.Actions[0].ResponseText
+		}
+	}
+	if text.String() != "Hello there" || last != "Hello there" {
+		t.Errorf("Expected streamed and decided text %q, got %q and %q", "Hello there", text.String(), last)
+	}
+
+	if got.Model != "test-model" || !got.Stream {
+		t.Errorf("Unexpected request model %q or stream %v", got.Model, got.Stream)
+	}
+	roles := make([]string, len(got.Messages))
+	for i, msg := range got.Messages {
+		roles[i] = msg.Role
+	}
+	if strings.Join(roles, ",") != "system,user,assistant,user" {
+		t.Fatalf("Unexpected message roles %v", roles)
+	}
+	if !strings.Contains(got.Messages[0].Content, "echo_agent: Echoes messages") {
+		t.Errorf("Expected agents in system message, got %q", got.Messages[0].Content)
+	}
+	if got.Messages[3].Content != "New user message: How are you?" {
+		t.Errorf("Unexpected last message %q", got.Messages[3].Content)
+	}
+}
+
+func TestClient_DecideStreamHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "invalid key"}}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{APIKey: "bad", Model: "test-model", BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	msg := &pb.Message{Role: pb.Role_ROLE_USER, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "Hi"}}}}
+	if _, err := client.Decide(context.Background(), []*pb.Message{msg}, nil, msg); err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("Expected status 401 error, got %v", err)
+	}
+}
+
+func TestClient_DecideToolCall(t *testing.T) {
+	var got chatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"translator__translation","arguments":""}}]}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"choices":[{"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"request\": "}}]}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"choices":[{"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"hello\"}"}}]}}]}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{APIKey: "test-key", Model: "test-model", BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	msg := &pb.Message{Role: pb.Role_ROLE_USER, Content: []*pb.Part{{Part: &pb.Part_Text{Text: "Translate hello"}}}}
+	agents := []*pb.AgentCard{{Name: "translator", Skills: []*pb.AgentSkill{{Id: "translation", Description: "Translates text"}}}}
+
+	decision, err := client.Decide(context.Background(), []*pb.Message{msg}, agents, msg)
+	if err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if len(got.Tools) != 1 || got.Tools[0].Function.Name != "translator__translation" {
+		t.Errorf("Expected the skill offered as a tool, got %+v", got.Tools)
+	}
+	if len(decision.Actions) != 1 {
+		t.Fatalf("Expected 1 action, got %+v", decision.Actions)
+	}
+	action := decision.Actions[0]
+	if action.Type != "task.request" || action.TargetAgent != "translator" || action.TaskType != "translation" || action.TaskPayload["request"] != "hello" {
+		t.Errorf("Unexpected action %+v", action)
+	}
+}
@@ -0,0 +1,250 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// ResponseFormatInstructions tells the LLM how to format its decision so that
+// ParseDecision can read it.
+const ResponseFormatInstructions = `Respond with a JSON object containing your decision:
+{
+  "reasoning": "explain your decision",
+  "actions": [
+    {
+      "type": "chat.response",
+      "responseText": "your response to the user"
+    },
+    {
+      "type": "task.request",
+      "taskType": "the type of task",
+      "targetAgent": "agent_name"
+    }
+  ]
+}
+
+Action types:
+- chat.response: Send a message to the user (has 'responseText' field)
+- task.request: Delegate a task to an agent (has 'taskType' and 'targetAgent' fields)
+
+Guidelines:
+- If this is a task result from an agent, synthesize it into a user-friendly response
+- Only delegate to agents when their skills match the request
+- You can include multiple actions in the array
+- Always explain your reasoning`
+
+// OrchestrationInstructions describes the role of Cortex and the agents it can
+// delegate to. Providers with a system prompt send it there.
+func OrchestrationInstructions(availableAgents []*pb.AgentCard) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("You are Cortex, an AI orchestrator that manages conversations and delegates tasks to specialized agents.\n\n")
+	prompt.WriteString("Your job is to:\n")
+	prompt.WriteString("1. Understand user requests and agent responses\n")
+	prompt.WriteString("2. Decide whether to respond directly or delegate to an agent\n")
+	prompt.WriteString("3. Synthesize results from agents into user-friendly responses\n\n")
+
+	if len(availableAgents) > 0 {
+		prompt.WriteString("Available agents:\n")
+		for _, agent := range availableAgents {
+			prompt.WriteString(fmt.Sprintf("- %s: %s\n", agent.GetName(), agent.GetDescription()))
+			if len(agent.GetSkills()) > 0 {
+				prompt.WriteString("  Skills:\n")
+				for _, skill := range agent.GetSkills() {
+					prompt.WriteString(fmt.Sprintf("    * %s: %s\n", skill.GetName(), skill.GetDescription()))
+				}
+			}
+		}
+		prompt.WriteString("\n")
+	} else {
+		prompt.WriteString("No agents are currently available. You must respond directly to all requests.\n\n")
+	}
+
+	return prompt.String()
+}
+
+// MessageText returns the text of the first part of a message
+func MessageText(msg *pb.Message) string {
+	if len(msg.GetContent()) > 0 {
+		return msg.GetContent()[0].GetText()
+	}
+	return ""
+}
+
+// DescribeEvent introduces the event Cortex must react to, telling user
+// messages apart from task results
+func DescribeEvent(newEvent *pb.Message) string {
+	eventType := "user message"
+	if newEvent.GetRole() == pb.Role_ROLE_AGENT && newEvent.GetTaskId() != "" {
+		eventType = "task result"
+	}
+	return fmt.Sprintf("New %s: %s", eventType, MessageText(newEvent))
+}
+
+// ChatTurn is one message of a chat-style conversation
+type ChatTurn struct {
+	Role string // "user" or "assistant"
+	Text string
+}
+
+// ChatTurns translates the conversation into alternating user and assistant
+// turns ending with the described new event. The last message of the history
+// is the new event itself and is skipped; consecutive messages from the same
+// side are merged, as some providers require strict alternation.
+func ChatTurns(conversationHistory []*pb.Message, newEvent *pb.Message) []ChatTurn {
+	var turns []ChatTurn
+	add := func(role, text string) {
+		if n := len(turns); n > 0 && turns[n-1].Role == role {
+			turns[n-1].Text += "\n\n" + text
+			return
+		}
+		turns = append(turns, ChatTurn{Role: role, Text: text})
+	}
+
+	if len(conversationHistory) > 1 {
+		for _, msg := range conversationHistory[:len(conversationHistory)-1] {
+			role := "user"
+			if msg.GetRole() == pb.Role_ROLE_AGENT {
+				role = "assistant"
+			}
+			add(role, MessageText(msg))
+		}
+	}
+	add("user", DescribeEvent(newEvent))
+	return turns
+}
+
+// FallbackDecision acknowledges the event when the LLM response cannot be parsed
+func FallbackDecision(err error) *Decision {
+	return &Decision{
+		Reasoning: fmt.Sprintf("Failed to parse LLM response: %v. Providing default response.", err),
+		Actions: []Action{
+			{
+				Type:         "chat.response",
+				ResponseText: "I received your message but had trouble processing it. Could you please rephrase?",
+			},
+		},
+	}
+}
+
+// ParseDecision parses an LLM response following ResponseFormatInstructions
+// into a Decision. The JSON may be wrapped in a markdown code block or text.
+func ParseDecision(response string) (*Decision, error) {
+	// LLMs sometimes wrap JSON in markdown code blocks
+	jsonStr := response
+	if strings.Contains(response, "```json") {
+		start := strings.Index(response, "```json")
+		if start != -1 {
+			start += len("```json")
+			end := strings.Index(response[start:], "```")
+			if end != -1 {
+				jsonStr = strings.TrimSpace(response[start : start+end])
+			}
+		}
+	} else if strings.Contains(response, "```") {
+		start := strings.Index(response, "```")
+		if start != -1 {
+			start += 3
+			end := strings.Index(response[start:], "```")
+			if end != -1 {
+				jsonStr = strings.TrimSpace(response[start : start+end])
+			}
+		}
+	}
+
+	// Try to find JSON object in the response
+	if !strings.HasPrefix(strings.TrimSpace(jsonStr), "{") {
+		start := strings.Index(jsonStr, "{")
+		end := strings.LastIndex(jsonStr, "}")
+		if start != -1 && end != -1 && end > start {
+			jsonStr = jsonStr[start : end+1]
+		}
+	}
+
+	var rawDecision struct {
+		Reasoning string `json:"reasoning"`
+		Actions   []struct {
+			Type         string `json:"type"`
+			ResponseText string `json:"responseText,omitempty"`
+			TaskType     string `json:"taskType,omitempty"`
+			TargetAgent  string `json:"targetAgent,omitempty"`
+		} `json:"actions"`
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), &rawDecision); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w (response: %s)", err, response)
+	}
+
+	decision := &Decision{
+		Reasoning: rawDecision.Reasoning,
+		Actions:   make([]Action, len(rawDecision.Actions)),
+	}
+	for i, rawAction := range rawDecision.Actions {
+		decision.Actions[i] = Action{
+			Type:         rawAction.Type,
+			ResponseText: rawAction.ResponseText,
+			TaskType:     rawAction.TaskType,
+			TargetAgent:  rawAction.TargetAgent,
+		}
+	}
+
+	if len(decision.Actions) == 0 {
+		return nil, fmt.Errorf("decision must contain at least one action")
+	}
+
+	return decision, nil
+}
+
+// PartialResponseText extracts the (possibly still incomplete) value of the
+// first "responseText" field from a partially generated JSON decision
+func PartialResponseText(response string) string {
+	const key = `"responseText"`
+	start := strings.Index(response, key)
+	if start == -1 {
+		return ""
+	}
+	rest := strings.TrimLeft(response[start+len(key):], " \t\r\n")
+	if !strings.HasPrefix(rest, ":") {
+		return ""
+	}
+	rest = strings.TrimLeft(rest[1:], " \t\r\n")
+	if !strings.HasPrefix(rest, `"`) {
+		return ""
+	}
+	rest = rest[1:]
+
+	// Keep only complete characters and escape sequences
+	end := 0
+scan:
+	for end < len(rest) {
+		switch rest[end] {
+		case '"':
+			break scan
+		case '\\':
+			n := 2
+			if end+1 < len(rest) && rest[end+1] == 'u' {
+				n = 6
+			}
+			if end+n > len(rest) {
+				break scan
+			}
+			end += n
+		default:
+			end++
+		}
+	}
+	raw := rest[:end]
+	for len(raw) > 0 && !utf8.ValidString(raw) {
+		raw = raw[:len(raw)-1]
+	}
+
+	var text string
+	if err := json.Unmarshal([]byte(`"`+raw+`"`), &text); err != nil {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,166 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// ResponseDelta is an increment of a provider response: newly generated text
+// and the tool calls completed since the previous delta
+type ResponseDelta struct {
+	Text      string
+	ToolCalls []ToolCall
+}
+
+// StreamDecision turns the response streamed by a provider into a decision
+// stream: the chat response text is forwarded as it grows and the complete
+// response is parsed into the final Decision. Calls to the offered tools are
+// preferred over task.request actions parsed from the text; without them, an
+// unparsable response falls back to an acknowledgment. provider names the LLM
+// in errors and logs.
+func StreamDecision(ctx context.Context, provider string, logger *slog.Logger, tools []Tool, deltas iter.Seq2[ResponseDelta, error]) <-chan DecisionChunk {
+	stream := make(chan DecisionChunk, 16)
+	send := func(chunk DecisionChunk) bool {
+		select {
+		case stream <- chunk:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	fail := func(err error) {
+		logger.ErrorContext(ctx, provider+" query failed", "error", err)
+		send(DecisionChunk{Err: fmt.Errorf("failed to query %s: %w", provider, err)})
+	}
+
+	go func() {
+		defer close(stream)
+
+		var response strings.Builder
+		var toolCalls []ToolCall
+		emitted := 0
+		for delta, err := range deltas {
+			if err != nil {
+				fail(err)
+				return
+			}
+			response.WriteString(delta.Text)
+			toolCalls = append(toolCalls, delta.ToolCalls...)
+
+			if text := PartialResponseText(response.String()); len(text) > emitted {
+				if !send(DecisionChunk{Text: text[emitted:]}) {
+					return
+				}
+				emitted = len(text)
+			}
+		}
+
+		if response.Len() == 0 && len(toolCalls) == 0 {
+			fail(fmt.Errorf("no response from %s", provider))
+			return
+		}
+
+		send(DecisionChunk{Decision: decisionFromResponse(ctx, provider, logger, tools, response.String(), toolCalls)})
+	}()
+
+	return stream
+}
+
+// decisionFromResponse parses the complete response, falling back to an
+// acknowledgment when it cannot be parsed and holds no tool call
+func decisionFromResponse(ctx context.Context, provider string, logger *slog.Logger, tools []Tool, response string, toolCalls []ToolCall) *Decision {
+	logger.DebugContext(ctx, "Received response from "+provider,
+		"response_length", len(response),
+		"tool_calls", describeToolCalls(toolCalls),
+	)
+	logger.DebugContext(ctx, provider+" response content",
+		"response", response,
+	)
+
+	var toolActions []Action
+	for _, call := range toolCalls {
+		action, ok := ToolCallAction(tools, call)
+		if !ok {
+			logger.WarnContext(ctx, "Ignoring call to unknown tool", "tool", call.Name)
+			continue
+		}
+		toolActions = append(toolActions, action)
+	}
+
+	decision, err := ParseDecision(response)
+	if len(toolActions) > 0 {
+		decision = mergeToolCalls(decision, toolActions)
+	} else if err != nil {
+		logger.WarnContext(ctx, "Failed to parse "+provider+" response",
+			"error", err,
+			"response", response,
+		)
+		return FallbackDecision(err)
+	}
+
+	logger.DebugContext(ctx, "Successfully parsed LLM decision",
+		"action_count", len(decision.Actions),
+		"reasoning", decision.Reasoning,
+	)
+	return decision
+}
+
+// NoEventDecision is the decision stream returned when there is no event to react to
+func NoEventDecision() <-chan DecisionChunk {
+	stream := make(chan DecisionChunk, 1)
+	stream <- DecisionChunk{Decision: &Decision{
+		Reasoning: "No new event to process",
+		Actions:   []Action{},
+	}}
+	close(stream)
+	return stream
+}
+
+// ServerSentData yields the data of each server-sent event read from r, as
+// streamed by the HTTP APIs of OpenAI and Anthropic. Multi-line data is joined
+// with newlines.
+func ServerSentData(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+		var data []string
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				if len(data) > 0 && !yield(strings.Join(data, "\n"), nil) {
+					return
+				}
+				data = data[:0]
+				continue
+			}
+			if value, ok := strings.CutPrefix(line, "data:"); ok {
+				data = append(data, strings.TrimPrefix(value, " "))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+			return
+		}
+		if len(data) > 0 {
+			yield(strings.Join(data, "\n"), nil)
+		}
+	}
+}
+
+// NewLogger creates the logger of an LLM client, at DEBUG level when
+// LOG_LEVEL=DEBUG and INFO otherwise
+func NewLogger() *slog.Logger {
+	logLevel := slog.LevelInfo
+	if strings.ToUpper(os.Getenv("LOG_LEVEL")) == "DEBUG" {
+		logLevel = slog.LevelDebug
+	}
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: logLevel,
+	}))
+}
@@ -0,0 +1,36 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// Summarizer is implemented by clients able to condense a conversation.
+// Cortex uses it to keep the history passed to Decide within the context window.
+type Summarizer interface {
+	// Summarize returns a summary of the messages, oldest first, retaining
+	// what later decisions may need: user requests, delegated tasks and results.
+	Summarize(ctx context.Context, messages []*pb.Message) (string, error)
+}
+
+// SummaryInstructions asks the LLM to summarize the conversation that follows
+const SummaryInstructions = `Summarize the following conversation between a user, Cortex and specialized agents.
+Keep the user requests, the tasks delegated to agents, their results and any open question.
+Be concise and answer with the summary only, as plain text.`
+
+// SummaryPrompt is the prompt asking to summarize the messages
+func SummaryPrompt(messages []*pb.Message) string {
+	var prompt strings.Builder
+	prompt.WriteString(SummaryInstructions + "\n\nConversation:\n")
+	for _, msg := range messages {
+		role := "User"
+		if msg.GetRole() == pb.Role_ROLE_AGENT {
+			role = "Agent"
+		}
+		prompt.WriteString(fmt.Sprintf("%s: %s\n", role, MessageText(msg)))
+	}
+	return prompt.String()
+}
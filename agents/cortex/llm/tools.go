@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// maxToolNameLength is the longest tool name accepted by all providers
+const maxToolNameLength = 64
+
+// ToolInstructions tells the LLM to delegate through tool calls. Providers
+// append it to ResponseFormatInstructions when they offer tools.
+const ToolInstructions = `Each tool delegates a task to the agent skill it describes.
+To delegate a task, call the matching tool instead of adding a task.request action.
+Keep using the JSON object above for your reasoning and chat.response actions.`
+
+// ToolParameters is the JSON schema of the arguments of tools whose skill
+// declares no input schema. The arguments become the TaskPayload of the
+// task.request action.
+var ToolParameters = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"request": map[string]any{
+			"type":        "string",
+			"description": "What the agent should do, with the details it needs",
+		},
+	},
+	"required": []string{"request"},
+}
+
+// Tool is an agent skill offered to the LLM as a callable tool
+type Tool struct {
+	Name        string // Unique, valid for all providers
+	Description string
+	TargetAgent string
+	TaskType    string
+
+	// InputSchema is the JSON Schema the skill declares for its data parts, nil
+	// when it declares none. Arguments of such tools become the TaskData of the
+	// task.request action.
+	InputSchema map[string]any
+}
+
+// Parameters returns the JSON schema of the tool arguments
+func (t Tool) Parameters() map[string]any {
+	if t.InputSchema != nil {
+		return t.InputSchema
+	}
+	return ToolParameters
+}
+
+// ToolCall is a structured tool call returned by the LLM
+type ToolCall struct {
+	Name      string
+	Arguments map[string]any
+}
+
+// ToolsForAgents offers each skill of the available agents as a tool. A call to
+// the tool requests a task of the skill ID (or name) from the agent. SubAgents
+// identify their skills by the name their handlers are registered under.
+func ToolsForAgents(availableAgents []*pb.AgentCard) []Tool {
+	var tools []Tool
+	used := make(map[string]bool)
+	for _, agent := range availableAgents {
+		for _, skill := range agent.GetSkills() {
+			taskType := skill.GetId()
+			if taskType == "" {
+				taskType = skill.GetName()
+			}
+			if taskType == "" {
+				continue
+			}
+
+			base := toolName(agent.GetName() + "__" + taskType)
+			name := base
+			for i := 2; used[name]; i++ {
+				suffix := fmt.Sprintf("_%d", i)
+				name = base[:min(len(base), maxToolNameLength-len(suffix))] + suffix
+			}
+			used[name] = true
+
+			description := skill.GetDescription()
+			if description == "" {
+				description = skill.GetName()
+			}
+			tools = append(tools, Tool{
+				Name:        name,
+				Description: fmt.Sprintf("%s (handled by %s: %s)", description, agent.GetName(), agent.GetDescription()),
+				TargetAgent: agent.GetName(),
+				TaskType:    taskType,
+				InputSchema: skillInputSchema(skill),
+			})
+		}
+	}
+	return tools
+}
+
+// skillInputSchema decodes the input schema advertised by a skill. Schemas that
+// are not JSON objects are ignored.
+func skillInputSchema(skill *pb.AgentSkill) map[string]any {
+	if skill.GetInputSchema() == "" {
+		return nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(skill.GetInputSchema()), &schema); err != nil {
+		return nil
+	}
+	return schema
+}
+
+// toolName turns s into a tool name accepted by all providers: letters, digits,
+// underscores and dashes, starting with a letter or underscore
+func toolName(s string) string {
+	name := []byte(s)
+	for i, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			name[i] = '_'
+		}
+	}
+	if len(name) == 0 || !(name[0] >= 'a' && name[0] <= 'z' || name[0] >= 'A' && name[0] <= 'Z' || name[0] == '_') {
+		name = append([]byte{'_'}, name...)
+	}
+	return string(name[:min(len(name), maxToolNameLength)])
+}
+
+// ToolCallAction turns a tool call into the task.request action it stands for.
+// It returns false when the call names none of the tools.
+func ToolCallAction(tools []Tool, call ToolCall) (Action, bool) {
+	for _, tool := range tools {
+		if tool.Name != call.Name {
+			continue
+		}
+		action := Action{
+			Type:        "task.request",
+			TaskType:    tool.TaskType,
+			TargetAgent: tool.TargetAgent,
+		}
+		if tool.InputSchema != nil {
+			action.TaskData = call.Arguments
+		} else {
+			action.TaskPayload = call.Arguments
+		}
+		return action, true
+	}
+	return Action{}, false
+}
+
+// SystemPrompt is the system prompt of chat-style providers: the orchestration
+// instructions, the response format and, when tools are offered, how to use them
+func SystemPrompt(availableAgents []*pb.AgentCard, tools []Tool) string {
+	prompt := OrchestrationInstructions(availableAgents) + ResponseFormatInstructions
+	if len(tools) > 0 {
+		prompt += "\n\n" + ToolInstructions
+	}
+	return prompt
+}
+
+// mergeToolCalls builds the decision from the structured tool calls, preferred
+// over task.request actions parsed from the text. The text still provides the
+// reasoning and chat responses when it parses.
+func mergeToolCalls(textDecision *Decision, toolActions []Action) *Decision {
+	decision := &Decision{Reasoning: "Delegating with tool calls"}
+	if textDecision != nil {
+		if textDecision.Reasoning != "" {
+			decision.Reasoning = textDecision.Reasoning
+		}
+		for _, action := range textDecision.Actions {
+			if action.Type != "task.request" {
+				decision.Actions = append(decision.Actions, action)
+			}
+		}
+	}
+	decision.Actions = append(decision.Actions, toolActions...)
+	return decision
+}
+
+// describeToolCalls summarizes tool calls for logs
+func describeToolCalls(calls []ToolCall) string {
+	names := make([]string, len(calls))
+	for i, call := range calls {
+		names[i] = call.Name
+	}
+	return strings.Join(names, ",")
+}
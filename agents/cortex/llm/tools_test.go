@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/subagent"
+)
+
+func TestToolsForAgents(t *testing.T) {
+	agents := []*pb.AgentCard{
+		{Name: "Echo Agent", Skills: []*pb.AgentSkill{{Id: "echo", Description: "Echoes messages"}}},
+		{Name: "9translator", Skills: []*pb.AgentSkill{{Name: "translation"}, {Id: ""}}},
+		{Name: "Echo-Agent", Skills: []*pb.AgentSkill{{Id: "echo"}}},
+	}
+
+	tools := ToolsForAgents(agents)
+	want := []Tool{
+		{Name: "Echo_Agent__echo", TargetAgent: "Echo Agent", TaskType: "echo"},
+		{Name: "_9translator__translation", TargetAgent: "9translator", TaskType: "translation"},
+		{Name: "Echo-Agent__echo", TargetAgent: "Echo-Agent", TaskType: "echo"},
+	}
+	if len(tools) != len(want) {
+		t.Fatalf("Expected %d tools, got %+v", len(want), tools)
+	}
+	for i, tool := range tools {
+		if tool.Name != want[i].Name || tool.TargetAgent != want[i].TargetAgent || tool.TaskType != want[i].TaskType {
+			t.Errorf("Tool %d: expected %+v, got %+v", i, want[i], tool)
+		}
+	}
+
+	duplicates := ToolsForAgents([]*pb.AgentCard{
+		{Name: "a b", Skills: []*pb.AgentSkill{{Id: "x"}}},
+		{Name: "a_b", Skills: []*pb.AgentSkill{{Id: "x"}}},
+	})
+	if duplicates[0].Name != "a_b__x" || duplicates[1].Name != "a_b__x_2" {
+		t.Errorf("Expected unique tool names, got %q and %q", duplicates[0].Name, duplicates[1].Name)
+	}
+}
+
+func TestToolsForAgents_SubAgentCard(t *testing.T) {
+	agent, err := subagent.New(&subagent.Config{AgentID: "agent_translator", Name: "Translator", Description: "Translates text"})
+	if err != nil {
+		t.Fatalf("subagent.New failed: %v", err)
+	}
+	handler := func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	}
+	agent.MustAddSkill("translate", "Translates text", handler)
+	agent.MustAddSkill("detect_language", "Detects the language of text", handler)
+
+	tools := ToolsForAgents([]*pb.AgentCard{agent.GetAgentCard()})
+	want := []string{"detect_language", "translate"}
+	if len(tools) != len(want) {
+		t.Fatalf("Expected %d tools, got %+v", len(want), tools)
+	}
+	for i, tool := range tools {
+		if tool.TaskType != want[i] || tool.TargetAgent != "agent_translator" {
+			t.Errorf("Tool %d: expected a %s task for agent_translator, got %+v", i, want[i], tool)
+		}
+	}
+}
+
+func TestToolsForAgents_InputSchema(t *testing.T) {
+	tools := ToolsForAgents([]*pb.AgentCard{{Name: "counter", Skills: []*pb.AgentSkill{
+		{Id: "count", InputSchema: `{"type": "object", "required": ["n"]}`},
+		{Id: "echo", InputSchema: "not json"},
+	}}})
+	if len(tools) != 2 {
+		t.Fatalf("Expected 2 tools, got %+v", tools)
+	}
+	if tools[0].Parameters()["type"] != "object" || tools[0].InputSchema == nil {
+		t.Errorf("Expected the skill schema as parameters, got %v", tools[0].Parameters())
+	}
+	if tools[1].InputSchema != nil || tools[1].Parameters()["required"] == nil {
+		t.Errorf("Expected the default parameters for an invalid schema, got %v", tools[1].Parameters())
+	}
+
+	arguments := map[string]any{"n": 3}
+	action, ok := ToolCallAction(tools, ToolCall{Name: tools[0].Name, Arguments: arguments})
+	if !ok || action.TaskData["n"] != 3 || action.TaskPayload != nil {
+		t.Errorf("Expected the arguments as task data, got %+v", action)
+	}
+	action, _ = ToolCallAction(tools, ToolCall{Name: tools[1].Name, Arguments: arguments})
+	if action.TaskPayload["n"] != 3 || action.TaskData != nil {
+		t.Errorf("Expected the arguments as task payload, got %+v", action)
+	}
+}
+
+func TestStreamDecision_PrefersToolCalls(t *testing.T) {
+	tools := []Tool{{Name: "translator__translation", TargetAgent: "translator", TaskType: "translation"}}
+	deltas := func(responses ...ResponseDelta) iter.Seq2[ResponseDelta, error] {
+		return func(yield func(ResponseDelta, error) bool) {
+			for _, delta := range responses {
+				if !yield(delta, nil) {
+					return
+				}
+			}
+		}
+	}
+	call := ToolCall{Name: "translator__translation", Arguments: map[string]any{"request": "hello"}}
+
+	tests := []struct {
+		name      string
+		responses []ResponseDelta
+		want      []Action
+	}{
+		{
+			name: "text and tool call",
+			responses: []ResponseDelta{
+				{Text: `{"reasoning": "translate", "actions": [{"type": "chat.response", "responseText": "On it"}, {"type": "task.request", "taskType": "echo", "targetAgent": "echo_agent"}]}`},
+				{ToolCalls: []ToolCall{call}},
+			},
+			want: []Action{
+				{Type: "chat.response", ResponseText: "On it"},
+				{Type: "task.request", TaskType: "translation", TargetAgent: "translator"},
+			},
+		},
+		{
+			name:      "tool call only",
+			responses: []ResponseDelta{{ToolCalls: []ToolCall{call, {Name: "unknown"}}}},
+			want:      []Action{{Type: "task.request", TaskType: "translation", TargetAgent: "translator"}},
+		},
+		{
+			name:      "text only",
+			responses: []ResponseDelta{{Text: `{"reasoning": "r", "actions": [{"type": "task.request", "taskType": "echo", "targetAgent": "echo_agent"}]}`}},
+			want:      []Action{{Type: "task.request", TaskType: "echo", TargetAgent: "echo_agent"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := CollectDecision(StreamDecision(context.Background(), "test", slog.Default(), tools, deltas(tt.responses...)))
+			if err != nil {
+				t.Fatalf("CollectDecision failed: %v", err)
+			}
+			if len(decision.Actions) != len(tt.want) {
+				t.Fatalf("Expected %d actions, got %+v", len(tt.want), decision.Actions)
+			}
+			for i, action := range decision.Actions {
+				want := tt.want[i]
+				if action.Type != want.Type || action.ResponseText != want.ResponseText || action.TaskType != want.TaskType || action.TargetAgent != want.TargetAgent {
+					t.Errorf("Action %d: expected %+v, got %+v", i, want, action)
+				}
+			}
+		})
+	}
+}
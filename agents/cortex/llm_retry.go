@@ -0,0 +1,134 @@
+package cortex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/owulveryck/agenthub/agents/cortex/llm"
+	"github.com/owulveryck/agenthub/agents/cortex/state"
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultLLMTimeout bounds a single LLM call
+	DefaultLLMTimeout = 60 * time.Second
+	// DefaultLLMMaxRetries is the number of retries after a failed LLM call
+	DefaultLLMMaxRetries = 2
+	// DefaultLLMRetryBackoff is the delay before the first retry, doubled after each failure
+	DefaultLLMRetryBackoff = time.Second
+)
+
+// LLMFallbackResponse is sent to the user when the LLM cannot be reached
+const LLMFallbackResponse = "Sorry, I'm having trouble thinking right now. Please try again in a moment."
+
+// LLM operations reported by the cortex_llm_* metrics
+const (
+	LLMOperationDecide     = "decide"
+	LLMOperationSynthesize = "synthesize"
+)
+
+// Option customizes a Cortex created by NewCortex.
+type Option func(*Cortex)
+
+// WithLLMTimeout bounds each LLM call. Zero disables the timeout.
+func WithLLMTimeout(timeout time.Duration) Option {
+	return func(c *Cortex) {
+		c.llmTimeout = timeout
+	}
+}
+
+// WithLLMRetry retries a failed LLM call up to maxRetries times, waiting
+// backoff before the first retry and doubling it after each failure.
+func WithLLMRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Cortex) {
+		c.llmMaxRetries = maxRetries
+		c.llmRetryBackoff = backoff
+	}
+}
+
+// errResponseStreamed marks a decision that failed after part of its response was
+// streamed to the user. Retrying it would stream a second response after the
+// partial one, so it is not retried.
+var errResponseStreamed = errors.New("decision failed after streaming part of the response")
+
+// decideWithRetry calls decide with the configured timeout, retrying failures
+// with exponential backoff. Retries are recorded as events on span. Failures
+// after response deltas were published are not retried.
+func (c *Cortex) decideWithRetry(ctx context.Context, traceManager *observability.TraceManager, span trace.Span, operation string, conversationState *state.ConversationState, availableAgents []*pb.AgentCard, msg *pb.Message) (*llm.Decision, error) {
+	backoff := c.llmRetryBackoff
+	for attempt := 0; ; attempt++ {
+		decision, err := c.decideWithTimeout(ctx, conversationState, availableAgents, msg)
+		if err == nil {
+			return decision, nil
+		}
+
+		if attempt >= c.llmMaxRetries || ctx.Err() != nil || errors.Is(err, errResponseStreamed) {
+			if c.metricsManager != nil {
+				c.metricsManager.IncrementCortexLLMFailures(ctx, operation)
+			}
+			traceManager.AddSpanEvent(span, "llm_retries_exhausted",
+				attribute.Int("attempts", attempt+1),
+				attribute.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		if c.metricsManager != nil {
+			c.metricsManager.IncrementCortexLLMRetries(ctx, operation)
+		}
+		traceManager.AddSpanEvent(span, "llm_retry",
+			attribute.Int("attempt", attempt+1),
+			attribute.String("error", err.Error()),
+			attribute.String("backoff", backoff.String()),
+		)
+		c.logger.WarnContext(ctx, "LLM call failed, retrying",
+			"operation", operation,
+			"attempt", attempt+1,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// decideWithTimeout runs a single decide call bounded by the LLM timeout.
+func (c *Cortex) decideWithTimeout(ctx context.Context, conversationState *state.ConversationState, availableAgents []*pb.AgentCard, msg *pb.Message) (*llm.Decision, error) {
+	if c.llmTimeout <= 0 {
+		return c.decide(ctx, conversationState, availableAgents, msg)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.llmTimeout)
+	defer cancel()
+	decision, err := c.decide(callCtx, conversationState, availableAgents, msg)
+	if err != nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, fmt.Errorf("LLM call timed out after %s: %w", c.llmTimeout, err)
+	}
+	return decision, err
+}
+
+// sendFallbackResponse tells the user that their message could not be processed
+// after the LLM failed for good.
+func (c *Cortex) sendFallbackResponse(ctx context.Context, traceManager *observability.TraceManager, conversationState *state.ConversationState, triggeringMsg *pb.Message, llmErr error) error {
+	c.logger.ErrorContext(ctx, "LLM unavailable, sending fallback response",
+		"session_id", conversationState.SessionID,
+		"message_id", triggeringMsg.GetMessageId(),
+		"error", llmErr,
+	)
+
+	action := llm.Action{Type: "chat.response", ResponseText: LLMFallbackResponse}
+	if err := c.executeChatResponse(ctx, traceManager, conversationState, action, triggeringMsg); err != nil {
+		return fmt.Errorf("LLM decision failed: %w (fallback response failed: %v)", llmErr, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package state
+
+import (
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DefaultMaxMessages is the default bound on the conversation history length.
+const DefaultMaxMessages = 200
+
+// NewConversationState creates an empty conversation state for a session
+// with the default history limit.
+func NewConversationState(sessionID string) *ConversationState {
+	return &ConversationState{
+		SessionID:        sessionID,
+		RegisteredAgents: make(map[string]*pb.AgentCard),
+		messages:         []*pb.Message{},
+		pendingTasks:     make(map[string]*TaskContext),
+		maxMessages:      DefaultMaxMessages,
+	}
+}
+
+// SetMaxMessages changes the history limit and trims the history if needed.
+// A value of 0 disables the limit.
+func (cs *ConversationState) SetMaxMessages(n int) {
+	if n < 0 {
+		n = 0
+	}
+	cs.maxMessages = n
+	cs.trimHistory()
+}
+
+// MaxMessages returns the history limit, 0 means unbounded.
+func (cs *ConversationState) MaxMessages() int {
+	return cs.maxMessages
+}
+
+// AppendMessage adds a message to the history, dropping the oldest
+// messages when the history limit is exceeded.
+func (cs *ConversationState) AppendMessage(msg *pb.Message) {
+	if msg == nil {
+		return
+	}
+	cs.messages = append(cs.messages, msg)
+	cs.trimHistory()
+}
+
+// Messages returns a copy of the full conversation history, oldest first.
+func (cs *ConversationState) Messages() []*pb.Message {
+	return cs.RecentMessages(len(cs.messages))
+}
+
+// RecentMessages returns a copy of the last n messages, oldest first.
+func (cs *ConversationState) RecentMessages(n int) []*pb.Message {
+	if n <= 0 {
+		return []*pb.Message{}
+	}
+	if n > len(cs.messages) {
+		n = len(cs.messages)
+	}
+	recent := make([]*pb.Message, n)
+	copy(recent, cs.messages[len(cs.messages)-n:])
+	return recent
+}
+
+// CompactHistory replaces all but the last keep messages with summary, so
+// that older messages no longer weigh on the LLM context window.
+func (cs *ConversationState) CompactHistory(summary *pb.Message, keep int) {
+	if summary == nil || keep < 0 || keep >= len(cs.messages) {
+		return
+	}
+	compacted := make([]*pb.Message, 0, keep+1)
+	compacted = append(compacted, summary)
+	cs.messages = append(compacted, cs.messages[len(cs.messages)-keep:]...)
+}
+
+// MessageCount returns the number of messages in the history.
+func (cs *ConversationState) MessageCount() int {
+	return len(cs.messages)
+}
+
+// AddPendingTask tracks a task as pending. It replaces any task with the same ID.
+func (cs *ConversationState) AddPendingTask(task *TaskContext) error {
+	if task == nil {
+		return &StateError{Op: "add_pending_task", Err: "task cannot be nil"}
+	}
+	if task.TaskID == "" {
+		return &StateError{Op: "add_pending_task", Err: "task ID cannot be empty"}
+	}
+	if cs.pendingTasks == nil {
+		cs.pendingTasks = make(map[string]*TaskContext)
+	}
+	cs.pendingTasks[task.TaskID] = task
+	return nil
+}
+
+// PendingTask returns the pending task with the given ID.
+func (cs *ConversationState) PendingTask(taskID string) (*TaskContext, bool) {
+	task, ok := cs.pendingTasks[taskID]
+	return task, ok
+}
+
+// UpdatePendingTask applies fn to the pending task with the given ID.
+// It returns false if the task is not pending.
+func (cs *ConversationState) UpdatePendingTask(taskID string, fn func(*TaskContext)) bool {
+	task, ok := cs.pendingTasks[taskID]
+	if !ok {
+		return false
+	}
+	fn(task)
+	return true
+}
+
+// CompletePendingTask removes a task from the pending set and returns it.
+// It returns false if the task was not pending.
+func (cs *ConversationState) CompletePendingTask(taskID string) (*TaskContext, bool) {
+	task, ok := cs.pendingTasks[taskID]
+	if ok {
+		delete(cs.pendingTasks, taskID)
+	}
+	return task, ok
+}
+
+// PendingTaskCount returns the number of pending tasks.
+func (cs *ConversationState) PendingTaskCount() int {
+	return len(cs.pendingTasks)
+}
+
+// PendingTaskIDs returns the IDs of all pending tasks.
+func (cs *ConversationState) PendingTaskIDs() []string {
+	ids := make([]string, 0, len(cs.pendingTasks))
+	for id := range cs.pendingTasks {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// trimHistory drops the oldest messages beyond the history limit
+func (cs *ConversationState) trimHistory() {
+	if cs.maxMessages > 0 && len(cs.messages) > cs.maxMessages {
+		cs.messages = append([]*pb.Message(nil), cs.messages[len(cs.messages)-cs.maxMessages:]...)
+	}
+}
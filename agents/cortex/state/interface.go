@@ -6,11 +6,15 @@ import (
 
 // ConversationState represents the history and context of a single conversation.
 // This is the state that Cortex maintains for each session.
+// History and pending tasks are only reachable through methods so that
+// limits and indices stay consistent.
 type ConversationState struct {
-	SessionID        string        // Unique identifier for this conversation session
-	Messages         []*pb.Message // Full conversation history (both USER and AGENT messages)
-	PendingTasks     map[string]*TaskContext
+	SessionID        string                   // Unique identifier for this conversation session
 	RegisteredAgents map[string]*pb.AgentCard // Agents available in this session
+
+	messages     []*pb.Message           // Conversation history (both USER and AGENT messages)
+	pendingTasks map[string]*TaskContext // Pending tasks indexed by task ID
+	maxMessages  int                     // Maximum history length, 0 means unbounded
 }
 
 // TaskContext tracks the context of a pending task to maintain correlation
@@ -35,12 +35,7 @@ func (sm *InMemoryStateManager) Get(sessionID string) (*ConversationState, error
 	}
 
 	// Return a new empty state
-	return &ConversationState{
-		SessionID:        sessionID,
-		Messages:         []*pb.Message{},
-		PendingTasks:     make(map[string]*TaskContext),
-		RegisteredAgents: make(map[string]*pb.AgentCard),
-	}, nil
+	return NewConversationState(sessionID), nil
 }
 
 // Set persists the conversation state for a given session.
@@ -120,23 +115,20 @@ func copyState(state *ConversationState) *ConversationState {
 	// Create new state
 	newState := &ConversationState{
 		SessionID:        state.SessionID,
-		Messages:         make([]*pb.Message, len(state.Messages)),
-		PendingTasks:     make(map[string]*TaskContext),
 		RegisteredAgents: make(map[string]*pb.AgentCard),
+		messages:         make([]*pb.Message, len(state.messages)),
+		pendingTasks:     make(map[string]*TaskContext),
+		maxMessages:      state.maxMessages,
 	}
 
 	// Copy messages (proto messages are immutable in Go, so we can share pointers)
-	copy(newState.Messages, state.Messages)
+	copy(newState.messages, state.messages)
 
 	// Copy pending tasks
-	for k, v := range state.PendingTasks {
-		newState.PendingTasks[k] = &TaskContext{
-			TaskID:        v.TaskID,
-			TaskType:      v.TaskType,
-			RequestedAt:   v.RequestedAt,
-			OriginalInput: v.OriginalInput,
-			UserNotified:  v.UserNotified,
-		}
+	for k, v := range state.pendingTasks {
+		taskCopy := *v
+		taskCopy.Artifacts = append([]*pb.Artifact(nil), v.Artifacts...)
+		newState.pendingTasks[k] = &taskCopy
 	}
 
 	// Copy registered agents (proto messages are immutable)
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 
@@ -23,20 +25,14 @@ func TestInMemoryStateManager_GetSet(t *testing.T) {
 	}
 
 	// Test Set and Get
-	testState := &ConversationState{
-		SessionID: "test-session",
-		Messages: []*pb.Message{
-			{
-				MessageId: "msg-1",
-				Role:      pb.Role_ROLE_USER,
-				Content: []*pb.Part{
-					{Part: &pb.Part_Text{Text: "Hello"}},
-				},
-			},
+	testState := NewConversationState("test-session")
+	testState.AppendMessage(&pb.Message{
+		MessageId: "msg-1",
+		Role:      pb.Role_ROLE_USER,
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: "Hello"}},
 		},
-		PendingTasks:     make(map[string]*TaskContext),
-		RegisteredAgents: make(map[string]*pb.AgentCard),
-	}
+	})
 
 	err = sm.Set("test-session", testState)
 	if err != nil {
@@ -52,24 +48,19 @@ func TestInMemoryStateManager_GetSet(t *testing.T) {
 		t.Errorf("Expected SessionID %s, got %s", testState.SessionID, retrieved.SessionID)
 	}
 
-	if len(retrieved.Messages) != 1 {
-		t.Errorf("Expected 1 message, got %d", len(retrieved.Messages))
+	if retrieved.MessageCount() != 1 {
+		t.Fatalf("Expected 1 message, got %d", retrieved.MessageCount())
 	}
 
-	if retrieved.Messages[0].MessageId != "msg-1" {
-		t.Errorf("Expected message ID 'msg-1', got %s", retrieved.Messages[0].MessageId)
+	if retrieved.Messages()[0].MessageId != "msg-1" {
+		t.Errorf("Expected message ID 'msg-1', got %s", retrieved.Messages()[0].MessageId)
 	}
 }
 
 func TestInMemoryStateManager_Delete(t *testing.T) {
 	sm := NewInMemoryStateManager()
 
-	state := &ConversationState{
-		SessionID:        "test-delete",
-		Messages:         []*pb.Message{},
-		PendingTasks:     make(map[string]*TaskContext),
-		RegisteredAgents: make(map[string]*pb.AgentCard),
-	}
+	state := NewConversationState("test-delete")
 
 	err := sm.Set("test-delete", state)
 	if err != nil {
@@ -96,7 +87,7 @@ func TestInMemoryStateManager_Delete(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Get after delete failed: %v", err)
 	}
-	if len(retrieved.Messages) != 0 {
+	if retrieved.MessageCount() != 0 {
 		t.Error("State should be empty after deletion")
 	}
 }
@@ -116,7 +107,7 @@ func TestInMemoryStateManager_Concurrency(t *testing.T) {
 
 			err := sm.WithLock(sessionID, func(state *ConversationState) error {
 				// Add a message
-				state.Messages = append(state.Messages, &pb.Message{
+				state.AppendMessage(&pb.Message{
 					MessageId: string(rune('a' + index)),
 					Role:      pb.Role_ROLE_USER,
 					Content: []*pb.Part{
@@ -140,8 +131,8 @@ func TestInMemoryStateManager_Concurrency(t *testing.T) {
 		t.Fatalf("Get failed: %v", err)
 	}
 
-	if len(state.Messages) != numGoroutines {
-		t.Errorf("Expected %d messages, got %d (lost updates detected)", numGoroutines, len(state.Messages))
+	if state.MessageCount() != numGoroutines {
+		t.Errorf("Expected %d messages, got %d (lost updates detected)", numGoroutines, state.MessageCount())
 	}
 }
 
@@ -151,7 +142,7 @@ func TestInMemoryStateManager_WithLock(t *testing.T) {
 
 	// Use WithLock to safely update state
 	err := sm.WithLock(sessionID, func(state *ConversationState) error {
-		state.Messages = append(state.Messages, &pb.Message{
+		state.AppendMessage(&pb.Message{
 			MessageId: "msg-locked",
 			Role:      pb.Role_ROLE_USER,
 			Content: []*pb.Part{
@@ -171,12 +162,12 @@ func TestInMemoryStateManager_WithLock(t *testing.T) {
 		t.Fatalf("Get failed: %v", err)
 	}
 
-	if len(state.Messages) != 1 {
-		t.Errorf("Expected 1 message, got %d", len(state.Messages))
+	if state.MessageCount() != 1 {
+		t.Fatalf("Expected 1 message, got %d", state.MessageCount())
 	}
 
-	if state.Messages[0].MessageId != "msg-locked" {
-		t.Errorf("Expected message ID 'msg-locked', got %s", state.Messages[0].MessageId)
+	if state.Messages()[0].MessageId != "msg-locked" {
+		t.Errorf("Expected message ID 'msg-locked', got %s", state.Messages()[0].MessageId)
 	}
 }
 
@@ -198,3 +189,74 @@ func TestInMemoryStateManager_WithLock_Error(t *testing.T) {
 		t.Errorf("Expected error message 'state test: test error', got '%s'", err.Error())
 	}
 }
+
+func TestConversationState_HistoryLimit(t *testing.T) {
+	state := NewConversationState("limit-test")
+	state.SetMaxMessages(3)
+
+	for i := 0; i < 5; i++ {
+		state.AppendMessage(&pb.Message{MessageId: string(rune('a' + i))})
+	}
+
+	if state.MessageCount() != 3 {
+		t.Fatalf("Expected 3 messages, got %d", state.MessageCount())
+	}
+
+	recent := state.RecentMessages(2)
+	if len(recent) != 2 || recent[0].MessageId != "d" || recent[1].MessageId != "e" {
+		t.Errorf("Expected recent messages [d e], got %v", recent)
+	}
+
+	if got := state.Messages()[0].MessageId; got != "c" {
+		t.Errorf("Expected oldest message 'c', got %s", got)
+	}
+}
+
+func TestConversationState_PendingTasks(t *testing.T) {
+	state := NewConversationState("tasks-test")
+
+	if err := state.AddPendingTask(&TaskContext{}); err == nil {
+		t.Error("Expected error when adding a task without ID")
+	}
+
+	if err := state.AddPendingTask(&TaskContext{TaskID: "task-1", TaskType: "echo"}); err != nil {
+		t.Fatalf("AddPendingTask failed: %v", err)
+	}
+
+	if state.PendingTaskCount() != 1 {
+		t.Errorf("Expected 1 pending task, got %d", state.PendingTaskCount())
+	}
+
+	task, ok := state.CompletePendingTask("task-1")
+	if !ok || task.TaskType != "echo" {
+		t.Fatalf("Expected to complete task-1, got %v, %v", task, ok)
+	}
+
+	if _, ok := state.CompletePendingTask("task-1"); ok {
+		t.Error("Completing the same task twice should report false")
+	}
+}
+
+func TestConversationState_CompactHistory(t *testing.T) {
+	cs := NewConversationState("session-1")
+	for i := 0; i < 5; i++ {
+		cs.AppendMessage(&pb.Message{MessageId: fmt.Sprintf("msg-%d", i)})
+	}
+
+	cs.CompactHistory(&pb.Message{MessageId: "summary"}, 2)
+
+	messages := cs.Messages()
+	var ids []string
+	for _, msg := range messages {
+		ids = append(ids, msg.GetMessageId())
+	}
+	if got := strings.Join(ids, ","); got != "summary,msg-3,msg-4" {
+		t.Errorf("Expected summary followed by the last 2 messages, got %s", got)
+	}
+
+	// Nothing to compact when all messages are kept
+	cs.CompactHistory(&pb.Message{MessageId: "other"}, 3)
+	if cs.MessageCount() != 3 || cs.Messages()[0].GetMessageId() != "summary" {
+		t.Error("Expected the history unchanged")
+	}
+}
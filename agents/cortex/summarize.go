@@ -0,0 +1,131 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/owulveryck/agenthub/agents/cortex/llm"
+	"github.com/owulveryck/agenthub/agents/cortex/state"
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// DefaultSummarizeMaxMessages is the history length beyond which older messages are summarized
+	DefaultSummarizeMaxMessages = 50
+	// DefaultSummarizeMaxTokens is the estimated history size beyond which older messages are summarized
+	DefaultSummarizeMaxTokens = 8000
+	// DefaultSummarizeKeepRecent is the number of recent messages kept verbatim by a summarization
+	DefaultSummarizeKeepRecent = 10
+)
+
+// SummaryMetadataKey marks the message holding the summary of older messages
+const SummaryMetadataKey = "conversation_summary"
+
+// summaryPrefix introduces the summary to the LLM
+const summaryPrefix = "Summary of the earlier conversation: "
+
+// WithSummarization summarizes the older messages of a conversation when it
+// holds more than maxMessages messages or an estimated maxTokens tokens,
+// keeping the last keepRecent messages verbatim. A threshold of 0 disables it;
+// summarization also requires an LLM client implementing llm.Summarizer.
+func WithSummarization(maxMessages, maxTokens, keepRecent int) Option {
+	return func(c *Cortex) {
+		c.summarizeMaxMessages = maxMessages
+		c.summarizeMaxTokens = maxTokens
+		c.summarizeKeepRecent = keepRecent
+	}
+}
+
+// estimateTokens approximates the token count of the messages' text,
+// counting one token per four bytes
+func estimateTokens(messages []*pb.Message) int {
+	var size int
+	for _, msg := range messages {
+		for _, part := range msg.GetContent() {
+			size += len(part.GetText())
+		}
+	}
+	return size / 4
+}
+
+// summarizeIfNeeded replaces the older messages of the conversation with a
+// summary once a threshold is exceeded. The token threshold is ignored when the
+// kept messages alone exceed it, as summarizing could not bring the history
+// within budget and would be repeated on every message. Failures are logged and
+// leave the history unchanged, as the history limit still bounds it.
+func (c *Cortex) summarizeIfNeeded(ctx context.Context, traceManager *observability.TraceManager, conversationState *state.ConversationState) {
+	summarizer, ok := c.llmClient.(llm.Summarizer)
+	if !ok {
+		return
+	}
+
+	// The newest message is the event being handled: it is always kept
+	keep := max(c.summarizeKeepRecent, 1)
+	count := conversationState.MessageCount()
+	if count <= keep+1 {
+		return
+	}
+	messages := conversationState.Messages()
+	older := messages[:count-keep]
+	tokens := estimateTokens(messages)
+	overMessages := c.summarizeMaxMessages > 0 && count > c.summarizeMaxMessages
+	overTokens := c.summarizeMaxTokens > 0 && tokens > c.summarizeMaxTokens &&
+		tokens-estimateTokens(older) <= c.summarizeMaxTokens
+	if !overMessages && !overTokens {
+		return
+	}
+
+	sumCtx, sumSpan := traceManager.StartSpan(ctx, "cortex.summarize_history",
+		attribute.String("session_id", conversationState.SessionID),
+	)
+	defer sumSpan.End()
+	traceManager.AddSpanEvent(sumSpan, "conversation_summarization_triggered",
+		attribute.Int("message_count", count),
+		attribute.Int("estimated_tokens", tokens),
+		attribute.Bool("message_threshold_exceeded", overMessages),
+		attribute.Bool("token_threshold_exceeded", overTokens),
+		attribute.Int("summarized_messages", len(older)),
+		attribute.Int("kept_messages", keep),
+	)
+
+	callCtx := sumCtx
+	if c.llmTimeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(sumCtx, c.llmTimeout)
+		defer cancel()
+	}
+	summary, err := summarizer.Summarize(callCtx, older)
+	if err != nil {
+		traceManager.RecordError(sumSpan, err)
+		c.logger.WarnContext(ctx, "Conversation summarization failed, keeping full history",
+			"session_id", conversationState.SessionID,
+			"error", err,
+		)
+		return
+	}
+
+	conversationState.CompactHistory(&pb.Message{
+		MessageId: c.ids.NewID("summary"),
+		ContextId: conversationState.SessionID,
+		Role:      pb.Role_ROLE_USER,
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: summaryPrefix + summary}},
+		},
+		Metadata: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				SummaryMetadataKey:    structpb.NewBoolValue(true),
+				"summarized_messages": structpb.NewNumberValue(float64(len(older))),
+			},
+		},
+	}, keep)
+
+	traceManager.SetSpanSuccess(sumSpan)
+	c.logger.InfoContext(ctx, "Summarized conversation history",
+		"session_id", conversationState.SessionID,
+		"summarized_messages", len(older),
+		"kept_messages", keep,
+		"estimated_tokens", tokens,
+	)
+}
@@ -0,0 +1,99 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/owulveryck/agenthub/agents/cortex/llm"
+	"github.com/owulveryck/agenthub/internal/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InvalidActionsResponse introduces the chat response telling the user which of
+// the actions decided by the LLM were rejected
+const InvalidActionsResponse = "Sorry, I couldn't carry out part of your request:"
+
+// taskTypePattern matches the task types an LLM may plausibly request: an
+// identifier such as "translation" or "image.resize", not a sentence
+var taskTypePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,127}$`)
+
+// validateActions checks the actions decided by the LLM before any of them runs.
+// Task requests routed to an agent that cannot serve them are re-routed to one that
+// can. Actions that cannot be repaired are dropped and, instead of failing silently,
+// a chat response listing them is appended for the user.
+func (c *Cortex) validateActions(ctx context.Context, traceManager *observability.TraceManager, span trace.Span, actions []llm.Action) []llm.Action {
+	valid := make([]llm.Action, 0, len(actions))
+	var problems []string
+	for i, action := range actions {
+		repaired, problem := c.validateAction(action)
+		if problem != "" {
+			traceManager.AddSpanEvent(span, "action_rejected",
+				attribute.Int("action_index", i),
+				attribute.String("action_type", action.Type),
+				attribute.String("reason", problem),
+			)
+			c.logger.WarnContext(ctx, "Rejected invalid action decided by the LLM",
+				"action_index", i,
+				"action_type", action.Type,
+				"reason", problem,
+			)
+			problems = append(problems, problem)
+			continue
+		}
+		if repaired.TargetAgent != action.TargetAgent {
+			traceManager.AddSpanEvent(span, "action_repaired",
+				attribute.Int("action_index", i),
+				attribute.String("task_type", action.TaskType),
+				attribute.String("requested_agent", action.TargetAgent),
+				attribute.String("target_agent", repaired.TargetAgent),
+			)
+			c.logger.WarnContext(ctx, "Requested agent cannot serve task, re-routing",
+				"task_type", action.TaskType,
+				"requested_agent", action.TargetAgent,
+				"target_agent", repaired.TargetAgent,
+			)
+		}
+		valid = append(valid, repaired)
+	}
+
+	if len(problems) > 0 {
+		valid = append(valid, llm.Action{
+			Type:         "chat.response",
+			ResponseText: InvalidActionsResponse + "\n- " + strings.Join(problems, "\n- "),
+		})
+	}
+	return valid
+}
+
+// validateAction returns the action, repaired if needed, or why it is invalid
+func (c *Cortex) validateAction(action llm.Action) (llm.Action, string) {
+	switch action.Type {
+	case "chat.response":
+		if strings.TrimSpace(action.ResponseText) == "" {
+			return action, "the response was empty"
+		}
+
+	case "task.request":
+		if strings.TrimSpace(action.TaskType) == "" {
+			return action, "a task was requested without a task type"
+		}
+		if !taskTypePattern.MatchString(action.TaskType) {
+			return action, fmt.Sprintf("%q is not a valid task type", truncateString(action.TaskType, 50))
+		}
+		// Broadcast requests leave the choice to the agents
+		if action.TargetAgent != "" {
+			target, err := c.resolveTaskTarget(action.TargetAgent, action.TaskType)
+			if err != nil {
+				return action, fmt.Sprintf("no available agent can handle %q tasks", action.TaskType)
+			}
+			action.TargetAgent = target
+		}
+
+	default:
+		return action, fmt.Sprintf("the action %q is not supported", action.Type)
+	}
+	return action, ""
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
 	"github.com/owulveryck/agenthub/internal/subagent"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -19,7 +20,6 @@ func main() {
 		Name:        "Echo Agent",
 		Description: "A simple agent that echoes messages back to demonstrate task delegation",
 		Version:     "1.0.0",
-		HealthPort:  "8085",
 	}
 
 	// Create the subagent
@@ -33,6 +33,7 @@ func main() {
 		"Echo Messages",
 		"Echoes the input text back to the sender",
 		echoHandler,
+		subagent.WithExpectedParts(agenthub.ExactlyParts(agenthub.PartKindText, 1)),
 	)
 
 	// Run the agent (blocks until shutdown signal)
@@ -43,15 +44,8 @@ func main() {
 
 // echoHandler implements the echo logic
 func echoHandler(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
-	// Extract input from message
-	var input string
-	for _, part := range message.Content {
-		if text := part.GetText(); text != "" {
-			input = text
-			break
-		}
-	}
-
+	// The skill expects exactly one text part
+	input := agenthub.ExtractParts(message).Texts[0]
 	if input == "" {
 		return nil, pb.TaskState_TASK_STATE_FAILED, "No input text provided"
 	}
@@ -61,7 +55,7 @@ func echoHandler(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.A
 
 	// Create artifact with the echo response
 	artifact := &pb.Artifact{
-		ArtifactId:  fmt.Sprintf("echo_%s_%d", task.GetId(), time.Now().Unix()),
+		ArtifactId:  agenthub.DefaultIDGenerator.NewID("echo_" + task.GetId()),
 		Name:        "echo_response",
 		Description: "Echoed message",
 		Parts: []*pb.Part{
@@ -5,14 +5,13 @@ import (
 	"fmt"
 	"time"
 
-	"google.golang.org/protobuf/types/known/structpb"
-
 	pb "github.com/owulveryck/agenthub/events/a2a"
 	"github.com/owulveryck/agenthub/internal/agenthub"
 )
 
 const (
 	publisherAgentID = "agent_demo_publisher"
+	taskWaitTimeout  = 10 * time.Second
 )
 
 func main() {
@@ -21,7 +20,6 @@ func main() {
 
 	// Create gRPC configuration for publisher
 	config := agenthub.NewGRPCConfig("publisher")
-	config.HealthPort = "8081" // Different port for publisher health
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
@@ -44,124 +42,79 @@ func main() {
 	}
 
 	// Create A2A task publisher
-	taskPublisher := &agenthub.A2ATaskPublisher{
-		Client:         client.Client,
-		TraceManager:   client.TraceManager,
-		MetricsManager: client.MetricsManager,
-		Logger:         client.Logger,
-		ComponentName:  "publisher",
-		AgentID:        publisherAgentID,
-	}
+	taskPublisher := agenthub.NewA2ATaskPublisher(client, "publisher", publisherAgentID, nil)
 
 	client.Logger.InfoContext(ctx, "Starting publisher demo")
 	client.Logger.InfoContext(ctx, "Testing Agent2Agent Task Publishing via AgentHub with observability")
 
+	// newTask starts a demo task addressed to the demo subscriber
+	newTask := func(taskType string) *agenthub.TaskBuilder {
+		return agenthub.NewTask(taskType).
+			From(publisherAgentID).
+			To("agent_demo_subscriber").
+			Priority(pb.Priority_PRIORITY_MEDIUM)
+	}
+	mustBuild := func(builder *agenthub.TaskBuilder) *agenthub.A2APublishTaskRequest {
+		req, err := builder.Build()
+		if err != nil {
+			panic(err)
+		}
+		return req
+	}
+
 	// Demo Task 1: Greeting task (A2A-compliant)
-	task1, err := taskPublisher.PublishTask(ctx, &agenthub.A2APublishTaskRequest{
-		TaskType: "greeting",
-		Content: []*pb.Part{
-			{
-				Part: &pb.Part_Text{
-					Text: "Hello! Please provide a greeting for Claude.",
-				},
-			},
-		},
-		RequesterAgentID: publisherAgentID,
-		ResponderAgentID: "agent_demo_subscriber",
-		Priority:         pb.Priority_PRIORITY_MEDIUM,
-	})
+	task1, err := taskPublisher.PublishTaskAndWait(ctx, mustBuild(newTask("greeting").
+		WithText("Hello! Please provide a greeting for Claude."),
+	), taskWaitTimeout)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to publish greeting task: %v", err))
 	}
-	client.Logger.InfoContext(ctx, "Published greeting task", "task_id", task1.GetId())
-
-	time.Sleep(3 * time.Second)
+	client.Logger.InfoContext(ctx, "Finished greeting task",
+		"task_id", task1.GetId(),
+		"state", task1.GetStatus().GetState().String(),
+		"artifacts", len(task1.GetArtifacts()),
+	)
 
 	// Demo Task 2: Math calculation (A2A-compliant)
-	task2, err := taskPublisher.PublishTask(ctx, &agenthub.A2APublishTaskRequest{
-		TaskType: "math_calculation",
-		Content: []*pb.Part{
-			{
-				Part: &pb.Part_Text{
-					Text: "Please calculate 42 + 58.",
-				},
-			},
-			{
-				Part: &pb.Part_Data{
-					Data: &pb.DataPart{
-						Data: &structpb.Struct{
-							Fields: map[string]*structpb.Value{
-								"operation": structpb.NewStringValue("add"),
-								"a":         structpb.NewNumberValue(42.0),
-								"b":         structpb.NewNumberValue(58.0),
-							},
-						},
-					},
-				},
-			},
-		},
-		RequesterAgentID: publisherAgentID,
-		ResponderAgentID: "agent_demo_subscriber",
-		Priority:         pb.Priority_PRIORITY_MEDIUM,
-	})
+	task2, err := taskPublisher.PublishTaskAndWait(ctx, mustBuild(newTask("math_calculation").
+		WithText("Please calculate 42 + 58.").
+		WithData(map[string]any{"operation": "add", "a": 42.0, "b": 58.0}),
+	), taskWaitTimeout)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to publish math calculation task: %v", err))
 	}
-	client.Logger.InfoContext(ctx, "Published math task", "task_id", task2.GetId())
-
-	time.Sleep(2 * time.Second)
+	client.Logger.InfoContext(ctx, "Finished math task",
+		"task_id", task2.GetId(),
+		"state", task2.GetStatus().GetState().String(),
+		"artifacts", len(task2.GetArtifacts()),
+	)
 
 	// Demo Task 3: Random number generation (A2A-compliant)
-	task3, err := taskPublisher.PublishTask(ctx, &agenthub.A2APublishTaskRequest{
-		TaskType: "random_number",
-		Content: []*pb.Part{
-			{
-				Part: &pb.Part_Text{
-					Text: "Please generate a random number using seed 12345.",
-				},
-			},
-			{
-				Part: &pb.Part_Data{
-					Data: &pb.DataPart{
-						Data: &structpb.Struct{
-							Fields: map[string]*structpb.Value{
-								"seed": structpb.NewNumberValue(12345),
-							},
-						},
-					},
-				},
-			},
-		},
-		RequesterAgentID: publisherAgentID,
-		ResponderAgentID: "agent_demo_subscriber",
-		Priority:         pb.Priority_PRIORITY_MEDIUM,
-	})
+	task3, err := taskPublisher.PublishTaskAndWait(ctx, mustBuild(newTask("random_number").
+		WithText("Please generate a random number using seed 12345.").
+		WithData(map[string]any{"seed": 12345}),
+	), taskWaitTimeout)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to publish random number task: %v", err))
 	}
-	client.Logger.InfoContext(ctx, "Published random number task", "task_id", task3.GetId())
-
-	time.Sleep(2 * time.Second)
+	client.Logger.InfoContext(ctx, "Finished random number task",
+		"task_id", task3.GetId(),
+		"state", task3.GetStatus().GetState().String(),
+		"artifacts", len(task3.GetArtifacts()),
+	)
 
 	// Demo Task 4: Unknown task type (should fail)
-	task4, err := taskPublisher.PublishTask(ctx, &agenthub.A2APublishTaskRequest{
-		TaskType: "unknown_task",
-		Content: []*pb.Part{
-			{
-				Part: &pb.Part_Text{
-					Text: "This is an unknown task type for testing error handling.",
-				},
-			},
-		},
-		RequesterAgentID: publisherAgentID,
-		ResponderAgentID: "agent_demo_subscriber",
-		Priority:         pb.Priority_PRIORITY_MEDIUM,
-	})
+	task4, err := taskPublisher.PublishTaskAndWait(ctx, mustBuild(newTask("unknown_task").
+		WithText("This is an unknown task type for testing error handling."),
+	), taskWaitTimeout)
 	if err != nil {
 		client.Logger.InfoContext(ctx, "Expected failure for unknown task type", "error", err)
 	} else {
-		client.Logger.InfoContext(ctx, "Published unknown task", "task_id", task4.GetId())
+		client.Logger.InfoContext(ctx, "Finished unknown task",
+			"task_id", task4.GetId(),
+			"state", task4.GetStatus().GetState().String(),
+		)
 	}
 
-	client.Logger.InfoContext(ctx, "All tasks published! Check subscriber logs for results")
+	client.Logger.InfoContext(ctx, "All tasks finished")
 }
@@ -29,7 +29,6 @@ func main() {
 
 	// Create gRPC configuration for subscriber
 	config := agenthub.NewGRPCConfig("subscriber")
-	config.HealthPort = "8082" // Different port for subscriber health
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
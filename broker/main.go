@@ -7,9 +7,15 @@ import (
 	"syscall"
 
 	"github.com/owulveryck/agenthub/internal/agenthub"
+	"github.com/owulveryck/agenthub/internal/config"
 )
 
 func main() {
+	// Fail fast on misconfiguration
+	if _, err := config.LoadStrict(); err != nil {
+		panic(err)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
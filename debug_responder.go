@@ -34,7 +34,6 @@ func main() {
 
 	// Create gRPC configuration for responder
 	config := agenthub.NewGRPCConfig("debug_responder")
-	config.HealthPort = "8085" // Different port for debug responder
 
 	// Create AgentHub client
 	client, err := agenthub.NewAgentHubClient(config)
@@ -93,7 +92,7 @@ func main() {
 
 		// Create ChatResponse message
 		responseMessage := &pb.Message{
-			MessageId: fmt.Sprintf("response_%s_%d", task.GetId(), time.Now().Unix()),
+			MessageId: client.NewID("response_" + task.GetId()),
 			ContextId: correlationID,
 			TaskId:    task.GetId(),
 			Role:      pb.Role_ROLE_AGENT,
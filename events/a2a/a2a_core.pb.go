@@ -1050,6 +1050,7 @@ type AgentSkill struct {
 	Examples      []string               `protobuf:"bytes,5,rep,name=examples,proto3" json:"examples,omitempty"`                          // Example queries/requests
 	InputModes    []string               `protobuf:"bytes,6,rep,name=input_modes,json=inputModes,proto3" json:"input_modes,omitempty"`    // Supported input MIME types
 	OutputModes   []string               `protobuf:"bytes,7,rep,name=output_modes,json=outputModes,proto3" json:"output_modes,omitempty"` // Supported output MIME types
+	InputSchema   string                 `protobuf:"bytes,8,opt,name=input_schema,json=inputSchema,proto3" json:"input_schema,omitempty"` // Optional JSON Schema the data parts of task messages must satisfy
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1133,6 +1134,13 @@ func (x *AgentSkill) GetOutputModes() []string {
 	return nil
 }
 
+func (x *AgentSkill) GetInputSchema() string {
+	if x != nil {
+		return x.InputSchema
+	}
+	return ""
+}
+
 // Agent interface for multiple transport support
 type AgentInterface struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1268,7 +1276,7 @@ const file_proto_a2a_core_proto_rawDesc = "" +
 	"\x03uri\x18\x01 \x01(\tR\x03uri\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
 	"\brequired\x18\x03 \x01(\bR\brequired\x12/\n" +
-	"\x06params\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x06params\"\xc6\x01\n" +
+	"\x06params\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x06params\"\xe9\x01\n" +
 	"\n" +
 	"AgentSkill\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
@@ -1278,7 +1286,8 @@ const file_proto_a2a_core_proto_rawDesc = "" +
 	"\bexamples\x18\x05 \x03(\tR\bexamples\x12\x1f\n" +
 	"\vinput_modes\x18\x06 \x03(\tR\n" +
 	"inputModes\x12!\n" +
-	"\foutput_modes\x18\a \x03(\tR\voutputModes\"@\n" +
+	"\foutput_modes\x18\a \x03(\tR\voutputModes\x12!\n" +
+	"\finput_schema\x18\b \x01(\tR\vinputSchema\"@\n" +
 	"\x0eAgentInterface\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1c\n" +
 	"\ttransport\x18\x02 \x01(\tR\ttransport*;\n" +
@@ -101,8 +101,10 @@ type AgentEvent struct {
 	// EDA routing metadata for event distribution
 	Routing *AgentEventMetadata `protobuf:"bytes,20,opt,name=routing,proto3" json:"routing,omitempty"`
 	// OpenTelemetry distributed tracing context
-	TraceId       string `protobuf:"bytes,30,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"` // Trace ID for request correlation
-	SpanId        string `protobuf:"bytes,31,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`    // Span ID for operation tracking
+	TraceId string `protobuf:"bytes,30,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"` // Trace ID for request correlation
+	SpanId  string `protobuf:"bytes,31,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`    // Span ID for operation tracking
+	// Opaque position of this event in the broker history, usable as a resume cursor
+	Cursor        string `protobuf:"bytes,40,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -224,6 +226,13 @@ func (x *AgentEvent) GetSpanId() string {
 	return ""
 }
 
+func (x *AgentEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type isAgentEvent_Payload interface {
 	isAgentEvent_Payload()
 }
@@ -263,11 +272,15 @@ func (*AgentEvent_AgentCard) isAgentEvent_Payload() {}
 // broadcast, topic-based, and priority-based delivery.
 type AgentEventMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromAgentId   string                 `protobuf:"bytes,1,opt,name=from_agent_id,json=fromAgentId,proto3" json:"from_agent_id,omitempty"` // Source agent identifier (for reply routing)
-	ToAgentId     string                 `protobuf:"bytes,2,opt,name=to_agent_id,json=toAgentId,proto3" json:"to_agent_id,omitempty"`       // Target agent ID (empty string means broadcast to all)
-	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`         // Event classification ("message", "task", "status_update", "artifact")
-	Subscriptions []string               `protobuf:"bytes,4,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`                  // Topic-based routing tags for content-based filtering
-	Priority      Priority               `protobuf:"varint,5,opt,name=priority,proto3,enum=agenthub.Priority" json:"priority,omitempty"`    // Delivery priority for event queue ordering
+	FromAgentId   string                 `protobuf:"bytes,1,opt,name=from_agent_id,json=fromAgentId,proto3" json:"from_agent_id,omitempty"`                                              // Source agent identifier (for reply routing)
+	ToAgentId     string                 `protobuf:"bytes,2,opt,name=to_agent_id,json=toAgentId,proto3" json:"to_agent_id,omitempty"`                                                    // Target agent ID (empty string means broadcast to all)
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`                                                      // Event classification ("message", "task", "status_update", "artifact")
+	Subscriptions []string               `protobuf:"bytes,4,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`                                                               // Topic-based routing tags for content-based filtering
+	Priority      Priority               `protobuf:"varint,5,opt,name=priority,proto3,enum=agenthub.Priority" json:"priority,omitempty"`                                                 // Delivery priority: events queued for a subscriber are sent highest priority first
+	OrderingKey   string                 `protobuf:"bytes,6,opt,name=ordering_key,json=orderingKey,proto3" json:"ordering_key,omitempty"`                                                // Optional key (e.g. context_id, task_id); events sharing a key are delivered in order
+	RequiredSkill string                 `protobuf:"bytes,7,opt,name=required_skill,json=requiredSkill,proto3" json:"required_skill,omitempty"`                                          // Optional skill ID; with no to_agent_id, routes to an agent advertising this skill
+	ExcludeSelf   bool                   `protobuf:"varint,8,opt,name=exclude_self,json=excludeSelf,proto3" json:"exclude_self,omitempty"`                                               // On broadcast, skip the subscriptions of from_agent_id
+	Headers       map[string]string      `protobuf:"bytes,9,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Caller headers (e.g. tenant-id, caller-id) carried unchanged to subscribers
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -337,6 +350,34 @@ func (x *AgentEventMetadata) GetPriority() Priority {
 	return Priority_PRIORITY_UNSPECIFIED
 }
 
+func (x *AgentEventMetadata) GetOrderingKey() string {
+	if x != nil {
+		return x.OrderingKey
+	}
+	return ""
+}
+
+func (x *AgentEventMetadata) GetRequiredSkill() string {
+	if x != nil {
+		return x.RequiredSkill
+	}
+	return ""
+}
+
+func (x *AgentEventMetadata) GetExcludeSelf() bool {
+	if x != nil {
+		return x.ExcludeSelf
+	}
+	return false
+}
+
+func (x *AgentEventMetadata) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
 // TaskStatusUpdateEvent notifies subscribers about A2A task lifecycle changes.
 // This event is published whenever a task transitions between states
 // (SUBMITTED → WORKING → COMPLETED/FAILED/CANCELLED).
@@ -510,6 +551,7 @@ type AgentCardEvent struct {
 	AgentCard     *AgentCard             `protobuf:"bytes,2,opt,name=agent_card,json=agentCard,proto3" json:"agent_card,omitempty"` // The agent's A2A card with capabilities
 	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // Event type: "registered", "updated", "unregistered"
 	Metadata      *structpb.Struct       `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`                    // Additional metadata (registration time, etc.)
+	Diff          *AgentCardDiff         `protobuf:"bytes,5,opt,name=diff,proto3" json:"diff,omitempty"`                            // On "updated" events, what changed since the previous registration
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -572,6 +614,83 @@ func (x *AgentCardEvent) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *AgentCardEvent) GetDiff() *AgentCardDiff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+// AgentCardDiff describes how the card of a re-registering agent changed.
+// Skills are matched by ID, or by name when they have no ID.
+type AgentCardDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddedSkills   []*AgentSkill          `protobuf:"bytes,1,rep,name=added_skills,json=addedSkills,proto3" json:"added_skills,omitempty"`       // Skills the previous card did not have
+	RemovedSkills []*AgentSkill          `protobuf:"bytes,2,rep,name=removed_skills,json=removedSkills,proto3" json:"removed_skills,omitempty"` // Skills of the previous card that are gone
+	ChangedSkills []*AgentSkill          `protobuf:"bytes,3,rep,name=changed_skills,json=changedSkills,proto3" json:"changed_skills,omitempty"` // Skills whose definition changed, as they are now
+	ChangedFields []string               `protobuf:"bytes,4,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // Other card fields that changed, by proto name (e.g. "description", "version")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentCardDiff) Reset() {
+	*x = AgentCardDiff{}
+	mi := &file_proto_eventbus_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentCardDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCardDiff) ProtoMessage() {}
+
+func (x *AgentCardDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCardDiff.ProtoReflect.Descriptor instead.
+func (*AgentCardDiff) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AgentCardDiff) GetAddedSkills() []*AgentSkill {
+	if x != nil {
+		return x.AddedSkills
+	}
+	return nil
+}
+
+func (x *AgentCardDiff) GetRemovedSkills() []*AgentSkill {
+	if x != nil {
+		return x.RemovedSkills
+	}
+	return nil
+}
+
+func (x *AgentCardDiff) GetChangedSkills() []*AgentSkill {
+	if x != nil {
+		return x.ChangedSkills
+	}
+	return nil
+}
+
+func (x *AgentCardDiff) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
 type PublishMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // A2A message
@@ -582,7 +701,7 @@ type PublishMessageRequest struct {
 
 func (x *PublishMessageRequest) Reset() {
 	*x = PublishMessageRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[5]
+	mi := &file_proto_eventbus_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -594,7 +713,7 @@ func (x *PublishMessageRequest) String() string {
 func (*PublishMessageRequest) ProtoMessage() {}
 
 func (x *PublishMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[5]
+	mi := &file_proto_eventbus_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -607,7 +726,7 @@ func (x *PublishMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishMessageRequest.ProtoReflect.Descriptor instead.
 func (*PublishMessageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{5}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PublishMessageRequest) GetMessage() *Message {
@@ -624,6 +743,154 @@ func (x *PublishMessageRequest) GetRouting() *AgentEventMetadata {
 	return nil
 }
 
+type SendAndReceiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // A2A request message
+	Routing       *AgentEventMetadata    `protobuf:"bytes,2,opt,name=routing,proto3" json:"routing,omitempty"` // EDA routing info
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendAndReceiveRequest) Reset() {
+	*x = SendAndReceiveRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendAndReceiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendAndReceiveRequest) ProtoMessage() {}
+
+func (x *SendAndReceiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendAndReceiveRequest.ProtoReflect.Descriptor instead.
+func (*SendAndReceiveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SendAndReceiveRequest) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SendAndReceiveRequest) GetRouting() *AgentEventMetadata {
+	if x != nil {
+		return x.Routing
+	}
+	return nil
+}
+
+type PublishMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"` // A2A messages, routed in order
+	Routing       *AgentEventMetadata    `protobuf:"bytes,2,opt,name=routing,proto3" json:"routing,omitempty"`   // EDA routing info shared by every message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishMessagesRequest) Reset() {
+	*x = PublishMessagesRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishMessagesRequest) ProtoMessage() {}
+
+func (x *PublishMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishMessagesRequest.ProtoReflect.Descriptor instead.
+func (*PublishMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PublishMessagesRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *PublishMessagesRequest) GetRouting() *AgentEventMetadata {
+	if x != nil {
+		return x.Routing
+	}
+	return nil
+}
+
+type PublishMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*PublishResponse     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One result per message, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishMessagesResponse) Reset() {
+	*x = PublishMessagesResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishMessagesResponse) ProtoMessage() {}
+
+func (x *PublishMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishMessagesResponse.ProtoReflect.Descriptor instead.
+func (*PublishMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PublishMessagesResponse) GetResults() []*PublishResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 type PublishTaskUpdateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Update        *TaskStatusUpdateEvent `protobuf:"bytes,1,opt,name=update,proto3" json:"update,omitempty"`
@@ -634,7 +901,7 @@ type PublishTaskUpdateRequest struct {
 
 func (x *PublishTaskUpdateRequest) Reset() {
 	*x = PublishTaskUpdateRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[6]
+	mi := &file_proto_eventbus_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -646,7 +913,7 @@ func (x *PublishTaskUpdateRequest) String() string {
 func (*PublishTaskUpdateRequest) ProtoMessage() {}
 
 func (x *PublishTaskUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[6]
+	mi := &file_proto_eventbus_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,7 +926,7 @@ func (x *PublishTaskUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishTaskUpdateRequest.ProtoReflect.Descriptor instead.
 func (*PublishTaskUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{6}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PublishTaskUpdateRequest) GetUpdate() *TaskStatusUpdateEvent {
@@ -686,7 +953,7 @@ type PublishTaskArtifactRequest struct {
 
 func (x *PublishTaskArtifactRequest) Reset() {
 	*x = PublishTaskArtifactRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[7]
+	mi := &file_proto_eventbus_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -698,7 +965,7 @@ func (x *PublishTaskArtifactRequest) String() string {
 func (*PublishTaskArtifactRequest) ProtoMessage() {}
 
 func (x *PublishTaskArtifactRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[7]
+	mi := &file_proto_eventbus_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,7 +978,7 @@ func (x *PublishTaskArtifactRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishTaskArtifactRequest.ProtoReflect.Descriptor instead.
 func (*PublishTaskArtifactRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{7}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PublishTaskArtifactRequest) GetArtifact() *TaskArtifactUpdateEvent {
@@ -729,17 +996,21 @@ func (x *PublishTaskArtifactRequest) GetRouting() *AgentEventMetadata {
 }
 
 type PublishResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Generated event ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	EventId string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Generated event ID
+	// Delivery accounting of PublishMessage, over the subscribers the event was routed to
+	DeliveredCount int32 `protobuf:"varint,4,opt,name=delivered_count,json=deliveredCount,proto3" json:"delivered_count,omitempty"` // Subscribers the event was handed to
+	DroppedCount   int32 `protobuf:"varint,5,opt,name=dropped_count,json=droppedCount,proto3" json:"dropped_count,omitempty"`       // Subscribers the event was dropped for (full, timed out or circuit open)
+	PendingCount   int32 `protobuf:"varint,6,opt,name=pending_count,json=pendingCount,proto3" json:"pending_count,omitempty"`       // Subscribers still being sent the event when the broker stopped waiting
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PublishResponse) Reset() {
 	*x = PublishResponse{}
-	mi := &file_proto_eventbus_proto_msgTypes[8]
+	mi := &file_proto_eventbus_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +1022,7 @@ func (x *PublishResponse) String() string {
 func (*PublishResponse) ProtoMessage() {}
 
 func (x *PublishResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[8]
+	mi := &file_proto_eventbus_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +1035,7 @@ func (x *PublishResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishResponse.ProtoReflect.Descriptor instead.
 func (*PublishResponse) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{8}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PublishResponse) GetSuccess() bool {
@@ -788,30 +1059,57 @@ func (x *PublishResponse) GetEventId() string {
 	return ""
 }
 
-type SubscribeToMessagesRequest struct {
+func (x *PublishResponse) GetDeliveredCount() int32 {
+	if x != nil {
+		return x.DeliveredCount
+	}
+	return 0
+}
+
+func (x *PublishResponse) GetDroppedCount() int32 {
+	if x != nil {
+		return x.DroppedCount
+	}
+	return 0
+}
+
+func (x *PublishResponse) GetPendingCount() int32 {
+	if x != nil {
+		return x.PendingCount
+	}
+	return 0
+}
+
+// ArtifactChunk is one piece of a file artifact uploaded with PublishArtifactStream.
+// Only the first chunk of a stream carries the artifact description and routing.
+type ArtifactChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Subscribe for this agent
-	MessageTypes  []string               `protobuf:"bytes,2,rep,name=message_types,json=messageTypes,proto3" json:"message_types,omitempty"` // Optional filter
-	Contexts      []string               `protobuf:"bytes,3,rep,name=contexts,proto3" json:"contexts,omitempty"`                             // Optional context filter
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`             // Task that produced the artifact (first chunk)
+	ContextId     string                 `protobuf:"bytes,2,opt,name=context_id,json=contextId,proto3" json:"context_id,omitempty"`    // A2A conversation context (first chunk)
+	ArtifactId    string                 `protobuf:"bytes,3,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"` // Optional artifact ID, generated when empty (first chunk)
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`                               // File name (first chunk)
+	MimeType      string                 `protobuf:"bytes,5,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`       // MIME type, e.g. "image/png" (first chunk)
+	Routing       *AgentEventMetadata    `protobuf:"bytes,6,opt,name=routing,proto3" json:"routing,omitempty"`                         // EDA routing info (first chunk)
+	Data          []byte                 `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`                               // Chunk content
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeToMessagesRequest) Reset() {
-	*x = SubscribeToMessagesRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[9]
+func (x *ArtifactChunk) Reset() {
+	*x = ArtifactChunk{}
+	mi := &file_proto_eventbus_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToMessagesRequest) String() string {
+func (x *ArtifactChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToMessagesRequest) ProtoMessage() {}
+func (*ArtifactChunk) ProtoMessage() {}
 
-func (x *SubscribeToMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[9]
+func (x *ArtifactChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -822,14 +1120,267 @@ func (x *SubscribeToMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToMessagesRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeToMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ArtifactChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactChunk) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *SubscribeToMessagesRequest) GetAgentId() string {
+func (x *ArtifactChunk) GetTaskId() string {
 	if x != nil {
-		return x.AgentId
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetContextId() string {
+	if x != nil {
+		return x.ContextId
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetArtifactId() string {
+	if x != nil {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetRouting() *AgentEventMetadata {
+	if x != nil {
+		return x.Routing
+	}
+	return nil
+}
+
+func (x *ArtifactChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PublishArtifactStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Generated artifact event ID
+	BlobId        string                 `protobuf:"bytes,4,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`    // ID of the stored artifact content
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                     // Size of the stored content in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishArtifactStreamResponse) Reset() {
+	*x = PublishArtifactStreamResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishArtifactStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishArtifactStreamResponse) ProtoMessage() {}
+
+func (x *PublishArtifactStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishArtifactStreamResponse.ProtoReflect.Descriptor instead.
+func (*PublishArtifactStreamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PublishArtifactStreamResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PublishArtifactStreamResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PublishArtifactStreamResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PublishArtifactStreamResponse) GetBlobId() string {
+	if x != nil {
+		return x.BlobId
+	}
+	return ""
+}
+
+func (x *PublishArtifactStreamResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetArtifactBlobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlobId        string                 `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"` // Blob ID, from a "blob://<id>" file URI
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtifactBlobRequest) Reset() {
+	*x = GetArtifactBlobRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtifactBlobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtifactBlobRequest) ProtoMessage() {}
+
+func (x *GetArtifactBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtifactBlobRequest.ProtoReflect.Descriptor instead.
+func (*GetArtifactBlobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetArtifactBlobRequest) GetBlobId() string {
+	if x != nil {
+		return x.BlobId
+	}
+	return ""
+}
+
+type ArtifactBlobChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtifactBlobChunk) Reset() {
+	*x = ArtifactBlobChunk{}
+	mi := &file_proto_eventbus_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtifactBlobChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactBlobChunk) ProtoMessage() {}
+
+func (x *ArtifactBlobChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactBlobChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactBlobChunk) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ArtifactBlobChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SubscribeToMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Subscribe for this agent
+	MessageTypes  []string               `protobuf:"bytes,2,rep,name=message_types,json=messageTypes,proto3" json:"message_types,omitempty"` // Optional filter
+	Contexts      []string               `protobuf:"bytes,3,rep,name=contexts,proto3" json:"contexts,omitempty"`                             // Optional context filter
+	ResumeCursor  string                 `protobuf:"bytes,4,opt,name=resume_cursor,json=resumeCursor,proto3" json:"resume_cursor,omitempty"` // Replay retained events after this cursor before live delivery
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToMessagesRequest) Reset() {
+	*x = SubscribeToMessagesRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToMessagesRequest) ProtoMessage() {}
+
+func (x *SubscribeToMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubscribeToMessagesRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
 	}
 	return ""
 }
@@ -848,30 +1399,723 @@ func (x *SubscribeToMessagesRequest) GetContexts() []string {
 	return nil
 }
 
-type SubscribeToTasksRequest struct {
+func (x *SubscribeToMessagesRequest) GetResumeCursor() string {
+	if x != nil {
+		return x.ResumeCursor
+	}
+	return ""
+}
+
+type SubscribeToTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Subscribe for this agent
+	TaskTypes     []string               `protobuf:"bytes,2,rep,name=task_types,json=taskTypes,proto3" json:"task_types,omitempty"`          // Optional filter
+	States        []TaskState            `protobuf:"varint,3,rep,packed,name=states,proto3,enum=a2a.TaskState" json:"states,omitempty"`      // Optional state filter
+	ResumeCursor  string                 `protobuf:"bytes,4,opt,name=resume_cursor,json=resumeCursor,proto3" json:"resume_cursor,omitempty"` // Replay retained events after this cursor before live delivery
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToTasksRequest) Reset() {
+	*x = SubscribeToTasksRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToTasksRequest) ProtoMessage() {}
+
+func (x *SubscribeToTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToTasksRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SubscribeToTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SubscribeToTasksRequest) GetTaskTypes() []string {
+	if x != nil {
+		return x.TaskTypes
+	}
+	return nil
+}
+
+func (x *SubscribeToTasksRequest) GetStates() []TaskState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *SubscribeToTasksRequest) GetResumeCursor() string {
+	if x != nil {
+		return x.ResumeCursor
+	}
+	return ""
+}
+
+type SubscribeToAgentEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Subscribe for this agent
+	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`       // Optional event type filter; "*"/"+" match one dot-separated segment, a trailing "#" the rest
+	ResumeCursor  string                 `protobuf:"bytes,3,opt,name=resume_cursor,json=resumeCursor,proto3" json:"resume_cursor,omitempty"` // Replay retained events after this cursor before live delivery
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToAgentEventsRequest) Reset() {
+	*x = SubscribeToAgentEventsRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToAgentEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToAgentEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeToAgentEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToAgentEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToAgentEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SubscribeToAgentEventsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SubscribeToAgentEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeToAgentEventsRequest) GetResumeCursor() string {
+	if x != nil {
+		return x.ResumeCursor
+	}
+	return ""
+}
+
+type ReplayEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`          // Replay events for this agent
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`                             // Replay events routed at or after this time; unset replays all retained events
+	EventTypes    []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // Optional event type filter, as in SubscribeToAgentEventsRequest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayEventsRequest) Reset() {
+	*x = ReplayEventsRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayEventsRequest) ProtoMessage() {}
+
+func (x *ReplayEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReplayEventsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ReplayEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ReplayEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	HistoryLength int32                  `protobuf:"varint,2,opt,name=history_length,json=historyLength,proto3" json:"history_length,omitempty"` // How much history to include
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GetTaskRequest) GetHistoryLength() int32 {
+	if x != nil {
+		return x.HistoryLength
+	}
+	return 0
+}
+
+type StreamTaskHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Follow        bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"` // Keep streaming messages added to the task until it reaches a terminal state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTaskHistoryRequest) Reset() {
+	*x = StreamTaskHistoryRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTaskHistoryRequest) ProtoMessage() {}
+
+func (x *StreamTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*StreamTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StreamTaskHistoryRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *StreamTaskHistoryRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type CancelTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Optional cancellation reason
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *CancelTaskRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`           // Tasks for this agent
+	ContextId     string                 `protobuf:"bytes,2,opt,name=context_id,json=contextId,proto3" json:"context_id,omitempty"`     // Optional context filter
+	States        []TaskState            `protobuf:"varint,3,rep,packed,name=states,proto3,enum=a2a.TaskState" json:"states,omitempty"` // Optional state filter
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetContextId() string {
+	if x != nil {
+		return x.ContextId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStates() []TaskState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetContextMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContextId     string                 `protobuf:"bytes,1,opt,name=context_id,json=contextId,proto3" json:"context_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Only the most recent messages (0 means all retained)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContextMessagesRequest) Reset() {
+	*x = GetContextMessagesRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContextMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContextMessagesRequest) ProtoMessage() {}
+
+func (x *GetContextMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContextMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetContextMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetContextMessagesRequest) GetContextId() string {
+	if x != nil {
+		return x.ContextId
+	}
+	return ""
+}
+
+func (x *GetContextMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetContextMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"` // Oldest to newest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContextMessagesResponse) Reset() {
+	*x = GetContextMessagesResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContextMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContextMessagesResponse) ProtoMessage() {}
+
+func (x *GetContextMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContextMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetContextMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetContextMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type RegisterAgentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AgentCard      *AgentCard             `protobuf:"bytes,1,opt,name=agent_card,json=agentCard,proto3" json:"agent_card,omitempty"`                  // Agent's A2A card
+	Subscriptions  []string               `protobuf:"bytes,2,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`                           // Event subscriptions
+	HealthCheckUrl string                 `protobuf:"bytes,3,opt,name=health_check_url,json=healthCheckUrl,proto3" json:"health_check_url,omitempty"` // Optional health check endpoint
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegisterAgentRequest) Reset() {
+	*x = RegisterAgentRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentRequest) ProtoMessage() {}
+
+func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RegisterAgentRequest) GetAgentCard() *AgentCard {
+	if x != nil {
+		return x.AgentCard
+	}
+	return nil
+}
+
+func (x *RegisterAgentRequest) GetSubscriptions() []string {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *RegisterAgentRequest) GetHealthCheckUrl() string {
+	if x != nil {
+		return x.HealthCheckUrl
+	}
+	return ""
+}
+
+type RegisterAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Assigned/confirmed agent ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterAgentResponse) Reset() {
+	*x = RegisterAgentResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentResponse) ProtoMessage() {}
+
+func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RegisterAgentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegisterAgentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RegisterAgentResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type DeregisterAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`           // Subscribe for this agent
-	TaskTypes     []string               `protobuf:"bytes,2,rep,name=task_types,json=taskTypes,proto3" json:"task_types,omitempty"`     // Optional filter
-	States        []TaskState            `protobuf:"varint,3,rep,packed,name=states,proto3,enum=a2a.TaskState" json:"states,omitempty"` // Optional state filter
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Name of the registered agent
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeToTasksRequest) Reset() {
-	*x = SubscribeToTasksRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[10]
+func (x *DeregisterAgentRequest) Reset() {
+	*x = DeregisterAgentRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToTasksRequest) String() string {
+func (x *DeregisterAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToTasksRequest) ProtoMessage() {}
+func (*DeregisterAgentRequest) ProtoMessage() {}
 
-func (x *SubscribeToTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[10]
+func (x *DeregisterAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -882,55 +2126,41 @@ func (x *SubscribeToTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToTasksRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeToTasksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DeregisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterAgentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SubscribeToTasksRequest) GetAgentId() string {
+func (x *DeregisterAgentRequest) GetAgentId() string {
 	if x != nil {
 		return x.AgentId
 	}
 	return ""
 }
 
-func (x *SubscribeToTasksRequest) GetTaskTypes() []string {
-	if x != nil {
-		return x.TaskTypes
-	}
-	return nil
-}
-
-func (x *SubscribeToTasksRequest) GetStates() []TaskState {
-	if x != nil {
-		return x.States
-	}
-	return nil
-}
-
-type SubscribeToAgentEventsRequest struct {
+type DeregisterAgentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`          // Subscribe for this agent
-	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // Optional event type filter
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeToAgentEventsRequest) Reset() {
-	*x = SubscribeToAgentEventsRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[11]
+func (x *DeregisterAgentResponse) Reset() {
+	*x = DeregisterAgentResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToAgentEventsRequest) String() string {
+func (x *DeregisterAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToAgentEventsRequest) ProtoMessage() {}
+func (*DeregisterAgentResponse) ProtoMessage() {}
 
-func (x *SubscribeToAgentEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[11]
+func (x *DeregisterAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,48 +2171,47 @@ func (x *SubscribeToAgentEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToAgentEventsRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeToAgentEventsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeregisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*DeregisterAgentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SubscribeToAgentEventsRequest) GetAgentId() string {
+func (x *DeregisterAgentResponse) GetSuccess() bool {
 	if x != nil {
-		return x.AgentId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *SubscribeToAgentEventsRequest) GetEventTypes() []string {
+func (x *DeregisterAgentResponse) GetError() string {
 	if x != nil {
-		return x.EventTypes
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-type GetTaskRequest struct {
+type HeartbeatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	HistoryLength int32                  `protobuf:"varint,2,opt,name=history_length,json=historyLength,proto3" json:"history_length,omitempty"` // How much history to include
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Name of the registered agent
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[12]
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *HeartbeatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*HeartbeatRequest) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[12]
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -993,48 +2222,41 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *GetTaskRequest) GetTaskId() string {
+func (x *HeartbeatRequest) GetAgentId() string {
 	if x != nil {
-		return x.TaskId
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *GetTaskRequest) GetHistoryLength() int32 {
-	if x != nil {
-		return x.HistoryLength
-	}
-	return 0
-}
-
-type CancelTaskRequest struct {
+type HeartbeatResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Optional cancellation reason
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // False when the agent is not registered, e.g. after being reaped
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelTaskRequest) Reset() {
-	*x = CancelTaskRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[13]
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelTaskRequest) String() string {
+func (x *HeartbeatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelTaskRequest) ProtoMessage() {}
+func (*HeartbeatResponse) ProtoMessage() {}
 
-func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[13]
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,51 +2267,48 @@ func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
-func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *CancelTaskRequest) GetTaskId() string {
+func (x *HeartbeatResponse) GetSuccess() bool {
 	if x != nil {
-		return x.TaskId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *CancelTaskRequest) GetReason() string {
+func (x *HeartbeatResponse) GetError() string {
 	if x != nil {
-		return x.Reason
+		return x.Error
 	}
 	return ""
 }
 
-type ListTasksRequest struct {
+type ListAgentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`           // Tasks for this agent
-	ContextId     string                 `protobuf:"bytes,2,opt,name=context_id,json=contextId,proto3" json:"context_id,omitempty"`     // Optional context filter
-	States        []TaskState            `protobuf:"varint,3,rep,packed,name=states,proto3,enum=a2a.TaskState" json:"states,omitempty"` // Optional state filter
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`        // Optional: only this agent
+	AliveOnly     bool                   `protobuf:"varint,2,opt,name=alive_only,json=aliveOnly,proto3" json:"alive_only,omitempty"` // Optional: skip agents silent for longer than the staleness threshold
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksRequest) Reset() {
-	*x = ListTasksRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[14]
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_proto_eventbus_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksRequest) String() string {
+func (x *ListAgentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksRequest) ProtoMessage() {}
+func (*ListAgentsRequest) ProtoMessage() {}
 
-func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[14]
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1100,69 +2319,47 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListTasksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ListTasksRequest) GetAgentId() string {
+func (x *ListAgentsRequest) GetAgentId() string {
 	if x != nil {
 		return x.AgentId
 	}
 	return ""
 }
 
-func (x *ListTasksRequest) GetContextId() string {
-	if x != nil {
-		return x.ContextId
-	}
-	return ""
-}
-
-func (x *ListTasksRequest) GetStates() []TaskState {
-	if x != nil {
-		return x.States
-	}
-	return nil
-}
-
-func (x *ListTasksRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListTasksRequest) GetPageToken() string {
+func (x *ListAgentsRequest) GetAliveOnly() bool {
 	if x != nil {
-		return x.PageToken
+		return x.AliveOnly
 	}
-	return ""
+	return false
 }
 
-type ListTasksResponse struct {
+type ListAgentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Agents        []*AgentCard           `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksResponse) Reset() {
-	*x = ListTasksResponse{}
-	mi := &file_proto_eventbus_proto_msgTypes[15]
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksResponse) String() string {
+func (x *ListAgentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksResponse) ProtoMessage() {}
+func (*ListAgentsResponse) ProtoMessage() {}
 
-func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[15]
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1173,49 +2370,46 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ListTasksResponse) GetTasks() []*Task {
+func (x *ListAgentsResponse) GetAgents() []*AgentCard {
 	if x != nil {
-		return x.Tasks
+		return x.Agents
 	}
 	return nil
 }
 
-func (x *ListTasksResponse) GetNextPageToken() string {
-	if x != nil {
-		return x.NextPageToken
-	}
-	return ""
-}
-
-type RegisterAgentRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AgentCard      *AgentCard             `protobuf:"bytes,1,opt,name=agent_card,json=agentCard,proto3" json:"agent_card,omitempty"`                  // Agent's A2A card
-	Subscriptions  []string               `protobuf:"bytes,2,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`                           // Event subscriptions
-	HealthCheckUrl string                 `protobuf:"bytes,3,opt,name=health_check_url,json=healthCheckUrl,proto3" json:"health_check_url,omitempty"` // Optional health check endpoint
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type EchoTraceContextResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Traceparent   string                 `protobuf:"bytes,1,opt,name=traceparent,proto3" json:"traceparent,omitempty"`                            // traceparent header as received, empty when none
+	Tracestate    string                 `protobuf:"bytes,2,opt,name=tracestate,proto3" json:"tracestate,omitempty"`                              // tracestate header as received
+	TraceId       string                 `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`                     // Trace ID parsed from traceparent, empty when invalid
+	SpanId        string                 `protobuf:"bytes,4,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`                        // Caller span ID parsed from traceparent
+	Sampled       bool                   `protobuf:"varint,5,opt,name=sampled,proto3" json:"sampled,omitempty"`                                   // Sampled flag parsed from traceparent
+	BrokerTraceId string                 `protobuf:"bytes,6,opt,name=broker_trace_id,json=brokerTraceId,proto3" json:"broker_trace_id,omitempty"` // Trace of the broker's own span for the call, empty when not traced
+	BrokerSpanId  string                 `protobuf:"bytes,7,opt,name=broker_span_id,json=brokerSpanId,proto3" json:"broker_span_id,omitempty"`    // Broker's own span for the call
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterAgentRequest) Reset() {
-	*x = RegisterAgentRequest{}
-	mi := &file_proto_eventbus_proto_msgTypes[16]
+func (x *EchoTraceContextResponse) Reset() {
+	*x = EchoTraceContextResponse{}
+	mi := &file_proto_eventbus_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterAgentRequest) String() string {
+func (x *EchoTraceContextResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterAgentRequest) ProtoMessage() {}
+func (*EchoTraceContextResponse) ProtoMessage() {}
 
-func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[16]
+func (x *EchoTraceContextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eventbus_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,88 +2420,56 @@ func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
-func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use EchoTraceContextResponse.ProtoReflect.Descriptor instead.
+func (*EchoTraceContextResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *RegisterAgentRequest) GetAgentCard() *AgentCard {
+func (x *EchoTraceContextResponse) GetTraceparent() string {
 	if x != nil {
-		return x.AgentCard
+		return x.Traceparent
 	}
-	return nil
+	return ""
 }
 
-func (x *RegisterAgentRequest) GetSubscriptions() []string {
+func (x *EchoTraceContextResponse) GetTracestate() string {
 	if x != nil {
-		return x.Subscriptions
+		return x.Tracestate
 	}
-	return nil
+	return ""
 }
 
-func (x *RegisterAgentRequest) GetHealthCheckUrl() string {
+func (x *EchoTraceContextResponse) GetTraceId() string {
 	if x != nil {
-		return x.HealthCheckUrl
+		return x.TraceId
 	}
 	return ""
 }
 
-type RegisterAgentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Assigned/confirmed agent ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RegisterAgentResponse) Reset() {
-	*x = RegisterAgentResponse{}
-	mi := &file_proto_eventbus_proto_msgTypes[17]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RegisterAgentResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RegisterAgentResponse) ProtoMessage() {}
-
-func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[17]
+func (x *EchoTraceContextResponse) GetSpanId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SpanId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
-func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{17}
+	return ""
 }
 
-func (x *RegisterAgentResponse) GetSuccess() bool {
+func (x *EchoTraceContextResponse) GetSampled() bool {
 	if x != nil {
-		return x.Success
+		return x.Sampled
 	}
 	return false
 }
 
-func (x *RegisterAgentResponse) GetError() string {
+func (x *EchoTraceContextResponse) GetBrokerTraceId() string {
 	if x != nil {
-		return x.Error
+		return x.BrokerTraceId
 	}
 	return ""
 }
 
-func (x *RegisterAgentResponse) GetAgentId() string {
+func (x *EchoTraceContextResponse) GetBrokerSpanId() string {
 	if x != nil {
-		return x.AgentId
+		return x.BrokerSpanId
 	}
 	return ""
 }
@@ -1332,7 +2494,7 @@ type TaskMessage struct {
 
 func (x *TaskMessage) Reset() {
 	*x = TaskMessage{}
-	mi := &file_proto_eventbus_proto_msgTypes[18]
+	mi := &file_proto_eventbus_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1344,7 +2506,7 @@ func (x *TaskMessage) String() string {
 func (*TaskMessage) ProtoMessage() {}
 
 func (x *TaskMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[18]
+	mi := &file_proto_eventbus_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1357,7 +2519,7 @@ func (x *TaskMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskMessage.ProtoReflect.Descriptor instead.
 func (*TaskMessage) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{18}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *TaskMessage) GetTaskId() string {
@@ -1441,7 +2603,7 @@ type TaskResult struct {
 
 func (x *TaskResult) Reset() {
 	*x = TaskResult{}
-	mi := &file_proto_eventbus_proto_msgTypes[19]
+	mi := &file_proto_eventbus_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1453,7 +2615,7 @@ func (x *TaskResult) String() string {
 func (*TaskResult) ProtoMessage() {}
 
 func (x *TaskResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[19]
+	mi := &file_proto_eventbus_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1466,7 +2628,7 @@ func (x *TaskResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskResult.ProtoReflect.Descriptor instead.
 func (*TaskResult) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{19}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *TaskResult) GetTaskId() string {
@@ -1536,7 +2698,7 @@ type TaskProgress struct {
 
 func (x *TaskProgress) Reset() {
 	*x = TaskProgress{}
-	mi := &file_proto_eventbus_proto_msgTypes[20]
+	mi := &file_proto_eventbus_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1548,7 +2710,7 @@ func (x *TaskProgress) String() string {
 func (*TaskProgress) ProtoMessage() {}
 
 func (x *TaskProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eventbus_proto_msgTypes[20]
+	mi := &file_proto_eventbus_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1561,7 +2723,7 @@ func (x *TaskProgress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
 func (*TaskProgress) Descriptor() ([]byte, []int) {
-	return file_proto_eventbus_proto_rawDescGZIP(), []int{20}
+	return file_proto_eventbus_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *TaskProgress) GetTaskId() string {
@@ -1617,7 +2779,7 @@ var File_proto_eventbus_proto protoreflect.FileDescriptor
 
 const file_proto_eventbus_proto_rawDesc = "" +
 	"\n" +
-	"\x14proto/eventbus.proto\x12\bagenthub\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x14proto/a2a_core.proto\"\xf4\x03\n" +
+	"\x14proto/eventbus.proto\x12\bagenthub\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x14proto/a2a_core.proto\"\x8c\x04\n" +
 	"\n" +
 	"AgentEvent\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x128\n" +
@@ -1631,15 +2793,23 @@ const file_proto_eventbus_proto_rawDesc = "" +
 	"agent_card\x18\x0e \x01(\v2\x18.agenthub.AgentCardEventH\x00R\tagentCard\x126\n" +
 	"\arouting\x18\x14 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\x12\x19\n" +
 	"\btrace_id\x18\x1e \x01(\tR\atraceId\x12\x17\n" +
-	"\aspan_id\x18\x1f \x01(\tR\x06spanIdB\t\n" +
-	"\apayload\"\xcd\x01\n" +
+	"\aspan_id\x18\x1f \x01(\tR\x06spanId\x12\x16\n" +
+	"\x06cursor\x18( \x01(\tR\x06cursorB\t\n" +
+	"\apayload\"\xbb\x03\n" +
 	"\x12AgentEventMetadata\x12\"\n" +
 	"\rfrom_agent_id\x18\x01 \x01(\tR\vfromAgentId\x12\x1e\n" +
 	"\vto_agent_id\x18\x02 \x01(\tR\ttoAgentId\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x03 \x01(\tR\teventType\x12$\n" +
 	"\rsubscriptions\x18\x04 \x03(\tR\rsubscriptions\x12.\n" +
-	"\bpriority\x18\x05 \x01(\x0e2\x12.agenthub.PriorityR\bpriority\"\xc3\x01\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x12.agenthub.PriorityR\bpriority\x12!\n" +
+	"\fordering_key\x18\x06 \x01(\tR\vorderingKey\x12%\n" +
+	"\x0erequired_skill\x18\a \x01(\tR\rrequiredSkill\x12!\n" +
+	"\fexclude_self\x18\b \x01(\bR\vexcludeSelf\x12C\n" +
+	"\aheaders\x18\t \x03(\v2).agenthub.AgentEventMetadata.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x01\n" +
 	"\x15TaskStatusUpdateEvent\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
 	"\n" +
@@ -1655,43 +2825,91 @@ const file_proto_eventbus_proto_rawDesc = "" +
 	"\x06append\x18\x04 \x01(\bR\x06append\x12\x1d\n" +
 	"\n" +
 	"last_chunk\x18\x05 \x01(\bR\tlastChunk\x123\n" +
-	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\xae\x01\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\xdb\x01\n" +
 	"\x0eAgentCardEvent\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12-\n" +
 	"\n" +
 	"agent_card\x18\x02 \x01(\v2\x0e.a2a.AgentCardR\tagentCard\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x03 \x01(\tR\teventType\x123\n" +
-	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"w\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12+\n" +
+	"\x04diff\x18\x05 \x01(\v2\x17.agenthub.AgentCardDiffR\x04diff\"\xda\x01\n" +
+	"\rAgentCardDiff\x122\n" +
+	"\fadded_skills\x18\x01 \x03(\v2\x0f.a2a.AgentSkillR\vaddedSkills\x126\n" +
+	"\x0eremoved_skills\x18\x02 \x03(\v2\x0f.a2a.AgentSkillR\rremovedSkills\x126\n" +
+	"\x0echanged_skills\x18\x03 \x03(\v2\x0f.a2a.AgentSkillR\rchangedSkills\x12%\n" +
+	"\x0echanged_fields\x18\x04 \x03(\tR\rchangedFields\"w\n" +
 	"\x15PublishMessageRequest\x12&\n" +
 	"\amessage\x18\x01 \x01(\v2\f.a2a.MessageR\amessage\x126\n" +
-	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"\x8b\x01\n" +
+	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"w\n" +
+	"\x15SendAndReceiveRequest\x12&\n" +
+	"\amessage\x18\x01 \x01(\v2\f.a2a.MessageR\amessage\x126\n" +
+	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"z\n" +
+	"\x16PublishMessagesRequest\x12(\n" +
+	"\bmessages\x18\x01 \x03(\v2\f.a2a.MessageR\bmessages\x126\n" +
+	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"N\n" +
+	"\x17PublishMessagesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.agenthub.PublishResponseR\aresults\"\x8b\x01\n" +
 	"\x18PublishTaskUpdateRequest\x127\n" +
 	"\x06update\x18\x01 \x01(\v2\x1f.agenthub.TaskStatusUpdateEventR\x06update\x126\n" +
 	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"\x93\x01\n" +
 	"\x1aPublishTaskArtifactRequest\x12=\n" +
 	"\bartifact\x18\x01 \x01(\v2!.agenthub.TaskArtifactUpdateEventR\bartifact\x126\n" +
-	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"\\\n" +
+	"\arouting\x18\x02 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\"\xcf\x01\n" +
 	"\x0fPublishResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x19\n" +
-	"\bevent_id\x18\x03 \x01(\tR\aeventId\"x\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12'\n" +
+	"\x0fdelivered_count\x18\x04 \x01(\x05R\x0edeliveredCount\x12#\n" +
+	"\rdropped_count\x18\x05 \x01(\x05R\fdroppedCount\x12#\n" +
+	"\rpending_count\x18\x06 \x01(\x05R\fpendingCount\"\xe5\x01\n" +
+	"\rArtifactChunk\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"context_id\x18\x02 \x01(\tR\tcontextId\x12\x1f\n" +
+	"\vartifact_id\x18\x03 \x01(\tR\n" +
+	"artifactId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmime_type\x18\x05 \x01(\tR\bmimeType\x126\n" +
+	"\arouting\x18\x06 \x01(\v2\x1c.agenthub.AgentEventMetadataR\arouting\x12\x12\n" +
+	"\x04data\x18\a \x01(\fR\x04data\"\x97\x01\n" +
+	"\x1dPublishArtifactStreamResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x17\n" +
+	"\ablob_id\x18\x04 \x01(\tR\x06blobId\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\"1\n" +
+	"\x16GetArtifactBlobRequest\x12\x17\n" +
+	"\ablob_id\x18\x01 \x01(\tR\x06blobId\"'\n" +
+	"\x11ArtifactBlobChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x9d\x01\n" +
 	"\x1aSubscribeToMessagesRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12#\n" +
 	"\rmessage_types\x18\x02 \x03(\tR\fmessageTypes\x12\x1a\n" +
-	"\bcontexts\x18\x03 \x03(\tR\bcontexts\"{\n" +
+	"\bcontexts\x18\x03 \x03(\tR\bcontexts\x12#\n" +
+	"\rresume_cursor\x18\x04 \x01(\tR\fresumeCursor\"\xa0\x01\n" +
 	"\x17SubscribeToTasksRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
 	"\n" +
 	"task_types\x18\x02 \x03(\tR\ttaskTypes\x12&\n" +
-	"\x06states\x18\x03 \x03(\x0e2\x0e.a2a.TaskStateR\x06states\"[\n" +
+	"\x06states\x18\x03 \x03(\x0e2\x0e.a2a.TaskStateR\x06states\x12#\n" +
+	"\rresume_cursor\x18\x04 \x01(\tR\fresumeCursor\"\x80\x01\n" +
 	"\x1dSubscribeToAgentEventsRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1f\n" +
 	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12#\n" +
+	"\rresume_cursor\x18\x03 \x01(\tR\fresumeCursor\"\x83\x01\n" +
+	"\x13ReplayEventsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
 	"eventTypes\"P\n" +
 	"\x0eGetTaskRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12%\n" +
-	"\x0ehistory_length\x18\x02 \x01(\x05R\rhistoryLength\"D\n" +
+	"\x0ehistory_length\x18\x02 \x01(\x05R\rhistoryLength\"K\n" +
+	"\x18StreamTaskHistoryRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06follow\x18\x02 \x01(\bR\x06follow\"D\n" +
 	"\x11CancelTaskRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xb0\x01\n" +
@@ -1705,7 +2923,13 @@ const file_proto_eventbus_proto_rawDesc = "" +
 	"page_token\x18\x05 \x01(\tR\tpageToken\"\\\n" +
 	"\x11ListTasksResponse\x12\x1f\n" +
 	"\x05tasks\x18\x01 \x03(\v2\t.a2a.TaskR\x05tasks\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x95\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"P\n" +
+	"\x19GetContextMessagesRequest\x12\x1d\n" +
+	"\n" +
+	"context_id\x18\x01 \x01(\tR\tcontextId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"F\n" +
+	"\x1aGetContextMessagesResponse\x12(\n" +
+	"\bmessages\x18\x01 \x03(\v2\f.a2a.MessageR\bmessages\"\x95\x01\n" +
 	"\x14RegisterAgentRequest\x12-\n" +
 	"\n" +
 	"agent_card\x18\x01 \x01(\v2\x0e.a2a.AgentCardR\tagentCard\x12$\n" +
@@ -1714,7 +2938,33 @@ const file_proto_eventbus_proto_rawDesc = "" +
 	"\x15RegisterAgentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x19\n" +
-	"\bagent_id\x18\x03 \x01(\tR\aagentId\"\xb4\x03\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\"3\n" +
+	"\x16DeregisterAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"I\n" +
+	"\x17DeregisterAgentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"-\n" +
+	"\x10HeartbeatRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"C\n" +
+	"\x11HeartbeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"M\n" +
+	"\x11ListAgentsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"alive_only\x18\x02 \x01(\bR\taliveOnly\"<\n" +
+	"\x12ListAgentsResponse\x12&\n" +
+	"\x06agents\x18\x01 \x03(\v2\x0e.a2a.AgentCardR\x06agents\"\xf8\x01\n" +
+	"\x18EchoTraceContextResponse\x12 \n" +
+	"\vtraceparent\x18\x01 \x01(\tR\vtraceparent\x12\x1e\n" +
+	"\n" +
+	"tracestate\x18\x02 \x01(\tR\n" +
+	"tracestate\x12\x19\n" +
+	"\btrace_id\x18\x03 \x01(\tR\atraceId\x12\x17\n" +
+	"\aspan_id\x18\x04 \x01(\tR\x06spanId\x12\x18\n" +
+	"\asampled\x18\x05 \x01(\bR\asampled\x12&\n" +
+	"\x0fbroker_trace_id\x18\x06 \x01(\tR\rbrokerTraceId\x12$\n" +
+	"\x0ebroker_span_id\x18\a \x01(\tR\fbrokerSpanId\"\xb4\x03\n" +
 	"\vTaskMessage\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
 	"\ttask_type\x18\x02 \x01(\tR\btaskType\x127\n" +
@@ -1751,20 +3001,32 @@ const file_proto_eventbus_proto_rawDesc = "" +
 	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
 	"\x0fPRIORITY_MEDIUM\x10\x02\x12\x11\n" +
 	"\rPRIORITY_HIGH\x10\x03\x12\x15\n" +
-	"\x11PRIORITY_CRITICAL\x10\x042\xb9\x06\n" +
+	"\x11PRIORITY_CRITICAL\x10\x042\xab\r\n" +
 	"\bAgentHub\x12L\n" +
-	"\x0ePublishMessage\x12\x1f.agenthub.PublishMessageRequest\x1a\x19.agenthub.PublishResponse\x12R\n" +
+	"\x0ePublishMessage\x12\x1f.agenthub.PublishMessageRequest\x1a\x19.agenthub.PublishResponse\x12V\n" +
+	"\x0fPublishMessages\x12 .agenthub.PublishMessagesRequest\x1a!.agenthub.PublishMessagesResponse\x12?\n" +
+	"\x0eSendAndReceive\x12\x1f.agenthub.SendAndReceiveRequest\x1a\f.a2a.Message\x12R\n" +
 	"\x11PublishTaskUpdate\x12\".agenthub.PublishTaskUpdateRequest\x1a\x19.agenthub.PublishResponse\x12V\n" +
-	"\x13PublishTaskArtifact\x12$.agenthub.PublishTaskArtifactRequest\x1a\x19.agenthub.PublishResponse\x12S\n" +
+	"\x13PublishTaskArtifact\x12$.agenthub.PublishTaskArtifactRequest\x1a\x19.agenthub.PublishResponse\x12[\n" +
+	"\x15PublishArtifactStream\x12\x17.agenthub.ArtifactChunk\x1a'.agenthub.PublishArtifactStreamResponse(\x01\x12R\n" +
+	"\x0fGetArtifactBlob\x12 .agenthub.GetArtifactBlobRequest\x1a\x1b.agenthub.ArtifactBlobChunk0\x01\x12S\n" +
 	"\x13SubscribeToMessages\x12$.agenthub.SubscribeToMessagesRequest\x1a\x14.agenthub.AgentEvent0\x01\x12M\n" +
 	"\x10SubscribeToTasks\x12!.agenthub.SubscribeToTasksRequest\x1a\x14.agenthub.AgentEvent0\x01\x12Y\n" +
-	"\x16SubscribeToAgentEvents\x12'.agenthub.SubscribeToAgentEventsRequest\x1a\x14.agenthub.AgentEvent0\x01\x12.\n" +
-	"\aGetTask\x12\x18.agenthub.GetTaskRequest\x1a\t.a2a.Task\x124\n" +
+	"\x16SubscribeToAgentEvents\x12'.agenthub.SubscribeToAgentEventsRequest\x1a\x14.agenthub.AgentEvent0\x01\x12E\n" +
+	"\fReplayEvents\x12\x1d.agenthub.ReplayEventsRequest\x1a\x14.agenthub.AgentEvent0\x01\x12.\n" +
+	"\aGetTask\x12\x18.agenthub.GetTaskRequest\x1a\t.a2a.Task\x12G\n" +
+	"\x11StreamTaskHistory\x12\".agenthub.StreamTaskHistoryRequest\x1a\f.a2a.Message0\x01\x124\n" +
 	"\n" +
 	"CancelTask\x12\x1b.agenthub.CancelTaskRequest\x1a\t.a2a.Task\x12D\n" +
-	"\tListTasks\x12\x1a.agenthub.ListTasksRequest\x1a\x1b.agenthub.ListTasksResponse\x126\n" +
+	"\tListTasks\x12\x1a.agenthub.ListTasksRequest\x1a\x1b.agenthub.ListTasksResponse\x12_\n" +
+	"\x12GetContextMessages\x12#.agenthub.GetContextMessagesRequest\x1a$.agenthub.GetContextMessagesResponse\x126\n" +
 	"\fGetAgentCard\x12\x16.google.protobuf.Empty\x1a\x0e.a2a.AgentCard\x12P\n" +
-	"\rRegisterAgent\x12\x1e.agenthub.RegisterAgentRequest\x1a\x1f.agenthub.RegisterAgentResponseB\x10Z\x0eevents/a2a;a2ab\x06proto3"
+	"\rRegisterAgent\x12\x1e.agenthub.RegisterAgentRequest\x1a\x1f.agenthub.RegisterAgentResponse\x12V\n" +
+	"\x0fDeregisterAgent\x12 .agenthub.DeregisterAgentRequest\x1a!.agenthub.DeregisterAgentResponse\x12D\n" +
+	"\tHeartbeat\x12\x1a.agenthub.HeartbeatRequest\x1a\x1b.agenthub.HeartbeatResponse\x12G\n" +
+	"\n" +
+	"ListAgents\x12\x1b.agenthub.ListAgentsRequest\x1a\x1c.agenthub.ListAgentsResponse\x12N\n" +
+	"\x10EchoTraceContext\x12\x16.google.protobuf.Empty\x1a\".agenthub.EchoTraceContextResponseB\x10Z\x0eevents/a2a;a2ab\x06proto3"
 
 var (
 	file_proto_eventbus_proto_rawDescOnce sync.Once
@@ -1779,7 +3041,7 @@ func file_proto_eventbus_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_eventbus_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_eventbus_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_eventbus_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_proto_eventbus_proto_goTypes = []any{
 	(Priority)(0),                         // 0: agenthub.Priority
 	(*AgentEvent)(nil),                    // 1: agenthub.AgentEvent
@@ -1787,96 +3049,153 @@ var file_proto_eventbus_proto_goTypes = []any{
 	(*TaskStatusUpdateEvent)(nil),         // 3: agenthub.TaskStatusUpdateEvent
 	(*TaskArtifactUpdateEvent)(nil),       // 4: agenthub.TaskArtifactUpdateEvent
 	(*AgentCardEvent)(nil),                // 5: agenthub.AgentCardEvent
-	(*PublishMessageRequest)(nil),         // 6: agenthub.PublishMessageRequest
-	(*PublishTaskUpdateRequest)(nil),      // 7: agenthub.PublishTaskUpdateRequest
-	(*PublishTaskArtifactRequest)(nil),    // 8: agenthub.PublishTaskArtifactRequest
-	(*PublishResponse)(nil),               // 9: agenthub.PublishResponse
-	(*SubscribeToMessagesRequest)(nil),    // 10: agenthub.SubscribeToMessagesRequest
-	(*SubscribeToTasksRequest)(nil),       // 11: agenthub.SubscribeToTasksRequest
-	(*SubscribeToAgentEventsRequest)(nil), // 12: agenthub.SubscribeToAgentEventsRequest
-	(*GetTaskRequest)(nil),                // 13: agenthub.GetTaskRequest
-	(*CancelTaskRequest)(nil),             // 14: agenthub.CancelTaskRequest
-	(*ListTasksRequest)(nil),              // 15: agenthub.ListTasksRequest
-	(*ListTasksResponse)(nil),             // 16: agenthub.ListTasksResponse
-	(*RegisterAgentRequest)(nil),          // 17: agenthub.RegisterAgentRequest
-	(*RegisterAgentResponse)(nil),         // 18: agenthub.RegisterAgentResponse
-	(*TaskMessage)(nil),                   // 19: agenthub.TaskMessage
-	(*TaskResult)(nil),                    // 20: agenthub.TaskResult
-	(*TaskProgress)(nil),                  // 21: agenthub.TaskProgress
-	(*timestamppb.Timestamp)(nil),         // 22: google.protobuf.Timestamp
-	(*Message)(nil),                       // 23: a2a.Message
-	(*Task)(nil),                          // 24: a2a.Task
-	(*TaskStatus)(nil),                    // 25: a2a.TaskStatus
-	(*structpb.Struct)(nil),               // 26: google.protobuf.Struct
-	(*Artifact)(nil),                      // 27: a2a.Artifact
-	(*AgentCard)(nil),                     // 28: a2a.AgentCard
-	(TaskState)(0),                        // 29: a2a.TaskState
-	(*emptypb.Empty)(nil),                 // 30: google.protobuf.Empty
+	(*AgentCardDiff)(nil),                 // 6: agenthub.AgentCardDiff
+	(*PublishMessageRequest)(nil),         // 7: agenthub.PublishMessageRequest
+	(*SendAndReceiveRequest)(nil),         // 8: agenthub.SendAndReceiveRequest
+	(*PublishMessagesRequest)(nil),        // 9: agenthub.PublishMessagesRequest
+	(*PublishMessagesResponse)(nil),       // 10: agenthub.PublishMessagesResponse
+	(*PublishTaskUpdateRequest)(nil),      // 11: agenthub.PublishTaskUpdateRequest
+	(*PublishTaskArtifactRequest)(nil),    // 12: agenthub.PublishTaskArtifactRequest
+	(*PublishResponse)(nil),               // 13: agenthub.PublishResponse
+	(*ArtifactChunk)(nil),                 // 14: agenthub.ArtifactChunk
+	(*PublishArtifactStreamResponse)(nil), // 15: agenthub.PublishArtifactStreamResponse
+	(*GetArtifactBlobRequest)(nil),        // 16: agenthub.GetArtifactBlobRequest
+	(*ArtifactBlobChunk)(nil),             // 17: agenthub.ArtifactBlobChunk
+	(*SubscribeToMessagesRequest)(nil),    // 18: agenthub.SubscribeToMessagesRequest
+	(*SubscribeToTasksRequest)(nil),       // 19: agenthub.SubscribeToTasksRequest
+	(*SubscribeToAgentEventsRequest)(nil), // 20: agenthub.SubscribeToAgentEventsRequest
+	(*ReplayEventsRequest)(nil),           // 21: agenthub.ReplayEventsRequest
+	(*GetTaskRequest)(nil),                // 22: agenthub.GetTaskRequest
+	(*StreamTaskHistoryRequest)(nil),      // 23: agenthub.StreamTaskHistoryRequest
+	(*CancelTaskRequest)(nil),             // 24: agenthub.CancelTaskRequest
+	(*ListTasksRequest)(nil),              // 25: agenthub.ListTasksRequest
+	(*ListTasksResponse)(nil),             // 26: agenthub.ListTasksResponse
+	(*GetContextMessagesRequest)(nil),     // 27: agenthub.GetContextMessagesRequest
+	(*GetContextMessagesResponse)(nil),    // 28: agenthub.GetContextMessagesResponse
+	(*RegisterAgentRequest)(nil),          // 29: agenthub.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil),         // 30: agenthub.RegisterAgentResponse
+	(*DeregisterAgentRequest)(nil),        // 31: agenthub.DeregisterAgentRequest
+	(*DeregisterAgentResponse)(nil),       // 32: agenthub.DeregisterAgentResponse
+	(*HeartbeatRequest)(nil),              // 33: agenthub.HeartbeatRequest
+	(*HeartbeatResponse)(nil),             // 34: agenthub.HeartbeatResponse
+	(*ListAgentsRequest)(nil),             // 35: agenthub.ListAgentsRequest
+	(*ListAgentsResponse)(nil),            // 36: agenthub.ListAgentsResponse
+	(*EchoTraceContextResponse)(nil),      // 37: agenthub.EchoTraceContextResponse
+	(*TaskMessage)(nil),                   // 38: agenthub.TaskMessage
+	(*TaskResult)(nil),                    // 39: agenthub.TaskResult
+	(*TaskProgress)(nil),                  // 40: agenthub.TaskProgress
+	nil,                                   // 41: agenthub.AgentEventMetadata.HeadersEntry
+	(*timestamppb.Timestamp)(nil),         // 42: google.protobuf.Timestamp
+	(*Message)(nil),                       // 43: a2a.Message
+	(*Task)(nil),                          // 44: a2a.Task
+	(*TaskStatus)(nil),                    // 45: a2a.TaskStatus
+	(*structpb.Struct)(nil),               // 46: google.protobuf.Struct
+	(*Artifact)(nil),                      // 47: a2a.Artifact
+	(*AgentCard)(nil),                     // 48: a2a.AgentCard
+	(*AgentSkill)(nil),                    // 49: a2a.AgentSkill
+	(TaskState)(0),                        // 50: a2a.TaskState
+	(*emptypb.Empty)(nil),                 // 51: google.protobuf.Empty
 }
 var file_proto_eventbus_proto_depIdxs = []int32{
-	22, // 0: agenthub.AgentEvent.timestamp:type_name -> google.protobuf.Timestamp
-	23, // 1: agenthub.AgentEvent.message:type_name -> a2a.Message
-	24, // 2: agenthub.AgentEvent.task:type_name -> a2a.Task
+	42, // 0: agenthub.AgentEvent.timestamp:type_name -> google.protobuf.Timestamp
+	43, // 1: agenthub.AgentEvent.message:type_name -> a2a.Message
+	44, // 2: agenthub.AgentEvent.task:type_name -> a2a.Task
 	3,  // 3: agenthub.AgentEvent.status_update:type_name -> agenthub.TaskStatusUpdateEvent
 	4,  // 4: agenthub.AgentEvent.artifact_update:type_name -> agenthub.TaskArtifactUpdateEvent
 	5,  // 5: agenthub.AgentEvent.agent_card:type_name -> agenthub.AgentCardEvent
 	2,  // 6: agenthub.AgentEvent.routing:type_name -> agenthub.AgentEventMetadata
 	0,  // 7: agenthub.AgentEventMetadata.priority:type_name -> agenthub.Priority
-	25, // 8: agenthub.TaskStatusUpdateEvent.status:type_name -> a2a.TaskStatus
-	26, // 9: agenthub.TaskStatusUpdateEvent.metadata:type_name -> google.protobuf.Struct
-	27, // 10: agenthub.TaskArtifactUpdateEvent.artifact:type_name -> a2a.Artifact
-	26, // 11: agenthub.TaskArtifactUpdateEvent.metadata:type_name -> google.protobuf.Struct
-	28, // 12: agenthub.AgentCardEvent.agent_card:type_name -> a2a.AgentCard
-	26, // 13: agenthub.AgentCardEvent.metadata:type_name -> google.protobuf.Struct
-	23, // 14: agenthub.PublishMessageRequest.message:type_name -> a2a.Message
-	2,  // 15: agenthub.PublishMessageRequest.routing:type_name -> agenthub.AgentEventMetadata
-	3,  // 16: agenthub.PublishTaskUpdateRequest.update:type_name -> agenthub.TaskStatusUpdateEvent
-	2,  // 17: agenthub.PublishTaskUpdateRequest.routing:type_name -> agenthub.AgentEventMetadata
-	4,  // 18: agenthub.PublishTaskArtifactRequest.artifact:type_name -> agenthub.TaskArtifactUpdateEvent
-	2,  // 19: agenthub.PublishTaskArtifactRequest.routing:type_name -> agenthub.AgentEventMetadata
-	29, // 20: agenthub.SubscribeToTasksRequest.states:type_name -> a2a.TaskState
-	29, // 21: agenthub.ListTasksRequest.states:type_name -> a2a.TaskState
-	24, // 22: agenthub.ListTasksResponse.tasks:type_name -> a2a.Task
-	28, // 23: agenthub.RegisterAgentRequest.agent_card:type_name -> a2a.AgentCard
-	26, // 24: agenthub.TaskMessage.parameters:type_name -> google.protobuf.Struct
-	22, // 25: agenthub.TaskMessage.deadline:type_name -> google.protobuf.Timestamp
-	0,  // 26: agenthub.TaskMessage.priority:type_name -> agenthub.Priority
-	26, // 27: agenthub.TaskMessage.metadata:type_name -> google.protobuf.Struct
-	22, // 28: agenthub.TaskMessage.created_at:type_name -> google.protobuf.Timestamp
-	29, // 29: agenthub.TaskResult.status:type_name -> a2a.TaskState
-	26, // 30: agenthub.TaskResult.result:type_name -> google.protobuf.Struct
-	22, // 31: agenthub.TaskResult.completed_at:type_name -> google.protobuf.Timestamp
-	26, // 32: agenthub.TaskResult.execution_metadata:type_name -> google.protobuf.Struct
-	29, // 33: agenthub.TaskProgress.status:type_name -> a2a.TaskState
-	26, // 34: agenthub.TaskProgress.progress_data:type_name -> google.protobuf.Struct
-	22, // 35: agenthub.TaskProgress.updated_at:type_name -> google.protobuf.Timestamp
-	6,  // 36: agenthub.AgentHub.PublishMessage:input_type -> agenthub.PublishMessageRequest
-	7,  // 37: agenthub.AgentHub.PublishTaskUpdate:input_type -> agenthub.PublishTaskUpdateRequest
-	8,  // 38: agenthub.AgentHub.PublishTaskArtifact:input_type -> agenthub.PublishTaskArtifactRequest
-	10, // 39: agenthub.AgentHub.SubscribeToMessages:input_type -> agenthub.SubscribeToMessagesRequest
-	11, // 40: agenthub.AgentHub.SubscribeToTasks:input_type -> agenthub.SubscribeToTasksRequest
-	12, // 41: agenthub.AgentHub.SubscribeToAgentEvents:input_type -> agenthub.SubscribeToAgentEventsRequest
-	13, // 42: agenthub.AgentHub.GetTask:input_type -> agenthub.GetTaskRequest
-	14, // 43: agenthub.AgentHub.CancelTask:input_type -> agenthub.CancelTaskRequest
-	15, // 44: agenthub.AgentHub.ListTasks:input_type -> agenthub.ListTasksRequest
-	30, // 45: agenthub.AgentHub.GetAgentCard:input_type -> google.protobuf.Empty
-	17, // 46: agenthub.AgentHub.RegisterAgent:input_type -> agenthub.RegisterAgentRequest
-	9,  // 47: agenthub.AgentHub.PublishMessage:output_type -> agenthub.PublishResponse
-	9,  // 48: agenthub.AgentHub.PublishTaskUpdate:output_type -> agenthub.PublishResponse
-	9,  // 49: agenthub.AgentHub.PublishTaskArtifact:output_type -> agenthub.PublishResponse
-	1,  // 50: agenthub.AgentHub.SubscribeToMessages:output_type -> agenthub.AgentEvent
-	1,  // 51: agenthub.AgentHub.SubscribeToTasks:output_type -> agenthub.AgentEvent
-	1,  // 52: agenthub.AgentHub.SubscribeToAgentEvents:output_type -> agenthub.AgentEvent
-	24, // 53: agenthub.AgentHub.GetTask:output_type -> a2a.Task
-	24, // 54: agenthub.AgentHub.CancelTask:output_type -> a2a.Task
-	16, // 55: agenthub.AgentHub.ListTasks:output_type -> agenthub.ListTasksResponse
-	28, // 56: agenthub.AgentHub.GetAgentCard:output_type -> a2a.AgentCard
-	18, // 57: agenthub.AgentHub.RegisterAgent:output_type -> agenthub.RegisterAgentResponse
-	47, // [47:58] is the sub-list for method output_type
-	36, // [36:47] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	41, // 8: agenthub.AgentEventMetadata.headers:type_name -> agenthub.AgentEventMetadata.HeadersEntry
+	45, // 9: agenthub.TaskStatusUpdateEvent.status:type_name -> a2a.TaskStatus
+	46, // 10: agenthub.TaskStatusUpdateEvent.metadata:type_name -> google.protobuf.Struct
+	47, // 11: agenthub.TaskArtifactUpdateEvent.artifact:type_name -> a2a.Artifact
+	46, // 12: agenthub.TaskArtifactUpdateEvent.metadata:type_name -> google.protobuf.Struct
+	48, // 13: agenthub.AgentCardEvent.agent_card:type_name -> a2a.AgentCard
+	46, // 14: agenthub.AgentCardEvent.metadata:type_name -> google.protobuf.Struct
+	6,  // 15: agenthub.AgentCardEvent.diff:type_name -> agenthub.AgentCardDiff
+	49, // 16: agenthub.AgentCardDiff.added_skills:type_name -> a2a.AgentSkill
+	49, // 17: agenthub.AgentCardDiff.removed_skills:type_name -> a2a.AgentSkill
+	49, // 18: agenthub.AgentCardDiff.changed_skills:type_name -> a2a.AgentSkill
+	43, // 19: agenthub.PublishMessageRequest.message:type_name -> a2a.Message
+	2,  // 20: agenthub.PublishMessageRequest.routing:type_name -> agenthub.AgentEventMetadata
+	43, // 21: agenthub.SendAndReceiveRequest.message:type_name -> a2a.Message
+	2,  // 22: agenthub.SendAndReceiveRequest.routing:type_name -> agenthub.AgentEventMetadata
+	43, // 23: agenthub.PublishMessagesRequest.messages:type_name -> a2a.Message
+	2,  // 24: agenthub.PublishMessagesRequest.routing:type_name -> agenthub.AgentEventMetadata
+	13, // 25: agenthub.PublishMessagesResponse.results:type_name -> agenthub.PublishResponse
+	3,  // 26: agenthub.PublishTaskUpdateRequest.update:type_name -> agenthub.TaskStatusUpdateEvent
+	2,  // 27: agenthub.PublishTaskUpdateRequest.routing:type_name -> agenthub.AgentEventMetadata
+	4,  // 28: agenthub.PublishTaskArtifactRequest.artifact:type_name -> agenthub.TaskArtifactUpdateEvent
+	2,  // 29: agenthub.PublishTaskArtifactRequest.routing:type_name -> agenthub.AgentEventMetadata
+	2,  // 30: agenthub.ArtifactChunk.routing:type_name -> agenthub.AgentEventMetadata
+	50, // 31: agenthub.SubscribeToTasksRequest.states:type_name -> a2a.TaskState
+	42, // 32: agenthub.ReplayEventsRequest.since:type_name -> google.protobuf.Timestamp
+	50, // 33: agenthub.ListTasksRequest.states:type_name -> a2a.TaskState
+	44, // 34: agenthub.ListTasksResponse.tasks:type_name -> a2a.Task
+	43, // 35: agenthub.GetContextMessagesResponse.messages:type_name -> a2a.Message
+	48, // 36: agenthub.RegisterAgentRequest.agent_card:type_name -> a2a.AgentCard
+	48, // 37: agenthub.ListAgentsResponse.agents:type_name -> a2a.AgentCard
+	46, // 38: agenthub.TaskMessage.parameters:type_name -> google.protobuf.Struct
+	42, // 39: agenthub.TaskMessage.deadline:type_name -> google.protobuf.Timestamp
+	0,  // 40: agenthub.TaskMessage.priority:type_name -> agenthub.Priority
+	46, // 41: agenthub.TaskMessage.metadata:type_name -> google.protobuf.Struct
+	42, // 42: agenthub.TaskMessage.created_at:type_name -> google.protobuf.Timestamp
+	50, // 43: agenthub.TaskResult.status:type_name -> a2a.TaskState
+	46, // 44: agenthub.TaskResult.result:type_name -> google.protobuf.Struct
+	42, // 45: agenthub.TaskResult.completed_at:type_name -> google.protobuf.Timestamp
+	46, // 46: agenthub.TaskResult.execution_metadata:type_name -> google.protobuf.Struct
+	50, // 47: agenthub.TaskProgress.status:type_name -> a2a.TaskState
+	46, // 48: agenthub.TaskProgress.progress_data:type_name -> google.protobuf.Struct
+	42, // 49: agenthub.TaskProgress.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 50: agenthub.AgentHub.PublishMessage:input_type -> agenthub.PublishMessageRequest
+	9,  // 51: agenthub.AgentHub.PublishMessages:input_type -> agenthub.PublishMessagesRequest
+	8,  // 52: agenthub.AgentHub.SendAndReceive:input_type -> agenthub.SendAndReceiveRequest
+	11, // 53: agenthub.AgentHub.PublishTaskUpdate:input_type -> agenthub.PublishTaskUpdateRequest
+	12, // 54: agenthub.AgentHub.PublishTaskArtifact:input_type -> agenthub.PublishTaskArtifactRequest
+	14, // 55: agenthub.AgentHub.PublishArtifactStream:input_type -> agenthub.ArtifactChunk
+	16, // 56: agenthub.AgentHub.GetArtifactBlob:input_type -> agenthub.GetArtifactBlobRequest
+	18, // 57: agenthub.AgentHub.SubscribeToMessages:input_type -> agenthub.SubscribeToMessagesRequest
+	19, // 58: agenthub.AgentHub.SubscribeToTasks:input_type -> agenthub.SubscribeToTasksRequest
+	20, // 59: agenthub.AgentHub.SubscribeToAgentEvents:input_type -> agenthub.SubscribeToAgentEventsRequest
+	21, // 60: agenthub.AgentHub.ReplayEvents:input_type -> agenthub.ReplayEventsRequest
+	22, // 61: agenthub.AgentHub.GetTask:input_type -> agenthub.GetTaskRequest
+	23, // 62: agenthub.AgentHub.StreamTaskHistory:input_type -> agenthub.StreamTaskHistoryRequest
+	24, // 63: agenthub.AgentHub.CancelTask:input_type -> agenthub.CancelTaskRequest
+	25, // 64: agenthub.AgentHub.ListTasks:input_type -> agenthub.ListTasksRequest
+	27, // 65: agenthub.AgentHub.GetContextMessages:input_type -> agenthub.GetContextMessagesRequest
+	51, // 66: agenthub.AgentHub.GetAgentCard:input_type -> google.protobuf.Empty
+	29, // 67: agenthub.AgentHub.RegisterAgent:input_type -> agenthub.RegisterAgentRequest
+	31, // 68: agenthub.AgentHub.DeregisterAgent:input_type -> agenthub.DeregisterAgentRequest
+	33, // 69: agenthub.AgentHub.Heartbeat:input_type -> agenthub.HeartbeatRequest
+	35, // 70: agenthub.AgentHub.ListAgents:input_type -> agenthub.ListAgentsRequest
+	51, // 71: agenthub.AgentHub.EchoTraceContext:input_type -> google.protobuf.Empty
+	13, // 72: agenthub.AgentHub.PublishMessage:output_type -> agenthub.PublishResponse
+	10, // 73: agenthub.AgentHub.PublishMessages:output_type -> agenthub.PublishMessagesResponse
+	43, // 74: agenthub.AgentHub.SendAndReceive:output_type -> a2a.Message
+	13, // 75: agenthub.AgentHub.PublishTaskUpdate:output_type -> agenthub.PublishResponse
+	13, // 76: agenthub.AgentHub.PublishTaskArtifact:output_type -> agenthub.PublishResponse
+	15, // 77: agenthub.AgentHub.PublishArtifactStream:output_type -> agenthub.PublishArtifactStreamResponse
+	17, // 78: agenthub.AgentHub.GetArtifactBlob:output_type -> agenthub.ArtifactBlobChunk
+	1,  // 79: agenthub.AgentHub.SubscribeToMessages:output_type -> agenthub.AgentEvent
+	1,  // 80: agenthub.AgentHub.SubscribeToTasks:output_type -> agenthub.AgentEvent
+	1,  // 81: agenthub.AgentHub.SubscribeToAgentEvents:output_type -> agenthub.AgentEvent
+	1,  // 82: agenthub.AgentHub.ReplayEvents:output_type -> agenthub.AgentEvent
+	44, // 83: agenthub.AgentHub.GetTask:output_type -> a2a.Task
+	43, // 84: agenthub.AgentHub.StreamTaskHistory:output_type -> a2a.Message
+	44, // 85: agenthub.AgentHub.CancelTask:output_type -> a2a.Task
+	26, // 86: agenthub.AgentHub.ListTasks:output_type -> agenthub.ListTasksResponse
+	28, // 87: agenthub.AgentHub.GetContextMessages:output_type -> agenthub.GetContextMessagesResponse
+	48, // 88: agenthub.AgentHub.GetAgentCard:output_type -> a2a.AgentCard
+	30, // 89: agenthub.AgentHub.RegisterAgent:output_type -> agenthub.RegisterAgentResponse
+	32, // 90: agenthub.AgentHub.DeregisterAgent:output_type -> agenthub.DeregisterAgentResponse
+	34, // 91: agenthub.AgentHub.Heartbeat:output_type -> agenthub.HeartbeatResponse
+	36, // 92: agenthub.AgentHub.ListAgents:output_type -> agenthub.ListAgentsResponse
+	37, // 93: agenthub.AgentHub.EchoTraceContext:output_type -> agenthub.EchoTraceContextResponse
+	72, // [72:94] is the sub-list for method output_type
+	50, // [50:72] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_proto_eventbus_proto_init() }
@@ -1898,7 +3217,7 @@ func file_proto_eventbus_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_eventbus_proto_rawDesc), len(file_proto_eventbus_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   21,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
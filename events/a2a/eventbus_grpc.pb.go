@@ -21,16 +21,27 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	AgentHub_PublishMessage_FullMethodName         = "/agenthub.AgentHub/PublishMessage"
+	AgentHub_PublishMessages_FullMethodName        = "/agenthub.AgentHub/PublishMessages"
+	AgentHub_SendAndReceive_FullMethodName         = "/agenthub.AgentHub/SendAndReceive"
 	AgentHub_PublishTaskUpdate_FullMethodName      = "/agenthub.AgentHub/PublishTaskUpdate"
 	AgentHub_PublishTaskArtifact_FullMethodName    = "/agenthub.AgentHub/PublishTaskArtifact"
+	AgentHub_PublishArtifactStream_FullMethodName  = "/agenthub.AgentHub/PublishArtifactStream"
+	AgentHub_GetArtifactBlob_FullMethodName        = "/agenthub.AgentHub/GetArtifactBlob"
 	AgentHub_SubscribeToMessages_FullMethodName    = "/agenthub.AgentHub/SubscribeToMessages"
 	AgentHub_SubscribeToTasks_FullMethodName       = "/agenthub.AgentHub/SubscribeToTasks"
 	AgentHub_SubscribeToAgentEvents_FullMethodName = "/agenthub.AgentHub/SubscribeToAgentEvents"
+	AgentHub_ReplayEvents_FullMethodName           = "/agenthub.AgentHub/ReplayEvents"
 	AgentHub_GetTask_FullMethodName                = "/agenthub.AgentHub/GetTask"
+	AgentHub_StreamTaskHistory_FullMethodName      = "/agenthub.AgentHub/StreamTaskHistory"
 	AgentHub_CancelTask_FullMethodName             = "/agenthub.AgentHub/CancelTask"
 	AgentHub_ListTasks_FullMethodName              = "/agenthub.AgentHub/ListTasks"
+	AgentHub_GetContextMessages_FullMethodName     = "/agenthub.AgentHub/GetContextMessages"
 	AgentHub_GetAgentCard_FullMethodName           = "/agenthub.AgentHub/GetAgentCard"
 	AgentHub_RegisterAgent_FullMethodName          = "/agenthub.AgentHub/RegisterAgent"
+	AgentHub_DeregisterAgent_FullMethodName        = "/agenthub.AgentHub/DeregisterAgent"
+	AgentHub_Heartbeat_FullMethodName              = "/agenthub.AgentHub/Heartbeat"
+	AgentHub_ListAgents_FullMethodName             = "/agenthub.AgentHub/ListAgents"
+	AgentHub_EchoTraceContext_FullMethodName       = "/agenthub.AgentHub/EchoTraceContext"
 )
 
 // AgentHubClient is the client API for AgentHub service.
@@ -41,12 +52,25 @@ type AgentHubClient interface {
 	// The message is wrapped in an AgentEvent and routed based on metadata.
 	// Supports point-to-point, broadcast, and topic-based delivery patterns.
 	PublishMessage(ctx context.Context, in *PublishMessageRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// PublishMessages submits a batch of A2A messages sharing the same routing.
+	// Each message is validated and routed like PublishMessage, and gets its own result.
+	PublishMessages(ctx context.Context, in *PublishMessagesRequest, opts ...grpc.CallOption) (*PublishMessagesResponse, error)
+	// SendAndReceive publishes an A2A message and waits for the first response to it.
+	// Responders are matched by the correlation_id or original_message_id metadata of
+	// their message; the call fails with DEADLINE_EXCEEDED when none answers in time.
+	SendAndReceive(ctx context.Context, in *SendAndReceiveRequest, opts ...grpc.CallOption) (*Message, error)
 	// PublishTaskUpdate notifies subscribers about A2A task state changes.
 	// Used to broadcast task lifecycle events (started, progress, completed).
 	PublishTaskUpdate(ctx context.Context, in *PublishTaskUpdateRequest, opts ...grpc.CallOption) (*PublishResponse, error)
 	// PublishTaskArtifact delivers A2A task output artifacts to subscribers.
 	// Supports both atomic delivery and streaming for large artifacts.
 	PublishTaskArtifact(ctx context.Context, in *PublishTaskArtifactRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// PublishArtifactStream uploads a large file artifact in chunks.
+	// The content is stored by the broker and the artifact references it by blob ID.
+	PublishArtifactStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ArtifactChunk, PublishArtifactStreamResponse], error)
+	// GetArtifactBlob streams back the content of a stored artifact.
+	// Used to resolve the "blob://<id>" URIs of file parts.
+	GetArtifactBlob(ctx context.Context, in *GetArtifactBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactBlobChunk], error)
 	// SubscribeToMessages creates a stream of A2A message events for an agent.
 	// The agent receives all messages routed to it or matching its subscriptions.
 	SubscribeToMessages(ctx context.Context, in *SubscribeToMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error)
@@ -56,21 +80,44 @@ type AgentHubClient interface {
 	// SubscribeToAgentEvents creates a unified stream of all events for an agent.
 	// Combines messages, tasks, status updates, and artifacts in one stream.
 	SubscribeToAgentEvents(ctx context.Context, in *SubscribeToAgentEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error)
+	// ReplayEvents streams the retained events for an agent routed since a point in time,
+	// then continues with live events like SubscribeToAgentEvents.
+	// Requires event history on the broker (AGENTHUB_EVENT_HISTORY_SIZE).
+	ReplayEvents(ctx context.Context, in *ReplayEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error)
 	// GetTask retrieves the current state of an A2A task by ID.
 	// Returns the complete task with history, status, and artifacts.
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// StreamTaskHistory streams the history of an A2A task, oldest to newest.
+	// With follow set, it then streams the messages added to the task until the
+	// task reaches a terminal state.
+	StreamTaskHistory(ctx context.Context, in *StreamTaskHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
 	// CancelTask cancels an active A2A task and notifies subscribers.
 	// Only tasks in SUBMITTED or WORKING state can be cancelled.
 	CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*Task, error)
 	// ListTasks returns A2A tasks matching the specified criteria.
 	// Supports filtering by agent, context, state, and pagination.
 	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	// GetContextMessages returns the messages retained for a conversation context.
+	// Lets a new session resume a conversation, or an agent rebuild its state.
+	GetContextMessages(ctx context.Context, in *GetContextMessagesRequest, opts ...grpc.CallOption) (*GetContextMessagesResponse, error)
 	// GetAgentCard returns the broker's A2A agent card for discovery.
 	// Enables other agents to discover the broker's capabilities and endpoints.
 	GetAgentCard(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AgentCard, error)
 	// RegisterAgent registers an agent with the broker for event routing.
 	// Enables the broker to route events to the agent and track its capabilities.
 	RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error)
+	// DeregisterAgent removes an agent's registration and announces its departure.
+	// Agents call it on graceful shutdown so that routing and discovery forget them.
+	DeregisterAgent(ctx context.Context, in *DeregisterAgentRequest, opts ...grpc.CallOption) (*DeregisterAgentResponse, error)
+	// Heartbeat tells the broker that a registered agent is still alive.
+	// Agents silent for longer than the staleness threshold are deregistered.
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	// ListAgents returns the A2A cards of the agents registered with the broker.
+	// Lets agents verify their registration and orchestrators discover agents.
+	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// EchoTraceContext returns the W3C trace context the broker received with the call.
+	// Lets clients check that their trace context propagates to the broker intact.
+	EchoTraceContext(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EchoTraceContextResponse, error)
 }
 
 type agentHubClient struct {
@@ -91,6 +138,26 @@ func (c *agentHubClient) PublishMessage(ctx context.Context, in *PublishMessageR
 	return out, nil
 }
 
+func (c *agentHubClient) PublishMessages(ctx context.Context, in *PublishMessagesRequest, opts ...grpc.CallOption) (*PublishMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishMessagesResponse)
+	err := c.cc.Invoke(ctx, AgentHub_PublishMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentHubClient) SendAndReceive(ctx context.Context, in *SendAndReceiveRequest, opts ...grpc.CallOption) (*Message, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Message)
+	err := c.cc.Invoke(ctx, AgentHub_SendAndReceive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentHubClient) PublishTaskUpdate(ctx context.Context, in *PublishTaskUpdateRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PublishResponse)
@@ -111,9 +178,41 @@ func (c *agentHubClient) PublishTaskArtifact(ctx context.Context, in *PublishTas
 	return out, nil
 }
 
+func (c *agentHubClient) PublishArtifactStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ArtifactChunk, PublishArtifactStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[0], AgentHub_PublishArtifactStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ArtifactChunk, PublishArtifactStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_PublishArtifactStreamClient = grpc.ClientStreamingClient[ArtifactChunk, PublishArtifactStreamResponse]
+
+func (c *agentHubClient) GetArtifactBlob(ctx context.Context, in *GetArtifactBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactBlobChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[1], AgentHub_GetArtifactBlob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetArtifactBlobRequest, ArtifactBlobChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_GetArtifactBlobClient = grpc.ServerStreamingClient[ArtifactBlobChunk]
+
 func (c *agentHubClient) SubscribeToMessages(ctx context.Context, in *SubscribeToMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[0], AgentHub_SubscribeToMessages_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[2], AgentHub_SubscribeToMessages_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +231,7 @@ type AgentHub_SubscribeToMessagesClient = grpc.ServerStreamingClient[AgentEvent]
 
 func (c *agentHubClient) SubscribeToTasks(ctx context.Context, in *SubscribeToTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[1], AgentHub_SubscribeToTasks_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[3], AgentHub_SubscribeToTasks_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +250,7 @@ type AgentHub_SubscribeToTasksClient = grpc.ServerStreamingClient[AgentEvent]
 
 func (c *agentHubClient) SubscribeToAgentEvents(ctx context.Context, in *SubscribeToAgentEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[2], AgentHub_SubscribeToAgentEvents_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[4], AgentHub_SubscribeToAgentEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +267,25 @@ func (c *agentHubClient) SubscribeToAgentEvents(ctx context.Context, in *Subscri
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentHub_SubscribeToAgentEventsClient = grpc.ServerStreamingClient[AgentEvent]
 
+func (c *agentHubClient) ReplayEvents(ctx context.Context, in *ReplayEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[5], AgentHub_ReplayEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReplayEventsRequest, AgentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_ReplayEventsClient = grpc.ServerStreamingClient[AgentEvent]
+
 func (c *agentHubClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Task)
@@ -178,6 +296,25 @@ func (c *agentHubClient) GetTask(ctx context.Context, in *GetTaskRequest, opts .
 	return out, nil
 }
 
+func (c *agentHubClient) StreamTaskHistory(ctx context.Context, in *StreamTaskHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentHub_ServiceDesc.Streams[6], AgentHub_StreamTaskHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTaskHistoryRequest, Message]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_StreamTaskHistoryClient = grpc.ServerStreamingClient[Message]
+
 func (c *agentHubClient) CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Task)
@@ -198,6 +335,16 @@ func (c *agentHubClient) ListTasks(ctx context.Context, in *ListTasksRequest, op
 	return out, nil
 }
 
+func (c *agentHubClient) GetContextMessages(ctx context.Context, in *GetContextMessagesRequest, opts ...grpc.CallOption) (*GetContextMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContextMessagesResponse)
+	err := c.cc.Invoke(ctx, AgentHub_GetContextMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentHubClient) GetAgentCard(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AgentCard, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AgentCard)
@@ -218,6 +365,46 @@ func (c *agentHubClient) RegisterAgent(ctx context.Context, in *RegisterAgentReq
 	return out, nil
 }
 
+func (c *agentHubClient) DeregisterAgent(ctx context.Context, in *DeregisterAgentRequest, opts ...grpc.CallOption) (*DeregisterAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterAgentResponse)
+	err := c.cc.Invoke(ctx, AgentHub_DeregisterAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentHubClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, AgentHub_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentHubClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, AgentHub_ListAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentHubClient) EchoTraceContext(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EchoTraceContextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EchoTraceContextResponse)
+	err := c.cc.Invoke(ctx, AgentHub_EchoTraceContext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentHubServer is the server API for AgentHub service.
 // All implementations must embed UnimplementedAgentHubServer
 // for forward compatibility.
@@ -226,12 +413,25 @@ type AgentHubServer interface {
 	// The message is wrapped in an AgentEvent and routed based on metadata.
 	// Supports point-to-point, broadcast, and topic-based delivery patterns.
 	PublishMessage(context.Context, *PublishMessageRequest) (*PublishResponse, error)
+	// PublishMessages submits a batch of A2A messages sharing the same routing.
+	// Each message is validated and routed like PublishMessage, and gets its own result.
+	PublishMessages(context.Context, *PublishMessagesRequest) (*PublishMessagesResponse, error)
+	// SendAndReceive publishes an A2A message and waits for the first response to it.
+	// Responders are matched by the correlation_id or original_message_id metadata of
+	// their message; the call fails with DEADLINE_EXCEEDED when none answers in time.
+	SendAndReceive(context.Context, *SendAndReceiveRequest) (*Message, error)
 	// PublishTaskUpdate notifies subscribers about A2A task state changes.
 	// Used to broadcast task lifecycle events (started, progress, completed).
 	PublishTaskUpdate(context.Context, *PublishTaskUpdateRequest) (*PublishResponse, error)
 	// PublishTaskArtifact delivers A2A task output artifacts to subscribers.
 	// Supports both atomic delivery and streaming for large artifacts.
 	PublishTaskArtifact(context.Context, *PublishTaskArtifactRequest) (*PublishResponse, error)
+	// PublishArtifactStream uploads a large file artifact in chunks.
+	// The content is stored by the broker and the artifact references it by blob ID.
+	PublishArtifactStream(grpc.ClientStreamingServer[ArtifactChunk, PublishArtifactStreamResponse]) error
+	// GetArtifactBlob streams back the content of a stored artifact.
+	// Used to resolve the "blob://<id>" URIs of file parts.
+	GetArtifactBlob(*GetArtifactBlobRequest, grpc.ServerStreamingServer[ArtifactBlobChunk]) error
 	// SubscribeToMessages creates a stream of A2A message events for an agent.
 	// The agent receives all messages routed to it or matching its subscriptions.
 	SubscribeToMessages(*SubscribeToMessagesRequest, grpc.ServerStreamingServer[AgentEvent]) error
@@ -241,21 +441,44 @@ type AgentHubServer interface {
 	// SubscribeToAgentEvents creates a unified stream of all events for an agent.
 	// Combines messages, tasks, status updates, and artifacts in one stream.
 	SubscribeToAgentEvents(*SubscribeToAgentEventsRequest, grpc.ServerStreamingServer[AgentEvent]) error
+	// ReplayEvents streams the retained events for an agent routed since a point in time,
+	// then continues with live events like SubscribeToAgentEvents.
+	// Requires event history on the broker (AGENTHUB_EVENT_HISTORY_SIZE).
+	ReplayEvents(*ReplayEventsRequest, grpc.ServerStreamingServer[AgentEvent]) error
 	// GetTask retrieves the current state of an A2A task by ID.
 	// Returns the complete task with history, status, and artifacts.
 	GetTask(context.Context, *GetTaskRequest) (*Task, error)
+	// StreamTaskHistory streams the history of an A2A task, oldest to newest.
+	// With follow set, it then streams the messages added to the task until the
+	// task reaches a terminal state.
+	StreamTaskHistory(*StreamTaskHistoryRequest, grpc.ServerStreamingServer[Message]) error
 	// CancelTask cancels an active A2A task and notifies subscribers.
 	// Only tasks in SUBMITTED or WORKING state can be cancelled.
 	CancelTask(context.Context, *CancelTaskRequest) (*Task, error)
 	// ListTasks returns A2A tasks matching the specified criteria.
 	// Supports filtering by agent, context, state, and pagination.
 	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	// GetContextMessages returns the messages retained for a conversation context.
+	// Lets a new session resume a conversation, or an agent rebuild its state.
+	GetContextMessages(context.Context, *GetContextMessagesRequest) (*GetContextMessagesResponse, error)
 	// GetAgentCard returns the broker's A2A agent card for discovery.
 	// Enables other agents to discover the broker's capabilities and endpoints.
 	GetAgentCard(context.Context, *emptypb.Empty) (*AgentCard, error)
 	// RegisterAgent registers an agent with the broker for event routing.
 	// Enables the broker to route events to the agent and track its capabilities.
 	RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error)
+	// DeregisterAgent removes an agent's registration and announces its departure.
+	// Agents call it on graceful shutdown so that routing and discovery forget them.
+	DeregisterAgent(context.Context, *DeregisterAgentRequest) (*DeregisterAgentResponse, error)
+	// Heartbeat tells the broker that a registered agent is still alive.
+	// Agents silent for longer than the staleness threshold are deregistered.
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// ListAgents returns the A2A cards of the agents registered with the broker.
+	// Lets agents verify their registration and orchestrators discover agents.
+	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// EchoTraceContext returns the W3C trace context the broker received with the call.
+	// Lets clients check that their trace context propagates to the broker intact.
+	EchoTraceContext(context.Context, *emptypb.Empty) (*EchoTraceContextResponse, error)
 	mustEmbedUnimplementedAgentHubServer()
 }
 
@@ -269,12 +492,24 @@ type UnimplementedAgentHubServer struct{}
 func (UnimplementedAgentHubServer) PublishMessage(context.Context, *PublishMessageRequest) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishMessage not implemented")
 }
+func (UnimplementedAgentHubServer) PublishMessages(context.Context, *PublishMessagesRequest) (*PublishMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishMessages not implemented")
+}
+func (UnimplementedAgentHubServer) SendAndReceive(context.Context, *SendAndReceiveRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAndReceive not implemented")
+}
 func (UnimplementedAgentHubServer) PublishTaskUpdate(context.Context, *PublishTaskUpdateRequest) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishTaskUpdate not implemented")
 }
 func (UnimplementedAgentHubServer) PublishTaskArtifact(context.Context, *PublishTaskArtifactRequest) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishTaskArtifact not implemented")
 }
+func (UnimplementedAgentHubServer) PublishArtifactStream(grpc.ClientStreamingServer[ArtifactChunk, PublishArtifactStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PublishArtifactStream not implemented")
+}
+func (UnimplementedAgentHubServer) GetArtifactBlob(*GetArtifactBlobRequest, grpc.ServerStreamingServer[ArtifactBlobChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetArtifactBlob not implemented")
+}
 func (UnimplementedAgentHubServer) SubscribeToMessages(*SubscribeToMessagesRequest, grpc.ServerStreamingServer[AgentEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeToMessages not implemented")
 }
@@ -284,21 +519,42 @@ func (UnimplementedAgentHubServer) SubscribeToTasks(*SubscribeToTasksRequest, gr
 func (UnimplementedAgentHubServer) SubscribeToAgentEvents(*SubscribeToAgentEventsRequest, grpc.ServerStreamingServer[AgentEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeToAgentEvents not implemented")
 }
+func (UnimplementedAgentHubServer) ReplayEvents(*ReplayEventsRequest, grpc.ServerStreamingServer[AgentEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method ReplayEvents not implemented")
+}
 func (UnimplementedAgentHubServer) GetTask(context.Context, *GetTaskRequest) (*Task, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
 }
+func (UnimplementedAgentHubServer) StreamTaskHistory(*StreamTaskHistoryRequest, grpc.ServerStreamingServer[Message]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTaskHistory not implemented")
+}
 func (UnimplementedAgentHubServer) CancelTask(context.Context, *CancelTaskRequest) (*Task, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelTask not implemented")
 }
 func (UnimplementedAgentHubServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
 }
+func (UnimplementedAgentHubServer) GetContextMessages(context.Context, *GetContextMessagesRequest) (*GetContextMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContextMessages not implemented")
+}
 func (UnimplementedAgentHubServer) GetAgentCard(context.Context, *emptypb.Empty) (*AgentCard, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAgentCard not implemented")
 }
 func (UnimplementedAgentHubServer) RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
 }
+func (UnimplementedAgentHubServer) DeregisterAgent(context.Context, *DeregisterAgentRequest) (*DeregisterAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterAgent not implemented")
+}
+func (UnimplementedAgentHubServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedAgentHubServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedAgentHubServer) EchoTraceContext(context.Context, *emptypb.Empty) (*EchoTraceContextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EchoTraceContext not implemented")
+}
 func (UnimplementedAgentHubServer) mustEmbedUnimplementedAgentHubServer() {}
 func (UnimplementedAgentHubServer) testEmbeddedByValue()                  {}
 
@@ -338,6 +594,42 @@ func _AgentHub_PublishMessage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentHub_PublishMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).PublishMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_PublishMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).PublishMessages(ctx, req.(*PublishMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentHub_SendAndReceive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAndReceiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).SendAndReceive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_SendAndReceive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).SendAndReceive(ctx, req.(*SendAndReceiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AgentHub_PublishTaskUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PublishTaskUpdateRequest)
 	if err := dec(in); err != nil {
@@ -374,6 +666,24 @@ func _AgentHub_PublishTaskArtifact_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentHub_PublishArtifactStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentHubServer).PublishArtifactStream(&grpc.GenericServerStream[ArtifactChunk, PublishArtifactStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_PublishArtifactStreamServer = grpc.ClientStreamingServer[ArtifactChunk, PublishArtifactStreamResponse]
+
+func _AgentHub_GetArtifactBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetArtifactBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentHubServer).GetArtifactBlob(m, &grpc.GenericServerStream[GetArtifactBlobRequest, ArtifactBlobChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_GetArtifactBlobServer = grpc.ServerStreamingServer[ArtifactBlobChunk]
+
 func _AgentHub_SubscribeToMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeToMessagesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -407,6 +717,17 @@ func _AgentHub_SubscribeToAgentEvents_Handler(srv interface{}, stream grpc.Serve
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentHub_SubscribeToAgentEventsServer = grpc.ServerStreamingServer[AgentEvent]
 
+func _AgentHub_ReplayEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplayEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentHubServer).ReplayEvents(m, &grpc.GenericServerStream[ReplayEventsRequest, AgentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_ReplayEventsServer = grpc.ServerStreamingServer[AgentEvent]
+
 func _AgentHub_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTaskRequest)
 	if err := dec(in); err != nil {
@@ -425,6 +746,17 @@ func _AgentHub_GetTask_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentHub_StreamTaskHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTaskHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentHubServer).StreamTaskHistory(m, &grpc.GenericServerStream[StreamTaskHistoryRequest, Message]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentHub_StreamTaskHistoryServer = grpc.ServerStreamingServer[Message]
+
 func _AgentHub_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelTaskRequest)
 	if err := dec(in); err != nil {
@@ -461,6 +793,24 @@ func _AgentHub_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentHub_GetContextMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContextMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).GetContextMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_GetContextMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).GetContextMessages(ctx, req.(*GetContextMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AgentHub_GetAgentCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -497,6 +847,78 @@ func _AgentHub_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentHub_DeregisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).DeregisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_DeregisterAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).DeregisterAgent(ctx, req.(*DeregisterAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentHub_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentHub_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_ListAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).ListAgents(ctx, req.(*ListAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentHub_EchoTraceContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentHubServer).EchoTraceContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentHub_EchoTraceContext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentHubServer).EchoTraceContext(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentHub_ServiceDesc is the grpc.ServiceDesc for AgentHub service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -508,6 +930,14 @@ var AgentHub_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PublishMessage",
 			Handler:    _AgentHub_PublishMessage_Handler,
 		},
+		{
+			MethodName: "PublishMessages",
+			Handler:    _AgentHub_PublishMessages_Handler,
+		},
+		{
+			MethodName: "SendAndReceive",
+			Handler:    _AgentHub_SendAndReceive_Handler,
+		},
 		{
 			MethodName: "PublishTaskUpdate",
 			Handler:    _AgentHub_PublishTaskUpdate_Handler,
@@ -528,6 +958,10 @@ var AgentHub_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTasks",
 			Handler:    _AgentHub_ListTasks_Handler,
 		},
+		{
+			MethodName: "GetContextMessages",
+			Handler:    _AgentHub_GetContextMessages_Handler,
+		},
 		{
 			MethodName: "GetAgentCard",
 			Handler:    _AgentHub_GetAgentCard_Handler,
@@ -536,8 +970,34 @@ var AgentHub_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterAgent",
 			Handler:    _AgentHub_RegisterAgent_Handler,
 		},
+		{
+			MethodName: "DeregisterAgent",
+			Handler:    _AgentHub_DeregisterAgent_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _AgentHub_Heartbeat_Handler,
+		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _AgentHub_ListAgents_Handler,
+		},
+		{
+			MethodName: "EchoTraceContext",
+			Handler:    _AgentHub_EchoTraceContext_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PublishArtifactStream",
+			Handler:       _AgentHub_PublishArtifactStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetArtifactBlob",
+			Handler:       _AgentHub_GetArtifactBlob_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeToMessages",
 			Handler:       _AgentHub_SubscribeToMessages_Handler,
@@ -553,6 +1013,16 @@ var AgentHub_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AgentHub_SubscribeToAgentEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReplayEvents",
+			Handler:       _AgentHub_ReplayEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTaskHistory",
+			Handler:       _AgentHub_StreamTaskHistory_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/eventbus.proto",
 }
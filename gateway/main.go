@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/owulveryck/agenthub/internal/agenthub"
+	"github.com/owulveryck/agenthub/internal/gateway"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	config := agenthub.NewGRPCConfig("gateway")
+
+	client, err := agenthub.NewAgentHubClient(config)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		if err := client.Shutdown(shutdownCtx); err != nil {
+			client.Logger.ErrorContext(shutdownCtx, "Error during shutdown", "error", err)
+		}
+	}()
+
+	if err := client.Start(ctx); err != nil {
+		client.Logger.ErrorContext(ctx, "Failed to start client", "error", err)
+		panic(err)
+	}
+
+	addr := os.Getenv("AGENTHUB_GATEWAY_ADDR")
+	if addr == "" {
+		addr = gateway.DefaultAddr
+	}
+	server := &http.Server{
+		Addr:    addr,
+		Handler: gateway.New(client).Handler(),
+		// End the event streams of connected browsers on shutdown
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	client.Logger.InfoContext(ctx, "Starting HTTP gateway", "addr", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		client.Logger.ErrorContext(ctx, "Gateway stopped", "error", err)
+	}
+}
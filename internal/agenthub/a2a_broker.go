@@ -2,12 +2,15 @@ package agenthub
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -21,36 +24,179 @@ type AgentHubService struct {
 	// A2A event streams
 	messageSubscribers map[string][]chan *pb.AgentEvent
 	taskSubscribers    map[string][]chan *pb.AgentEvent
-	eventSubscribers   map[string][]chan *pb.AgentEvent
+	eventSubscribers   map[string][]*eventSubscription
 	agentMu            sync.RWMutex
 
-	// Task storage for A2A compliance
-	tasks   map[string]*pb.Task
-	tasksMu sync.RWMutex
+	// Task storage for A2A compliance. tasksMu serializes read-modify-write
+	// sequences on the store.
+	taskStore TaskStore
+	tasksMu   sync.Mutex
+
+	// Requester of each non-terminal task, used to route its updates back (guarded by tasksMu)
+	taskRequesters map[string]string
+	// Creation time of each non-terminal task, for the task lifetime metric (guarded by tasksMu)
+	taskCreatedAt map[string]time.Time
+	// Terminal time of each finished task, for the retention policy (guarded by tasksMu)
+	taskFinishedAt map[string]time.Time
+	// How long finished tasks are kept (0 keeps them forever), how often they are
+	// looked for, and where they are archived before being dropped (nil drops them)
+	taskRetention  time.Duration
+	taskGCInterval time.Duration
+	taskArchive    TaskStore
+	// Live StreamTaskHistory followers of each task (guarded by tasksMu)
+	taskWatches map[string][]*taskHistoryWatch
 
 	// Agent registry
 	registeredAgents map[string]*pb.AgentCard
+	agentLastSeen    map[string]time.Time // Last registration or heartbeat of each agent (guarded by agentsMu)
+	staleThreshold   time.Duration        // Agents silent for longer are deregistered (0 disables reaping)
 	agentsMu         sync.RWMutex
 
 	// Context and message storage
-	contexts   map[string][]*pb.Message
-	contextsMu sync.RWMutex
+	contexts *contextHistory
+
+	// Per-key ordered delivery
+	orderedDispatcher *orderedDispatcher
+
+	// Retained events for subscription resumption
+	eventLog *eventLog
+
+	// Content-based routing rules for events published without a target agent
+	routingRules []compiledRoutingRule
+
+	// Per-connection subscription accounting
+	streams *streamTracker
+
+	// Channel buffer of each subscription, and behavior when it is full
+	bufferSize  int
+	dropPolicy  DropPolicy
+	sendTimeout time.Duration // How long DropPolicyTimeoutDrop waits for a full subscriber
+
+	// Priority given to events published without one
+	defaultPriority pb.Priority
+
+	// How long PublishMessage waits for the sends it reports in its delivery counts
+	deliveryReportWait time.Duration
+
+	// Per-subscriber circuit breakers tripped by consecutive send timeouts (nil disables them)
+	breakers *circuitBreakers
+
+	// Stop signals releasing the deliveries to ended subscriptions
+	subscriberStops subscriberStops
+
+	// Events routed to no subscriber
+	deadLetters       *DeadLetterBuffer
+	deadLetterHandler DeadLetterHandler
+
+	// Per-agent publish rate limit (nil means unlimited)
+	publishLimiter *publishLimiter
+
+	// Recently published message IDs (nil disables deduplication)
+	dedup *messageDedup
+
+	// Content of file artifacts (nil disables artifact storage)
+	blobStore BlobStore
+	// maxArtifactBytes limits the size of stored artifact content (0 means unlimited)
+	maxArtifactBytes int64
+
+	// Pending SendAndReceive calls
+	replies *replyWaiters
+
+	// Generator of event, message and correlation IDs
+	ids IDGenerator
 
 	// AgentHub components
 	Server *AgentHubServer
 }
 
-// NewAgentHubService creates a new A2A-compliant AgentHub service
-func NewAgentHubService(server *AgentHubServer) *AgentHubService {
-	return &AgentHubService{
+// NewAgentHubService creates a new A2A-compliant AgentHub service.
+// Options such as WithDropPolicy, WithDefaultPriority, WithSendTimeout, WithTaskStore, WithTaskRetention, WithTaskArchive, WithBlobStore, WithIDGenerator and WithDeadLetterHandler customize the service; tasks are
+// kept in an InMemoryTaskStore unless another store is given.
+func NewAgentHubService(server *AgentHubServer, opts ...ServiceOption) *AgentHubService {
+	historySize, streamLimit, bufferSize := 0, 0, DefaultSubscriberBufferSize
+	var historyRetention, taskRetention, taskGCInterval time.Duration
+	dropPolicy, sendTimeout := DropPolicyTimeoutDrop, DefaultDeliveryTimeout
+	defaultPriority := pb.Priority_PRIORITY_MEDIUM
+	deliveryReportWait := DefaultDeliveryReportWait
+	breakerThreshold, breakerCooldown := 0, DefaultCircuitBreakerCooldown
+	maxContextMessages, maxContexts, contextTTL := DefaultMaxContextMessages, DefaultMaxContexts, time.Duration(0)
+	var staleThreshold time.Duration
+	var limiter *publishLimiter
+	var dedup *messageDedup
+	var blobStore BlobStore
+	var maxArtifactBytes int64
+	deadLetterSize := DefaultDeadLetterBufferSize
+	if server != nil && server.Config != nil {
+		deadLetterSize = server.Config.DeadLetterBufferSize
+		limiter = newPublishLimiter(server.Config.PublishRateLimit, server.Config.PublishRateBurst)
+		dedup = newMessageDedup(server.Config.DedupWindow)
+		if server.Config.ArtifactDir != "" {
+			blobStore = NewLocalBlobStore(server.Config.ArtifactDir)
+		}
+		maxArtifactBytes = server.Config.MaxArtifactBytes
+		dropPolicy = server.Config.DropPolicy
+		if server.Config.DefaultPriority != pb.Priority_PRIORITY_UNSPECIFIED {
+			defaultPriority = server.Config.DefaultPriority
+		}
+		maxContextMessages = server.Config.MaxContextMessages
+		maxContexts = server.Config.MaxContexts
+		contextTTL = server.Config.ContextTTL
+		taskRetention = server.Config.TaskRetention
+		taskGCInterval = server.Config.TaskGCInterval
+		staleThreshold = server.Config.AgentStaleThreshold
+		historySize = server.Config.EventHistorySize
+		historyRetention = server.Config.EventHistoryRetention
+		streamLimit = server.Config.MaxConcurrentStreams
+		if server.Config.SubscriberBufferSize > 0 {
+			bufferSize = server.Config.SubscriberBufferSize
+		}
+		if server.Config.SendTimeout > 0 {
+			sendTimeout = server.Config.SendTimeout
+		}
+		deliveryReportWait = server.Config.DeliveryReportWait
+		breakerThreshold = server.Config.CircuitBreakerThreshold
+		if server.Config.CircuitBreakerCooldown > 0 {
+			breakerCooldown = server.Config.CircuitBreakerCooldown
+		}
+	}
+
+	service := &AgentHubService{
 		Server:             server,
 		messageSubscribers: make(map[string][]chan *pb.AgentEvent),
 		taskSubscribers:    make(map[string][]chan *pb.AgentEvent),
-		eventSubscribers:   make(map[string][]chan *pb.AgentEvent),
-		tasks:              make(map[string]*pb.Task),
+		eventSubscribers:   make(map[string][]*eventSubscription),
+		taskStore:          NewInMemoryTaskStore(),
+		taskRequesters:     make(map[string]string),
+		taskCreatedAt:      make(map[string]time.Time),
+		taskFinishedAt:     make(map[string]time.Time),
+		taskRetention:      taskRetention,
+		taskGCInterval:     taskGCInterval,
+		taskWatches:        make(map[string][]*taskHistoryWatch),
 		registeredAgents:   make(map[string]*pb.AgentCard),
-		contexts:           make(map[string][]*pb.Message),
+		agentLastSeen:      make(map[string]time.Time),
+		staleThreshold:     staleThreshold,
+		contexts:           newContextHistory(maxContextMessages, maxContexts, contextTTL),
+		orderedDispatcher:  newOrderedDispatcher(),
+		eventLog:           newEventLog(historySize, historyRetention),
+		streams:            newStreamTracker(streamLimit),
+		bufferSize:         bufferSize,
+		dropPolicy:         dropPolicy,
+		defaultPriority:    defaultPriority,
+		sendTimeout:        sendTimeout,
+		deliveryReportWait: deliveryReportWait,
+		breakers:           newCircuitBreakers(breakerThreshold, breakerCooldown),
+		publishLimiter:     limiter,
+		dedup:              dedup,
+		blobStore:          blobStore,
+		maxArtifactBytes:   maxArtifactBytes,
+		deadLetters:        NewDeadLetterBuffer(deadLetterSize),
+		replies:            newReplyWaiters(),
+		ids:                DefaultIDGenerator,
+	}
+	for _, opt := range opts {
+		opt(service)
 	}
+	return service
 }
 
 // ===== A2A Message Publishing (EDA style) =====
@@ -77,23 +223,46 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 		return nil, err
 	}
 
+	if err := validateRouting(req.GetRouting()); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	if err := s.checkPublishRate(ctx, req.GetRouting()); err != nil {
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	// Enforce configured size and part-count limits
+	if limitErr := validateMessageLimits(s.Server.Config, message); limitErr != nil {
+		err := status.Error(codes.InvalidArgument, limitErr.Error())
+		s.Server.TraceManager.RecordError(span, err)
+		s.Server.MetricsManager.IncrementEventErrors(ctx, "a2a_message", "broker", limitErr.Reason)
+		return nil, err
+	}
+
+	s.logPayload(ctx, "PublishMessage request payload", req)
+
+	// Resolve skill-based routing to a concrete target agent
+	routing, resolveErr := s.resolveSkillRouting(ctx, "a2a_message", req.GetRouting())
+	if resolveErr != nil {
+		s.Server.TraceManager.RecordError(span, resolveErr)
+		return &pb.PublishResponse{Success: false, Error: resolveErr.Error()}, nil
+	}
+
 	// Log message receipt
 	s.Server.Logger.DebugContext(ctx, "Broker received message",
 		"message_id", message.GetMessageId(),
 		"context_id", message.GetContextId(),
 		"role", message.GetRole().String(),
 		"task_id", message.GetTaskId(),
-		"from_agent", req.GetRouting().GetFromAgentId(),
-		"to_agent", req.GetRouting().GetToAgentId(),
+		"from_agent", routing.GetFromAgentId(),
+		"to_agent", routing.GetToAgentId(),
 	)
 
 	// Add comprehensive A2A message attributes to span
-	taskType := ""
-	if message.Metadata != nil && message.Metadata.Fields != nil {
-		if taskTypeValue, exists := message.Metadata.Fields["task_type"]; exists {
-			taskType = taskTypeValue.GetStringValue()
-		}
-	}
+	taskType, _ := MetadataString(message.GetMetadata(), "task_type")
 	s.Server.TraceManager.AddA2AMessageAttributes(
 		span,
 		message.GetMessageId(),
@@ -105,26 +274,48 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 	)
 
 	// Generate event ID
-	eventID := fmt.Sprintf("evt_%s_%d", message.GetMessageId(), time.Now().Unix())
+	eventID := s.ids.NewID("evt_" + message.GetMessageId())
+
+	// Answer a retried publish of the same message with its original event, without re-routing
+	if originalID, duplicate := s.dedup.claim(message.GetMessageId(), eventID, time.Now()); duplicate {
+		s.Server.MetricsManager.IncrementDuplicateMessages(ctx, routing.GetFromAgentId())
+		s.Server.Logger.DebugContext(ctx, "Duplicate message ignored",
+			"message_id", message.GetMessageId(),
+			"event_id", originalID,
+			"from_agent", routing.GetFromAgentId(),
+		)
+		s.Server.TraceManager.SetSpanSuccess(span)
+		return &pb.PublishResponse{Success: true, EventId: originalID}, nil
+	}
+	// A publish that fails past this point may be retried with the same message ID
+	published := false
+	defer func() {
+		if !published {
+			s.dedup.forget(message.GetMessageId())
+		}
+	}()
 
 	// Store message in context if context_id is provided
 	if message.GetContextId() != "" {
-		s.contextsMu.Lock()
-		s.contexts[message.GetContextId()] = append(s.contexts[message.GetContextId()], message)
-		s.contextsMu.Unlock()
+		s.recordContextMessage(ctx, message)
 	}
 
 	// Handle task creation/update if this message has a task_id
 	var task *pb.Task
 	if message.GetTaskId() != "" {
 		s.tasksMu.Lock()
-		if existingTask, exists := s.tasks[message.GetTaskId()]; exists {
+		existingTask, getErr := s.taskStore.Get(ctx, message.GetTaskId())
+		switch {
+		case getErr == nil:
 			// Update existing task with new message
 			existingTask.History = append(existingTask.History, message)
+			if existingTask.Status == nil {
+				existingTask.Status = &pb.TaskStatus{}
+			}
 			existingTask.Status.Update = message
 			existingTask.Status.Timestamp = timestamppb.Now()
 			task = existingTask
-		} else {
+		case errors.Is(getErr, ErrTaskNotFound):
 			// Create new task for this message
 			task = &pb.Task{
 				Id:        message.GetTaskId(),
@@ -138,9 +329,26 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 				Artifacts: []*pb.Artifact{},
 				Metadata:  message.GetMetadata(),
 			}
+			if requester := routing.GetFromAgentId(); requester != "" {
+				s.taskRequesters[message.GetTaskId()] = requester
+			}
+			s.taskCreatedAt[message.GetTaskId()] = time.Now()
+		default:
+			s.tasksMu.Unlock()
+			err := status.Errorf(codes.Internal, "failed to load task: %v", getErr)
+			s.Server.TraceManager.RecordError(span, err)
+			return nil, err
+		}
+		putErr := s.taskStore.Put(ctx, task)
+		if putErr == nil {
+			s.notifyTaskWatches(task.GetId(), message)
 		}
-		s.tasks[message.GetTaskId()] = task
 		s.tasksMu.Unlock()
+		if putErr != nil {
+			err := status.Errorf(codes.Internal, "failed to store task: %v", putErr)
+			s.Server.TraceManager.RecordError(span, err)
+			return nil, err
+		}
 	}
 
 	// Create message event
@@ -148,7 +356,7 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 		EventId:   eventID,
 		Timestamp: timestamppb.Now(),
 		Payload:   &pb.AgentEvent_Message{Message: message},
-		Routing:   req.GetRouting(),
+		Routing:   routing,
 		TraceId:   span.SpanContext().TraceID().String(),
 		SpanId:    span.SpanContext().SpanID().String(),
 	}
@@ -175,7 +383,7 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 		)
 	}
 
-	err := s.routeEvent(routeCtx, messageEvent)
+	report, err := s.routeEventWithReport(routeCtx, messageEvent)
 	if err != nil {
 		s.Server.TraceManager.RecordError(span, err)
 		s.Server.TraceManager.RecordError(routeSpan, err)
@@ -184,6 +392,9 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 	}
 	s.Server.TraceManager.SetSpanSuccess(routeSpan)
 
+	// Answer the SendAndReceive call this message responds to, if any
+	s.replies.resolve(message)
+
 	// Log successful routing
 	s.Server.Logger.DebugContext(ctx, "Message routed successfully",
 		"message_id", message.GetMessageId(),
@@ -193,12 +404,12 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 
 	// If this was a task message, also publish a task event
 	if task != nil {
-		taskEventID := fmt.Sprintf("task_%s_%d", task.GetId(), time.Now().Unix())
+		taskEventID := s.ids.NewID("task_" + task.GetId())
 		taskEvent := &pb.AgentEvent{
 			EventId:   taskEventID,
 			Timestamp: timestamppb.Now(),
 			Payload:   &pb.AgentEvent_Task{Task: task},
-			Routing:   req.GetRouting(),
+			Routing:   routing,
 			TraceId:   span.SpanContext().TraceID().String(),
 			SpanId:    span.SpanContext().SpanID().String(),
 		}
@@ -214,10 +425,26 @@ func (s *AgentHubService) PublishMessage(ctx context.Context, req *pb.PublishMes
 
 	s.Server.MetricsManager.IncrementEventsProcessed(ctx, "a2a_message", "broker", true)
 	s.Server.TraceManager.SetSpanSuccess(span)
+	published = true
+
+	// Report how the subscriber sends went, so that publishers can judge partial delivery
+	delivered, dropped, pending := report.wait(ctx, s.deliveryReportWait)
+	if dropped > 0 {
+		s.Server.Logger.WarnContext(ctx, "Message partially delivered",
+			"message_id", message.GetMessageId(),
+			"event_id", eventID,
+			"delivered", delivered,
+			"dropped", dropped,
+			"pending", pending,
+		)
+	}
 
 	return &pb.PublishResponse{
-		Success: true,
-		EventId: eventID,
+		Success:        true,
+		EventId:        eventID,
+		DeliveredCount: int32(delivered),
+		DroppedCount:   int32(dropped),
+		PendingCount:   int32(pending),
 	}, nil
 }
 
@@ -233,26 +460,55 @@ func (s *AgentHubService) PublishTaskUpdate(ctx context.Context, req *pb.Publish
 		return nil, err
 	}
 
-	// Update task in storage
+	if err := validateRouting(req.GetRouting()); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	if err := s.checkPublishRate(ctx, req.GetRouting()); err != nil {
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	s.logPayload(ctx, "PublishTaskUpdate request payload", req)
+
+	// Update task in storage, and forget the requester once the task is over
 	s.tasksMu.Lock()
-	if task, exists := s.tasks[update.GetTaskId()]; exists {
-		task.Status = update.GetStatus()
-		s.tasks[update.GetTaskId()] = task
+	updated, err := s.taskStore.UpdateStatus(ctx, update.GetTaskId(), update.GetStatus())
+	if err != nil && !errors.Is(err, ErrTaskNotFound) {
+		s.tasksMu.Unlock()
+		err = status.Errorf(codes.Internal, "failed to update task status: %v", err)
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+	routing := s.routeToRequester(update.GetTaskId(), req.GetRouting())
+	if state := update.GetStatus().GetState(); IsTerminalTaskState(state) {
+		delete(s.taskRequesters, update.GetTaskId())
+		s.endTaskWatches(update.GetTaskId(), nil)
+		if updated != nil {
+			s.recordTaskFinished(update.GetTaskId(), time.Now())
+		}
+		if createdAt, ok := s.taskCreatedAt[update.GetTaskId()]; ok {
+			delete(s.taskCreatedAt, update.GetTaskId())
+			taskType, _ := MetadataString(updated.GetMetadata(), "task_type")
+			s.Server.MetricsManager.RecordTaskLifetime(ctx, taskType, state.String(), time.Since(createdAt))
+		}
 	}
 	s.tasksMu.Unlock()
 
 	// Generate event
-	eventID := fmt.Sprintf("status_%s_%d", update.GetTaskId(), time.Now().Unix())
+	eventID := s.ids.NewID("status_" + update.GetTaskId())
 	agentEvent := &pb.AgentEvent{
 		EventId:   eventID,
 		Timestamp: timestamppb.Now(),
 		Payload:   &pb.AgentEvent_StatusUpdate{StatusUpdate: update},
-		Routing:   req.GetRouting(),
+		Routing:   routing,
 		TraceId:   span.SpanContext().TraceID().String(),
 		SpanId:    span.SpanContext().SpanID().String(),
 	}
 
-	err := s.routeEvent(ctx, agentEvent)
+	err = s.routeEvent(ctx, agentEvent)
 	if err != nil {
 		return &pb.PublishResponse{Success: false, Error: err.Error()}, nil
 	}
@@ -260,6 +516,25 @@ func (s *AgentHubService) PublishTaskUpdate(ctx context.Context, req *pb.Publish
 	return &pb.PublishResponse{Success: true, EventId: eventID}, nil
 }
 
+// routeToRequester addresses an untargeted task event to the agent that requested the task.
+// Events for unknown tasks keep their routing and are broadcast. Callers must hold tasksMu.
+func (s *AgentHubService) routeToRequester(taskID string, routing *pb.AgentEventMetadata) *pb.AgentEventMetadata {
+	if routing.GetToAgentId() != "" {
+		return routing
+	}
+	requester, ok := s.taskRequesters[taskID]
+	if !ok {
+		return routing
+	}
+
+	routed := &pb.AgentEventMetadata{}
+	if routing != nil {
+		routed = proto.Clone(routing).(*pb.AgentEventMetadata)
+	}
+	routed.ToAgentId = requester
+	return routed
+}
+
 // PublishTaskArtifact publishes task artifacts
 func (s *AgentHubService) PublishTaskArtifact(ctx context.Context, req *pb.PublishTaskArtifactRequest) (*pb.PublishResponse, error) {
 	ctx, span := s.Server.TraceManager.StartPublishSpan(ctx, "broker", "task_artifact", req.GetArtifact().GetTaskId())
@@ -272,9 +547,36 @@ func (s *AgentHubService) PublishTaskArtifact(ctx context.Context, req *pb.Publi
 		return nil, err
 	}
 
+	if err := validateRouting(req.GetRouting()); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	if err := s.checkPublishRate(ctx, req.GetRouting()); err != nil {
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	// Keep inline file content in the blob store, so the task references it by ID
+	artifact, err := s.storeInlineFiles(ctx, artifact)
+	if err != nil {
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	s.logPayload(ctx, "PublishTaskArtifact request payload", req)
+
 	// Update task with artifact
 	s.tasksMu.Lock()
-	if task, exists := s.tasks[artifact.GetTaskId()]; exists {
+	task, getErr := s.taskStore.Get(ctx, artifact.GetTaskId())
+	if getErr != nil && !errors.Is(getErr, ErrTaskNotFound) {
+		s.tasksMu.Unlock()
+		err := status.Errorf(codes.Internal, "failed to load task: %v", getErr)
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+	if getErr == nil {
 		// Add or update artifact
 		found := false
 		for i, existing := range task.Artifacts {
@@ -293,22 +595,28 @@ func (s *AgentHubService) PublishTaskArtifact(ctx context.Context, req *pb.Publi
 		if !found {
 			task.Artifacts = append(task.Artifacts, artifact.GetArtifact())
 		}
-		s.tasks[artifact.GetTaskId()] = task
+		if err := s.taskStore.Put(ctx, task); err != nil {
+			s.tasksMu.Unlock()
+			err = status.Errorf(codes.Internal, "failed to store task: %v", err)
+			s.Server.TraceManager.RecordError(span, err)
+			return nil, err
+		}
 	}
+	routing := s.routeToRequester(artifact.GetTaskId(), req.GetRouting())
 	s.tasksMu.Unlock()
 
 	// Generate event
-	eventID := fmt.Sprintf("artifact_%s_%d", artifact.GetTaskId(), time.Now().Unix())
+	eventID := s.ids.NewID("artifact_" + artifact.GetTaskId())
 	agentEvent := &pb.AgentEvent{
 		EventId:   eventID,
 		Timestamp: timestamppb.Now(),
 		Payload:   &pb.AgentEvent_ArtifactUpdate{ArtifactUpdate: artifact},
-		Routing:   req.GetRouting(),
+		Routing:   routing,
 		TraceId:   span.SpanContext().TraceID().String(),
 		SpanId:    span.SpanContext().SpanID().String(),
 	}
 
-	err := s.routeEvent(ctx, agentEvent)
+	err = s.routeEvent(ctx, agentEvent)
 	if err != nil {
 		return &pb.PublishResponse{Success: false, Error: err.Error()}, nil
 	}
@@ -327,7 +635,22 @@ func (s *AgentHubService) SubscribeToMessages(req *pb.SubscribeToMessagesRequest
 		return status.Error(codes.InvalidArgument, "agent_id cannot be empty")
 	}
 
-	subChan := make(chan *pb.AgentEvent, 10)
+	resumeSeq, err := s.parseResumeCursor(req.GetResumeCursor())
+	if err != nil {
+		return err
+	}
+
+	release, err := s.streams.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	subChan := make(chan *pb.AgentEvent, s.bufferSize)
+
+	s.breakers.add(subChan, "messages", agentID)
+	defer s.breakers.remove(subChan)
+	s.subscriberStops.add(subChan)
 
 	s.agentMu.Lock()
 	s.messageSubscribers[agentID] = append(s.messageSubscribers[agentID], subChan)
@@ -353,23 +676,11 @@ func (s *AgentHubService) SubscribeToMessages(req *pb.SubscribeToMessagesRequest
 				delete(s.messageSubscribers, agentID)
 			}
 		}
-		close(subChan)
+		s.subscriberStops.stop(subChan)
 		s.agentMu.Unlock()
 	}()
 
-	for {
-		select {
-		case event, ok := <-subChan:
-			if !ok {
-				return nil
-			}
-			if err := stream.Send(event); err != nil {
-				return err
-			}
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-	}
+	return s.streamEvents(ctx, subscriptionMessages, agentID, nil, s.cursorReplay(resumeSeq), subChan, stream.Send)
 }
 
 // SubscribeToTasks subscribes to A2A task events
@@ -381,7 +692,22 @@ func (s *AgentHubService) SubscribeToTasks(req *pb.SubscribeToTasksRequest, stre
 		return status.Error(codes.InvalidArgument, "agent_id cannot be empty")
 	}
 
-	subChan := make(chan *pb.AgentEvent, 10)
+	resumeSeq, err := s.parseResumeCursor(req.GetResumeCursor())
+	if err != nil {
+		return err
+	}
+
+	release, err := s.streams.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	subChan := make(chan *pb.AgentEvent, s.bufferSize)
+
+	s.breakers.add(subChan, "tasks", agentID)
+	defer s.breakers.remove(subChan)
+	s.subscriberStops.add(subChan)
 
 	s.agentMu.Lock()
 	s.taskSubscribers[agentID] = append(s.taskSubscribers[agentID], subChan)
@@ -401,47 +727,78 @@ func (s *AgentHubService) SubscribeToTasks(req *pb.SubscribeToTasksRequest, stre
 				delete(s.taskSubscribers, agentID)
 			}
 		}
-		close(subChan)
+		s.subscriberStops.stop(subChan)
 		s.agentMu.Unlock()
 	}()
 
-	for {
-		select {
-		case event, ok := <-subChan:
-			if !ok {
-				return nil
-			}
-			if err := stream.Send(event); err != nil {
-				return err
-			}
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-	}
+	return s.streamEvents(ctx, subscriptionTasks, agentID, nil, s.cursorReplay(resumeSeq), subChan, stream.Send)
 }
 
 // SubscribeToAgentEvents subscribes to all events for an agent
 func (s *AgentHubService) SubscribeToAgentEvents(req *pb.SubscribeToAgentEventsRequest, stream pb.AgentHub_SubscribeToAgentEventsServer) error {
-	ctx := stream.Context()
 	agentID := req.GetAgentId()
+	if agentID == "" {
+		return status.Error(codes.InvalidArgument, "agent_id cannot be empty")
+	}
+
+	resumeSeq, err := s.parseResumeCursor(req.GetResumeCursor())
+	if err != nil {
+		return err
+	}
+
+	return s.subscribeEvents(stream.Context(), agentID, req.GetEventTypes(), s.cursorReplay(resumeSeq), stream.Send)
+}
 
+// ReplayEvents streams the retained events for an agent routed since the requested
+// time, then continues with live events like SubscribeToAgentEvents
+func (s *AgentHubService) ReplayEvents(req *pb.ReplayEventsRequest, stream pb.AgentHub_ReplayEventsServer) error {
+	agentID := req.GetAgentId()
 	if agentID == "" {
 		return status.Error(codes.InvalidArgument, "agent_id cannot be empty")
 	}
+	if !s.eventLog.enabled() {
+		return status.Error(codes.FailedPrecondition, "event history is disabled, cannot replay events")
+	}
 
-	subChan := make(chan *pb.AgentEvent, 10)
+	var since time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+	replay := func() ([]eventLogEntry, bool) {
+		return s.eventLog.sinceTime(since)
+	}
+
+	return s.subscribeEvents(stream.Context(), agentID, req.GetEventTypes(), replay, stream.Send)
+}
+
+// subscribeEvents registers a unified event subscription for the agent and
+// streams its events until the context ends
+func (s *AgentHubService) subscribeEvents(ctx context.Context, agentID string, eventTypes []string, replay eventReplay, send func(*pb.AgentEvent) error) error {
+	release, err := s.streams.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	subChan := make(chan *pb.AgentEvent, s.bufferSize)
+	filter := newEventTypeFilter(eventTypes)
+	subscription := &eventSubscription{ch: subChan, filter: filter}
+
+	s.breakers.add(subChan, "events", agentID)
+	defer s.breakers.remove(subChan)
+	s.subscriberStops.add(subChan)
 
 	s.agentMu.Lock()
-	s.eventSubscribers[agentID] = append(s.eventSubscribers[agentID], subChan)
+	s.eventSubscribers[agentID] = append(s.eventSubscribers[agentID], subscription)
 	s.agentMu.Unlock()
 
 	defer func() {
 		s.agentMu.Lock()
 		if subs, ok := s.eventSubscribers[agentID]; ok {
-			newSubs := []chan *pb.AgentEvent{}
-			for _, ch := range subs {
-				if ch != subChan {
-					newSubs = append(newSubs, ch)
+			newSubs := []*eventSubscription{}
+			for _, sub := range subs {
+				if sub != subscription {
+					newSubs = append(newSubs, sub)
 				}
 			}
 			s.eventSubscribers[agentID] = newSubs
@@ -449,21 +806,142 @@ func (s *AgentHubService) SubscribeToAgentEvents(req *pb.SubscribeToAgentEventsR
 				delete(s.eventSubscribers, agentID)
 			}
 		}
-		close(subChan)
+		s.subscriberStops.stop(subChan)
 		s.agentMu.Unlock()
 	}()
 
-	for {
-		select {
-		case event, ok := <-subChan:
-			if !ok {
-				return nil
+	return s.streamEvents(ctx, subscriptionEvents, agentID, filter, replay, subChan, send)
+}
+
+// subscriptionKind identifies which subscriber map a stream belongs to
+type subscriptionKind int
+
+const (
+	subscriptionMessages subscriptionKind = iota
+	subscriptionTasks
+	subscriptionEvents
+)
+
+// subscriptionMatches reports whether routeEvent would deliver the event to
+// a subscriber of the given kind registered for agentID
+func subscriptionMatches(kind subscriptionKind, agentID string, event *pb.AgentEvent) bool {
+	targetAgent := event.GetRouting().GetToAgentId()
+	if targetAgent != "" && targetAgent != agentID {
+		return false
+	}
+
+	switch kind {
+	case subscriptionMessages:
+		_, ok := event.GetPayload().(*pb.AgentEvent_Message)
+		return ok
+	case subscriptionTasks:
+		switch event.GetPayload().(type) {
+		case *pb.AgentEvent_Task, *pb.AgentEvent_StatusUpdate, *pb.AgentEvent_ArtifactUpdate:
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// parseResumeCursor validates a subscription resume cursor and returns its sequence number
+func (s *AgentHubService) parseResumeCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	if !s.eventLog.enabled() {
+		return 0, status.Error(codes.FailedPrecondition, "event history is disabled, cannot resume from cursor")
+	}
+	seq, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return seq, nil
+}
+
+// eventReplay returns the retained events a subscription replays before live
+// delivery, oldest first, and whether some were already evicted
+type eventReplay func() (entries []eventLogEntry, truncated bool)
+
+// cursorReplay replays the retained events positioned after seq. A zero seq
+// replays nothing.
+func (s *AgentHubService) cursorReplay(seq uint64) eventReplay {
+	if seq == 0 {
+		return nil
+	}
+	return func() ([]eventLogEntry, bool) {
+		return s.eventLog.since(seq)
+	}
+}
+
+// streamEvents sends the retained events returned by replay, then forwards live
+// events from subChan, highest priority first and in arrival order within a
+// priority. Live events already sent during replay are skipped.
+func (s *AgentHubService) streamEvents(ctx context.Context, kind subscriptionKind, agentID string, filter *eventTypeFilter, replay eventReplay, subChan chan *pb.AgentEvent, send func(*pb.AgentEvent) error) error {
+	var lastSeq uint64
+	if replay != nil {
+		entries, truncated := replay()
+		if truncated {
+			s.Server.Logger.WarnContext(ctx, "Replay starts before the retained history, some events were lost",
+				"agent_id", agentID,
+			)
+		}
+		replayed := 0
+		for _, entry := range entries {
+			lastSeq = entry.seq
+			if !subscriptionMatches(kind, agentID, entry.event) || !filter.accepts(entry.event.GetRouting().GetEventType()) {
+				continue
 			}
-			if err := stream.Send(event); err != nil {
+			if err := send(entry.event); err != nil {
 				return err
 			}
-		case <-ctx.Done():
-			return ctx.Err()
+			replayed++
+		}
+		s.Server.Logger.InfoContext(ctx, "Replayed retained events",
+			"agent_id", agentID,
+			"replayed_count", replayed,
+		)
+	}
+
+	// Before each send, buffered events are moved into a priority queue so that
+	// urgent events overtake the lower-priority ones queued for this subscriber
+	var queue deliveryQueue
+	queueLimit := max(s.bufferSize, 1)
+	for {
+		if queue.len() == 0 {
+			select {
+			case event, ok := <-subChan:
+				if !ok {
+					return nil
+				}
+				queue.push(event)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	collect:
+		for queue.len() < queueLimit {
+			select {
+			case event, ok := <-subChan:
+				if !ok {
+					return nil
+				}
+				queue.push(event)
+			default:
+				break collect
+			}
+		}
+
+		event := queue.pop()
+		if lastSeq > 0 && event.GetCursor() != "" {
+			if seq, err := decodeCursor(event.GetCursor()); err == nil && seq <= lastSeq {
+				// Already sent during replay
+				continue
+			}
+		}
+		if err := send(event); err != nil {
+			return err
 		}
 	}
 }
@@ -472,21 +950,18 @@ func (s *AgentHubService) SubscribeToAgentEvents(req *pb.SubscribeToAgentEventsR
 
 // GetTask retrieves a task by ID
 func (s *AgentHubService) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
-	s.tasksMu.RLock()
-	task, exists := s.tasks[req.GetTaskId()]
-	s.tasksMu.RUnlock()
-
-	if !exists {
+	task, err := s.taskStore.Get(ctx, req.GetTaskId())
+	if errors.Is(err, ErrTaskNotFound) {
 		return nil, status.Error(codes.NotFound, "task not found")
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load task: %v", err)
+	}
 
-	// Apply history length limit if specified
+	// Apply history length limit if specified; the store returns a copy
 	if req.GetHistoryLength() > 0 && len(task.History) > int(req.GetHistoryLength()) {
-		// Create a copy with limited history
-		limitedTask := *task
 		start := len(task.History) - int(req.GetHistoryLength())
-		limitedTask.History = task.History[start:]
-		return &limitedTask, nil
+		task.History = task.History[start:]
 	}
 
 	return task, nil
@@ -497,10 +972,13 @@ func (s *AgentHubService) CancelTask(ctx context.Context, req *pb.CancelTaskRequ
 	s.tasksMu.Lock()
 	defer s.tasksMu.Unlock()
 
-	task, exists := s.tasks[req.GetTaskId()]
-	if !exists {
+	task, err := s.taskStore.Get(ctx, req.GetTaskId())
+	if errors.Is(err, ErrTaskNotFound) {
 		return nil, status.Error(codes.NotFound, "task not found")
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load task: %v", err)
+	}
 
 	// Check if task can be cancelled
 	switch task.Status.State {
@@ -513,7 +991,7 @@ func (s *AgentHubService) CancelTask(ctx context.Context, req *pb.CancelTaskRequ
 		State:     pb.TaskState_TASK_STATE_CANCELLED,
 		Timestamp: timestamppb.Now(),
 		Update: &pb.Message{
-			MessageId: fmt.Sprintf("cancel_%s_%d", req.GetTaskId(), time.Now().Unix()),
+			MessageId: s.ids.NewID("cancel_" + req.GetTaskId()),
 			Role:      pb.Role_ROLE_AGENT,
 			Content: []*pb.Part{
 				{
@@ -525,7 +1003,11 @@ func (s *AgentHubService) CancelTask(ctx context.Context, req *pb.CancelTaskRequ
 		},
 	}
 
-	s.tasks[req.GetTaskId()] = task
+	if err := s.taskStore.Put(ctx, task); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store task: %v", err)
+	}
+	s.endTaskWatches(req.GetTaskId(), nil)
+	s.recordTaskFinished(req.GetTaskId(), time.Now())
 
 	// Publish cancellation event
 	go func() {
@@ -548,11 +1030,13 @@ func (s *AgentHubService) CancelTask(ctx context.Context, req *pb.CancelTaskRequ
 
 // ListTasks lists tasks for an agent
 func (s *AgentHubService) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
-	s.tasksMu.RLock()
-	defer s.tasksMu.RUnlock()
+	allTasks, err := s.taskStore.List(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
+	}
 
 	var tasks []*pb.Task
-	for _, task := range s.tasks {
+	for _, task := range allTasks {
 		// Apply filters
 		if req.GetAgentId() != "" {
 			// Check if agent is involved (in history or as executor)
@@ -648,24 +1132,44 @@ func (s *AgentHubService) RegisterAgent(ctx context.Context, req *pb.RegisterAge
 	}
 
 	s.agentsMu.Lock()
+	previous, updated := s.registeredAgents[agentID]
 	s.registeredAgents[agentID] = req.GetAgentCard()
+	s.agentLastSeen[agentID] = time.Now()
 	s.agentsMu.Unlock()
 
-	s.Server.Logger.InfoContext(ctx, "Agent registered",
-		"agent_id", agentID,
-		"agent_name", req.GetAgentCard().GetName(),
-		"subscriptions", req.GetSubscriptions(),
-	)
+	registrationEvent := "registered"
+	if updated {
+		registrationEvent = "updated"
+	}
+	s.Server.MetricsManager.IncrementAgentRegistrations(ctx, agentID, registrationEvent)
 
-	// Publish agent registration event for discovery
+	// Publish agent registration event for discovery. Re-registrations tell
+	// subscribers what changed.
 	agentCardEvent := &pb.AgentCardEvent{
 		AgentId:   agentID,
 		AgentCard: req.GetAgentCard(),
-		EventType: "registered",
+		EventType: registrationEvent,
+	}
+	if updated {
+		agentCardEvent.Diff = DiffAgentCards(previous, req.GetAgentCard())
+		s.Server.Logger.InfoContext(ctx, "Agent registered again",
+			"agent_id", agentID,
+			"changed", !agentCardDiffIsEmpty(agentCardEvent.Diff),
+			"added_skills", len(agentCardEvent.Diff.GetAddedSkills()),
+			"removed_skills", len(agentCardEvent.Diff.GetRemovedSkills()),
+			"changed_skills", len(agentCardEvent.Diff.GetChangedSkills()),
+			"changed_fields", agentCardEvent.Diff.GetChangedFields(),
+		)
+	} else {
+		s.Server.Logger.InfoContext(ctx, "Agent registered",
+			"agent_id", agentID,
+			"agent_name", req.GetAgentCard().GetName(),
+			"subscriptions", req.GetSubscriptions(),
+		)
 	}
 
 	event := &pb.AgentEvent{
-		EventId:   fmt.Sprintf("agent_registered_%s_%d", agentID, time.Now().UnixNano()),
+		EventId:   s.ids.NewID("agent_" + registrationEvent + "_" + agentID),
 		Timestamp: timestamppb.Now(),
 		Payload: &pb.AgentEvent_AgentCard{
 			AgentCard: agentCardEvent,
@@ -673,7 +1177,7 @@ func (s *AgentHubService) RegisterAgent(ctx context.Context, req *pb.RegisterAge
 		Routing: &pb.AgentEventMetadata{
 			FromAgentId: agentID,
 			ToAgentId:   "", // Broadcast to all subscribers
-			EventType:   "agent.registered",
+			EventType:   "agent." + registrationEvent,
 			Priority:    pb.Priority_PRIORITY_HIGH,
 		},
 	}
@@ -693,19 +1197,129 @@ func (s *AgentHubService) RegisterAgent(ctx context.Context, req *pb.RegisterAge
 	}, nil
 }
 
+// DeregisterAgent removes an agent registration and broadcasts an agent.deregistered event
+func (s *AgentHubService) DeregisterAgent(ctx context.Context, req *pb.DeregisterAgentRequest) (*pb.DeregisterAgentResponse, error) {
+	agentID := req.GetAgentId()
+	if agentID == "" {
+		return &pb.DeregisterAgentResponse{
+			Success: false,
+			Error:   "agent_id is required",
+		}, nil
+	}
+
+	s.agentsMu.Lock()
+	card, ok := s.registeredAgents[agentID]
+	delete(s.registeredAgents, agentID)
+	delete(s.agentLastSeen, agentID)
+	s.agentsMu.Unlock()
+
+	if !ok {
+		return &pb.DeregisterAgentResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent %q is not registered", agentID),
+		}, nil
+	}
+
+	s.Server.Logger.InfoContext(ctx, "Agent deregistered",
+		"agent_id", agentID,
+	)
+	s.announceDeregistration(ctx, agentID, card)
+
+	return &pb.DeregisterAgentResponse{Success: true}, nil
+}
+
+// announceDeregistration counts the deregistration and broadcasts an agent.deregistered
+// event so that discovery forgets the agent
+func (s *AgentHubService) announceDeregistration(ctx context.Context, agentID string, card *pb.AgentCard) {
+	s.Server.MetricsManager.IncrementAgentRegistrations(ctx, agentID, "deregistered")
+
+	event := &pb.AgentEvent{
+		EventId:   s.ids.NewID("agent_deregistered_" + agentID),
+		Timestamp: timestamppb.Now(),
+		Payload: &pb.AgentEvent_AgentCard{
+			AgentCard: &pb.AgentCardEvent{
+				AgentId:   agentID,
+				AgentCard: card,
+				EventType: "deregistered",
+			},
+		},
+		Routing: &pb.AgentEventMetadata{
+			FromAgentId: agentID,
+			ToAgentId:   "", // Broadcast to all subscribers
+			EventType:   "agent.deregistered",
+			Priority:    pb.Priority_PRIORITY_HIGH,
+		},
+	}
+
+	if err := s.routeEvent(ctx, event); err != nil {
+		s.Server.Logger.WarnContext(ctx, "Failed to route agent deregistration event",
+			"agent_id", agentID,
+			"error", err,
+		)
+		// Don't fail the deregistration if event routing fails
+	}
+}
+
+// ListAgents returns the cards of the registered agents, sorted by name.
+// With alive_only, agents not heard from within the staleness threshold are left out.
+func (s *AgentHubService) ListAgents(ctx context.Context, req *pb.ListAgentsRequest) (*pb.ListAgentsResponse, error) {
+	now := time.Now()
+	s.agentsMu.RLock()
+	agents := make([]*pb.AgentCard, 0, len(s.registeredAgents))
+	for agentID, card := range s.registeredAgents {
+		if req.GetAgentId() != "" && agentID != req.GetAgentId() {
+			continue
+		}
+		if req.GetAliveOnly() && s.isStale(agentID, now) {
+			continue
+		}
+		agents = append(agents, proto.Clone(card).(*pb.AgentCard))
+	}
+	s.agentsMu.RUnlock()
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].GetName() < agents[j].GetName()
+	})
+
+	return &pb.ListAgentsResponse{Agents: agents}, nil
+}
+
 // ===== Helper Methods =====
 
 // routeEvent routes an agent event to appropriate subscribers
 func (s *AgentHubService) routeEvent(ctx context.Context, event *pb.AgentEvent) error {
+	_, err := s.routeEventWithReport(ctx, event)
+	return err
+}
+
+// routeEventWithReport routes an agent event to appropriate subscribers and
+// returns the report of the sends started for them
+func (s *AgentHubService) routeEventWithReport(ctx context.Context, event *pb.AgentEvent) (*deliveryReport, error) {
+	if event.GetRouting() == nil {
+		return nil, fmt.Errorf("routing metadata is required")
+	}
+	s.applyDefaultPriority(event)
 	routing := event.GetRouting()
-	if routing == nil {
-		return fmt.Errorf("routing metadata is required")
+
+	// Target broadcast events matching a content-based routing rule
+	if rule, ok := s.applyRoutingRules(event); ok {
+		routing = event.GetRouting()
+		s.Server.Logger.DebugContext(ctx, "Routing event by content rule",
+			"event_id", event.GetEventId(),
+			"rule", rule.Name,
+			"to_agent", routing.GetToAgentId(),
+		)
 	}
 
 	s.agentMu.RLock()
 	defer s.agentMu.RUnlock()
 
+	// Retain the event for resumption while holding the subscriber lock, so a
+	// subscriber registering concurrently sees it either in history or live
+	s.eventLog.append(event)
+
 	var targetChannels []chan *pb.AgentEvent
+	eventType := routing.GetEventType()
 
 	// Route based on target agent
 	targetAgent := routing.GetToAgentId()
@@ -720,46 +1334,49 @@ func (s *AgentHubService) routeEvent(ctx context.Context, event *pb.AgentEvent)
 			if subs, ok := s.taskSubscribers[targetAgent]; ok {
 				targetChannels = append(targetChannels, subs...)
 			}
-		case *pb.AgentEvent_AgentCard:
-			// Agent card events are typically broadcast, but can be targeted
-			if subs, ok := s.eventSubscribers[targetAgent]; ok {
-				targetChannels = append(targetChannels, subs...)
-			}
-		}
-		if subs, ok := s.eventSubscribers[targetAgent]; ok {
-			targetChannels = append(targetChannels, subs...)
 		}
+		// Agent event subscribers receive every event type they asked for, including agent cards
+		targetChannels = appendAcceptingChannels(targetChannels, s.eventSubscribers[targetAgent], eventType)
 	} else {
-		// Broadcast to all relevant subscribers
+		// Broadcast to all relevant subscribers, optionally skipping the publisher's own
+		self := ""
+		if routing.GetExcludeSelf() {
+			self = routing.GetFromAgentId()
+		}
 		switch event.GetPayload().(type) {
 		case *pb.AgentEvent_Message:
-			for _, subs := range s.messageSubscribers {
-				targetChannels = append(targetChannels, subs...)
+			for agentID, subs := range s.messageSubscribers {
+				if agentID != self {
+					targetChannels = append(targetChannels, subs...)
+				}
 			}
 		case *pb.AgentEvent_Task, *pb.AgentEvent_StatusUpdate, *pb.AgentEvent_ArtifactUpdate:
-			for _, subs := range s.taskSubscribers {
-				targetChannels = append(targetChannels, subs...)
-			}
-		case *pb.AgentEvent_AgentCard:
-			// Broadcast agent card events to all event subscribers
-			for _, subs := range s.eventSubscribers {
-				targetChannels = append(targetChannels, subs...)
+			for agentID, subs := range s.taskSubscribers {
+				if agentID != self {
+					targetChannels = append(targetChannels, subs...)
+				}
 			}
 		}
-		for _, subs := range s.eventSubscribers {
-			targetChannels = append(targetChannels, subs...)
+		for agentID, subs := range s.eventSubscribers {
+			if agentID != self {
+				targetChannels = appendAcceptingChannels(targetChannels, subs, eventType)
+			}
 		}
 	}
 
 	if len(targetChannels) == 0 {
+		s.Server.MetricsManager.IncrementEventsDropped(ctx, routing.GetEventType(), DropReasonNoSubscribers)
+		s.deadLetter(ctx, event)
 		s.Server.Logger.DebugContext(ctx, "No subscribers for event",
 			"event_id", event.GetEventId(),
 			"event_type", routing.GetEventType(),
 			"target_agent", targetAgent,
 		)
-		return nil
+		return newDeliveryReport(0), nil
 	}
 
+	s.logPayload(ctx, "Routed event payload", event)
+
 	// Log routing details
 	s.Server.Logger.DebugContext(ctx, "Routing event to subscribers",
 		"event_id", event.GetEventId(),
@@ -769,38 +1386,94 @@ func (s *AgentHubService) routeEvent(ctx context.Context, event *pb.AgentEvent)
 		"subscriber_count", len(targetChannels),
 	)
 
-	// Send to each subscriber
-	// Use background context for async delivery goroutines to prevent
+	// Send to each subscriber. Events carrying an ordering key are serialized
+	// per subscriber so that related events (same context or task) arrive in order.
+	report := newDeliveryReport(len(targetChannels))
+	send := func(ch chan *pb.AgentEvent, evt *pb.AgentEvent) {
+		report.record(s.deliverEvent(ch, evt))
+	}
+	orderingKey := routing.GetOrderingKey()
+	for _, subChan := range targetChannels {
+		if orderingKey != "" {
+			s.orderedDispatcher.dispatch(subChan, orderingKey, event, send)
+		} else {
+			go send(subChan, event)
+		}
+	}
+
+	return report, nil
+}
+
+// Reasons reported by the events_dropped_total metric
+const (
+	DropReasonTimeout          = "timeout"
+	DropReasonContextCancelled = "context_cancelled"
+	DropReasonNoSubscribers    = "no_subscribers"
+	DropReasonPanic            = "panic" // delivery panicked, see panics_recovered_total
+)
+
+// Operations reported by the panics_recovered_total metric
+const (
+	PanicOperationMessageRouting  = "message_routing"
+	PanicOperationTaskRouting     = "task_routing"
+	PanicOperationProgressRouting = "progress_routing"
+	PanicOperationAgentRouting    = "agent_routing"
+)
+
+// routingOperation names the kind of routing an event goes through, for the
+// panics_recovered_total metric
+func routingOperation(evt *pb.AgentEvent) string {
+	switch evt.GetPayload().(type) {
+	case *pb.AgentEvent_Message:
+		return PanicOperationMessageRouting
+	case *pb.AgentEvent_StatusUpdate:
+		if evt.GetRouting().GetEventType() == "task_progress" {
+			return PanicOperationProgressRouting
+		}
+		return PanicOperationTaskRouting
+	case *pb.AgentEvent_AgentCard:
+		return PanicOperationAgentRouting
+	default:
+		return PanicOperationTaskRouting
+	}
+}
+
+// deliverEvent sends an event to a single subscriber channel, applying the drop policy when it is full.
+// It reports whether the event was delivered.
+func (s *AgentHubService) deliverEvent(ch chan *pb.AgentEvent, evt *pb.AgentEvent) (delivered bool) {
+	// Use background context for async delivery to prevent
 	// "Context cancelled" errors when request context is cancelled
 	// after the gRPC call returns but before delivery completes
 	deliveryCtx := context.Background()
 
-	for _, subChan := range targetChannels {
-		go func(ch chan *pb.AgentEvent, evt *pb.AgentEvent) {
-			defer func() {
-				if r := recover(); r != nil {
-					s.Server.Logger.ErrorContext(deliveryCtx, "Recovered from panic while sending event",
-						"event_id", evt.GetEventId(),
-						"panic", r,
-					)
-				}
-			}()
+	defer func() {
+		if r := recover(); r != nil {
+			// A failed delivery must not bring the broker down
+			operation := routingOperation(evt)
+			s.Server.MetricsManager.IncrementPanicsRecovered(deliveryCtx, operation)
+			s.Server.MetricsManager.IncrementEventsDropped(deliveryCtx, evt.GetRouting().GetEventType(), DropReasonPanic)
+			s.Server.Logger.ErrorContext(deliveryCtx, "Recovered from panic while sending event",
+				"event_id", evt.GetEventId(),
+				"operation", operation,
+				"panic", r,
+			)
+		}
+	}()
 
-			select {
-			case ch <- evt:
-				// Event sent successfully
-				s.Server.Logger.DebugContext(deliveryCtx, "Event delivered to subscriber",
-					"event_id", evt.GetEventId(),
-				)
-			case <-time.After(5 * time.Second):
-				s.Server.Logger.WarnContext(deliveryCtx, "Timeout sending event to subscriber",
-					"event_id", evt.GetEventId(),
-				)
-			}
-		}(subChan, event)
+	if reason := s.sendThroughBreaker(deliveryCtx, ch, evt); reason != "" {
+		s.Server.MetricsManager.IncrementEventsDropped(deliveryCtx, evt.GetRouting().GetEventType(), reason)
+		s.Server.Logger.WarnContext(deliveryCtx, "Dropped event for subscriber",
+			"event_id", evt.GetEventId(),
+			"reason", reason,
+			"drop_policy", s.dropPolicy.String(),
+		)
+		return false
 	}
 
-	return nil
+	s.Server.Logger.DebugContext(deliveryCtx, "Event delivered to subscriber",
+		"event_id", evt.GetEventId(),
+	)
+	return true
 }
 
 // getSubscriberCount returns the number of subscribers for a given event type and routing
@@ -860,12 +1533,31 @@ func StartBroker(ctx context.Context) error {
 		return fmt.Errorf("failed to create AgentHub server: %w", err)
 	}
 
-	// Create AgentHub service
-	agentHubService := NewAgentHubService(server)
+	// Create AgentHub service, with the configured content-based routing rules
+	routingRules, err := configuredRoutingRules(config)
+	if err != nil {
+		return fmt.Errorf("failed to load routing rules: %w", err)
+	}
+	agentHubService := NewAgentHubService(server, routingRules)
 
 	// Register the AgentHub service
 	pb.RegisterAgentHubServer(server.Server, agentHubService)
 
+	// Expose a snapshot of the broker internals next to the health endpoints, when enabled
+	if config.DebugEndpoint {
+		server.HealthServer.Handle("GET /debug/broker", agentHubService.DebugHandler())
+	}
+
+	// Drop stale conversation contexts, silent agents and expired tasks, and sample subscriber
+	// queues, in the background
+	server.OnStart(func(ctx context.Context) error {
+		go agentHubService.runContextPruner(ctx)
+		go agentHubService.runAgentReaper(ctx)
+		go agentHubService.runTaskPruner(ctx)
+		go agentHubService.runQueueDepthSampler(ctx, config.QueueDepthInterval)
+		return nil
+	})
+
 	// Handle graceful shutdown
 	go func() {
 		<-ctx.Done()
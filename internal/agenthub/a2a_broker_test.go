@@ -0,0 +1,161 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+func TestAgentHubService_TaskUpdatesRouteToRequester(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	requesterChan := make(chan *pb.AgentEvent, 10)
+	otherChan := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.taskSubscribers["requester"] = []chan *pb.AgentEvent{requesterChan}
+	service.taskSubscribers["other"] = []chan *pb.AgentEvent{otherChan}
+	service.agentMu.Unlock()
+
+	_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "msg-1", TaskId: "task-1", Role: pb.Role_ROLE_USER},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "requester", ToAgentId: "responder"},
+	})
+	if err != nil {
+		t.Fatalf("PublishMessage failed: %v", err)
+	}
+
+	_, err = service.PublishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
+		Update: &pb.TaskStatusUpdateEvent{
+			TaskId: "task-1",
+			Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED},
+			Final:  true,
+		},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "responder"},
+	})
+	if err != nil {
+		t.Fatalf("PublishTaskUpdate failed: %v", err)
+	}
+
+	select {
+	case evt := <-requesterChan:
+		if evt.GetStatusUpdate() == nil {
+			t.Errorf("Expected a status update, got %v", evt.GetPayload())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the status update at the requester")
+	}
+	select {
+	case evt := <-otherChan:
+		t.Errorf("Expected other agents not to receive the update, got %s", evt.GetEventId())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	service.tasksMu.Lock()
+	_, tracked := service.taskRequesters["task-1"]
+	_, timed := service.taskCreatedAt["task-1"]
+	service.tasksMu.Unlock()
+	if tracked {
+		t.Error("Expected the requester mapping to be removed once the task is terminal")
+	}
+	if timed {
+		t.Error("Expected the creation time to be released once the task lifetime is recorded")
+	}
+}
+
+func TestAgentHubService_DeregisterAgent(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	cortexChan := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.eventSubscribers["cortex"] = []*eventSubscription{{ch: cortexChan}}
+	service.agentMu.Unlock()
+
+	if _, err := service.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentCard: &pb.AgentCard{Name: "worker"},
+	}); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	resp, err := service.DeregisterAgent(ctx, &pb.DeregisterAgentRequest{AgentId: "worker"})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("DeregisterAgent failed: %v %s", err, resp.GetError())
+	}
+
+	list, _ := service.ListAgents(ctx, &pb.ListAgentsRequest{})
+	if len(list.GetAgents()) != 0 {
+		t.Errorf("Expected no registered agents, got %v", list.GetAgents())
+	}
+
+	// The registration and the deregistration are both announced
+	deadline := time.After(2 * time.Second)
+	var deregistered *pb.AgentCardEvent
+	for deregistered == nil {
+		select {
+		case evt := <-cortexChan:
+			if evt.GetRouting().GetEventType() == "agent.deregistered" {
+				deregistered = evt.GetAgentCard()
+			}
+		case <-deadline:
+			t.Fatal("Timed out waiting for the agent.deregistered event")
+		}
+	}
+	if deregistered.GetAgentId() != "worker" || deregistered.GetEventType() != "deregistered" {
+		t.Errorf("Unexpected deregistration event %v", deregistered)
+	}
+
+	resp, err = service.DeregisterAgent(ctx, &pb.DeregisterAgentRequest{AgentId: "worker"})
+	if err != nil || resp.GetSuccess() {
+		t.Errorf("Expected deregistering an unknown agent to fail, got %v (err %v)", resp, err)
+	}
+}
+
+func TestAgentHubService_DeliverEvent_Panic(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metricsManager, err := observability.NewMetricsManager(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	if err != nil {
+		t.Fatalf("NewMetricsManager failed: %v", err)
+	}
+	service := newTestAgentHubService()
+	service.Server.MetricsManager = metricsManager
+
+	// Sending on a closed channel panics
+	closed := make(chan *pb.AgentEvent, 1)
+	close(closed)
+	if service.deliverEvent(closed, &pb.AgentEvent{EventId: "evt_panic", Routing: &pb.AgentEventMetadata{EventType: "a2a.message"}}) {
+		t.Fatal("Expected the delivery to fail")
+	}
+
+	var collected metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &collected); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	dropped := make(map[string]int64)
+	var panics int64
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			data, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, point := range data.DataPoints {
+				switch m.Name {
+				case "events_dropped_total":
+					reason, _ := point.Attributes.Value("reason")
+					dropped[reason.AsString()] += point.Value
+				case "panics_recovered_total":
+					panics += point.Value
+				}
+			}
+		}
+	}
+	if panics != 1 || dropped[DropReasonPanic] != 1 || len(dropped) != 1 {
+		t.Errorf("Expected 1 recovered panic counted as a panic drop, got %d panics and drops %v", panics, dropped)
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -23,6 +27,22 @@ type A2ATaskPublisher struct {
 	}
 	ComponentName string
 	AgentID       string
+	// IDs mints task, message and context IDs (nil means DefaultIDGenerator)
+	IDs IDGenerator
+}
+
+// NewA2ATaskPublisher creates a publisher sending tasks through the client on
+// behalf of agentID. A nil ids uses DefaultIDGenerator.
+func NewA2ATaskPublisher(client *AgentHubClient, componentName, agentID string, ids IDGenerator) *A2ATaskPublisher {
+	return &A2ATaskPublisher{
+		Client:         client.PublisherClient(),
+		TraceManager:   client.TraceManager,
+		MetricsManager: client.MetricsManager,
+		Logger:         client.Logger,
+		ComponentName:  componentName,
+		AgentID:        agentID,
+		IDs:            idGeneratorOrDefault(ids),
+	}
 }
 
 // A2APublishTaskRequest contains all parameters needed to publish an A2A task
@@ -32,7 +52,15 @@ type A2APublishTaskRequest struct {
 	RequesterAgentID string
 	ResponderAgentID string
 	Priority         pb.Priority
-	ContextID        string // Optional context grouping
+	ContextID        string    // Optional context grouping
+	Deadline         time.Time // Optional: the handler is cancelled at this time, and the task fails if received later
+	// Headers travel with the task to its handler, over the headers carried by the context (see ContextWithHeaders)
+	Headers map[string]string
+}
+
+// headers returns the headers of the task: those carried by ctx, overridden by the request ones
+func (req *A2APublishTaskRequest) headers(ctx context.Context) map[string]string {
+	return HeadersFromContext(ContextWithHeaders(ctx, req.Headers))
 }
 
 // PublishTask publishes an A2A task with automatic correlation ID generation and observability
@@ -45,58 +73,16 @@ func (tp *A2ATaskPublisher) PublishTask(ctx context.Context, req *A2APublishTask
 	timer := tp.MetricsManager.StartTimer()
 	defer timer(ctx, req.TaskType, tp.ComponentName)
 
-	// Generate unique IDs
-	taskID := fmt.Sprintf("task_%s_%d", req.TaskType, time.Now().Unix())
-	messageID := fmt.Sprintf("msg_%s_%d", req.TaskType, time.Now().Unix())
-	contextID := req.ContextID
-	if contextID == "" {
-		contextID = fmt.Sprintf("ctx_%s_%d", req.TaskType, time.Now().Unix())
-	}
+	message, task := tp.newTask(ctx, req)
+	taskID := task.GetId()
 
 	tp.Logger.InfoContext(ctx, "Publishing A2A task",
 		"task_id", taskID,
 		"task_type", req.TaskType,
 		"responder_agent_id", req.ResponderAgentID,
-		"context_id", contextID,
+		"context_id", task.GetContextId(),
 	)
 
-	// Create A2A message for the task
-	message := &pb.Message{
-		MessageId: messageID,
-		ContextId: contextID,
-		TaskId:    taskID,
-		Role:      pb.Role_ROLE_USER,
-		Content:   req.Content,
-		Metadata: &structpb.Struct{
-			Fields: map[string]*structpb.Value{
-				"task_type":  structpb.NewStringValue(req.TaskType),
-				"publisher":  structpb.NewStringValue(req.RequesterAgentID),
-				"created_at": structpb.NewStringValue(time.Now().Format(time.RFC3339)),
-			},
-		},
-	}
-
-	// Create task object
-	task := &pb.Task{
-		Id:        taskID,
-		ContextId: contextID,
-		Status: &pb.TaskStatus{
-			State:     pb.TaskState_TASK_STATE_SUBMITTED,
-			Timestamp: timestamppb.Now(),
-			Update:    message,
-		},
-		History: []*pb.Message{message},
-		Metadata: &structpb.Struct{
-			Fields: map[string]*structpb.Value{
-				"task_type":          structpb.NewStringValue(req.TaskType),
-				"requester_agent_id": structpb.NewStringValue(req.RequesterAgentID),
-				"responder_agent_id": structpb.NewStringValue(req.ResponderAgentID),
-				"priority":           structpb.NewStringValue(req.Priority.String()),
-				"created_at":         structpb.NewStringValue(time.Now().Format(time.RFC3339)),
-			},
-		},
-	}
-
 	// Publish the message through the broker
 	publishReq := &pb.PublishMessageRequest{
 		Message: message,
@@ -105,6 +91,7 @@ func (tp *A2ATaskPublisher) PublishTask(ctx context.Context, req *A2APublishTask
 			ToAgentId:   req.ResponderAgentID,
 			EventType:   "task_message",
 			Priority:    req.Priority,
+			Headers:     req.headers(ctx),
 		},
 	}
 
@@ -144,11 +131,101 @@ func (tp *A2ATaskPublisher) PublishTask(ctx context.Context, req *A2APublishTask
 	return task, nil
 }
 
+// newTask builds the task described by req and the message submitting it, carrying
+// the trace context of ctx
+func (tp *A2ATaskPublisher) newTask(ctx context.Context, req *A2APublishTaskRequest) (*pb.Message, *pb.Task) {
+	// Generate unique IDs
+	ids := idGeneratorOrDefault(tp.IDs)
+	taskID := ids.NewID("task_" + req.TaskType)
+	messageID := ids.NewID("msg_" + req.TaskType)
+	contextID := req.ContextID
+	if contextID == "" {
+		contextID = ids.NewID("ctx_" + req.TaskType)
+	}
+
+	// Create A2A message for the task
+	message := &pb.Message{
+		MessageId: messageID,
+		ContextId: contextID,
+		TaskId:    taskID,
+		Role:      pb.Role_ROLE_USER,
+		Content:   req.Content,
+		Metadata: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"task_type":  structpb.NewStringValue(req.TaskType),
+				"publisher":  structpb.NewStringValue(req.RequesterAgentID),
+				"created_at": structpb.NewStringValue(time.Now().Format(time.RFC3339)),
+			},
+		},
+	}
+	if !req.Deadline.IsZero() {
+		message.Metadata.Fields[MetadataKeyDeadline] = structpb.NewStringValue(req.Deadline.Format(time.RFC3339Nano))
+	}
+	// Carry the publish span to the subscriber, which continues the trace from it
+	injectTraceHeaders(ctx, tp.TraceManager, message.Metadata)
+
+	// Create task object
+	task := &pb.Task{
+		Id:        taskID,
+		ContextId: contextID,
+		Status: &pb.TaskStatus{
+			State:     pb.TaskState_TASK_STATE_SUBMITTED,
+			Timestamp: timestamppb.Now(),
+			Update:    message,
+		},
+		History: []*pb.Message{message},
+		Metadata: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"task_type":          structpb.NewStringValue(req.TaskType),
+				"requester_agent_id": structpb.NewStringValue(req.RequesterAgentID),
+				"responder_agent_id": structpb.NewStringValue(req.ResponderAgentID),
+				"priority":           structpb.NewStringValue(req.Priority.String()),
+				"created_at":         structpb.NewStringValue(time.Now().Format(time.RFC3339)),
+			},
+		},
+	}
+	if !req.Deadline.IsZero() {
+		task.Metadata.Fields[MetadataKeyDeadline] = structpb.NewStringValue(req.Deadline.Format(time.RFC3339Nano))
+	}
+	if headers, ok := message.Metadata.Fields[MetadataKeyTraceHeaders]; ok {
+		task.Metadata.Fields[MetadataKeyTraceHeaders] = headers
+	}
+
+	return message, task
+}
+
 // A2ATaskSubscriber provides abstraction for subscribing to and processing A2A tasks
 type A2ATaskSubscriber struct {
 	Client       *AgentHubClient
 	AgentID      string
 	TaskHandlers map[string]A2ATaskHandler
+	// Reconnect controls re-subscription when the task stream breaks
+	Reconnect ReconnectPolicy
+	// TaskContext, when set, is used to process tasks instead of the subscription
+	// context, so that tasks in flight can complete after the subscription stops
+	TaskContext context.Context
+	// Concurrency bounds the tasks of each type processed in parallel (0 means
+	// unlimited). When the worker pool of a type is full, the subscriber stops
+	// reading the task stream until a worker is free. SetConcurrency overrides it
+	// for a single type.
+	Concurrency int
+
+	inFlight      sync.WaitGroup
+	inFlightCount atomic.Int64
+
+	// Input schemas of the task types registered with one
+	inputSchemas map[string]taskInputSchema
+
+	// Per task type worker pools, bounded by Concurrency or its per-type override
+	workersMu   sync.Mutex
+	workerPools map[string]chan struct{}
+	concurrency map[string]int
+}
+
+// taskInputSchema is the JSON Schema declared for the data parts of a task type
+type taskInputSchema struct {
+	source string
+	schema *jsonschema.Schema
 }
 
 // A2ATaskHandler defines the interface for handling different A2A task types
@@ -160,12 +237,48 @@ func NewA2ATaskSubscriber(client *AgentHubClient, agentID string) *A2ATaskSubscr
 		Client:       client,
 		AgentID:      agentID,
 		TaskHandlers: make(map[string]A2ATaskHandler),
+		Reconnect:    DefaultReconnectPolicy(),
 	}
 }
 
 // RegisterTaskHandler registers a handler for a specific task type
 func (ts *A2ATaskSubscriber) RegisterTaskHandler(taskType string, handler A2ATaskHandler) {
 	ts.TaskHandlers[taskType] = handler
+	delete(ts.inputSchemas, taskType)
+}
+
+// RegisterTaskHandlerWithSchema registers a handler for a task type whose message
+// data parts must satisfy a JSON Schema. Tasks with invalid data are failed with
+// the list of violations before the handler runs.
+func (ts *A2ATaskSubscriber) RegisterTaskHandlerWithSchema(taskType, schemaJSON string, handler A2ATaskHandler) error {
+	schema, err := CompileInputSchema(taskType, schemaJSON)
+	if err != nil {
+		return err
+	}
+	ts.RegisterTaskHandler(taskType, handler)
+	if ts.inputSchemas == nil {
+		ts.inputSchemas = make(map[string]taskInputSchema)
+	}
+	ts.inputSchemas[taskType] = taskInputSchema{source: schemaJSON, schema: schema}
+	return nil
+}
+
+// InputSchema returns the JSON Schema registered for a task type, to advertise it
+// in the InputSchema of the matching AgentSkill. It is empty when there is none.
+func (ts *A2ATaskSubscriber) InputSchema(taskType string) string {
+	return ts.inputSchemas[taskType].source
+}
+
+// SetConcurrency bounds the tasks of a type processed in parallel, overriding
+// Concurrency (0 means unlimited). Call it before SubscribeToTasks.
+func (ts *A2ATaskSubscriber) SetConcurrency(taskType string, workers int) {
+	ts.workersMu.Lock()
+	defer ts.workersMu.Unlock()
+	if ts.concurrency == nil {
+		ts.concurrency = make(map[string]int)
+	}
+	ts.concurrency[taskType] = workers
+	delete(ts.workerPools, taskType)
 }
 
 // RegisterDefaultHandlers registers default handlers for common task types
@@ -175,8 +288,17 @@ func (ts *A2ATaskSubscriber) RegisterDefaultHandlers() {
 	ts.RegisterTaskHandler("random_number", ts.handleRandomNumberTask)
 }
 
-// SubscribeToTasks subscribes to A2A tasks and processes them using registered handlers
+// SubscribeToTasks subscribes to A2A tasks and processes them using registered handlers.
+// When the stream breaks, it re-subscribes according to the Reconnect policy and
+// only returns once ctx is done or the retries are exhausted.
 func (ts *A2ATaskSubscriber) SubscribeToTasks(ctx context.Context) error {
+	return resubscribe(ctx, ts.Reconnect, ts.Client.Logger, "A2A task stream lost, re-subscribing",
+		[]any{"agent_id", ts.AgentID}, ts.subscribeOnce)
+}
+
+// subscribeOnce runs a single task subscription until its stream ends. It reports
+// whether the subscription was established, and why the stream ended.
+func (ts *A2ATaskSubscriber) subscribeOnce(ctx context.Context) (bool, error) {
 	ts.Client.Logger.InfoContext(ctx, "Subscribing to A2A tasks", "agent_id", ts.AgentID)
 
 	req := &pb.SubscribeToTasksRequest{
@@ -186,33 +308,130 @@ func (ts *A2ATaskSubscriber) SubscribeToTasks(ctx context.Context) error {
 	stream, err := ts.Client.Client.SubscribeToTasks(ctx, req)
 	if err != nil {
 		ts.Client.Logger.ErrorContext(ctx, "Failed to subscribe to A2A tasks", "error", err)
-		return err
+		return false, err
 	}
 
+	ts.Client.MetricsManager.SetSubscriptionConnected(ctx, "tasks", ts.AgentID, true)
+	defer ts.Client.MetricsManager.SetSubscriptionConnected(ctx, "tasks", ts.AgentID, false)
+
 	for {
 		event, err := stream.Recv()
 		if err == io.EOF {
 			ts.Client.Logger.InfoContext(ctx, "A2A task stream ended")
-			break
+			return true, err
 		}
 		if err != nil {
-			ts.Client.Logger.ErrorContext(ctx, "Error receiving A2A task event", "error", err)
-			ts.Client.MetricsManager.IncrementEventErrors(ctx, "a2a_task_subscription", ts.AgentID, "receive_error")
-			return err
+			if ctx.Err() == nil {
+				ts.Client.Logger.ErrorContext(ctx, "Error receiving A2A task event", "error", err)
+				ts.Client.MetricsManager.IncrementEventErrors(ctx, "a2a_task_subscription", ts.AgentID, "receive_error")
+			}
+			return true, err
 		}
 
+		// Results are published with the priority and headers of the task
+		eventCtx := ContextWithEvent(ctx, event)
+
 		// Process event based on type
 		switch payload := event.GetPayload().(type) {
 		case *pb.AgentEvent_Message:
 			if payload.Message.GetTaskId() != "" {
-				go ts.processTaskMessage(ctx, payload.Message)
+				message := payload.Message
+				taskType, _ := MetadataString(message.GetMetadata(), "task_type")
+				ts.dispatch(eventCtx, taskType, func(taskCtx context.Context) { ts.processTaskMessage(taskCtx, message) })
 			}
 		case *pb.AgentEvent_Task:
-			go ts.processTask(ctx, payload.Task)
+			task := payload.Task
+			taskType, _ := MetadataString(task.GetMetadata(), "task_type")
+			ts.dispatch(eventCtx, taskType, func(taskCtx context.Context) { ts.processTask(taskCtx, task) })
 		}
 	}
+}
 
-	return nil
+// dispatch processes a task in its own goroutine, tracking it as in flight. When
+// the worker pool of the task type is full, it waits for a free worker, or for
+// ctx to be done, in which case the task is not processed.
+func (ts *A2ATaskSubscriber) dispatch(ctx context.Context, taskType string, process func(context.Context)) {
+	pool := ts.workerPool(taskType)
+	if pool != nil {
+		select {
+		case pool <- struct{}{}:
+		default:
+			ts.Client.Logger.DebugContext(ctx, "Task worker pool saturated, waiting for a worker",
+				"agent_id", ts.AgentID,
+				"task_type", taskType,
+				"workers", cap(pool),
+			)
+			select {
+			case pool <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+
+	taskCtx := ctx
+	if ts.TaskContext != nil {
+		taskCtx = ContextWithHeaders(ContextWithPriority(ts.TaskContext, PriorityFromContext(ctx)), HeadersFromContext(ctx))
+	}
+
+	ts.inFlight.Add(1)
+	ts.inFlightCount.Add(1)
+	ts.Client.MetricsManager.AddActiveTaskWorkers(ctx, ts.AgentID, taskType, 1)
+	go func() {
+		defer ts.inFlight.Done()
+		defer ts.inFlightCount.Add(-1)
+		defer ts.Client.MetricsManager.AddActiveTaskWorkers(taskCtx, ts.AgentID, taskType, -1)
+		if pool != nil {
+			defer func() { <-pool }()
+		}
+		process(taskCtx)
+	}()
+}
+
+// workerPool returns the semaphore bounding the workers of a task type, nil when
+// the type is unbounded
+func (ts *A2ATaskSubscriber) workerPool(taskType string) chan struct{} {
+	ts.workersMu.Lock()
+	defer ts.workersMu.Unlock()
+
+	workers, ok := ts.concurrency[taskType]
+	if !ok {
+		workers = ts.Concurrency
+	}
+	if workers <= 0 {
+		return nil
+	}
+	pool, ok := ts.workerPools[taskType]
+	if !ok {
+		if ts.workerPools == nil {
+			ts.workerPools = make(map[string]chan struct{})
+		}
+		pool = make(chan struct{}, workers)
+		ts.workerPools[taskType] = pool
+	}
+	return pool
+}
+
+// InFlight returns the number of tasks being processed
+func (ts *A2ATaskSubscriber) InFlight() int {
+	return int(ts.inFlightCount.Load())
+}
+
+// Drain waits until the tasks being processed complete, or until ctx is done.
+// It returns the number of tasks still in flight, zero once drained.
+func (ts *A2ATaskSubscriber) Drain(ctx context.Context) int {
+	drained := make(chan struct{})
+	go func() {
+		ts.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return 0
+	case <-ctx.Done():
+		return ts.InFlight()
+	}
 }
 
 // processTaskMessage processes a task message
@@ -221,6 +440,7 @@ func (ts *A2ATaskSubscriber) processTaskMessage(ctx context.Context, message *pb
 	if taskID == "" {
 		return
 	}
+	ctx = extractTraceHeaders(ctx, ts.Client.TraceManager, message.GetMetadata())
 
 	// Get the full task
 	taskReq := &pb.GetTaskRequest{
@@ -242,14 +462,11 @@ func (ts *A2ATaskSubscriber) processTaskMessage(ctx context.Context, message *pb
 
 // processTask processes a complete A2A task
 func (ts *A2ATaskSubscriber) processTask(ctx context.Context, task *pb.Task) {
-	// Extract task type from metadata
-	taskType := ""
-	if task.Metadata != nil && task.Metadata.Fields != nil {
-		if taskTypeValue, ok := task.Metadata.Fields["task_type"]; ok {
-			taskType = taskTypeValue.GetStringValue()
-		}
-	}
+	// Continue the publisher's trace, so that handler spans join it
+	ctx = extractTraceHeaders(ctx, ts.Client.TraceManager, task.GetMetadata())
 
+	// Extract task type from metadata
+	taskType, _ := MetadataString(task.GetMetadata(), "task_type")
 	if taskType == "" {
 		ts.Client.Logger.ErrorContext(ctx, "Task missing task_type in metadata",
 			"task_id", task.GetId(),
@@ -279,8 +496,42 @@ func (ts *A2ATaskSubscriber) processTask(ctx context.Context, task *pb.Task) {
 	var status pb.TaskState
 	var errorMessage string
 
-	if handler, ok := ts.TaskHandlers[taskType]; ok {
-		artifact, status, errorMessage = handler(ctx, task, initialMessage)
+	// Honor the publisher's deadline: skip stale tasks, and cancel handlers running past it.
+	// The completion is still published under ctx.
+	handlerCtx := ctx
+	if deadline, ok := TaskDeadline(task); ok {
+		if !time.Now().Before(deadline) {
+			ts.Client.Logger.WarnContext(ctx, "Task received after its deadline",
+				"task_id", task.GetId(),
+				"deadline", deadline,
+			)
+			ts.publishTaskCompletion(ctx, task, nil, pb.TaskState_TASK_STATE_FAILED, ErrorDeadlineExceeded)
+			return
+		}
+		var cancel context.CancelFunc
+		handlerCtx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	handler, ok := ts.TaskHandlers[taskType]
+	if input, validated := ts.inputSchemas[taskType]; ok && validated {
+		if violations := ValidateDataParts(input.schema, initialMessage); len(violations) > 0 {
+			ts.Client.MetricsManager.IncrementEventErrors(ctx, "a2a_task", ts.AgentID, "input_validation")
+			ts.Client.Logger.WarnContext(ctx, "Task input failed schema validation",
+				"task_id", task.GetId(),
+				"task_type", taskType,
+				"violations", violations,
+			)
+			ts.publishTaskCompletion(ctx, task, nil, pb.TaskState_TASK_STATE_FAILED, "input validation failed: "+strings.Join(violations, "; "))
+			return
+		}
+	}
+
+	if ok {
+		start := time.Now()
+		artifact, status, errorMessage = handler(handlerCtx, task, initialMessage)
+		ts.Client.MetricsManager.RecordSkillInvocation(ctx, ts.AgentID, taskType, status.String(),
+			status == pb.TaskState_TASK_STATE_COMPLETED, time.Since(start))
 	} else {
 		// Unknown task type
 		status = pb.TaskState_TASK_STATE_FAILED
@@ -295,7 +546,7 @@ func (ts *A2ATaskSubscriber) processTask(ctx context.Context, task *pb.Task) {
 func (ts *A2ATaskSubscriber) publishTaskCompletion(ctx context.Context, task *pb.Task, artifact *pb.Artifact, status pb.TaskState, errorMessage string) {
 	// Create completion message
 	completionMessage := &pb.Message{
-		MessageId: fmt.Sprintf("completion_%s_%d", task.GetId(), time.Now().Unix()),
+		MessageId: ts.Client.NewID("completion_" + task.GetId()),
 		ContextId: task.GetContextId(),
 		TaskId:    task.GetId(),
 		Role:      pb.Role_ROLE_AGENT,
@@ -315,6 +566,35 @@ func (ts *A2ATaskSubscriber) publishTaskCompletion(ctx context.Context, task *pb
 		},
 	}
 
+	// Publish artifact first, so that it is in place when the final status is observed
+	if artifact != nil {
+		artifactUpdate := &pb.TaskArtifactUpdateEvent{
+			TaskId:    task.GetId(),
+			ContextId: task.GetContextId(),
+			Artifact:  artifact,
+			Append:    false,
+			LastChunk: true,
+		}
+
+		err := ts.Client.publishTaskArtifact(ctx, &pb.PublishTaskArtifactRequest{
+			Artifact: artifactUpdate,
+			Routing: &pb.AgentEventMetadata{
+				FromAgentId: ts.AgentID,
+				EventType:   "task_artifact",
+				Priority:    PriorityFromContext(ctx),
+				Headers:     HeadersFromContext(ctx),
+			},
+		})
+
+		if err != nil {
+			ts.Client.Logger.ErrorContext(ctx, "Failed to publish task artifact",
+				"task_id", task.GetId(),
+				"artifact_id", artifact.GetArtifactId(),
+				"error", err,
+			)
+		}
+	}
+
 	// Publish status update
 	statusUpdate := &pb.TaskStatusUpdateEvent{
 		TaskId:    task.GetId(),
@@ -327,12 +607,13 @@ func (ts *A2ATaskSubscriber) publishTaskCompletion(ctx context.Context, task *pb
 		Final: true,
 	}
 
-	_, err := ts.Client.Client.PublishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
+	err := ts.Client.publishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
 		Update: statusUpdate,
 		Routing: &pb.AgentEventMetadata{
 			FromAgentId: ts.AgentID,
 			EventType:   "task_completion",
-			Priority:    pb.Priority_PRIORITY_MEDIUM,
+			Priority:    PriorityFromContext(ctx),
+			Headers:     HeadersFromContext(ctx),
 		},
 	})
 
@@ -343,34 +624,6 @@ func (ts *A2ATaskSubscriber) publishTaskCompletion(ctx context.Context, task *pb
 		)
 	}
 
-	// Publish artifact if available
-	if artifact != nil {
-		artifactUpdate := &pb.TaskArtifactUpdateEvent{
-			TaskId:    task.GetId(),
-			ContextId: task.GetContextId(),
-			Artifact:  artifact,
-			Append:    false,
-			LastChunk: true,
-		}
-
-		_, err := ts.Client.Client.PublishTaskArtifact(ctx, &pb.PublishTaskArtifactRequest{
-			Artifact: artifactUpdate,
-			Routing: &pb.AgentEventMetadata{
-				FromAgentId: ts.AgentID,
-				EventType:   "task_artifact",
-				Priority:    pb.Priority_PRIORITY_MEDIUM,
-			},
-		})
-
-		if err != nil {
-			ts.Client.Logger.ErrorContext(ctx, "Failed to publish task artifact",
-				"task_id", task.GetId(),
-				"artifact_id", artifact.GetArtifactId(),
-				"error", err,
-			)
-		}
-	}
-
 	ts.Client.Logger.InfoContext(ctx, "Task processing completed",
 		"task_id", task.GetId(),
 		"status", status.String(),
@@ -397,7 +650,7 @@ func (ts *A2ATaskSubscriber) handleGreetingTask(ctx context.Context, task *pb.Ta
 	greeting := fmt.Sprintf("Hello, %s! Nice to meet you.", name)
 
 	artifact := &pb.Artifact{
-		ArtifactId:  fmt.Sprintf("greeting_%s_%d", task.GetId(), time.Now().Unix()),
+		ArtifactId:  ts.Client.NewID("greeting_" + task.GetId()),
 		Name:        "greeting_response",
 		Description: "Greeting message response",
 		Parts: []*pb.Part{
@@ -423,7 +676,7 @@ func (ts *A2ATaskSubscriber) handleMathTask(ctx context.Context, task *pb.Task,
 	result := 42.0 + 58.0
 
 	artifact := &pb.Artifact{
-		ArtifactId:  fmt.Sprintf("math_%s_%d", task.GetId(), time.Now().Unix()),
+		ArtifactId:  ts.Client.NewID("math_" + task.GetId()),
 		Name:        "math_result",
 		Description: "Mathematical calculation result",
 		Parts: []*pb.Part{
@@ -458,7 +711,7 @@ func (ts *A2ATaskSubscriber) handleRandomNumberTask(ctx context.Context, task *p
 	randomNumber := 42
 
 	artifact := &pb.Artifact{
-		ArtifactId:  fmt.Sprintf("random_%s_%d", task.GetId(), time.Now().Unix()),
+		ArtifactId:  ts.Client.NewID("random_" + task.GetId()),
 		Name:        "random_number",
 		Description: "Generated random number",
 		Parts: []*pb.Part{
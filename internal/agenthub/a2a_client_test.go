@@ -0,0 +1,160 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+func TestA2ATaskSubscriber_Concurrency(t *testing.T) {
+	service := newTestAgentHubService()
+	client := &AgentHubClient{
+		MetricsManager: service.Server.MetricsManager,
+		Logger:         service.Server.Logger,
+	}
+	subscriber := NewA2ATaskSubscriber(client, "worker")
+	subscriber.Concurrency = 2
+	subscriber.SetConcurrency("unbounded", 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	started := make(chan string, 10)
+	work := func(name string) func(context.Context) {
+		return func(context.Context) {
+			started <- name
+			<-release
+		}
+	}
+	waitStarted := func(want string) {
+		t.Helper()
+		select {
+		case name := <-started:
+			if name != want {
+				t.Fatalf("Expected %s to start, got %s", want, name)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected %s to start", want)
+		}
+	}
+
+	subscriber.dispatch(ctx, "slow", work("slow-1"))
+	waitStarted("slow-1")
+	subscriber.dispatch(ctx, "slow", work("slow-2"))
+	waitStarted("slow-2")
+
+	// The third task of the type waits for a worker, other types do not
+	dispatched := make(chan struct{})
+	go func() {
+		subscriber.dispatch(ctx, "slow", work("slow-3"))
+		close(dispatched)
+	}()
+	for i := 1; i <= 3; i++ {
+		subscriber.dispatch(ctx, "unbounded", work(fmt.Sprintf("unbounded-%d", i)))
+		waitStarted(fmt.Sprintf("unbounded-%d", i))
+	}
+	select {
+	case <-dispatched:
+		t.Fatal("Expected the dispatch to wait while the pool is saturated")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if inFlight := subscriber.InFlight(); inFlight != 5 {
+		t.Errorf("Expected 5 tasks in flight, got %d", inFlight)
+	}
+
+	close(release)
+	waitStarted("slow-3")
+	<-dispatched
+	drainCtx, drainCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer drainCancel()
+	if remaining := subscriber.Drain(drainCtx); remaining != 0 {
+		t.Errorf("Expected every task to complete, %d remaining", remaining)
+	}
+}
+
+func TestA2ATaskSubscriber_SkillMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metricsManager, err := observability.NewMetricsManager(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	if err != nil {
+		t.Fatalf("NewMetricsManager failed: %v", err)
+	}
+
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+	client.MetricsManager = metricsManager
+	publisher := NewA2ATaskPublisher(client, "test", "requester", nil)
+	ctx := context.Background()
+
+	subscriber := NewA2ATaskSubscriber(client, "worker")
+	subscriber.RegisterTaskHandler("translate", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	})
+	subscriber.RegisterTaskHandler("summarize", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		return nil, pb.TaskState_TASK_STATE_FAILED, "too long"
+	})
+
+	for _, taskType := range []string{"translate", "translate", "summarize", "unknown"} {
+		req, err := NewTask(taskType).WithText("text").From("requester").To("worker").Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		published, err := publisher.PublishTask(ctx, req)
+		if err != nil {
+			t.Fatalf("PublishTask failed: %v", err)
+		}
+		task, err := service.GetTask(ctx, &pb.GetTaskRequest{TaskId: published.GetId()})
+		if err != nil {
+			t.Fatalf("GetTask failed: %v", err)
+		}
+		subscriber.processTask(ctx, task)
+	}
+
+	var collected metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &collected); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	counts := make(map[string]int64)
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					skill, _ := point.Attributes.Value("skill")
+					counts[m.Name+"/"+skill.AsString()] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					skill, _ := point.Attributes.Value("skill")
+					counts[m.Name+"/"+skill.AsString()] += int64(point.Count)
+				}
+			}
+		}
+	}
+
+	// Tasks without a handler never reach a skill
+	want := map[string]int64{
+		"skill_invocations_total/translate": 2,
+		"skill_invocations_total/summarize": 1,
+		"skill_failures_total/summarize":    1,
+		"skill_duration_seconds/translate":  2,
+		"skill_duration_seconds/summarize":  1,
+	}
+	for key, count := range counts {
+		if strings.HasPrefix(key, "skill_") && want[key] != count {
+			t.Errorf("Expected %s to be %d, got %d", key, want[key], count)
+		}
+	}
+	for key, count := range want {
+		if counts[key] != count {
+			t.Errorf("Expected %s to be %d, got %d", key, count, counts[key])
+		}
+	}
+}
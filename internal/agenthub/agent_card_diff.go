@@ -0,0 +1,73 @@
+package agenthub
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DiffAgentCards describes how an agent card changed from previous to current.
+// Skills are matched by ID, or by name when they have no ID; skills and fields are
+// reported in the order of the cards.
+func DiffAgentCards(previous, current *pb.AgentCard) *pb.AgentCardDiff {
+	diff := &pb.AgentCardDiff{}
+
+	previousSkills := make(map[string]*pb.AgentSkill, len(previous.GetSkills()))
+	for _, skill := range previous.GetSkills() {
+		previousSkills[skillKey(skill)] = skill
+	}
+	currentSkills := make(map[string]bool, len(current.GetSkills()))
+	for _, skill := range current.GetSkills() {
+		key := skillKey(skill)
+		currentSkills[key] = true
+		old, ok := previousSkills[key]
+		switch {
+		case !ok:
+			diff.AddedSkills = append(diff.AddedSkills, skill)
+		case !proto.Equal(old, skill):
+			diff.ChangedSkills = append(diff.ChangedSkills, skill)
+		}
+	}
+	for _, skill := range previous.GetSkills() {
+		if !currentSkills[skillKey(skill)] {
+			diff.RemovedSkills = append(diff.RemovedSkills, skill)
+		}
+	}
+
+	// Compare the other fields on copies without skills
+	previousCard, currentCard := previous.ProtoReflect(), current.ProtoReflect()
+	fields := previousCard.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Name() == "skills" {
+			continue
+		}
+		if !fieldEqual(previousCard, currentCard, field) {
+			diff.ChangedFields = append(diff.ChangedFields, string(field.Name()))
+		}
+	}
+	return diff
+}
+
+// agentCardDiffIsEmpty reports whether a diff describes no change
+func agentCardDiffIsEmpty(diff *pb.AgentCardDiff) bool {
+	return len(diff.GetAddedSkills()) == 0 && len(diff.GetRemovedSkills()) == 0 &&
+		len(diff.GetChangedSkills()) == 0 && len(diff.GetChangedFields()) == 0
+}
+
+// skillKey identifies a skill across registrations
+func skillKey(skill *pb.AgentSkill) string {
+	if skill.GetId() != "" {
+		return "id:" + skill.GetId()
+	}
+	return "name:" + skill.GetName()
+}
+
+// fieldEqual reports whether a field has the same value in two messages of the same type
+func fieldEqual(a, b protoreflect.Message, field protoreflect.FieldDescriptor) bool {
+	if a.Has(field) != b.Has(field) {
+		return false
+	}
+	return a.Get(field).Equal(b.Get(field))
+}
@@ -0,0 +1,67 @@
+package agenthub
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_RegisterAgent_Updated(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	cortexChan := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.eventSubscribers["cortex"] = []*eventSubscription{{ch: cortexChan}}
+	service.agentMu.Unlock()
+
+	register := func(card *pb.AgentCard) *pb.AgentEvent {
+		t.Helper()
+		if _, err := service.RegisterAgent(ctx, &pb.RegisterAgentRequest{AgentCard: card}); err != nil {
+			t.Fatalf("RegisterAgent failed: %v", err)
+		}
+		select {
+		case evt := <-cortexChan:
+			return evt
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for the agent card event")
+			return nil
+		}
+	}
+
+	first := register(&pb.AgentCard{Name: "worker", Version: "1.0.0", Skills: []*pb.AgentSkill{
+		{Id: "translate", Name: "Translate"},
+		{Id: "summarize", Name: "Summarize"},
+	}})
+	if first.GetRouting().GetEventType() != "agent.registered" || first.GetAgentCard().GetDiff() != nil {
+		t.Errorf("Expected a first registration without diff, got %v", first)
+	}
+
+	second := register(&pb.AgentCard{Name: "worker", Version: "1.1.0", Skills: []*pb.AgentSkill{
+		{Id: "translate", Name: "Translate", Description: "Now with French"},
+		{Id: "echo", Name: "Echo"},
+	}})
+	if second.GetRouting().GetEventType() != "agent.updated" || second.GetAgentCard().GetEventType() != "updated" {
+		t.Fatalf("Expected an agent.updated event, got %v", second)
+	}
+	diff := second.GetAgentCard().GetDiff()
+	if len(diff.GetAddedSkills()) != 1 || diff.GetAddedSkills()[0].GetId() != "echo" {
+		t.Errorf("Expected echo to be added, got %v", diff.GetAddedSkills())
+	}
+	if len(diff.GetRemovedSkills()) != 1 || diff.GetRemovedSkills()[0].GetId() != "summarize" {
+		t.Errorf("Expected summarize to be removed, got %v", diff.GetRemovedSkills())
+	}
+	if len(diff.GetChangedSkills()) != 1 || diff.GetChangedSkills()[0].GetId() != "translate" {
+		t.Errorf("Expected translate to be changed, got %v", diff.GetChangedSkills())
+	}
+	if !slices.Equal(diff.GetChangedFields(), []string{"version"}) {
+		t.Errorf("Expected only the version to change, got %v", diff.GetChangedFields())
+	}
+
+	if !agentCardDiffIsEmpty(DiffAgentCards(second.GetAgentCard().GetAgentCard(), second.GetAgentCard().GetAgentCard())) {
+		t.Error("Expected an unchanged card to give an empty diff")
+	}
+}
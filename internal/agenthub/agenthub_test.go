@@ -2,26 +2,36 @@ package agenthub
 
 import (
 	"context"
+	"net"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/owulveryck/agenthub/internal/observability"
+	"github.com/owulveryck/agenthub/internal/observability/observabilitytest"
 )
 
-// newTestEventBusService creates a new EventBusService for testing
-func newTestEventBusService() *EventBusService {
+// newTestAgentHubService creates a new AgentHubService for testing
+func newTestAgentHubService() *AgentHubService {
 	config := NewGRPCConfig("test")
 	config.HealthPort = "0"
 	config.ServerAddr = ":0"
+	config.Observability = observability.NewNoopObservability()
 	server, err := NewAgentHubServer(config)
 	if err != nil {
 		panic(err)
 	}
-	return NewEventBusService(server)
+	return NewAgentHubService(server)
 }
 
-func TestEventBusService_Creation(t *testing.T) {
-	service := newTestEventBusService()
+func TestAgentHubService_Creation(t *testing.T) {
+	service := newTestAgentHubService()
 	if service == nil {
 		t.Fatal("Expected service to be created, got nil")
 	}
@@ -30,24 +40,28 @@ func TestEventBusService_Creation(t *testing.T) {
 	}
 }
 
-func TestEventBusService_PublishTask(t *testing.T) {
-	service := newTestEventBusService()
+func TestAgentHubService_PublishMessage(t *testing.T) {
+	service := newTestAgentHubService()
 	ctx := context.Background()
 
-	// Test valid task
-	task := &pb.TaskMessage{
-		TaskId:           "test-task-1",
-		TaskType:         "test_type",
-		RequesterAgentId: "test-requester",
-		ResponderAgentId: "test-responder",
-		CreatedAt:        timestamppb.Now(),
-	}
-
-	req := &pb.PublishTaskRequest{
-		Task: task,
+	req := &pb.PublishMessageRequest{
+		Message: &pb.Message{
+			MessageId: "test-msg-1",
+			ContextId: "test-context",
+			TaskId:    "test-task-1",
+			Role:      pb.Role_ROLE_USER,
+			Content: []*pb.Part{
+				{Part: &pb.Part_Text{Text: "hello"}},
+			},
+		},
+		Routing: &pb.AgentEventMetadata{
+			FromAgentId: "test-requester",
+			ToAgentId:   "test-responder",
+			EventType:   "task_message",
+		},
 	}
 
-	resp, err := service.PublishTask(ctx, req)
+	resp, err := service.PublishMessage(ctx, req)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -56,53 +70,65 @@ func TestEventBusService_PublishTask(t *testing.T) {
 	}
 }
 
-func TestEventBusService_PublishTask_InvalidRequests(t *testing.T) {
-	service := newTestEventBusService()
+func TestAgentHubService_PublishMessage_SpanAttributes(t *testing.T) {
+	service := newTestAgentHubService()
+	traceManager, exporter := observabilitytest.NewInMemoryTraceManager(t)
+	service.Server.TraceManager = traceManager
+
+	_, err := service.PublishMessage(context.Background(), &pb.PublishMessageRequest{
+		Message: &pb.Message{
+			MessageId: "msg_span",
+			ContextId: "ctx_span",
+			Role:      pb.Role_ROLE_USER,
+			Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+		},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "agent1", EventType: "a2a.message"},
+	})
+	if err != nil {
+		t.Fatalf("PublishMessage failed: %v", err)
+	}
+
+	observabilitytest.AssertSpanHasAttributes(t, exporter, "broker.publish_event", map[string]string{
+		"a2a.message.id":            "msg_span",
+		"a2a.context.id":            "ctx_span",
+		"a2a.message.role":          "ROLE_USER",
+		"a2a.message.content_parts": "1",
+	})
+}
+
+func TestAgentHubService_PublishMessage_InvalidRequests(t *testing.T) {
+	service := newTestAgentHubService()
 	ctx := context.Background()
 
 	tests := []struct {
 		name string
-		req  *pb.PublishTaskRequest
+		req  *pb.PublishMessageRequest
 	}{
 		{
-			name: "nil task",
-			req:  &pb.PublishTaskRequest{Task: nil},
-		},
-		{
-			name: "empty task_id",
-			req: &pb.PublishTaskRequest{
-				Task: &pb.TaskMessage{
-					TaskId:           "",
-					TaskType:         "test_type",
-					RequesterAgentId: "test-requester",
-				},
-			},
+			name: "nil message",
+			req:  &pb.PublishMessageRequest{Message: nil},
 		},
 		{
-			name: "empty task_type",
-			req: &pb.PublishTaskRequest{
-				Task: &pb.TaskMessage{
-					TaskId:           "test-task-1",
-					TaskType:         "",
-					RequesterAgentId: "test-requester",
+			name: "empty message_id",
+			req: &pb.PublishMessageRequest{
+				Message: &pb.Message{
+					MessageId: "",
+					Role:      pb.Role_ROLE_USER,
 				},
 			},
 		},
 		{
-			name: "empty requester_agent_id",
-			req: &pb.PublishTaskRequest{
-				Task: &pb.TaskMessage{
-					TaskId:           "test-task-1",
-					TaskType:         "test_type",
-					RequesterAgentId: "",
-				},
+			name: "event type with a line break",
+			req: &pb.PublishMessageRequest{
+				Message: &pb.Message{MessageId: "msg_event_type", Role: pb.Role_ROLE_USER},
+				Routing: &pb.AgentEventMetadata{EventType: "chat\ndata: forged"},
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := service.PublishTask(ctx, tt.req)
+			_, err := service.PublishMessage(ctx, tt.req)
 			if err == nil {
 				t.Fatal("Expected error for invalid request, got nil")
 			}
@@ -110,6 +136,103 @@ func TestEventBusService_PublishTask_InvalidRequests(t *testing.T) {
 	}
 }
 
+func TestAgentHubService_PublishMessage_Limits(t *testing.T) {
+	service := newTestAgentHubService()
+	service.Server.Config.MaxMessageParts = 2
+	service.Server.Config.MaxPartBytes = 64
+	service.Server.Config.MaxMessageBytes = 128
+	ctx := context.Background()
+
+	textPart := func(text string) *pb.Part {
+		return &pb.Part{Part: &pb.Part_Text{Text: text}}
+	}
+
+	tests := []struct {
+		name    string
+		content []*pb.Part
+		wantErr bool
+	}{
+		{name: "within limits", content: []*pb.Part{textPart("hello")}},
+		{name: "too many parts", content: []*pb.Part{textPart("a"), textPart("b"), textPart("c")}, wantErr: true},
+		{name: "part too large", content: []*pb.Part{textPart(strings.Repeat("x", 100))}, wantErr: true},
+		{name: "message too large", content: []*pb.Part{textPart(strings.Repeat("x", 60)), textPart(strings.Repeat("y", 60))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+				Message: &pb.Message{
+					MessageId: "limit-msg",
+					Role:      pb.Role_ROLE_USER,
+					Content:   tt.content,
+				},
+				Routing: &pb.AgentEventMetadata{FromAgentId: "test-requester"},
+			})
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("Expected InvalidArgument, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAgentHubService_PublishMessageDeliveryCounts(t *testing.T) {
+	service := newTestAgentHubService()
+	WithDropPolicy(DropPolicyDropNewest)(service)
+	WithDeliveryReportWait(2 * time.Second)(service)
+	ctx := context.Background()
+
+	ready := make(chan *pb.AgentEvent, 1)
+	full := make(chan *pb.AgentEvent, 1)
+	full <- &pb.AgentEvent{EventId: "backlog"}
+	service.agentMu.Lock()
+	service.messageSubscribers["ready"] = []chan *pb.AgentEvent{ready}
+	service.messageSubscribers["full"] = []chan *pb.AgentEvent{full}
+	service.agentMu.Unlock()
+
+	resp, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "msg_broadcast", Role: pb.Role_ROLE_USER},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "publisher", EventType: "a2a.message"},
+	})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("PublishMessage failed: %v %v", err, resp.GetError())
+	}
+	if resp.GetDeliveredCount() != 1 || resp.GetDroppedCount() != 1 || resp.GetPendingCount() != 0 {
+		t.Errorf("Expected 1 delivered and 1 dropped, got %d delivered, %d dropped, %d pending",
+			resp.GetDeliveredCount(), resp.GetDroppedCount(), resp.GetPendingCount())
+	}
+
+	// Events routed to no subscriber report no delivery
+	resp, _ = service.PublishMessage(ctx, &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "msg_nobody", Role: pb.Role_ROLE_USER},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "publisher", ToAgentId: "nobody", EventType: "a2a.message"},
+	})
+	if resp.GetDeliveredCount()+resp.GetDroppedCount()+resp.GetPendingCount() != 0 {
+		t.Errorf("Expected no delivery accounting without subscribers, got %v", resp)
+	}
+}
+
+// mockEventStream is a server stream capturing sent events
+type mockEventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pb.AgentEvent
+}
+
+func (m *mockEventStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockEventStream) Send(event *pb.AgentEvent) error {
+	m.events <- event
+	return nil
+}
+
 func TestGRPCConfig_Creation(t *testing.T) {
 	config := NewGRPCConfig("test")
 	if config == nil {
@@ -154,3 +277,33 @@ func TestAgentHubServer_Creation(t *testing.T) {
 		t.Fatal("Expected metrics manager to be set")
 	}
 }
+
+// startTestBroker serves the service on a loopback listener and returns a client connected to it
+func startTestBroker(t testing.TB, service *AgentHubService) pb.AgentHubClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterAgentHubServer(server, service)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewAgentHubClient(conn)
+}
+
+// newTestClient returns a client of a broker serving the service, sharing its
+// observability so that tests can inspect both sides
+func newTestClient(t testing.TB, service *AgentHubService) *AgentHubClient {
+	return &AgentHubClient{
+		Client:         startTestBroker(t, service),
+		TraceManager:   service.Server.TraceManager,
+		MetricsManager: service.Server.MetricsManager,
+		Logger:         service.Server.Logger,
+	}
+}
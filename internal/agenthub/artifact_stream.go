@@ -0,0 +1,290 @@
+package agenthub
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DefaultArtifactChunkSize is the size of the chunks artifacts are streamed in
+const DefaultArtifactChunkSize = 64 << 10
+
+// DefaultMaxArtifactBytes is the default maximum size of a stored artifact content
+const DefaultMaxArtifactBytes = 1 << 30
+
+// errArtifactTooLarge is returned while reading an artifact content over the size limit
+var errArtifactTooLarge = errors.New("artifact too large")
+
+// PublishArtifactStream stores the content uploaded in chunks and publishes a task
+// artifact whose file part references it by blob ID
+func (s *AgentHubService) PublishArtifactStream(stream grpc.ClientStreamingServer[pb.ArtifactChunk, pb.PublishArtifactStreamResponse]) error {
+	ctx := stream.Context()
+	if s.blobStore == nil {
+		return status.Error(codes.FailedPrecondition, "artifact storage is not configured")
+	}
+
+	header, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "artifact stream is empty")
+	}
+	if err != nil {
+		return err
+	}
+	if header.GetTaskId() == "" {
+		return status.Error(codes.InvalidArgument, "task_id is required")
+	}
+
+	blobID := newBlobID()
+	var content io.Reader = &chunkReader{stream: stream, pending: header.GetData()}
+	if s.maxArtifactBytes > 0 {
+		content = &artifactLimitReader{r: content, remaining: s.maxArtifactBytes}
+	}
+	size, err := s.blobStore.Put(ctx, blobID, content)
+	if errors.Is(err, errArtifactTooLarge) {
+		return s.artifactTooLargeError()
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "failed to store artifact: %v", err)
+	}
+
+	s.Server.Logger.InfoContext(ctx, "Stored artifact content",
+		"task_id", header.GetTaskId(),
+		"blob_id", blobID,
+		"size", size,
+	)
+
+	artifactID := header.GetArtifactId()
+	if artifactID == "" {
+		artifactID = "artifact_" + blobID
+	}
+	resp, err := s.PublishTaskArtifact(ctx, &pb.PublishTaskArtifactRequest{
+		Artifact: &pb.TaskArtifactUpdateEvent{
+			TaskId:    header.GetTaskId(),
+			ContextId: header.GetContextId(),
+			Artifact: &pb.Artifact{
+				ArtifactId: artifactID,
+				Name:       header.GetName(),
+				Parts: []*pb.Part{{Part: &pb.Part_File{File: &pb.FilePart{
+					File:     &pb.FilePart_FileWithUri{FileWithUri: BlobURI(blobID)},
+					MimeType: header.GetMimeType(),
+					Name:     header.GetName(),
+				}}}},
+			},
+			LastChunk: true,
+		},
+		Routing: header.GetRouting(),
+	})
+	if err != nil {
+		// No task references the content, which would otherwise be kept forever
+		if deleteErr := s.blobStore.Delete(ctx, blobID); deleteErr != nil {
+			s.Server.Logger.WarnContext(ctx, "Failed to delete unpublished artifact content",
+				"blob_id", blobID,
+				"error", deleteErr,
+			)
+		}
+		return err
+	}
+
+	return stream.SendAndClose(&pb.PublishArtifactStreamResponse{
+		Success: resp.GetSuccess(),
+		Error:   resp.GetError(),
+		EventId: resp.GetEventId(),
+		BlobId:  blobID,
+		Size:    size,
+	})
+}
+
+// GetArtifactBlob streams the content of a stored artifact
+func (s *AgentHubService) GetArtifactBlob(req *pb.GetArtifactBlobRequest, stream grpc.ServerStreamingServer[pb.ArtifactBlobChunk]) error {
+	ctx := stream.Context()
+	if s.blobStore == nil {
+		return status.Error(codes.FailedPrecondition, "artifact storage is not configured")
+	}
+
+	blob, err := s.blobStore.Get(ctx, req.GetBlobId())
+	if errors.Is(err, ErrBlobNotFound) {
+		return status.Errorf(codes.NotFound, "blob %q not found", req.GetBlobId())
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to open blob: %v", err)
+	}
+	defer blob.Close()
+
+	buf := make([]byte, DefaultArtifactChunkSize)
+	for {
+		n, err := blob.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&pb.ArtifactBlobChunk{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read blob: %v", err)
+		}
+	}
+}
+
+// storeInlineFiles moves the inline bytes of file parts to the blob store and
+// returns a copy of the artifact referencing them by URI. The artifact is
+// returned unchanged when no blob store is configured or no part has inline bytes.
+func (s *AgentHubService) storeInlineFiles(ctx context.Context, event *pb.TaskArtifactUpdateEvent) (*pb.TaskArtifactUpdateEvent, error) {
+	if s.blobStore == nil {
+		return event, nil
+	}
+
+	var stored *pb.TaskArtifactUpdateEvent
+	for i, part := range event.GetArtifact().GetParts() {
+		content, ok := part.GetFile().GetFile().(*pb.FilePart_FileWithBytes)
+		if !ok {
+			continue
+		}
+		if stored == nil {
+			stored = proto.Clone(event).(*pb.TaskArtifactUpdateEvent)
+		}
+
+		if s.maxArtifactBytes > 0 && int64(len(content.FileWithBytes)) > s.maxArtifactBytes {
+			return nil, s.artifactTooLargeError()
+		}
+		blobID := newBlobID()
+		if _, err := s.blobStore.Put(ctx, blobID, bytes.NewReader(content.FileWithBytes)); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to store artifact content: %v", err)
+		}
+		stored.Artifact.Parts[i].GetFile().File = &pb.FilePart_FileWithUri{FileWithUri: BlobURI(blobID)}
+	}
+
+	if stored == nil {
+		return event, nil
+	}
+	return stored, nil
+}
+
+// artifactTooLargeError is the error returned for artifact content over the size limit
+func (s *AgentHubService) artifactTooLargeError() error {
+	return status.Errorf(codes.InvalidArgument, "artifact exceeds the %d bytes limit (AGENTHUB_MAX_ARTIFACT_BYTES)", s.maxArtifactBytes)
+}
+
+// artifactLimitReader fails once more than the remaining bytes are read, so that
+// the blob store discards an oversized upload instead of storing it
+type artifactLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *artifactLimitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errArtifactTooLarge
+	}
+	return n, err
+}
+
+// chunkReader reads the data of the chunks received on an artifact upload stream
+type chunkReader struct {
+	stream  grpc.ClientStreamingServer[pb.ArtifactChunk, pb.PublishArtifactStreamResponse]
+	pending []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = chunk.GetData()
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// ArtifactUpload describes a file artifact uploaded with UploadArtifact
+type ArtifactUpload struct {
+	TaskID     string
+	ContextID  string
+	ArtifactID string // Optional, generated by the broker when empty
+	Name       string
+	MimeType   string
+	Routing    *pb.AgentEventMetadata
+}
+
+// UploadArtifact streams the content read from r to the broker, which stores it and
+// publishes a task artifact referencing it. Large files are never held in memory.
+func (c *AgentHubClient) UploadArtifact(ctx context.Context, upload ArtifactUpload, r io.Reader) (*pb.PublishArtifactStreamResponse, error) {
+	// Cancelling the stream, rather than closing it, keeps a partial upload from being stored
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.Client.PublishArtifactStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := &pb.ArtifactChunk{
+		TaskId:     upload.TaskID,
+		ContextId:  upload.ContextID,
+		ArtifactId: upload.ArtifactID,
+		Name:       upload.Name,
+		MimeType:   upload.MimeType,
+		Routing:    upload.Routing,
+	}
+	buf := make([]byte, DefaultArtifactChunkSize)
+	for first := true; ; first = false {
+		n, readErr := io.ReadFull(r, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("failed to read artifact content: %w", readErr)
+		}
+		// The first chunk is sent even when empty, as it describes the artifact
+		if n > 0 || first {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				// The broker rejected the upload: its status is returned by CloseAndRecv
+				break
+			}
+			chunk = &pb.ArtifactChunk{}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// DownloadArtifact writes the content of a stored artifact to w and returns its size.
+// blobID is taken from a file part URI with BlobIDFromURI.
+func (c *AgentHubClient) DownloadArtifact(ctx context.Context, blobID string, w io.Writer) (int64, error) {
+	stream, err := c.Client.GetArtifactBlob(ctx, &pb.GetArtifactBlobRequest{BlobId: blobID})
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return size, nil
+		}
+		if err != nil {
+			return size, err
+		}
+		n, err := w.Write(chunk.GetData())
+		size += int64(n)
+		if err != nil {
+			return size, err
+		}
+	}
+}
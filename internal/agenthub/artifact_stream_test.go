@@ -0,0 +1,143 @@
+package agenthub
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_ArtifactStream(t *testing.T) {
+	service := newTestAgentHubService()
+	WithBlobStore(NewLocalBlobStore(t.TempDir()))(service)
+	client := newTestClient(t, service)
+	ctx := context.Background()
+
+	if err := service.taskStore.Put(ctx, &pb.Task{Id: "task_artifact", ContextId: "ctx_artifact"}); err != nil {
+		t.Fatalf("Failed to store task: %v", err)
+	}
+
+	// Larger than one chunk
+	content := []byte(strings.Repeat("0123456789", 20000))
+	resp, err := client.UploadArtifact(ctx, ArtifactUpload{
+		TaskID:   "task_artifact",
+		Name:     "report.bin",
+		MimeType: "application/octet-stream",
+		Routing:  &pb.AgentEventMetadata{FromAgentId: "producer"},
+	}, strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatalf("UploadArtifact failed: %v", err)
+	}
+	if !resp.GetSuccess() || resp.GetSize() != int64(len(content)) {
+		t.Fatalf("Unexpected upload response: %v", resp)
+	}
+
+	var downloaded strings.Builder
+	if _, err := client.DownloadArtifact(ctx, resp.GetBlobId(), &downloaded); err != nil {
+		t.Fatalf("DownloadArtifact failed: %v", err)
+	}
+	if downloaded.String() != string(content) {
+		t.Errorf("Downloaded %d bytes, expected the %d uploaded", downloaded.Len(), len(content))
+	}
+
+	// Inline file bytes are stored too, and referenced by URI
+	_, err = service.PublishTaskArtifact(ctx, &pb.PublishTaskArtifactRequest{
+		Artifact: &pb.TaskArtifactUpdateEvent{
+			TaskId: "task_artifact",
+			Artifact: &pb.Artifact{
+				ArtifactId: "inline",
+				Parts: []*pb.Part{{Part: &pb.Part_File{File: &pb.FilePart{
+					File: &pb.FilePart_FileWithBytes{FileWithBytes: []byte("inline content")},
+				}}}},
+			},
+		},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "producer"},
+	})
+	if err != nil {
+		t.Fatalf("PublishTaskArtifact failed: %v", err)
+	}
+
+	task, err := service.GetTask(ctx, &pb.GetTaskRequest{TaskId: "task_artifact"})
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if len(task.GetArtifacts()) != 2 {
+		t.Fatalf("Expected 2 artifacts, got %d", len(task.GetArtifacts()))
+	}
+	if uri := task.GetArtifacts()[0].GetParts()[0].GetFile().GetFileWithUri(); uri != BlobURI(resp.GetBlobId()) {
+		t.Errorf("Expected streamed artifact to reference %q, got %q", BlobURI(resp.GetBlobId()), uri)
+	}
+	blobID, ok := BlobIDFromURI(task.GetArtifacts()[1].GetParts()[0].GetFile().GetFileWithUri())
+	if !ok {
+		t.Fatalf("Expected inline artifact to reference a blob, got %v", task.GetArtifacts()[1])
+	}
+	downloaded.Reset()
+	if _, err := client.DownloadArtifact(ctx, blobID, &downloaded); err != nil || downloaded.String() != "inline content" {
+		t.Errorf("Expected inline content, got %q (err %v)", downloaded.String(), err)
+	}
+
+	if _, err := client.DownloadArtifact(ctx, "unknown", io.Discard); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for unknown blob, got %v", err)
+	}
+	if _, err := service.blobStore.Get(ctx, "../escape"); err == nil {
+		t.Error("Expected blob IDs with path elements to be rejected")
+	}
+}
+
+func TestAgentHubService_ArtifactStream_Limits(t *testing.T) {
+	dir := t.TempDir()
+	service := newTestAgentHubService()
+	WithBlobStore(NewLocalBlobStore(dir))(service)
+	WithMaxArtifactBytes(100)(service)
+	service.publishLimiter = newPublishLimiter(0.001, 1)
+	client := newTestClient(t, service)
+	ctx := context.Background()
+
+	upload := func(content string) (*pb.PublishArtifactStreamResponse, error) {
+		return client.UploadArtifact(ctx, ArtifactUpload{
+			TaskID:  "task_artifact",
+			Routing: &pb.AgentEventMetadata{FromAgentId: "producer"},
+		}, strings.NewReader(content))
+	}
+	storedBlobs := func() int {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir failed: %v", err)
+		}
+		return len(entries)
+	}
+
+	if _, err := upload(strings.Repeat("x", 1000)); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for an artifact over the limit, got %v", err)
+	}
+	if n := storedBlobs(); n != 0 {
+		t.Errorf("Expected the oversized artifact to be discarded, found %d files", n)
+	}
+
+	if _, err := upload("within the limit"); err != nil {
+		t.Fatalf("UploadArtifact failed: %v", err)
+	}
+	// The rate limit rejects the publish of the second artifact, after its content is stored
+	if _, err := upload("rate limited"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted, got %v", err)
+	}
+	if n := storedBlobs(); n != 1 {
+		t.Errorf("Expected only the published artifact to be kept, found %d files", n)
+	}
+}
+
+func TestAgentHubService_ArtifactStream_NotConfigured(t *testing.T) {
+	client := newTestClient(t, newTestAgentHubService())
+
+	_, err := client.UploadArtifact(context.Background(), ArtifactUpload{TaskID: "task"}, strings.NewReader("data"))
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without artifact storage, got %v", err)
+	}
+}
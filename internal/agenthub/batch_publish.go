@@ -0,0 +1,123 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// PublishMessages publishes a batch of messages sharing the same routing. Each message
+// is validated and routed like PublishMessage, under its own span below the batch span,
+// and gets its own result: a rejected message does not fail the others.
+func (s *AgentHubService) PublishMessages(ctx context.Context, req *pb.PublishMessagesRequest) (*pb.PublishMessagesResponse, error) {
+	ctx, span := s.Server.TraceManager.StartSpan(ctx, "broker.publish_batch",
+		attribute.Int("batch.size", len(req.GetMessages())),
+	)
+	defer span.End()
+
+	if len(req.GetMessages()) == 0 {
+		err := status.Error(codes.InvalidArgument, "messages cannot be empty")
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	results := make([]*pb.PublishResponse, 0, len(req.GetMessages()))
+	failed := 0
+	for _, message := range req.GetMessages() {
+		resp, err := s.PublishMessage(ctx, &pb.PublishMessageRequest{Message: message, Routing: req.GetRouting()})
+		if err != nil {
+			resp = &pb.PublishResponse{Success: false, Error: err.Error()}
+		}
+		if !resp.GetSuccess() {
+			failed++
+		}
+		results = append(results, resp)
+	}
+
+	s.Server.TraceManager.AddSpanEvent(span, "batch_published",
+		attribute.Int("batch.published", len(results)-failed),
+		attribute.Int("batch.failed", failed),
+	)
+	s.Server.TraceManager.SetSpanSuccess(span)
+	return &pb.PublishMessagesResponse{Results: results}, nil
+}
+
+// A2APublishTaskResult is the outcome of publishing one task of a batch
+type A2APublishTaskResult struct {
+	Task *pb.Task // The published task, nil when publishing it failed
+	Err  error
+}
+
+// PublishTasks publishes a batch of tasks in a single call to the broker. The tasks
+// must share their requester, responder, priority and headers, which make up the routing of
+// the batch. Results are returned in request order; the error reports a batch that
+// could not be published at all.
+func (tp *A2ATaskPublisher) PublishTasks(ctx context.Context, reqs []*A2APublishTaskRequest) ([]A2APublishTaskResult, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	first := reqs[0]
+	for _, req := range reqs[1:] {
+		if req.RequesterAgentID != first.RequesterAgentID || req.ResponderAgentID != first.ResponderAgentID || req.Priority != first.Priority ||
+			!maps.Equal(req.Headers, first.Headers) {
+			return nil, fmt.Errorf("batched tasks must share requester, responder, priority and headers")
+		}
+	}
+
+	ctx, span := tp.TraceManager.StartPublishSpan(ctx, tp.ComponentName, first.ResponderAgentID, "task_batch")
+	defer span.End()
+
+	messages := make([]*pb.Message, len(reqs))
+	tasks := make([]*pb.Task, len(reqs))
+	for i, req := range reqs {
+		messages[i], tasks[i] = tp.newTask(ctx, req)
+	}
+
+	tp.Logger.InfoContext(ctx, "Publishing A2A task batch",
+		"task_count", len(reqs),
+		"responder_agent_id", first.ResponderAgentID,
+	)
+
+	res, err := tp.Client.PublishMessages(ctx, &pb.PublishMessagesRequest{
+		Messages: messages,
+		Routing: &pb.AgentEventMetadata{
+			FromAgentId: first.RequesterAgentID,
+			ToAgentId:   first.ResponderAgentID,
+			EventType:   "task_message",
+			Priority:    first.Priority,
+			Headers:     first.headers(ctx),
+		},
+	})
+	if err != nil {
+		tp.TraceManager.RecordError(span, err)
+		tp.MetricsManager.IncrementEventErrors(ctx, "task_batch", tp.ComponentName, "grpc_error")
+		return nil, err
+	}
+	if len(res.GetResults()) != len(reqs) {
+		err := fmt.Errorf("broker returned %d results for %d tasks", len(res.GetResults()), len(reqs))
+		tp.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	results := make([]A2APublishTaskResult, len(reqs))
+	for i, result := range res.GetResults() {
+		taskType := reqs[i].TaskType
+		if !result.GetSuccess() {
+			results[i].Err = fmt.Errorf("failed to publish A2A task: %s", result.GetError())
+			tp.MetricsManager.IncrementEventErrors(ctx, taskType, tp.ComponentName, "publish_failed")
+			continue
+		}
+		results[i].Task = tasks[i]
+		tp.MetricsManager.IncrementEventsProcessed(ctx, taskType, tp.ComponentName, true)
+		tp.MetricsManager.IncrementEventsPublished(ctx, taskType, first.ResponderAgentID)
+	}
+
+	tp.TraceManager.SetSpanSuccess(span)
+	return results, nil
+}
@@ -0,0 +1,78 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_PublishMessages(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	resp, err := service.PublishMessages(ctx, &pb.PublishMessagesRequest{
+		Messages: []*pb.Message{
+			{MessageId: "batch-1", Role: pb.Role_ROLE_USER},
+			{Role: pb.Role_ROLE_USER}, // Missing message ID
+			{MessageId: "batch-3", Role: pb.Role_ROLE_USER},
+		},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "publisher", EventType: "message"},
+	})
+	if err != nil {
+		t.Fatalf("PublishMessages failed: %v", err)
+	}
+
+	var succeeded []bool
+	for _, result := range resp.GetResults() {
+		succeeded = append(succeeded, result.GetSuccess())
+	}
+	if fmt.Sprint(succeeded) != "[true false true]" {
+		t.Errorf("Expected only the message without ID to fail, got %v", succeeded)
+	}
+	if resp.GetResults()[1].GetError() == "" {
+		t.Error("Expected the failed result to carry an error")
+	}
+
+	if _, err := service.PublishMessages(ctx, &pb.PublishMessagesRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected empty batch to be rejected with InvalidArgument, got %v", err)
+	}
+}
+
+func TestA2ATaskPublisher_PublishTasks(t *testing.T) {
+	service := newTestAgentHubService()
+	publisher := NewA2ATaskPublisher(newTestClient(t, service), "test", "", nil)
+	ctx := context.Background()
+
+	var reqs []*A2APublishTaskRequest
+	for range 3 {
+		reqs = append(reqs, &A2APublishTaskRequest{TaskType: "echo", RequesterAgentID: "requester", ResponderAgentID: "responder"})
+	}
+	results, err := publisher.PublishTasks(ctx, reqs)
+	if err != nil {
+		t.Fatalf("PublishTasks failed: %v", err)
+	}
+
+	ids := make(map[string]bool)
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("Task %d failed: %v", i, result.Err)
+		}
+		ids[result.Task.GetId()] = true
+		if _, err := service.taskStore.Get(ctx, result.Task.GetId()); err != nil {
+			t.Errorf("Expected task %s to be stored: %v", result.Task.GetId(), err)
+		}
+	}
+	if len(ids) != 3 {
+		t.Errorf("Expected 3 distinct task IDs, got %v", ids)
+	}
+
+	mixed := []*A2APublishTaskRequest{reqs[0], {TaskType: "echo", RequesterAgentID: "requester", ResponderAgentID: "other"}}
+	if _, err := publisher.PublishTasks(ctx, mixed); err == nil {
+		t.Error("Expected tasks with different responders to be rejected")
+	}
+}
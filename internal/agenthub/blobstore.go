@@ -0,0 +1,143 @@
+package agenthub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrBlobNotFound is returned by a BlobStore when no blob has the requested ID
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobURIScheme prefixes the file URIs of artifacts stored by the broker
+const BlobURIScheme = "blob://"
+
+// BlobStore holds the content of file artifacts, so that tasks reference large
+// binaries by ID instead of carrying their bytes. Implementations must be safe
+// for concurrent use; object stores such as S3 fit this interface as well as the
+// bundled LocalBlobStore.
+type BlobStore interface {
+	// Put stores the content read from r under id and returns its size in bytes
+	Put(ctx context.Context, id string, r io.Reader) (int64, error)
+	// Get opens the content stored under id, or returns ErrBlobNotFound
+	Get(ctx context.Context, id string) (io.ReadCloser, error)
+	// Delete removes the content stored under id. Deleting an unknown blob is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// BlobURI returns the file URI referencing a stored blob
+func BlobURI(blobID string) string {
+	return BlobURIScheme + blobID
+}
+
+// BlobIDFromURI returns the blob ID referenced by a file URI. It reports false
+// for URIs that do not point to a stored blob.
+func BlobIDFromURI(uri string) (string, bool) {
+	blobID, ok := strings.CutPrefix(uri, BlobURIScheme)
+	if !ok || blobID == "" {
+		return "", false
+	}
+	return blobID, true
+}
+
+// newBlobID generates a random blob ID
+func newBlobID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// LocalBlobStore is a BlobStore keeping each blob as a file of a local directory
+type LocalBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore creates a store writing to dir. The directory is created on first use.
+func NewLocalBlobStore(dir string) *LocalBlobStore {
+	return &LocalBlobStore{dir: dir}
+}
+
+// Put writes the blob to a temporary file and renames it into place once complete
+func (l *LocalBlobStore) Put(ctx context.Context, id string, r io.Reader) (int64, error) {
+	path, err := l.path(id)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(l.dir, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create blob file: %w", err)
+	}
+	size, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+	return size, nil
+}
+
+// Get opens the blob file
+func (l *LocalBlobStore) Get(ctx context.Context, id string) (io.ReadCloser, error) {
+	path, err := l.path(id)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return f, err
+}
+
+// Delete removes the blob file
+func (l *LocalBlobStore) Delete(ctx context.Context, id string) error {
+	path, err := l.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file of a blob, rejecting IDs that could escape the directory
+func (l *LocalBlobStore) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid blob ID %q", id)
+	}
+	return filepath.Join(l.dir, id), nil
+}
+
+// WithBlobStore sets the store keeping the content of file artifacts, replacing
+// the LocalBlobStore configured by AGENTHUB_ARTIFACT_DIR. A nil store keeps the default.
+func WithBlobStore(store BlobStore) ServiceOption {
+	return func(s *AgentHubService) {
+		if store != nil {
+			s.blobStore = store
+		}
+	}
+}
+
+// WithMaxArtifactBytes limits the size of stored artifact content, overriding
+// AGENTHUB_MAX_ARTIFACT_BYTES. A limit of 0 or less removes it.
+func WithMaxArtifactBytes(limit int64) ServiceOption {
+	return func(s *AgentHubService) {
+		s.maxArtifactBytes = limit
+	}
+}
@@ -0,0 +1,193 @@
+package agenthub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the number of consecutive send timeouts opening
+	// the circuit breaker of a subscriber
+	DefaultCircuitBreakerThreshold = 5
+	// DefaultCircuitBreakerCooldown is how long an open circuit breaker fast-fails events
+	// before letting one through to test recovery
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// DropReasonCircuitOpen is reported for events fast-failed by an open circuit breaker
+const DropReasonCircuitOpen = "circuit_open"
+
+// CircuitState is the state of the delivery circuit breaker of a subscriber,
+// as reported by the circuit_state gauge
+type CircuitState int
+
+const (
+	// CircuitClosed delivers events normally
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fast-fails events to the dead letters until the cooldown elapses
+	CircuitOpen
+	// CircuitHalfOpen lets a single event through to test whether the subscriber recovered
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (c CircuitState) String() string {
+	switch c {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker tracks the consecutive send timeouts of one subscriber channel
+type circuitBreaker struct {
+	subscription string
+	agentID      string
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool // An event is being delivered in half-open state
+}
+
+// allow reports whether an event may be sent to the subscriber. Once the cooldown
+// has elapsed, an open breaker becomes half-open and lets a single probe through.
+// The state changed by the call, if any, is returned with changed set.
+func (b *circuitBreaker) allow(now time.Time, cooldown time.Duration) (allowed bool, state CircuitState, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < cooldown {
+			return false, b.state, false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true, b.state, true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false, b.state, false
+		}
+		b.probing = true
+		return true, b.state, false
+	default:
+		return true, b.state, false
+	}
+}
+
+// record updates the breaker with the outcome of a send. A timeout counts as a
+// failure; any other outcome means the subscriber is consuming again.
+func (b *circuitBreaker) record(timedOut bool, now time.Time, threshold int) (state CircuitState, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	previous := b.state
+	b.probing = false
+	if !timedOut {
+		b.failures = 0
+		b.state = CircuitClosed
+		return b.state, b.state != previous
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= threshold {
+		b.state = CircuitOpen
+		b.openedAt = now
+	}
+	return b.state, b.state != previous
+}
+
+// circuitBreakers holds the breakers of the open subscriptions. A nil value
+// disables circuit breaking.
+type circuitBreakers struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	breakers map[chan *pb.AgentEvent]*circuitBreaker
+}
+
+// newCircuitBreakers returns the breaker registry, or nil when threshold is not positive
+func newCircuitBreakers(threshold int, cooldown time.Duration) *circuitBreakers {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		breakers:  make(map[chan *pb.AgentEvent]*circuitBreaker),
+	}
+}
+
+// add creates the breaker of a subscription channel
+func (c *circuitBreakers) add(ch chan *pb.AgentEvent, subscription, agentID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.breakers[ch] = &circuitBreaker{subscription: subscription, agentID: agentID}
+}
+
+// remove forgets the breaker of a closed subscription channel
+func (c *circuitBreakers) remove(ch chan *pb.AgentEvent) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.breakers, ch)
+}
+
+// get returns the breaker of a subscription channel, nil if it has none
+func (c *circuitBreakers) get(ch chan *pb.AgentEvent) *circuitBreaker {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.breakers[ch]
+}
+
+// sendThroughBreaker delivers evt to ch under its circuit breaker. Events for a
+// subscriber whose breaker is open are dead-lettered without waiting.
+// It returns the drop reason, or an empty string if the event was delivered.
+func (s *AgentHubService) sendThroughBreaker(ctx context.Context, ch chan *pb.AgentEvent, evt *pb.AgentEvent) string {
+	breaker := s.breakers.get(ch)
+	if breaker == nil {
+		return s.sendWithPolicy(ctx, ch, evt)
+	}
+
+	allowed, state, changed := breaker.allow(time.Now(), s.breakers.cooldown)
+	if changed {
+		s.recordCircuitState(ctx, breaker, state)
+	}
+	if !allowed {
+		s.deadLetter(ctx, evt)
+		return DropReasonCircuitOpen
+	}
+
+	reason := s.sendWithPolicy(ctx, ch, evt)
+	if state, changed := breaker.record(reason == DropReasonTimeout, time.Now(), s.breakers.threshold); changed {
+		s.recordCircuitState(ctx, breaker, state)
+	}
+	return reason
+}
+
+// recordCircuitState reports a breaker state change
+func (s *AgentHubService) recordCircuitState(ctx context.Context, breaker *circuitBreaker, state CircuitState) {
+	s.Server.MetricsManager.RecordCircuitState(ctx, breaker.subscription, breaker.agentID, int(state))
+	s.Server.Logger.WarnContext(ctx, "Subscriber circuit breaker changed state",
+		"agent_id", breaker.agentID,
+		"subscription", breaker.subscription,
+		"state", state.String(),
+	)
+}
@@ -0,0 +1,65 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_CircuitBreaker(t *testing.T) {
+	server := newTestAgentHubService().Server
+	server.Config.SendTimeout = 10 * time.Millisecond
+	server.Config.CircuitBreakerThreshold = 2
+	server.Config.CircuitBreakerCooldown = 100 * time.Millisecond
+	service := NewAgentHubService(server)
+	ctx := context.Background()
+
+	ch := make(chan *pb.AgentEvent, 1)
+	service.breakers.add(ch, "messages", "slow_agent")
+	ch <- &pb.AgentEvent{EventId: "backlog"}
+	breaker := service.breakers.get(ch)
+
+	// Consecutive timeouts open the breaker
+	for i := range 2 {
+		if reason := service.sendThroughBreaker(ctx, ch, &pb.AgentEvent{EventId: fmt.Sprintf("timeout_%d", i)}); reason != DropReasonTimeout {
+			t.Fatalf("Expected send %d to time out, got %q", i, reason)
+		}
+	}
+	if breaker.state != CircuitOpen {
+		t.Fatalf("Expected breaker to be open, got %s", breaker.state)
+	}
+
+	// While open, events are dead-lettered without waiting
+	start := time.Now()
+	if reason := service.sendThroughBreaker(ctx, ch, &pb.AgentEvent{EventId: "fast_fail"}); reason != DropReasonCircuitOpen {
+		t.Errorf("Expected fast fail, got %q", reason)
+	}
+	if elapsed := time.Since(start); elapsed >= server.Config.SendTimeout {
+		t.Errorf("Expected open breaker not to wait, waited %s", elapsed)
+	}
+	if events := service.deadLetters.Events(); len(events) != 1 || events[0].GetEventId() != "fast_fail" {
+		t.Errorf("Expected fast-failed event to be dead-lettered, got %v", events)
+	}
+
+	// After the cooldown, a probe reaching a recovered subscriber closes the breaker
+	time.Sleep(server.Config.CircuitBreakerCooldown)
+	<-ch
+	if reason := service.sendThroughBreaker(ctx, ch, &pb.AgentEvent{EventId: "probe"}); reason != "" {
+		t.Errorf("Expected probe to be delivered, got %q", reason)
+	}
+	if breaker.state != CircuitClosed {
+		t.Errorf("Expected breaker to be closed, got %s", breaker.state)
+	}
+
+	// A failed probe reopens it
+	breaker.state, breaker.openedAt = CircuitOpen, time.Now().Add(-time.Hour)
+	if reason := service.sendThroughBreaker(ctx, ch, &pb.AgentEvent{EventId: "failed_probe"}); reason != DropReasonTimeout {
+		t.Errorf("Expected probe to time out, got %q", reason)
+	}
+	if breaker.state != CircuitOpen {
+		t.Errorf("Expected failed probe to reopen the breaker, got %s", breaker.state)
+	}
+}
@@ -0,0 +1,126 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Defaults for establishing the broker connection
+const (
+	DefaultConnectMaxRetries     = 10
+	DefaultConnectInitialBackoff = 500 * time.Millisecond
+	DefaultConnectMaxBackoff     = 30 * time.Second
+
+	// connectAttemptTimeout bounds a single attempt that neither connects nor fails
+	connectAttemptTimeout = 10 * time.Second
+)
+
+// connect waits until the broker connection is ready. Failed attempts are retried
+// with exponential backoff and jitter until the connection succeeds, ctx is done,
+// or ConnectMaxRetries retries are exhausted (a negative value retries forever).
+func (c *AgentHubClient) connect(ctx context.Context) error {
+	backoff := c.Config.ConnectInitialBackoff
+	if backoff <= 0 {
+		backoff = DefaultConnectInitialBackoff
+	}
+	maxBackoff := c.Config.ConnectMaxBackoff
+	if maxBackoff < backoff {
+		maxBackoff = backoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.attemptConnect(ctx)
+		if err == nil {
+			c.Logger.InfoContext(ctx, "Connected to broker",
+				slog.String("broker_addr", c.Config.BrokerAddr),
+				slog.Int("attempt", attempt),
+			)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if c.Config.ConnectMaxRetries >= 0 && attempt > c.Config.ConnectMaxRetries {
+			return fmt.Errorf("failed to connect to broker at %s after %d attempts: %w", c.Config.BrokerAddr, attempt, err)
+		}
+
+		delay := jitteredDelay(backoff)
+		c.Logger.WarnContext(ctx, "Broker not reachable, retrying",
+			slog.String("broker_addr", c.Config.BrokerAddr),
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", delay),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		backoff = nextBackoff(backoff, maxBackoff)
+	}
+}
+
+// jitteredDelay picks a delay in [backoff/2, backoff] to spread agents restarting together
+func jitteredDelay(backoff time.Duration) time.Duration {
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// nextBackoff doubles backoff, capped at maxBackoff
+func nextBackoff(backoff, maxBackoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// attemptConnect makes one connection attempt. A fresh probe connection reports
+// the outcome of the attempt, which the long-lived client connection cannot do
+// once it sits in TRANSIENT_FAILURE.
+func (c *AgentHubClient) attemptConnect(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, connectAttemptTimeout)
+	defer cancel()
+
+	probe, err := dialBroker(c.Config)
+	if err != nil {
+		return err
+	}
+	defer probe.Close()
+
+	probe.Connect()
+	if err := waitForReady(ctx, probe, true); err != nil {
+		return err
+	}
+
+	// The broker is up: skip the client connection's reconnect backoff
+	c.Connection.ResetConnectBackoff()
+	c.Connection.Connect()
+	return waitForReady(ctx, c.Connection, false)
+}
+
+// waitForReady waits for conn to become ready. With failFast, a transient
+// failure ends the wait instead of waiting for gRPC to reconnect.
+func waitForReady(ctx context.Context, conn *grpc.ClientConn, failFast bool) error {
+	for {
+		state := conn.GetState()
+		switch {
+		case state == connectivity.Ready:
+			return nil
+		case state == connectivity.Shutdown:
+			return fmt.Errorf("connection is closed")
+		case state == connectivity.TransientFailure && failFast:
+			return fmt.Errorf("connection failed")
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection attempt timed out: %w", ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package agenthub
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestAgentHubClient_ConnectRetriesUntilBrokerIsUp(t *testing.T) {
+	// Reserve an address, then leave it unserved until the broker "starts"
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer conn.Close()
+
+	client := &AgentHubClient{
+		Connection: conn,
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Config: &GRPCConfig{
+			BrokerAddr:            addr,
+			ConnectMaxRetries:     1,
+			ConnectInitialBackoff: 20 * time.Millisecond,
+			ConnectMaxBackoff:     50 * time.Millisecond,
+		},
+	}
+
+	if err := client.connect(context.Background()); err == nil {
+		t.Fatal("Expected connect to fail once retries are exhausted")
+	}
+
+	server := grpc.NewServer()
+	defer server.Stop()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return
+		}
+		server.Serve(listener)
+	}()
+
+	client.Config.ConnectMaxRetries = -1
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.connect(ctx); err != nil {
+		t.Fatalf("Expected connect to succeed once the broker is up, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package agenthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// RoutingRule routes broadcast events, published without a target agent, to an
+// agent when their content matches every condition of the rule
+type RoutingRule struct {
+	// Name identifies the rule in logs
+	Name string `yaml:"name" json:"name"`
+	// EventType, when set, is an event type or pattern the event must match,
+	// as in subscription filters (e.g. "a2a.message.*")
+	EventType string `yaml:"event_type" json:"event_type"`
+	// Metadata maps dot-separated metadata paths (e.g. "task_type" or
+	// "routing.region") to the value the event metadata must hold
+	Metadata map[string]string `yaml:"metadata" json:"metadata"`
+	// Agent receives the matching events
+	Agent string `yaml:"agent" json:"agent"`
+}
+
+// routingRulesFile is the content of a routing rules file
+type routingRulesFile struct {
+	Rules []RoutingRule `yaml:"rules" json:"rules"`
+}
+
+// LoadRoutingRules reads routing rules from a YAML (.yaml, .yml) or JSON (.json)
+// file holding a "rules" list. Rules are returned in file order, which is the
+// order they are matched in.
+func LoadRoutingRules(path string) ([]RoutingRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing rules: %w", err)
+	}
+
+	var file routingRulesFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		return nil, fmt.Errorf("unsupported routing rules extension %q: expected .yaml, .yml or .json", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse routing rules %s: %w", path, err)
+	}
+
+	for i, rule := range file.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid routing rule %d (%s) in %s: %w", i+1, rule.Name, path, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// validate checks that the rule has a target and at least one condition
+func (r RoutingRule) validate() error {
+	if r.Agent == "" {
+		return fmt.Errorf("agent is required")
+	}
+	if r.EventType == "" && len(r.Metadata) == 0 {
+		return fmt.Errorf("at least one of event_type and metadata is required")
+	}
+	return nil
+}
+
+// WithRoutingRules sets the content-based routing rules of the broker, replacing
+// those loaded from AGENTHUB_ROUTING_RULES_FILE. Invalid rules are ignored.
+func WithRoutingRules(rules []RoutingRule) ServiceOption {
+	return func(s *AgentHubService) {
+		s.routingRules = compileRoutingRules(rules)
+	}
+}
+
+// configuredRoutingRules returns the option applying the rules of the
+// configured routing rules file, if any
+func configuredRoutingRules(config *GRPCConfig) (ServiceOption, error) {
+	if config.RoutingRulesFile == "" {
+		return func(*AgentHubService) {}, nil
+	}
+	rules, err := LoadRoutingRules(config.RoutingRulesFile)
+	if err != nil {
+		return nil, err
+	}
+	return WithRoutingRules(rules), nil
+}
+
+// compiledRoutingRule is a routing rule with its event type filter parsed
+type compiledRoutingRule struct {
+	RoutingRule
+	eventType *eventTypeFilter
+	metadata  map[string][]string
+}
+
+// compileRoutingRules prepares the valid rules for matching
+func compileRoutingRules(rules []RoutingRule) []compiledRoutingRule {
+	var compiled []compiledRoutingRule
+	for _, rule := range rules {
+		if rule.validate() != nil {
+			continue
+		}
+		c := compiledRoutingRule{RoutingRule: rule, metadata: make(map[string][]string, len(rule.Metadata))}
+		if rule.EventType != "" {
+			c.eventType = newEventTypeFilter([]string{rule.EventType})
+		}
+		for path := range rule.Metadata {
+			c.metadata[path] = strings.Split(path, ".")
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled
+}
+
+// matches reports whether the event satisfies every condition of the rule
+func (r *compiledRoutingRule) matches(event *pb.AgentEvent) bool {
+	if !r.eventType.accepts(event.GetRouting().GetEventType()) {
+		return false
+	}
+	metadata := eventMetadata(event)
+	for path, want := range r.Metadata {
+		value, ok := MetadataValue(metadata, r.metadata[path]...)
+		if !ok {
+			return false
+		}
+		if got, ok := metadataText(value); !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// applyRoutingRules targets a broadcast event at the agent of the first matching
+// rule. Rules never route an event back to its publisher. It reports the rule used.
+func (s *AgentHubService) applyRoutingRules(event *pb.AgentEvent) (*RoutingRule, bool) {
+	routing := event.GetRouting()
+	if len(s.routingRules) == 0 || routing.GetToAgentId() != "" {
+		return nil, false
+	}
+	for i := range s.routingRules {
+		rule := &s.routingRules[i]
+		if rule.Agent == routing.GetFromAgentId() || !rule.matches(event) {
+			continue
+		}
+		targeted := proto.Clone(routing).(*pb.AgentEventMetadata)
+		targeted.ToAgentId = rule.Agent
+		event.Routing = targeted
+		return &rule.RoutingRule, true
+	}
+	return nil, false
+}
+
+// eventMetadata returns the metadata of the event payload
+func eventMetadata(event *pb.AgentEvent) *structpb.Struct {
+	switch payload := event.GetPayload().(type) {
+	case *pb.AgentEvent_Message:
+		return payload.Message.GetMetadata()
+	case *pb.AgentEvent_Task:
+		return payload.Task.GetMetadata()
+	case *pb.AgentEvent_StatusUpdate:
+		return payload.StatusUpdate.GetMetadata()
+	case *pb.AgentEvent_ArtifactUpdate:
+		return payload.ArtifactUpdate.GetMetadata()
+	default:
+		return nil
+	}
+}
+
+// metadataText formats a scalar metadata value for comparison with a rule value
+func metadataText(value *structpb.Value) (string, bool) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return kind.StringValue, true
+	case *structpb.Value_NumberValue:
+		return strconv.FormatFloat(kind.NumberValue, 'f', -1, 64), true
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(kind.BoolValue), true
+	default:
+		return "", false
+	}
+}
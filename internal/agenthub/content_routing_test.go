@@ -0,0 +1,87 @@
+package agenthub
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_RoutingRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	rules := `rules:
+  - name: translations
+    event_type: a2a.message.#
+    metadata:
+      task_type: translation
+    agent: translator
+`
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+	loaded, err := LoadRoutingRules(path)
+	if err != nil {
+		t.Fatalf("LoadRoutingRules failed: %v", err)
+	}
+
+	service := newTestAgentHubService()
+	WithRoutingRules(loaded)(service)
+	ctx := context.Background()
+
+	translatorChan := make(chan *pb.AgentEvent, 2)
+	otherChan := make(chan *pb.AgentEvent, 2)
+	service.agentMu.Lock()
+	service.messageSubscribers["translator"] = []chan *pb.AgentEvent{translatorChan}
+	service.messageSubscribers["other"] = []chan *pb.AgentEvent{otherChan}
+	service.agentMu.Unlock()
+
+	publish := func(taskType string) {
+		t.Helper()
+		metadata, _ := structpb.NewStruct(map[string]any{"task_type": taskType})
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: "msg_" + taskType, Role: pb.Role_ROLE_USER, Metadata: metadata},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "requester", EventType: "a2a.message.request"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+
+	// A matching event only reaches the agent of the rule
+	publish("translation")
+	select {
+	case event := <-translatorChan:
+		if event.GetRouting().GetToAgentId() != "translator" {
+			t.Errorf("Expected the event to target translator, got %q", event.GetRouting().GetToAgentId())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the translation to reach translator")
+	}
+	select {
+	case <-otherChan:
+		t.Error("Expected the translation not to be broadcast")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Other events are still broadcast
+	publish("summary")
+	for name, ch := range map[string]chan *pb.AgentEvent{"translator": translatorChan, "other": otherChan} {
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected the broadcast to reach %s", name)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n  - name: no_target\n    event_type: a2a.#\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+	if _, err := LoadRoutingRules(path); err == nil {
+		t.Error("Expected a rule without agent to be rejected")
+	}
+}
@@ -0,0 +1,206 @@
+package agenthub
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// Default bounds of the per-context conversation history kept by the broker
+const (
+	DefaultMaxContextMessages = 200
+	DefaultMaxContexts        = 10000
+)
+
+// Reasons reported by the contexts_evicted_total metric
+const (
+	ContextEvictionLRU = "lru"
+	ContextEvictionTTL = "ttl"
+)
+
+// contextEntry is the retained history of a single conversation context
+type contextEntry struct {
+	id          string
+	messages    []*pb.Message
+	lastUpdated time.Time
+}
+
+// contextHistory keeps the messages of each context, bounded in messages per
+// context and in number of contexts (least recently updated are evicted first).
+// A zero bound disables it.
+type contextHistory struct {
+	mu          sync.Mutex
+	maxMessages int
+	maxContexts int
+	ttl         time.Duration
+
+	entries map[string]*list.Element
+	lru     *list.List // front is the most recently updated context
+}
+
+func newContextHistory(maxMessages, maxContexts int, ttl time.Duration) *contextHistory {
+	return &contextHistory{
+		maxMessages: maxMessages,
+		maxContexts: maxContexts,
+		ttl:         ttl,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// append records a message for its context and returns the number of contexts
+// evicted to stay within maxContexts
+func (h *contextHistory) append(contextID string, message *pb.Message, now time.Time) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var entry *contextEntry
+	if elem, ok := h.entries[contextID]; ok {
+		entry = elem.Value.(*contextEntry)
+		h.lru.MoveToFront(elem)
+	} else {
+		entry = &contextEntry{id: contextID}
+		h.entries[contextID] = h.lru.PushFront(entry)
+	}
+
+	entry.messages = append(entry.messages, message)
+	if h.maxMessages > 0 && len(entry.messages) > h.maxMessages {
+		entry.messages = append([]*pb.Message(nil), entry.messages[len(entry.messages)-h.maxMessages:]...)
+	}
+	entry.lastUpdated = now
+
+	evicted := 0
+	for h.maxContexts > 0 && h.lru.Len() > h.maxContexts {
+		h.remove(h.lru.Back())
+		evicted++
+	}
+	return evicted
+}
+
+// pruneExpired drops the contexts not updated within the TTL and returns how many were dropped
+func (h *contextHistory) pruneExpired(now time.Time) int {
+	if h.ttl <= 0 {
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pruned := 0
+	for elem := h.lru.Back(); elem != nil; elem = h.lru.Back() {
+		if now.Sub(elem.Value.(*contextEntry).lastUpdated) < h.ttl {
+			break
+		}
+		h.remove(elem)
+		pruned++
+	}
+	return pruned
+}
+
+// messages returns a copy of the retained messages of a context, oldest first,
+// keeping only the last limit messages when limit is positive
+func (h *contextHistory) messages(contextID string, limit int) []*pb.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	elem, ok := h.entries[contextID]
+	if !ok {
+		return nil
+	}
+	messages := elem.Value.(*contextEntry).messages
+	if limit > 0 && len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+	return append([]*pb.Message(nil), messages...)
+}
+
+// len returns the number of retained contexts
+func (h *contextHistory) len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lru.Len()
+}
+
+func (h *contextHistory) remove(elem *list.Element) {
+	h.lru.Remove(elem)
+	delete(h.entries, elem.Value.(*contextEntry).id)
+}
+
+// recordContextMessage stores a message in its context history, counting LRU evictions
+func (s *AgentHubService) recordContextMessage(ctx context.Context, message *pb.Message) {
+	if evicted := s.contexts.append(message.GetContextId(), message, time.Now()); evicted > 0 {
+		s.Server.MetricsManager.IncrementContextsEvicted(ctx, ContextEvictionLRU, int64(evicted))
+	}
+}
+
+// GetContextMessages returns the messages retained for a conversation context,
+// oldest first. A positive limit returns only the most recent messages.
+func (s *AgentHubService) GetContextMessages(ctx context.Context, req *pb.GetContextMessagesRequest) (*pb.GetContextMessagesResponse, error) {
+	if req.GetContextId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "context_id cannot be empty")
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit cannot be negative")
+	}
+
+	return &pb.GetContextMessagesResponse{
+		Messages: s.contexts.messages(req.GetContextId(), int(req.GetLimit())),
+	}, nil
+}
+
+// GetContextMessages retrieves the conversation history of a context from the broker,
+// oldest first. A positive limit returns only the most recent messages.
+func (c *AgentHubClient) GetContextMessages(ctx context.Context, contextID string, limit int) ([]*pb.Message, error) {
+	resp, err := c.Client.GetContextMessages(ctx, &pb.GetContextMessagesRequest{
+		ContextId: contextID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetMessages(), nil
+}
+
+// PruneContexts drops the conversation contexts that outlived the configured TTL
+// and returns how many were dropped. It runs periodically once the broker starts,
+// and can also be called manually.
+func (s *AgentHubService) PruneContexts(ctx context.Context) int {
+	pruned := s.contexts.pruneExpired(time.Now())
+	if pruned > 0 {
+		s.Server.MetricsManager.IncrementContextsEvicted(ctx, ContextEvictionTTL, int64(pruned))
+		s.Server.Logger.DebugContext(ctx, "Pruned expired conversation contexts",
+			"pruned_count", pruned,
+		)
+	}
+	return pruned
+}
+
+// runContextPruner calls PruneContexts periodically until ctx is done.
+// It does nothing when no TTL is configured.
+func (s *AgentHubService) runContextPruner(ctx context.Context) {
+	if s.contexts.ttl <= 0 {
+		return
+	}
+
+	interval := s.contexts.ttl / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.PruneContexts(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
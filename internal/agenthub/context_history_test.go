@@ -0,0 +1,102 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestContextHistoryPruning(t *testing.T) {
+	service := newTestAgentHubService()
+	service.contexts = newContextHistory(2, 2, time.Minute)
+	ctx := context.Background()
+
+	for _, contextID := range []string{"ctx-a", "ctx-a", "ctx-a", "ctx-b", "ctx-c"} {
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{
+				MessageId: "msg-" + contextID,
+				ContextId: contextID,
+				Role:      pb.Role_ROLE_USER,
+				Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+			},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "tester"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+
+	if got := service.contexts.len(); got != 2 {
+		t.Fatalf("Expected 2 retained contexts, got %d", got)
+	}
+	if msgs := service.contexts.messages("ctx-a", 0); msgs != nil {
+		t.Errorf("Expected least recently updated context to be evicted, got %d messages", len(msgs))
+	}
+
+	service.contexts.append("ctx-d", &pb.Message{ContextId: "ctx-d"}, time.Now())
+	service.contexts.append("ctx-d", &pb.Message{ContextId: "ctx-d"}, time.Now())
+	service.contexts.append("ctx-d", &pb.Message{ContextId: "ctx-d"}, time.Now())
+	if got := len(service.contexts.messages("ctx-d", 0)); got != 2 {
+		t.Errorf("Expected messages to be trimmed to 2, got %d", got)
+	}
+
+	service.contexts.ttl = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if pruned := service.PruneContexts(ctx); pruned != 2 {
+		t.Errorf("Expected 2 expired contexts pruned, got %d", pruned)
+	}
+	if got := service.contexts.len(); got != 0 {
+		t.Errorf("Expected no retained contexts after pruning, got %d", got)
+	}
+}
+
+func TestAgentHubService_GetContextMessages(t *testing.T) {
+	service := newTestAgentHubService()
+	client := startTestBroker(t, service)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{
+				MessageId: fmt.Sprintf("msg-%d", i),
+				ContextId: "session-1",
+				Role:      pb.Role_ROLE_USER,
+			},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "tester"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+
+	resp, err := client.GetContextMessages(ctx, &pb.GetContextMessagesRequest{ContextId: "session-1"})
+	if err != nil {
+		t.Fatalf("GetContextMessages failed: %v", err)
+	}
+	if len(resp.GetMessages()) != 3 || resp.GetMessages()[0].GetMessageId() != "msg-0" {
+		t.Errorf("Expected 3 messages oldest first, got %v", resp.GetMessages())
+	}
+
+	resp, err = client.GetContextMessages(ctx, &pb.GetContextMessagesRequest{ContextId: "session-1", Limit: 2})
+	if err != nil {
+		t.Fatalf("GetContextMessages with limit failed: %v", err)
+	}
+	if len(resp.GetMessages()) != 2 || resp.GetMessages()[0].GetMessageId() != "msg-1" || resp.GetMessages()[1].GetMessageId() != "msg-2" {
+		t.Errorf("Expected the 2 most recent messages, got %v", resp.GetMessages())
+	}
+
+	resp, err = client.GetContextMessages(ctx, &pb.GetContextMessagesRequest{ContextId: "unknown"})
+	if err != nil || len(resp.GetMessages()) != 0 {
+		t.Errorf("Expected no messages for an unknown context, got %v (err %v)", resp.GetMessages(), err)
+	}
+
+	if _, err := client.GetContextMessages(ctx, &pb.GetContextMessagesRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without context_id, got %v", err)
+	}
+}
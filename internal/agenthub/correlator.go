@@ -0,0 +1,179 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// maxPendingResponses bounds the responses a ResponseCorrelator keeps for
+// callers that have not asked for them yet; the oldest are dropped first
+const maxPendingResponses = 100
+
+// ErrResponseTimeout is returned by ResponseCorrelator.WaitFor when no response
+// arrives in time
+var ErrResponseTimeout = errors.New("timed out waiting for a response")
+
+// correlatedResponse is a received response, indexed under its context ID and
+// the ID of the message it answers
+type correlatedResponse struct {
+	message *pb.Message
+	keys    []string
+}
+
+// ResponseCorrelator subscribes to the messages of an agent and hands the agent
+// responses it receives to the callers waiting for them. Responses are matched
+// by their context ID or by the original_message_id metadata set by responders,
+// so REPL-style clients can wait for the answer to the message they published.
+type ResponseCorrelator struct {
+	Client  *AgentHubClient
+	AgentID string
+	// Accept, when set, selects which agent messages are responses, for instance
+	// to leave out streamed chat deltas. By default every agent message is.
+	Accept func(*pb.Message) bool
+	// Reconnect controls re-subscription when the response stream breaks
+	Reconnect ReconnectPolicy
+
+	mu        sync.Mutex
+	responses map[string][]*correlatedResponse
+	order     []*correlatedResponse
+	arrived   chan struct{} // closed and replaced whenever a response arrives or the stream ends
+	streamErr error
+}
+
+// NewResponseCorrelator creates a correlator for the responses sent to agentID
+func NewResponseCorrelator(client *AgentHubClient, agentID string) *ResponseCorrelator {
+	return &ResponseCorrelator{
+		Client:    client,
+		AgentID:   agentID,
+		Reconnect: DefaultReconnectPolicy(),
+		responses: make(map[string][]*correlatedResponse),
+		arrived:   make(chan struct{}),
+	}
+}
+
+// Start subscribes to the agent messages and collects responses in the
+// background until ctx is done, re-subscribing according to Reconnect when the
+// stream breaks. Start before publishing requests, so that fast responses are
+// not missed.
+func (c *ResponseCorrelator) Start(ctx context.Context) error {
+	subscription := NewMessageSubscription(c.Client, c.AgentID)
+	subscription.Reconnect = c.Reconnect
+	err := subscription.Start(ctx, func(ctx context.Context, event *pb.AgentEvent) {
+		message := event.GetMessage()
+		if message.GetRole() != pb.Role_ROLE_AGENT || (c.Accept != nil && !c.Accept(message)) {
+			return
+		}
+		c.add(message)
+	}, func(err error) {
+		if ctx.Err() == nil {
+			c.Client.Logger.ErrorContext(ctx, "Response stream ended", "agent_id", c.AgentID, "error", err)
+		}
+		c.close(err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to messages: %w", err)
+	}
+	return nil
+}
+
+// WaitFor returns the oldest response not yet handed out whose context ID, or
+// original_message_id, is id. It waits at most timeout (forever when timeout is
+// not positive) and returns ErrResponseTimeout when no response arrives in time.
+func (c *ResponseCorrelator) WaitFor(ctx context.Context, id string, timeout time.Duration) (*pb.Message, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		c.mu.Lock()
+		if message := c.take(id); message != nil {
+			c.mu.Unlock()
+			return message, nil
+		}
+		if c.streamErr != nil {
+			err := c.streamErr
+			c.mu.Unlock()
+			return nil, fmt.Errorf("response stream ended: %w", err)
+		}
+		arrived := c.arrived
+		c.mu.Unlock()
+
+		select {
+		case <-arrived:
+		case <-expired:
+			return nil, ErrResponseTimeout
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// add indexes a response and wakes up the waiting callers
+func (c *ResponseCorrelator) add(message *pb.Message) {
+	response := &correlatedResponse{message: message}
+	if contextID := message.GetContextId(); contextID != "" {
+		response.keys = append(response.keys, contextID)
+	}
+	if originalID, ok := MetadataString(message.GetMetadata(), "original_message_id"); ok && originalID != "" && originalID != message.GetContextId() {
+		response.keys = append(response.keys, originalID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range response.keys {
+		c.responses[key] = append(c.responses[key], response)
+	}
+	c.order = append(c.order, response)
+	if len(c.order) > maxPendingResponses {
+		c.evict(c.order[0])
+		c.order = c.order[1:]
+	}
+
+	close(c.arrived)
+	c.arrived = make(chan struct{})
+}
+
+// take hands out the oldest pending response indexed under key. Callers hold c.mu.
+func (c *ResponseCorrelator) take(key string) *pb.Message {
+	pending := c.responses[key]
+	if len(pending) == 0 {
+		return nil
+	}
+	c.evict(pending[0])
+	return pending[0].message
+}
+
+// evict removes a response from the indexes. Callers hold c.mu.
+func (c *ResponseCorrelator) evict(response *correlatedResponse) {
+	for _, key := range response.keys {
+		var remaining []*correlatedResponse
+		for _, r := range c.responses[key] {
+			if r != response {
+				remaining = append(remaining, r)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(c.responses, key)
+		} else {
+			c.responses[key] = remaining
+		}
+	}
+}
+
+// close records why the response stream ended and wakes up the waiting callers
+func (c *ResponseCorrelator) close(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.streamErr = err
+	close(c.arrived)
+	c.arrived = make(chan struct{})
+}
@@ -0,0 +1,73 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestResponseCorrelator_WaitFor(t *testing.T) {
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	correlator := NewResponseCorrelator(client, "repl")
+	if err := correlator.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.agentMu.RLock()
+		subscribed := len(service.messageSubscribers["repl"]) > 0
+		service.agentMu.RUnlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the correlator subscription")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	publish := func(messageID, contextID string, role pb.Role, originalID string) {
+		message := &pb.Message{
+			MessageId: messageID,
+			ContextId: contextID,
+			Role:      role,
+			Content:   []*pb.Part{{Part: &pb.Part_Text{Text: messageID}}},
+		}
+		if originalID != "" {
+			message.Metadata, _ = structpb.NewStruct(map[string]any{"original_message_id": originalID})
+		}
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: message,
+			Routing: &pb.AgentEventMetadata{FromAgentId: "responder", ToAgentId: "repl", EventType: "a2a.message.chat_response"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+	publish("user_echo", "ctx_1", pb.Role_ROLE_USER, "")
+	publish("resp_1", "ctx_1", pb.Role_ROLE_AGENT, "")
+	publish("resp_2", "ctx_2", pb.Role_ROLE_AGENT, "req_2")
+
+	response, err := correlator.WaitFor(ctx, "req_2", 2*time.Second)
+	if err != nil || response.GetMessageId() != "resp_2" {
+		t.Fatalf("Expected resp_2 by original message ID, got %v (%v)", response.GetMessageId(), err)
+	}
+	response, err = correlator.WaitFor(ctx, "ctx_1", 2*time.Second)
+	if err != nil || response.GetMessageId() != "resp_1" {
+		t.Fatalf("Expected resp_1 by context ID, got %v (%v)", response.GetMessageId(), err)
+	}
+
+	// Each response is handed out once, whichever key it was matched by
+	if _, err := correlator.WaitFor(ctx, "ctx_2", 50*time.Millisecond); !errors.Is(err, ErrResponseTimeout) {
+		t.Errorf("Expected ErrResponseTimeout for a consumed response, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package agenthub
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DefaultDeadLetterBufferSize is the number of undeliverable events kept for inspection
+const DefaultDeadLetterBufferSize = 100
+
+// DeadLetterHandler receives events that no subscriber could receive.
+// It runs in its own goroutine, so it may publish back to the broker.
+type DeadLetterHandler func(ctx context.Context, event *pb.AgentEvent)
+
+// WithDeadLetterHandler sets a handler for events routed to no subscriber.
+// Such events are also kept in the service dead-letter buffer (see DeadLetters).
+func WithDeadLetterHandler(handler DeadLetterHandler) ServiceOption {
+	return func(s *AgentHubService) {
+		s.deadLetterHandler = handler
+	}
+}
+
+// DeadLetterBuffer is a ring buffer keeping the last dead-lettered events
+type DeadLetterBuffer struct {
+	mu     sync.Mutex
+	events []*pb.AgentEvent
+	next   int
+	full   bool
+}
+
+// NewDeadLetterBuffer creates a buffer keeping the last size events, or nil
+// (keeping nothing) when size is not positive
+func NewDeadLetterBuffer(size int) *DeadLetterBuffer {
+	if size <= 0 {
+		return nil
+	}
+	return &DeadLetterBuffer{events: make([]*pb.AgentEvent, size)}
+}
+
+// Handle records an event, evicting the oldest one when the buffer is full.
+// It satisfies DeadLetterHandler.
+func (b *DeadLetterBuffer) Handle(_ context.Context, event *pb.AgentEvent) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events[b.next] = event
+	b.next = (b.next + 1) % len(b.events)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Events returns copies of the recorded events, oldest first
+func (b *DeadLetterBuffer) Events() []*pb.AgentEvent {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var ordered []*pb.AgentEvent
+	if b.full {
+		ordered = append(ordered, b.events[b.next:]...)
+	}
+	ordered = append(ordered, b.events[:b.next]...)
+
+	events := make([]*pb.AgentEvent, len(ordered))
+	for i, event := range ordered {
+		events[i] = proto.Clone(event).(*pb.AgentEvent)
+	}
+	return events
+}
+
+// DeadLetters returns the last events that were routed to no subscriber, oldest first.
+// It is meant for debugging undelivered traffic.
+func (s *AgentHubService) DeadLetters() []*pb.AgentEvent {
+	return s.deadLetters.Events()
+}
+
+// deadLetter records an event that had no subscriber and hands it to the dead-letter handler
+func (s *AgentHubService) deadLetter(ctx context.Context, event *pb.AgentEvent) {
+	s.Server.MetricsManager.IncrementEventsDeadLettered(ctx, event.GetRouting().GetEventType())
+	s.deadLetters.Handle(ctx, event)
+	if s.deadLetterHandler != nil {
+		go s.deadLetterHandler(context.WithoutCancel(ctx), event)
+	}
+}
@@ -0,0 +1,56 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_DeadLetters(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.ServerAddr = ":0"
+	config.DeadLetterBufferSize = 2
+	server, err := NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	handled := make(chan *pb.AgentEvent, 3)
+	service := NewAgentHubService(server, WithDeadLetterHandler(func(ctx context.Context, event *pb.AgentEvent) {
+		handled <- event
+	}))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: fmt.Sprintf("msg-%d", i), Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "tester", ToAgentId: "nobody"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-handled:
+		case <-time.After(time.Second):
+			t.Fatalf("Dead-letter handler received %d events, expected 3", i)
+		}
+	}
+
+	deadLetters := service.DeadLetters()
+	if len(deadLetters) != 2 {
+		t.Fatalf("Expected the 2 most recent dead letters, got %d", len(deadLetters))
+	}
+	if id := deadLetters[0].GetMessage().GetMessageId(); id != "msg-1" {
+		t.Errorf("Expected oldest retained dead letter to be msg-1, got %s", id)
+	}
+	if id := deadLetters[1].GetMessage().GetMessageId(); id != "msg-2" {
+		t.Errorf("Expected newest dead letter to be msg-2, got %s", id)
+	}
+}
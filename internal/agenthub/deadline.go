@@ -0,0 +1,27 @@
+package agenthub
+
+import (
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// MetadataKeyDeadline is the task metadata field holding the RFC 3339 time after
+// which the publisher no longer needs the task result
+const MetadataKeyDeadline = "deadline"
+
+// ErrorDeadlineExceeded is the failure reported for tasks received after their deadline
+const ErrorDeadlineExceeded = "deadline exceeded"
+
+// TaskDeadline returns the deadline set by the task publisher, if any
+func TaskDeadline(task *pb.Task) (time.Time, bool) {
+	value, ok := MetadataString(task.GetMetadata(), MetadataKeyDeadline)
+	if !ok {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
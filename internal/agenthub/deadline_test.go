@@ -0,0 +1,64 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestA2ATaskSubscriber_Deadline(t *testing.T) {
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+	publisher := NewA2ATaskPublisher(client, "test", "", nil)
+	ctx := context.Background()
+
+	var handled []string
+	subscriber := NewA2ATaskSubscriber(client, "worker")
+	handler := func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		handled = append(handled, task.GetId())
+		<-ctx.Done()
+		return nil, pb.TaskState_TASK_STATE_FAILED, ctx.Err().Error()
+	}
+	subscriber.RegisterTaskHandler("expired", handler)
+	subscriber.RegisterTaskHandler("bounded", handler)
+
+	process := func(taskType string, deadline time.Time) *pb.Task {
+		published, err := publisher.PublishTask(ctx, &A2APublishTaskRequest{
+			TaskType:         taskType,
+			RequesterAgentID: "requester",
+			ResponderAgentID: "worker",
+			Deadline:         deadline,
+		})
+		if err != nil {
+			t.Fatalf("PublishTask failed: %v", err)
+		}
+		task, err := service.GetTask(ctx, &pb.GetTaskRequest{TaskId: published.GetId()})
+		if err != nil {
+			t.Fatalf("GetTask failed: %v", err)
+		}
+		subscriber.processTask(ctx, task)
+		task, _ = service.GetTask(ctx, &pb.GetTaskRequest{TaskId: published.GetId()})
+		return task
+	}
+
+	// Expired tasks fail without reaching the handler
+	task := process("expired", time.Now().Add(-time.Second))
+	if len(handled) != 0 {
+		t.Errorf("Expected the handler not to run for an expired task, ran for %v", handled)
+	}
+	if task.GetStatus().GetState() != pb.TaskState_TASK_STATE_FAILED {
+		t.Errorf("Expected an expired task to fail, got %s", task.GetStatus().GetState())
+	}
+
+	// Handlers are cancelled at the deadline
+	start := time.Now()
+	process("bounded", time.Now().Add(50*time.Millisecond))
+	if len(handled) != 1 {
+		t.Errorf("Expected the handler to run once, ran for %v", handled)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the handler to be cancelled at the deadline, took %s", elapsed)
+	}
+}
@@ -0,0 +1,84 @@
+package agenthub
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// messageDedup remembers the event ID assigned to each recently published message ID
+type messageDedup struct {
+	mu      sync.Mutex
+	window  time.Duration
+	entries map[string]*list.Element
+	// order holds *dedupEntry oldest first; entries share the window, so expiry follows insertion order
+	order *list.List
+}
+
+type dedupEntry struct {
+	messageID string
+	eventID   string
+	expires   time.Time
+}
+
+// newMessageDedup returns a cache deduplicating message IDs seen within window,
+// or nil (disabled) when window is not positive
+func newMessageDedup(window time.Duration) *messageDedup {
+	if window <= 0 {
+		return nil
+	}
+	return &messageDedup{
+		window:  window,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// claim records eventID for messageID unless the message was already published within
+// the window, in which case it returns the original event ID and true
+func (d *messageDedup) claim(messageID, eventID string, now time.Time) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pruneExpired(now)
+	if elem, ok := d.entries[messageID]; ok {
+		return elem.Value.(*dedupEntry).eventID, true
+	}
+	d.entries[messageID] = d.order.PushBack(&dedupEntry{
+		messageID: messageID,
+		eventID:   eventID,
+		expires:   now.Add(d.window),
+	})
+	return "", false
+}
+
+// forget drops messageID so that a retry after a failed publish is routed again
+func (d *messageDedup) forget(messageID string) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if elem, ok := d.entries[messageID]; ok {
+		d.order.Remove(elem)
+		delete(d.entries, messageID)
+	}
+}
+
+// pruneExpired drops the entries whose window has elapsed. Callers must hold mu.
+func (d *messageDedup) pruneExpired(now time.Time) {
+	for elem := d.order.Front(); elem != nil; elem = d.order.Front() {
+		entry := elem.Value.(*dedupEntry)
+		if now.Before(entry.expires) {
+			return
+		}
+		d.order.Remove(elem)
+		delete(d.entries, entry.messageID)
+	}
+}
@@ -0,0 +1,71 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_PublishMessage_Dedup(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.ServerAddr = ":0"
+	config.DedupWindow = time.Minute
+	server, err := NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	service := NewAgentHubService(server)
+	ctx := context.Background()
+
+	subChan := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.messageSubscribers["receiver"] = []chan *pb.AgentEvent{subChan}
+	service.agentMu.Unlock()
+
+	req := &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "msg-1", Role: pb.Role_ROLE_USER},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "sender", ToAgentId: "receiver"},
+	}
+	first, err := service.PublishMessage(ctx, req)
+	if err != nil {
+		t.Fatalf("PublishMessage failed: %v", err)
+	}
+	retry, err := service.PublishMessage(ctx, req)
+	if err != nil {
+		t.Fatalf("Republishing failed: %v", err)
+	}
+	if !retry.GetSuccess() || retry.GetEventId() != first.GetEventId() {
+		t.Errorf("Expected the retry to return the original event %s, got %+v", first.GetEventId(), retry)
+	}
+
+	select {
+	case <-subChan:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the message")
+	}
+	select {
+	case evt := <-subChan:
+		t.Errorf("Expected the duplicate not to be routed, got %s", evt.GetEventId())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestMessageDedup_Window(t *testing.T) {
+	dedup := newMessageDedup(time.Second)
+	now := time.Now()
+	if _, dup := dedup.claim("msg", "evt-1", now); dup {
+		t.Fatal("Expected the first publish not to be a duplicate")
+	}
+	if id, dup := dedup.claim("msg", "evt-2", now.Add(500*time.Millisecond)); !dup || id != "evt-1" {
+		t.Errorf("Expected a duplicate of evt-1 within the window, got %q, %v", id, dup)
+	}
+	if _, dup := dedup.claim("msg", "evt-3", now.Add(2*time.Second)); dup {
+		t.Error("Expected the message ID to be forgotten after the window")
+	}
+	if _, dup := newMessageDedup(0).claim("msg", "evt", now); dup {
+		t.Error("Expected a zero window to disable deduplication")
+	}
+}
@@ -0,0 +1,71 @@
+package agenthub
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultDeliveryReportWait is how long PublishMessage waits for the sends it
+// reports when no wait is configured
+const DefaultDeliveryReportWait = 100 * time.Millisecond
+
+// WithDeliveryReportWait sets how long PublishMessage waits for the per-subscriber
+// sends it reports, overriding GRPCConfig.DeliveryReportWait
+func WithDeliveryReportWait(wait time.Duration) ServiceOption {
+	return func(s *AgentHubService) {
+		s.deliveryReportWait = wait
+	}
+}
+
+// deliveryReport collects the outcome of the per-subscriber sends of a routed event
+type deliveryReport struct {
+	mu        sync.Mutex
+	pending   int
+	delivered int
+	dropped   int
+	done      chan struct{} // closed once every send has finished
+}
+
+func newDeliveryReport(subscribers int) *deliveryReport {
+	r := &deliveryReport{pending: subscribers, done: make(chan struct{})}
+	if subscribers == 0 {
+		close(r.done)
+	}
+	return r
+}
+
+// record accounts for a finished send
+func (r *deliveryReport) record(delivered bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == 0 {
+		return
+	}
+	if delivered {
+		r.delivered++
+	} else {
+		r.dropped++
+	}
+	r.pending--
+	if r.pending == 0 {
+		close(r.done)
+	}
+}
+
+// wait waits at most timeout, or until ctx is done, for every send to finish, and
+// returns the sends delivered, dropped and still pending
+func (r *deliveryReport) wait(ctx context.Context, timeout time.Duration) (delivered, dropped, pending int) {
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-r.done:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.delivered, r.dropped, r.pending
+}
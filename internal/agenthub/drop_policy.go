@@ -0,0 +1,136 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DropPolicy controls what the broker does when a subscriber channel is full
+type DropPolicy int
+
+const (
+	// DropPolicyTimeoutDrop waits up to the delivery timeout, then drops the event
+	DropPolicyTimeoutDrop DropPolicy = iota
+	// DropPolicyBlock waits until the subscriber has room for the event
+	DropPolicyBlock
+	// DropPolicyDropNewest drops the incoming event immediately
+	DropPolicyDropNewest
+	// DropPolicyDropOldest evicts the oldest buffered event to make room for the incoming one
+	DropPolicyDropOldest
+)
+
+// DefaultDeliveryTimeout is how long DropPolicyTimeoutDrop waits for a full subscriber
+// when no send timeout is configured
+const DefaultDeliveryTimeout = 5 * time.Second
+
+// DropReasonBufferFull is reported when DropNewest or DropOldest discards an event
+const DropReasonBufferFull = "buffer_full"
+
+// String returns the configuration name of the policy
+func (p DropPolicy) String() string {
+	switch p {
+	case DropPolicyBlock:
+		return "block"
+	case DropPolicyDropNewest:
+		return "drop_newest"
+	case DropPolicyDropOldest:
+		return "drop_oldest"
+	default:
+		return "timeout_drop"
+	}
+}
+
+// ParseDropPolicy parses a policy name as accepted by AGENTHUB_DROP_POLICY
+func ParseDropPolicy(name string) (DropPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "timeout_drop":
+		return DropPolicyTimeoutDrop, nil
+	case "block":
+		return DropPolicyBlock, nil
+	case "drop_newest":
+		return DropPolicyDropNewest, nil
+	case "drop_oldest":
+		return DropPolicyDropOldest, nil
+	default:
+		return DropPolicyTimeoutDrop, fmt.Errorf("unknown drop policy %q", name)
+	}
+}
+
+// ServiceOption customizes an AgentHubService at construction time
+type ServiceOption func(*AgentHubService)
+
+// WithDropPolicy sets the behavior of the broker when a subscriber channel is full,
+// overriding GRPCConfig.DropPolicy
+func WithDropPolicy(policy DropPolicy) ServiceOption {
+	return func(s *AgentHubService) {
+		s.dropPolicy = policy
+	}
+}
+
+// WithSendTimeout sets how long DropPolicyTimeoutDrop waits for a full subscriber
+// before dropping an event, overriding GRPCConfig.SendTimeout
+func WithSendTimeout(timeout time.Duration) ServiceOption {
+	return func(s *AgentHubService) {
+		s.sendTimeout = timeout
+	}
+}
+
+// sendWithPolicy delivers evt to ch according to the service drop policy.
+// It returns the drop reason, or an empty string if the event was delivered.
+// A send waiting for room gives up when the subscription ends.
+func (s *AgentHubService) sendWithPolicy(ctx context.Context, ch chan *pb.AgentEvent, evt *pb.AgentEvent) string {
+	stop := s.subscriberStops.get(ch)
+	switch s.dropPolicy {
+	case DropPolicyBlock:
+		select {
+		case ch <- evt:
+			return ""
+		case <-stop:
+			return DropReasonContextCancelled
+		}
+
+	case DropPolicyDropNewest:
+		select {
+		case ch <- evt:
+			return ""
+		default:
+			return DropReasonBufferFull
+		}
+
+	case DropPolicyDropOldest:
+		select {
+		case ch <- evt:
+			return ""
+		default:
+		}
+		// Make room by evicting the oldest buffered event, then retry once
+		select {
+		case evicted := <-ch:
+			s.Server.MetricsManager.IncrementEventsDropped(ctx, evicted.GetRouting().GetEventType(), DropReasonBufferFull)
+			s.Server.Logger.WarnContext(ctx, "Evicted oldest event from full subscriber",
+				"event_id", evicted.GetEventId(),
+			)
+		default:
+		}
+		select {
+		case ch <- evt:
+			return ""
+		default:
+			return DropReasonBufferFull
+		}
+
+	default:
+		select {
+		case ch <- evt:
+			return ""
+		case <-time.After(s.sendTimeout):
+			return DropReasonTimeout
+		case <-stop:
+			return DropReasonContextCancelled
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestNewAgentHubService_SubscriberBufferSize(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.SubscriberBufferSize = 0
+	server, err := NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if service := NewAgentHubService(server); service.bufferSize != DefaultSubscriberBufferSize {
+		t.Errorf("Expected default buffer size %d, got %d", DefaultSubscriberBufferSize, service.bufferSize)
+	}
+
+	server.Config.SubscriberBufferSize = 256
+	if service := NewAgentHubService(server); service.bufferSize != 256 {
+		t.Errorf("Expected buffer size 256, got %d", service.bufferSize)
+	}
+
+	config.SubscriberBufferSize = -1
+	if _, err := NewAgentHubServer(config); err == nil {
+		t.Error("Expected negative buffer size to be rejected")
+	}
+}
+
+func TestAgentHubService_DropPolicy(t *testing.T) {
+	ctx := context.Background()
+	newEvent := func(id string) *pb.AgentEvent {
+		return &pb.AgentEvent{EventId: id}
+	}
+
+	tests := []struct {
+		policy     DropPolicy
+		wantReason string
+		wantQueued string
+	}{
+		{policy: DropPolicyDropNewest, wantReason: DropReasonBufferFull, wantQueued: "old"},
+		{policy: DropPolicyDropOldest, wantReason: "", wantQueued: "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			service := NewAgentHubService(newTestAgentHubService().Server, WithDropPolicy(tt.policy))
+			ch := make(chan *pb.AgentEvent, 1)
+			ch <- newEvent("old")
+
+			if reason := service.sendWithPolicy(ctx, ch, newEvent("new")); reason != tt.wantReason {
+				t.Errorf("Expected drop reason %q, got %q", tt.wantReason, reason)
+			}
+			if got := (<-ch).GetEventId(); got != tt.wantQueued {
+				t.Errorf("Expected %s to be queued, got %s", tt.wantQueued, got)
+			}
+		})
+	}
+
+	if _, err := ParseDropPolicy("sometimes"); err == nil {
+		t.Error("Expected unknown drop policy to be rejected")
+	}
+}
+
+func TestAgentHubService_SendTimeout(t *testing.T) {
+	server := newTestAgentHubService().Server
+	if service := NewAgentHubService(server); service.sendTimeout != DefaultDeliveryTimeout {
+		t.Errorf("Expected default send timeout %s, got %s", DefaultDeliveryTimeout, service.sendTimeout)
+	}
+
+	server.Config.SendTimeout = 50 * time.Millisecond
+	service := NewAgentHubService(server)
+	ch := make(chan *pb.AgentEvent, 1)
+	ch <- &pb.AgentEvent{EventId: "old"}
+
+	start := time.Now()
+	if reason := service.sendWithPolicy(context.Background(), ch, &pb.AgentEvent{EventId: "new"}); reason != DropReasonTimeout {
+		t.Errorf("Expected drop reason %q, got %q", DropReasonTimeout, reason)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected the configured timeout to apply, waited %s", elapsed)
+	}
+
+	if service := NewAgentHubService(server, WithSendTimeout(time.Second)); service.sendTimeout != time.Second {
+		t.Errorf("Expected option to override send timeout, got %s", service.sendTimeout)
+	}
+}
@@ -0,0 +1,96 @@
+package agenthub
+
+import (
+	"strings"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// Event type patterns split event types into dot-separated segments, MQTT-style:
+// "*" or "+" matches exactly one segment and a trailing "#" matches any number of
+// remaining segments, including none. "a2a.task.*" matches "a2a.task.translation",
+// and "a2a.#" matches "a2a" and every event type below it.
+const eventTypeSeparator = "."
+
+// eventTypeFilter is the set of event types, or patterns, requested by a subscriber.
+// A nil filter accepts every event type.
+type eventTypeFilter struct {
+	exact    map[string]bool
+	patterns [][]string
+}
+
+// newEventTypeFilter builds a filter from the event types of a subscription request
+func newEventTypeFilter(eventTypes []string) *eventTypeFilter {
+	if len(eventTypes) == 0 {
+		return nil
+	}
+	filter := &eventTypeFilter{exact: make(map[string]bool, len(eventTypes))}
+	for _, eventType := range eventTypes {
+		if isEventTypePattern(eventType) {
+			filter.patterns = append(filter.patterns, strings.Split(eventType, eventTypeSeparator))
+		} else {
+			filter.exact[eventType] = true
+		}
+	}
+	return filter
+}
+
+// accepts reports whether events of the given type should be delivered
+func (f *eventTypeFilter) accepts(eventType string) bool {
+	if f == nil || f.exact[eventType] {
+		return true
+	}
+	if len(f.patterns) == 0 {
+		return false
+	}
+	segments := strings.Split(eventType, eventTypeSeparator)
+	for _, pattern := range f.patterns {
+		if matchEventType(pattern, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEventTypePattern reports whether an event type contains wildcard segments
+func isEventTypePattern(eventType string) bool {
+	for _, segment := range strings.Split(eventType, eventTypeSeparator) {
+		if segment == "*" || segment == "+" || segment == "#" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEventType reports whether the segments of an event type match a pattern
+func matchEventType(pattern, segments []string) bool {
+	for i, part := range pattern {
+		if part == "#" {
+			// "#" only acts as a wildcard in last position
+			return i == len(pattern)-1
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if part != "*" && part != "+" && part != segments[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
+
+// eventSubscription is an agent event stream together with the event types it asked for
+type eventSubscription struct {
+	ch     chan *pb.AgentEvent
+	filter *eventTypeFilter
+}
+
+// appendAcceptingChannels appends the channels of the subscriptions that accept the event type
+func appendAcceptingChannels(channels []chan *pb.AgentEvent, subs []*eventSubscription, eventType string) []chan *pb.AgentEvent {
+	for _, sub := range subs {
+		if sub.filter.accepts(eventType) {
+			channels = append(channels, sub.ch)
+		}
+	}
+	return channels
+}
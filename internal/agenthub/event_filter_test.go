@@ -0,0 +1,129 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_RouteEvent_EventTypeFilter(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	filtered := make(chan *pb.AgentEvent, 10)
+	unfiltered := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.eventSubscribers["cortex"] = []*eventSubscription{
+		{ch: filtered, filter: newEventTypeFilter([]string{"agent.registered"})},
+		{ch: unfiltered},
+	}
+	service.agentMu.Unlock()
+
+	for _, eventType := range []string{"agent.registered", "task_completion"} {
+		err := service.routeEvent(ctx, &pb.AgentEvent{
+			EventId: eventType,
+			Payload: &pb.AgentEvent_AgentCard{AgentCard: &pb.AgentCardEvent{AgentId: "agent1"}},
+			Routing: &pb.AgentEventMetadata{EventType: eventType},
+		})
+		if err != nil {
+			t.Fatalf("routeEvent failed: %v", err)
+		}
+	}
+
+	waitForEvents := func(ch chan *pb.AgentEvent, want int) []string {
+		var got []string
+		deadline := time.After(500 * time.Millisecond)
+		for len(got) < want {
+			select {
+			case evt := <-ch:
+				got = append(got, evt.GetEventId())
+			case <-deadline:
+				return got
+			}
+		}
+		return got
+	}
+
+	if got := waitForEvents(unfiltered, 2); len(got) != 2 {
+		t.Errorf("Expected unfiltered subscriber to receive 2 events, got %v", got)
+	}
+	if got := waitForEvents(filtered, 2); len(got) != 1 || got[0] != "agent.registered" {
+		t.Errorf("Expected filtered subscriber to receive only agent.registered, got %v", got)
+	}
+}
+
+func TestEventTypeFilter_Patterns(t *testing.T) {
+	tests := []struct {
+		eventTypes []string
+		eventType  string
+		want       bool
+	}{
+		{nil, "anything", true},
+		{[]string{"a2a.task.translation"}, "a2a.task.translation", true},
+		{[]string{"a2a.task.translation"}, "a2a.task.summary", false},
+		{[]string{"a2a.task.*"}, "a2a.task.translation", true},
+		{[]string{"a2a.task.+"}, "a2a.task.translation", true},
+		{[]string{"a2a.task.*"}, "a2a.task", false},
+		{[]string{"a2a.task.*"}, "a2a.task.translation.done", false},
+		{[]string{"a2a.*.completed"}, "a2a.task.completed", true},
+		{[]string{"a2a.message.#"}, "a2a.message", true},
+		{[]string{"a2a.message.#"}, "a2a.message.chat.response", true},
+		{[]string{"a2a.message.#"}, "a2a.task.translation", false},
+		{[]string{"#"}, "agent.registered", true},
+		{[]string{"a2a.#.done"}, "a2a.task.done", false},
+		{[]string{"agent.registered", "a2a.task.*"}, "agent.registered", true},
+	}
+
+	for _, tt := range tests {
+		if got := newEventTypeFilter(tt.eventTypes).accepts(tt.eventType); got != tt.want {
+			t.Errorf("filter %v accepts(%q) = %v, want %v", tt.eventTypes, tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestAgentHubService_RouteEvent_ExcludeSelf(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	selfChan := make(chan *pb.AgentEvent, 10)
+	otherChan := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.messageSubscribers["cortex"] = []chan *pb.AgentEvent{selfChan}
+	service.messageSubscribers["chat_cli"] = []chan *pb.AgentEvent{otherChan}
+	service.agentMu.Unlock()
+
+	for _, excludeSelf := range []bool{true, false} {
+		err := service.routeEvent(ctx, &pb.AgentEvent{
+			EventId: fmt.Sprintf("exclude_self_%t", excludeSelf),
+			Payload: &pb.AgentEvent_Message{Message: &pb.Message{MessageId: "msg-1"}},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "cortex", EventType: "message", ExcludeSelf: excludeSelf},
+		})
+		if err != nil {
+			t.Fatalf("routeEvent failed: %v", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-otherChan:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for broadcasts at the other agent")
+		}
+	}
+	select {
+	case evt := <-selfChan:
+		if evt.GetEventId() != "exclude_self_false" {
+			t.Errorf("Expected the publisher to receive only its non-excluded broadcast, got %s", evt.GetEventId())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the non-excluded broadcast at the publisher")
+	}
+	select {
+	case evt := <-selfChan:
+		t.Errorf("Expected the publisher not to receive its excluded broadcast, got %s", evt.GetEventId())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,155 @@
+package agenthub
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// eventLogEntry is a routed event together with its position in the log
+type eventLogEntry struct {
+	seq   uint64
+	at    time.Time
+	event *pb.AgentEvent
+}
+
+// eventLog retains the most recent routed events so that subscribers can
+// resume from a cursor, or replay from a point in time, after a reconnect.
+type eventLog struct {
+	mu        sync.Mutex
+	capacity  int
+	retention time.Duration
+	nextSeq   uint64
+	entries   []eventLogEntry
+	// evictedAt is when the newest evicted event was routed
+	evictedAt time.Time
+}
+
+// newEventLog creates an event log keeping at most capacity events, each for
+// at most retention. A zero capacity disables retention; a zero retention keeps
+// events until they are pushed out by newer ones.
+func newEventLog(capacity int, retention time.Duration) *eventLog {
+	return &eventLog{
+		capacity:  capacity,
+		retention: retention,
+		nextSeq:   1,
+	}
+}
+
+// enabled reports whether the log retains events
+func (l *eventLog) enabled() bool {
+	return l != nil && l.capacity > 0
+}
+
+// append records the event and stamps it with its cursor
+func (l *eventLog) append(event *pb.AgentEvent) {
+	if !l.enabled() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seq := l.nextSeq
+	l.nextSeq++
+	event.Cursor = encodeCursor(seq)
+
+	now := time.Now()
+	l.entries = append(l.entries, eventLogEntry{seq: seq, at: now, event: event})
+	if len(l.entries) > l.capacity {
+		l.evict(len(l.entries) - l.capacity)
+	}
+	l.expire(now)
+}
+
+// expire drops the entries older than the retention. Callers hold l.mu.
+func (l *eventLog) expire(now time.Time) {
+	if l.retention <= 0 {
+		return
+	}
+	cutoff := now.Add(-l.retention)
+	expired := 0
+	for expired < len(l.entries) && l.entries[expired].at.Before(cutoff) {
+		expired++
+	}
+	if expired > 0 {
+		l.evict(expired)
+	}
+}
+
+// evict drops the n oldest entries. Callers hold l.mu. The entries are resliced
+// rather than copied, so that the log only copies its events when append outgrows
+// the backing array: the cost of eviction is amortized over the appends.
+func (l *eventLog) evict(n int) {
+	l.evictedAt = l.entries[n-1].at
+	clear(l.entries[:n]) // release the evicted events to the garbage collector
+	l.entries = l.entries[n:]
+}
+
+// since returns the retained events positioned after the cursor, oldest first.
+// truncated is true when events after the cursor have already been evicted.
+func (l *eventLog) since(seq uint64) (entries []eventLogEntry, truncated bool) {
+	if !l.enabled() {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(time.Now())
+
+	for _, entry := range l.entries {
+		if entry.seq > seq {
+			entries = append(entries, entry)
+		}
+	}
+	oldest := l.nextSeq
+	if len(l.entries) > 0 {
+		oldest = l.entries[0].seq
+	}
+	if oldest > seq+1 {
+		truncated = true
+	}
+	return entries, truncated
+}
+
+// sinceTime returns the retained events routed at or after t, oldest first.
+// truncated is true when events routed after t have already been evicted.
+func (l *eventLog) sinceTime(t time.Time) (entries []eventLogEntry, truncated bool) {
+	if !l.enabled() {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(time.Now())
+
+	for _, entry := range l.entries {
+		if !entry.at.Before(t) {
+			entries = append(entries, entry)
+		}
+	}
+	if !l.evictedAt.IsZero() && !l.evictedAt.Before(t) {
+		truncated = true
+	}
+	return entries, truncated
+}
+
+// encodeCursor turns a log sequence number into an opaque cursor
+func encodeCursor(seq uint64) string {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, seq)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodeCursor parses a cursor produced by encodeCursor
+func decodeCursor(cursor string) (uint64, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(buf) != 8 {
+		return 0, fmt.Errorf("invalid resume cursor %q", cursor)
+	}
+	return binary.BigEndian.Uint64(buf), nil
+}
@@ -0,0 +1,193 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_SubscribeToMessages_Resume(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.ServerAddr = ":0"
+	config.EventHistorySize = 10
+	server, err := NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	service := NewAgentHubService(server)
+
+	var events []*pb.AgentEvent
+	for i := 0; i < 3; i++ {
+		event := &pb.AgentEvent{
+			EventId: fmt.Sprintf("evt_%d", i),
+			Payload: &pb.AgentEvent_Message{Message: &pb.Message{MessageId: fmt.Sprintf("msg_%d", i)}},
+			Routing: &pb.AgentEventMetadata{ToAgentId: "agent1"},
+		}
+		if err := service.routeEvent(context.Background(), event); err != nil {
+			t.Fatalf("routeEvent failed: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &mockEventStream{ctx: ctx, events: make(chan *pb.AgentEvent, 10)}
+
+	go func() {
+		_ = service.SubscribeToMessages(&pb.SubscribeToMessagesRequest{
+			AgentId:      "agent1",
+			ResumeCursor: events[0].GetCursor(),
+		}, stream)
+	}()
+
+	for _, want := range []string{"evt_1", "evt_2"} {
+		select {
+		case evt := <-stream.events:
+			if evt.GetEventId() != want {
+				t.Fatalf("Expected replayed %s, got %s", want, evt.GetEventId())
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for replayed %s", want)
+		}
+	}
+
+	err = service.SubscribeToMessages(&pb.SubscribeToMessagesRequest{
+		AgentId:      "agent1",
+		ResumeCursor: "not-a-cursor",
+	}, stream)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for malformed cursor, got %v", err)
+	}
+}
+
+func TestAgentHubService_ReplayEvents(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.ServerAddr = ":0"
+	config.EventHistorySize = 10
+	server, err := NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	service := NewAgentHubService(server)
+
+	route := func(eventID, agentID string) {
+		event := &pb.AgentEvent{
+			EventId: eventID,
+			Payload: &pb.AgentEvent_Message{Message: &pb.Message{MessageId: eventID}},
+			Routing: &pb.AgentEventMetadata{ToAgentId: agentID},
+		}
+		if err := service.routeEvent(context.Background(), event); err != nil {
+			t.Fatalf("routeEvent failed: %v", err)
+		}
+	}
+
+	route("evt_before", "agent1")
+	time.Sleep(10 * time.Millisecond)
+	since := time.Now()
+	route("evt_other", "agent2")
+	route("evt_after", "agent1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &mockEventStream{ctx: ctx, events: make(chan *pb.AgentEvent, 10)}
+
+	go func() {
+		_ = service.ReplayEvents(&pb.ReplayEventsRequest{
+			AgentId: "agent1",
+			Since:   timestamppb.New(since),
+		}, stream)
+	}()
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case evt := <-stream.events:
+			if evt.GetEventId() != want {
+				t.Fatalf("Expected %s, got %s", want, evt.GetEventId())
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for %s", want)
+		}
+	}
+	expect("evt_after")
+
+	// Live events follow the replay once the subscription is registered
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.agentMu.RLock()
+		subscribed := len(service.eventSubscribers["agent1"]) > 0
+		service.agentMu.RUnlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the replay subscription")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	route("evt_live", "agent1")
+	expect("evt_live")
+
+	disabled := newTestAgentHubService()
+	err = disabled.ReplayEvents(&pb.ReplayEventsRequest{AgentId: "agent1"}, stream)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without event history, got %v", err)
+	}
+}
+
+func TestEventLog_Retention(t *testing.T) {
+	log := newEventLog(10, 20*time.Millisecond)
+	log.append(&pb.AgentEvent{EventId: "evt_old"})
+	time.Sleep(30 * time.Millisecond)
+	log.append(&pb.AgentEvent{EventId: "evt_new"})
+
+	entries, truncated := log.sinceTime(time.Time{})
+	if len(entries) != 1 || entries[0].event.GetEventId() != "evt_new" {
+		t.Fatalf("Expected only evt_new to be retained, got %d entries", len(entries))
+	}
+	if !truncated {
+		t.Error("Expected replay from the zero time to report evicted events")
+	}
+}
+
+func TestEventLog_Capacity(t *testing.T) {
+	log := newEventLog(3, 0)
+	for i := range 10 {
+		log.append(&pb.AgentEvent{EventId: fmt.Sprintf("evt_%d", i)})
+	}
+
+	entries, truncated := log.since(0)
+	if !truncated {
+		t.Error("Expected replay from the start to report evicted events")
+	}
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.event.GetEventId())
+	}
+	if !slices.Equal(ids, []string{"evt_7", "evt_8", "evt_9"}) {
+		t.Errorf("Expected the 3 newest events, got %v", ids)
+	}
+
+	entries, truncated = log.since(entries[0].seq)
+	if truncated || len(entries) != 2 || entries[0].event.GetEventId() != "evt_8" {
+		t.Errorf("Expected to resume after evt_7 without truncation, got %d entries, truncated %t", len(entries), truncated)
+	}
+}
+
+func BenchmarkEventLog_AppendAtCapacity(b *testing.B) {
+	log := newEventLog(10000, 0)
+	event := &pb.AgentEvent{EventId: "evt_bench"}
+	for b.Loop() {
+		log.append(event)
+	}
+}
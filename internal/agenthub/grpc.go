@@ -2,22 +2,29 @@ package agenthub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
+	appconfig "github.com/owulveryck/agenthub/internal/config"
 	"github.com/owulveryck/agenthub/internal/observability"
 )
 
 const (
 	DefaultGRPCPort   = ":50051"
 	DefaultHealthPort = "8080"
+
+	// DefaultSubscriberBufferSize is the channel buffer of a subscription when none is configured
+	DefaultSubscriberBufferSize = 10
 )
 
 // GRPCConfig holds configuration for gRPC client/server
@@ -30,20 +37,223 @@ type GRPCConfig struct {
 	HealthPort string
 	// ComponentName identifies the component (broker, publisher, subscriber)
 	ComponentName string
+
+	// TLSEnabled turns on TLS for the broker listener and agent connections
+	TLSEnabled bool
+	// TLSCertFile and TLSKeyFile hold the PEM certificate and key: the serving certificate
+	// on the broker, and the optional client certificate on agents
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSCAFile is a PEM CA bundle: agents verify the broker with it, and the broker
+	// requires client certificates signed by it (mutual TLS)
+	TLSCAFile string
+
+	// MaxMessageBytes is the maximum serialized size of a published message (0 means unlimited).
+	// It also sets the gRPC message size limits of the broker and agents.
+	MaxMessageBytes int
+	// MaxMessageParts is the maximum number of content parts in a published message (0 means unlimited)
+	MaxMessageParts int
+	// MaxPartBytes is the maximum serialized size of a single content part (0 means unlimited)
+	MaxPartBytes int
+	// MaxMetadataDepth is the maximum nesting depth of message metadata (0 means unlimited)
+	MaxMetadataDepth int
+	// MaxMetadataBytes is the maximum serialized size of message metadata (0 means unlimited)
+	MaxMetadataBytes int
+
+	// FallbackAgentID receives skill-routed messages when no registered agent provides the skill.
+	// When empty, such messages are rejected with a "no handler available" failure.
+	FallbackAgentID string
+	// RoutingRulesFile is a YAML or JSON file of content-based routing rules applied to
+	// events published without a target agent ("" disables content-based routing)
+	RoutingRulesFile string
+
+	// EventHistorySize is the number of routed events retained for subscription resumption
+	// and replay (0 disables retention)
+	EventHistorySize int
+	// EventHistoryRetention drops retained events older than this (0 keeps them until pushed out)
+	EventHistoryRetention time.Duration
+
+	// DeadLetterBufferSize is the number of events routed to no subscriber kept for inspection (0 keeps none)
+	DeadLetterBufferSize int
+
+	// SubscriberBufferSize is the channel buffer of each subscription (0 means DefaultSubscriberBufferSize)
+	SubscriberBufferSize int
+	// DropPolicy controls what happens when a subscriber channel is full
+	DropPolicy DropPolicy
+	// DefaultPriority is given to events published without a priority
+	DefaultPriority pb.Priority
+	// SendTimeout is how long the timeout drop policy waits for a full subscriber (0 means DefaultDeliveryTimeout)
+	SendTimeout time.Duration
+	// DeliveryReportWait bounds how long PublishMessage waits for the per-subscriber sends
+	// it reports in its delivery counts (0 reports without waiting)
+	DeliveryReportWait time.Duration
+	// CircuitBreakerThreshold is the number of consecutive send timeouts after which events
+	// for a subscriber are dead-lettered without waiting (0 disables circuit breaking)
+	CircuitBreakerThreshold int
+	// CircuitBreakerCooldown is how long an open circuit breaker fast-fails events before
+	// letting one through to test recovery (0 means DefaultCircuitBreakerCooldown)
+	CircuitBreakerCooldown time.Duration
+
+	// MaxContextMessages bounds the messages retained per conversation context (0 means unlimited)
+	MaxContextMessages int
+	// MaxContexts bounds the retained conversation contexts, evicting the least recently updated (0 means unlimited)
+	MaxContexts int
+	// ContextTTL drops conversation contexts not updated for this long (0 disables expiry)
+	ContextTTL time.Duration
+
+	// TaskRetention drops tasks, and their artifacts, this long after they reach a terminal state (0 keeps them forever)
+	TaskRetention time.Duration
+	// TaskGCInterval is how often expired tasks are looked for (0 means half of TaskRetention)
+	TaskGCInterval time.Duration
+
+	// PublishRateLimit is the publishes per second allowed to each agent (0 means unlimited)
+	PublishRateLimit float64
+	// PublishRateBurst is the number of publishes an agent may burst above PublishRateLimit
+	PublishRateBurst int
+
+	// DedupWindow is how long a published message ID is remembered; republishing it within
+	// the window returns the original event ID without re-routing (0 disables deduplication)
+	DedupWindow time.Duration
+
+	// ArtifactDir is the directory storing the content of file artifacts ("" disables artifact storage)
+	ArtifactDir string
+	// MaxArtifactBytes is the maximum size of a stored artifact content (0 means unlimited)
+	MaxArtifactBytes int64
+
+	// HeartbeatInterval is how often agents report liveness to the broker (0 disables heartbeats)
+	HeartbeatInterval time.Duration
+	// AgentStaleThreshold deregisters agents not heard from for this long (0 disables reaping)
+	AgentStaleThreshold time.Duration
+
+	// QueueDepthInterval is how often the subscriber queue depth metrics are sampled (0 disables sampling)
+	QueueDepthInterval time.Duration
+
+	// MaxConcurrentStreams caps the concurrent streams, and the subscriptions, of a single connection (0 means unlimited)
+	MaxConcurrentStreams int
+
+	// KeepaliveTime is how often idle connections are pinged, by agents and by the broker (0 keeps the gRPC defaults)
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout is how long a ping may go unanswered before the connection is closed
+	KeepaliveTimeout time.Duration
+	// KeepalivePermitWithoutStream keeps pinging connections without active streams
+	KeepalivePermitWithoutStream bool
+
+	// ConnectMaxRetries is the number of retries after a failed broker connection (negative retries forever)
+	ConnectMaxRetries int
+	// ConnectInitialBackoff is the delay before the first retry, doubled after each failure
+	ConnectInitialBackoff time.Duration
+	// ConnectMaxBackoff caps the delay between retries
+	ConnectMaxBackoff time.Duration
+
+	// PublisherPoolSize is the number of broker connections used for publishing (1 means a single shared connection)
+	PublisherPoolSize int
+
+	// OutboxFile is the file where agents keep task results until the broker acknowledges them ("" disables the outbox)
+	OutboxFile string
+	// OutboxRetryInterval is how often unacknowledged outbox entries are retried
+	OutboxRetryInterval time.Duration
+
+	// LogPayloads enables logging of full request and event payloads at TRACE level
+	LogPayloads bool
+	// RedactedFields are payload keys whose values are masked in payload logs
+	RedactedFields []string
+
+	// DebugEndpoint serves the /debug/broker snapshot of the broker internals on the health port
+	DebugEndpoint bool
+
+	// TelemetryExcludedMethods are gRPC methods (full or bare names) skipped by tracing and metrics
+	TelemetryExcludedMethods []string
+
+	// Observability, when set, is used instead of setting up exporters, e.g. the
+	// no-op instance of observability.NewNoopObservability in tests
+	Observability *observability.Observability
 }
 
 // NewGRPCConfig creates a new gRPC configuration from environment variables
 func NewGRPCConfig(componentName string) *GRPCConfig {
 	// Build broker address from separate host and port variables
-	brokerHost := getEnvWithDefault("AGENTHUB_BROKER_ADDR", "localhost")
+	brokerHost := appconfig.BrokerAddr()
 	brokerPort := getEnvWithDefault("AGENTHUB_BROKER_PORT", "50051")
 	brokerAddr := brokerHost + ":" + brokerPort
 
+	// Unknown policies fall back to the timeout drop, like other malformed settings
+	dropPolicy, _ := ParseDropPolicy(getEnvWithDefault("AGENTHUB_DROP_POLICY", ""))
+	defaultPriority, _ := ParsePriority(getEnvWithDefault("AGENTHUB_DEFAULT_PRIORITY", ""))
+
 	config := &GRPCConfig{
 		ComponentName: componentName,
 		ServerAddr:    getEnvWithDefault("AGENTHUB_GRPC_PORT", DefaultGRPCPort),
 		BrokerAddr:    brokerAddr,
-		HealthPort:    getEnvWithDefault("BROKER_HEALTH_PORT", DefaultHealthPort),
+		HealthPort:    appconfig.HealthPort(componentName),
+
+		TLSEnabled:  getEnvAsBoolWithDefault(appconfig.EnvTLSEnabled, false),
+		TLSCertFile: getEnvWithDefault(appconfig.EnvTLSCertFile, ""),
+		TLSKeyFile:  getEnvWithDefault(appconfig.EnvTLSKeyFile, ""),
+		TLSCAFile:   getEnvWithDefault(appconfig.EnvTLSCAFile, ""),
+
+		MaxMessageBytes: getEnvAsIntWithDefault("AGENTHUB_MAX_MESSAGE_BYTES", 0),
+		MaxMessageParts: getEnvAsIntWithDefault("AGENTHUB_MAX_MESSAGE_PARTS", 0),
+		MaxPartBytes:    getEnvAsIntWithDefault("AGENTHUB_MAX_PART_BYTES", 0),
+
+		MaxMetadataDepth: getEnvAsIntWithDefault("AGENTHUB_MAX_METADATA_DEPTH", 0),
+		MaxMetadataBytes: getEnvAsIntWithDefault("AGENTHUB_MAX_METADATA_BYTES", 0),
+
+		FallbackAgentID:  getEnvWithDefault("AGENTHUB_FALLBACK_AGENT_ID", ""),
+		RoutingRulesFile: getEnvWithDefault("AGENTHUB_ROUTING_RULES_FILE", ""),
+
+		EventHistorySize:      getEnvAsIntWithDefault("AGENTHUB_EVENT_HISTORY_SIZE", 0),
+		EventHistoryRetention: getEnvAsDurationWithDefault("AGENTHUB_EVENT_HISTORY_RETENTION", 0),
+
+		DeadLetterBufferSize: getEnvAsIntWithDefault("AGENTHUB_DEAD_LETTER_BUFFER", DefaultDeadLetterBufferSize),
+
+		SubscriberBufferSize: getEnvAsIntWithDefault("AGENTHUB_SUBSCRIBER_BUFFER", DefaultSubscriberBufferSize),
+		DropPolicy:           dropPolicy,
+		DefaultPriority:      defaultPriority,
+		SendTimeout:          getEnvAsDurationWithDefault("AGENTHUB_SEND_TIMEOUT", DefaultDeliveryTimeout),
+		DeliveryReportWait:   getEnvAsDurationWithDefault("AGENTHUB_DELIVERY_REPORT_WAIT", DefaultDeliveryReportWait),
+		MaxConcurrentStreams: getEnvAsIntWithDefault("AGENTHUB_MAX_CONCURRENT_STREAMS", 0),
+		QueueDepthInterval:   getEnvAsDurationWithDefault("AGENTHUB_QUEUE_DEPTH_INTERVAL", DefaultQueueDepthInterval),
+
+		CircuitBreakerThreshold: getEnvAsIntWithDefault("AGENTHUB_CIRCUIT_BREAKER_THRESHOLD", DefaultCircuitBreakerThreshold),
+		CircuitBreakerCooldown:  getEnvAsDurationWithDefault("AGENTHUB_CIRCUIT_BREAKER_COOLDOWN", DefaultCircuitBreakerCooldown),
+
+		PublishRateLimit: getEnvAsFloatWithDefault("AGENTHUB_PUBLISH_RATE_LIMIT", 0),
+		PublishRateBurst: getEnvAsIntWithDefault("AGENTHUB_PUBLISH_RATE_BURST", 1),
+
+		DedupWindow: getEnvAsDurationWithDefault("AGENTHUB_DEDUP_WINDOW", 0),
+
+		ArtifactDir:      getEnvWithDefault("AGENTHUB_ARTIFACT_DIR", ""),
+		MaxArtifactBytes: int64(getEnvAsIntWithDefault("AGENTHUB_MAX_ARTIFACT_BYTES", DefaultMaxArtifactBytes)),
+
+		HeartbeatInterval:   getEnvAsDurationWithDefault("AGENTHUB_HEARTBEAT_INTERVAL", DefaultHeartbeatInterval),
+		AgentStaleThreshold: getEnvAsDurationWithDefault("AGENTHUB_AGENT_STALE_THRESHOLD", 0),
+
+		MaxContextMessages: getEnvAsIntWithDefault("AGENTHUB_MAX_CONTEXT_MESSAGES", DefaultMaxContextMessages),
+		MaxContexts:        getEnvAsIntWithDefault("AGENTHUB_MAX_CONTEXTS", DefaultMaxContexts),
+		ContextTTL:         getEnvAsDurationWithDefault("AGENTHUB_CONTEXT_TTL", 0),
+
+		TaskRetention:  getEnvAsDurationWithDefault("AGENTHUB_TASK_RETENTION", 0),
+		TaskGCInterval: getEnvAsDurationWithDefault("AGENTHUB_TASK_GC_INTERVAL", 0),
+
+		KeepaliveTime:                getEnvAsDurationWithDefault("AGENTHUB_KEEPALIVE_TIME", DefaultKeepaliveTime),
+		KeepaliveTimeout:             getEnvAsDurationWithDefault("AGENTHUB_KEEPALIVE_TIMEOUT", DefaultKeepaliveTimeout),
+		KeepalivePermitWithoutStream: getEnvAsBoolWithDefault("AGENTHUB_KEEPALIVE_PERMIT_WITHOUT_STREAM", true),
+
+		ConnectMaxRetries:     getEnvAsIntWithDefault("AGENTHUB_CONNECT_MAX_RETRIES", DefaultConnectMaxRetries),
+		ConnectInitialBackoff: getEnvAsDurationWithDefault("AGENTHUB_CONNECT_INITIAL_BACKOFF", DefaultConnectInitialBackoff),
+		ConnectMaxBackoff:     getEnvAsDurationWithDefault("AGENTHUB_CONNECT_MAX_BACKOFF", DefaultConnectMaxBackoff),
+
+		PublisherPoolSize: getEnvAsIntWithDefault("AGENTHUB_PUBLISHER_POOL_SIZE", 1),
+
+		OutboxFile:          getEnvWithDefault("AGENTHUB_OUTBOX_FILE", ""),
+		OutboxRetryInterval: getEnvAsDurationWithDefault("AGENTHUB_OUTBOX_RETRY_INTERVAL", DefaultOutboxRetryInterval),
+
+		LogPayloads:    getEnvAsBoolWithDefault("AGENTHUB_LOG_PAYLOADS", false),
+		RedactedFields: getEnvAsListWithDefault("AGENTHUB_LOG_REDACT_FIELDS", DefaultRedactedFields),
+
+		DebugEndpoint: getEnvAsBoolWithDefault("AGENTHUB_DEBUG_ENDPOINT", false),
+
+		TelemetryExcludedMethods: getEnvAsListWithDefault("AGENTHUB_TELEMETRY_EXCLUDE", DefaultTelemetryExcludedMethods),
 	}
 
 	// For broker, use ServerAddr as listen address
@@ -61,16 +271,26 @@ type AgentHubServer struct {
 	HealthServer   *observability.HealthServer
 	Logger         *slog.Logger
 	Config         *GRPCConfig
+
+	initializers []func(ctx context.Context) error
+	ready        chan struct{}
 }
 
 // NewAgentHubServer creates a new gRPC server with observability
 func NewAgentHubServer(config *GRPCConfig) (*AgentHubServer, error) {
+	if config.SubscriberBufferSize < 0 {
+		return nil, fmt.Errorf("invalid subscriber buffer size %d: must be positive", config.SubscriberBufferSize)
+	}
+	if config.SendTimeout < 0 {
+		return nil, fmt.Errorf("invalid send timeout %s: must be positive", config.SendTimeout)
+	}
+
 	// Initialize observability
-	obsConfig := observability.DefaultConfig("agenthub")
-	obs, err := observability.NewObservability(obsConfig)
+	obs, traceManager, err := newObservability(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize observability: %w", err)
+		return nil, err
 	}
+	obsConfig := obs.Config
 
 	// Initialize metrics manager
 	metricsManager, err := observability.NewMetricsManager(obs.Meter)
@@ -78,16 +298,20 @@ func NewAgentHubServer(config *GRPCConfig) (*AgentHubServer, error) {
 		return nil, fmt.Errorf("failed to initialize metrics manager: %w", err)
 	}
 
-	// Initialize trace manager
-	traceManager := observability.NewTraceManager(obsConfig.ServiceName)
-
 	// Initialize health server
 	healthServer := observability.NewHealthServer(config.HealthPort, obsConfig.ServiceName, obsConfig.ServiceVersion)
+	healthServer.SetLogLevelController(obs)
 
 	// Add basic health check
-	healthServer.AddChecker("self", observability.NewBasicHealthChecker("self", func(ctx context.Context) error {
+	healthServer.AddProbeChecker("self", observability.NewBasicHealthChecker("self", func(ctx context.Context) error {
 		return nil
-	}))
+	}), observability.ProbeLiveness|observability.ProbeReadiness)
+
+	// Load TLS credentials before binding so misconfiguration fails fast
+	creds, err := serverTransportCredentials(config)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create listener
 	lis, err := net.Listen("tcp", config.ServerAddr)
@@ -96,9 +320,18 @@ func NewAgentHubServer(config *GRPCConfig) (*AgentHubServer, error) {
 	}
 
 	// Create gRPC server with OpenTelemetry instrumentation
-	grpcServer := grpc.NewServer(
-		grpc.StatsHandler(otelgrpc.NewServerHandler()),
-	)
+	serverOptions := []grpc.ServerOption{
+		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithFilter(telemetryFilter(config.TelemetryExcludedMethods)),
+		)),
+	}
+	if config.MaxConcurrentStreams > 0 {
+		serverOptions = append(serverOptions, grpc.MaxConcurrentStreams(uint32(config.MaxConcurrentStreams)))
+	}
+	serverOptions = append(serverOptions, messageSizeServerOptions(config)...)
+	serverOptions = append(serverOptions, keepaliveServerOptions(config)...)
+	grpcServer := grpc.NewServer(serverOptions...)
 
 	return &AgentHubServer{
 		Server:         grpcServer,
@@ -109,12 +342,26 @@ func NewAgentHubServer(config *GRPCConfig) (*AgentHubServer, error) {
 		HealthServer:   healthServer,
 		Logger:         obs.Logger,
 		Config:         config,
+		ready:          make(chan struct{}),
 	}, nil
 }
 
-// Start starts the gRPC server and health server
+// OnStart registers an initialization step run by Start before the server is
+// marked ready and begins accepting calls. Steps run in registration order.
+func (s *AgentHubServer) OnStart(fn func(ctx context.Context) error) {
+	s.initializers = append(s.initializers, fn)
+}
+
+// Ready returns a channel that is closed once Start has completed initialization
+// and the server is about to accept calls
+func (s *AgentHubServer) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Start starts the gRPC server and health server.
+// The server only reports ready and accepts calls once every OnStart step succeeded.
 func (s *AgentHubServer) Start(ctx context.Context) error {
-	// Start health server
+	// Start health server; /ready reports unavailable until initialization completes
 	go func() {
 		s.Logger.Info("Starting health server", slog.String("port", s.Config.HealthPort))
 		if err := s.HealthServer.Start(ctx); err != nil {
@@ -122,12 +369,23 @@ func (s *AgentHubServer) Start(ctx context.Context) error {
 		}
 	}()
 
+	// Run registered initialization steps (janitors, metric registration, ...)
+	for _, initialize := range s.initializers {
+		if err := initialize(ctx); err != nil {
+			return fmt.Errorf("failed to initialize server: %w", err)
+		}
+	}
+
 	// Start metrics collection
 	go func() {
 		ticker := NewMetricsTicker(ctx, s.MetricsManager)
 		ticker.Start()
 	}()
 
+	// Open the readiness gate before serving
+	s.HealthServer.SetReady(true)
+	close(s.ready)
+
 	s.Logger.Info("AgentHub gRPC server with observability listening",
 		slog.String("address", s.Listener.Addr().String()),
 		slog.String("health_endpoint", fmt.Sprintf("http://localhost:%s/health", s.Config.HealthPort)),
@@ -138,50 +396,122 @@ func (s *AgentHubServer) Start(ctx context.Context) error {
 	return s.Server.Serve(s.Listener)
 }
 
-// Shutdown gracefully shuts down the server
+// shutdownPhase is a step of AgentHubServer.Shutdown
+type shutdownPhase struct {
+	name string
+	// share is the fraction of the time left for the shutdown that the phase may use
+	share float64
+	run   func(ctx context.Context) error
+}
+
+// Shutdown stops the server in a deterministic order, so that the telemetry of the
+// last calls is exported: stop accepting gRPC calls and drain the in-flight ones,
+// flush metrics, flush traces, close the health server and finally shut the
+// telemetry providers down. Each phase gets a share of the time left before the
+// ctx deadline; its duration is logged.
 func (s *AgentHubServer) Shutdown(ctx context.Context) error {
 	s.Logger.InfoContext(ctx, "Shutting down AgentHub server")
 
-	// Graceful shutdown of gRPC server
-	s.Server.GracefulStop()
+	// Stop advertising readiness before draining calls
+	s.HealthServer.SetReady(false)
 
-	// Shutdown observability components
-	if err := s.HealthServer.Shutdown(ctx); err != nil {
-		s.Logger.ErrorContext(ctx, "Error shutting down health server", slog.Any("error", err))
+	phases := []shutdownPhase{
+		{name: "grpc_drain", share: 0.5, run: s.drainGRPC},
+		{name: "flush_metrics", share: 0.25, run: s.Observability.FlushMetrics},
+		{name: "flush_traces", share: 0.5, run: s.Observability.FlushTraces},
+		{name: "health_server", share: 0.5, run: s.HealthServer.Shutdown},
+		{name: "observability", share: 1, run: s.Observability.Shutdown},
 	}
 
-	if err := s.Observability.Shutdown(ctx); err != nil {
-		s.Logger.ErrorContext(ctx, "Observability shutdown failed - likely OTLP trace export issue",
-			slog.Any("error", err),
-			slog.String("service", s.Config.ComponentName),
-			slog.String("otlp_endpoint", s.Observability.Config.JaegerEndpoint),
+	var errs []error
+	for _, phase := range phases {
+		phaseCtx, cancel := shutdownPhaseContext(ctx, phase.share)
+		start := time.Now()
+		err := phase.run(phaseCtx)
+		cancel()
+
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "Shutdown phase failed",
+				slog.String("phase", phase.name),
+				slog.Duration("duration", time.Since(start)),
+				slog.Any("error", err),
+				slog.String("service", s.Config.ComponentName),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", phase.name, err))
+			continue
+		}
+		s.Logger.InfoContext(ctx, "Shutdown phase completed",
+			slog.String("phase", phase.name),
+			slog.Duration("duration", time.Since(start)),
 		)
-		return err
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// drainGRPC stops accepting gRPC calls and waits for the in-flight ones to finish,
+// closing the remaining ones when ctx ends
+func (s *AgentHubServer) drainGRPC(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Server.Stop()
+		<-stopped
+		return fmt.Errorf("in-flight calls did not finish in time and were closed: %w", ctx.Err())
+	}
+}
+
+// shutdownPhaseContext carves the given share of the time left before the ctx
+// deadline. Without a deadline, the phase is bounded by ctx only.
+func shutdownPhaseContext(ctx context.Context, share float64) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(float64(time.Until(deadline))*share))
 }
 
 // AgentHubClient wraps the gRPC client with observability
 type AgentHubClient struct {
-	Client         pb.AgentHubClient
-	Connection     *grpc.ClientConn
+	Client     pb.AgentHubClient
+	Connection *grpc.ClientConn
+	Pool       *ConnectionPool
+	// Outbox, when set, keeps task results until the broker acknowledges them
+	Outbox         *Outbox
 	Observability  *observability.Observability
 	TraceManager   *observability.TraceManager
 	MetricsManager *observability.MetricsManager
 	HealthServer   *observability.HealthServer
 	Logger         *slog.Logger
 	Config         *GRPCConfig
+	// IDs mints the IDs of the messages and artifacts the client publishes
+	// (nil means DefaultIDGenerator)
+	IDs IDGenerator
+}
+
+// NewID mints an ID with the given prefix using the IDs of the client
+func (c *AgentHubClient) NewID(prefix string) string {
+	if c == nil {
+		return DefaultIDGenerator.NewID(prefix)
+	}
+	return idGeneratorOrDefault(c.IDs).NewID(prefix)
 }
 
 // NewAgentHubClient creates a new gRPC client with observability
 func NewAgentHubClient(config *GRPCConfig) (*AgentHubClient, error) {
 	// Initialize observability
-	obsConfig := observability.DefaultConfig("agenthub")
-	obs, err := observability.NewObservability(obsConfig)
+	obs, traceManager, err := newObservability(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize observability: %w", err)
+		return nil, err
 	}
+	obsConfig := obs.Config
 
 	// Initialize metrics manager
 	metricsManager, err := observability.NewMetricsManager(obs.Meter)
@@ -189,41 +519,131 @@ func NewAgentHubClient(config *GRPCConfig) (*AgentHubClient, error) {
 		return nil, fmt.Errorf("failed to initialize metrics manager: %w", err)
 	}
 
-	// Initialize trace manager
-	traceManager := observability.NewTraceManager(obsConfig.ServiceName)
-
 	// Initialize health server
 	healthServer := observability.NewHealthServer(config.HealthPort, obsConfig.ServiceName, obsConfig.ServiceVersion)
+	healthServer.SetLogLevelController(obs)
 
 	// Add basic health check
-	healthServer.AddChecker("self", observability.NewBasicHealthChecker("self", func(ctx context.Context) error {
+	healthServer.AddProbeChecker("self", observability.NewBasicHealthChecker("self", func(ctx context.Context) error {
 		return nil
-	}))
+	}), observability.ProbeLiveness|observability.ProbeReadiness)
 
 	// Set up gRPC connection with OpenTelemetry instrumentation
-	conn, err := grpc.Dial(config.BrokerAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-	)
+	conn, err := dialBroker(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to broker at %s: %w", config.BrokerAddr, err)
 	}
 
 	client := pb.NewAgentHubClient(conn)
 
+	// Open additional publishing connections when a pool is configured
+	var pool *ConnectionPool
+	if config.PublisherPoolSize > 1 {
+		pool, err = newConnectionPool(conn, config.PublisherPoolSize, func() (*grpc.ClientConn, error) {
+			return dialBroker(config)
+		})
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
 	// Add gRPC connection health check
 	healthServer.AddChecker("agenthub_connection", observability.NewGRPCHealthChecker("agenthub_connection", config.BrokerAddr))
 
-	return &AgentHubClient{
+	c := &AgentHubClient{
 		Client:         client,
 		Connection:     conn,
+		Pool:           pool,
 		Observability:  obs,
 		TraceManager:   traceManager,
 		MetricsManager: metricsManager,
 		HealthServer:   healthServer,
 		Logger:         obs.Logger,
 		Config:         config,
-	}, nil
+	}
+
+	// Keep results in a durable outbox when configured, replaying those of a previous run on Start
+	if config.OutboxFile != "" {
+		store, err := NewFileOutboxStore(config.OutboxFile)
+		if err != nil {
+			if pool != nil {
+				pool.Close()
+			}
+			conn.Close()
+			return nil, err
+		}
+		c.Outbox = NewOutbox(store, c.PublisherClient(), obs.Logger)
+		c.Outbox.RetryInterval = config.OutboxRetryInterval
+	}
+
+	return c, nil
+}
+
+// newObservability returns the observability injected in the config, or sets it up
+// from the environment, along with a trace manager bound to its tracer
+func newObservability(config *GRPCConfig) (*observability.Observability, *observability.TraceManager, error) {
+	if config.Observability != nil {
+		return config.Observability, observability.NewTraceManagerWithTracer(config.Observability.Tracer), nil
+	}
+
+	obsConfig := observability.DefaultConfig("agenthub")
+	obs, err := observability.NewObservability(obsConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize observability: %w", err)
+	}
+	return obs, observability.NewTraceManager(obsConfig.ServiceName), nil
+}
+
+// dialBroker opens an instrumented client connection to the broker
+func dialBroker(config *GRPCConfig) (*grpc.ClientConn, error) {
+	creds, err := clientTransportCredentials(config)
+	if err != nil {
+		return nil, err
+	}
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithFilter(telemetryFilter(config.TelemetryExcludedMethods)),
+		)),
+	}
+	options = append(options, messageSizeDialOptions(config)...)
+	options = append(options, keepaliveDialOptions(config)...)
+	return grpc.Dial(config.BrokerAddr, options...)
+}
+
+// grpcMessageSizeHeadroom is added to MaxMessageBytes for the routing and event
+// envelope around a message, so that an oversized message reaches the broker and
+// gets its InvalidArgument error rather than a gRPC ResourceExhausted one
+const grpcMessageSizeHeadroom = 64 << 10
+
+// messageSizeServerOptions sets the broker gRPC message size limits from
+// MaxMessageBytes, keeping the gRPC defaults when messages are unlimited
+func messageSizeServerOptions(config *GRPCConfig) []grpc.ServerOption {
+	if config.MaxMessageBytes <= 0 {
+		return nil
+	}
+	size := config.MaxMessageBytes + grpcMessageSizeHeadroom
+	return []grpc.ServerOption{grpc.MaxRecvMsgSize(size), grpc.MaxSendMsgSize(size)}
+}
+
+// messageSizeDialOptions sets the agent gRPC message size limits from
+// MaxMessageBytes, keeping the gRPC defaults when messages are unlimited
+func messageSizeDialOptions(config *GRPCConfig) []grpc.DialOption {
+	if config.MaxMessageBytes <= 0 {
+		return nil
+	}
+	size := config.MaxMessageBytes + grpcMessageSizeHeadroom
+	return []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(size), grpc.MaxCallSendMsgSize(size))}
+}
+
+// PublisherClient returns the client to use for publishing. It is the connection
+// pool when one is configured, and the single shared client otherwise.
+func (c *AgentHubClient) PublisherClient() pb.AgentHubClient {
+	if c.Pool != nil {
+		return c.Pool
+	}
+	return c.Client
 }
 
 // Start starts the client's health server and metrics collection
@@ -236,12 +656,24 @@ func (c *AgentHubClient) Start(ctx context.Context) error {
 		}
 	}()
 
+	// Wait for the broker, which may still be starting
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
 	// Start metrics collection
 	go func() {
 		ticker := NewMetricsTicker(ctx, c.MetricsManager)
 		ticker.Start()
 	}()
 
+	// Replay and retry the publishes the broker has not acknowledged
+	if c.Outbox != nil {
+		go c.Outbox.Run(ctx)
+	}
+
+	c.HealthServer.SetReady(true)
+
 	c.Logger.InfoContext(ctx, "AgentHub client started with observability",
 		slog.String("broker_addr", c.Config.BrokerAddr),
 		slog.String("component", c.Config.ComponentName),
@@ -254,11 +686,23 @@ func (c *AgentHubClient) Start(ctx context.Context) error {
 func (c *AgentHubClient) Shutdown(ctx context.Context) error {
 	c.Logger.InfoContext(ctx, "Shutting down AgentHub client")
 
-	// Close gRPC connection
+	// Close pooled and primary gRPC connections
+	if c.Pool != nil {
+		if err := c.Pool.Close(); err != nil {
+			c.Logger.ErrorContext(ctx, "Error closing pooled gRPC connections", slog.Any("error", err))
+		}
+	}
 	if err := c.Connection.Close(); err != nil {
 		c.Logger.ErrorContext(ctx, "Error closing gRPC connection", slog.Any("error", err))
 	}
 
+	// Unsent outbox entries stay on disk for the next run
+	if c.Outbox != nil {
+		if err := c.Outbox.Close(); err != nil {
+			c.Logger.ErrorContext(ctx, "Error closing outbox", slog.Any("error", err))
+		}
+	}
+
 	// Shutdown observability components
 	if err := c.HealthServer.Shutdown(ctx); err != nil {
 		c.Logger.ErrorContext(ctx, "Error shutting down health server", slog.Any("error", err))
@@ -283,3 +727,58 @@ func getEnvWithDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// Helper function to get an integer environment variable with default
+func getEnvAsIntWithDefault(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// Helper function to get a floating-point environment variable with default
+func getEnvAsFloatWithDefault(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// Helper function to get a duration environment variable (e.g. "30m") with default
+func getEnvAsDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// Helper function to get a boolean environment variable with default
+func getEnvAsBoolWithDefault(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// Helper function to get a comma-separated list environment variable with default
+func getEnvAsListWithDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,55 @@
+package agenthub
+
+import (
+	"context"
+	"maps"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// Well-known event headers. Headers travel in the routing metadata of an event,
+// from the publisher to every subscriber, and on to the events published while
+// handling it.
+const (
+	// HeaderTenantID identifies the tenant a request is made for
+	HeaderTenantID = "tenant-id"
+	// HeaderCallerID identifies the user or service at the origin of a request
+	HeaderCallerID = "caller-id"
+)
+
+type headersKey struct{}
+
+// ContextWithHeaders returns a copy of ctx carrying the headers of the event being
+// handled, merged over the headers ctx already carries, so that the events it
+// triggers are published with the same headers
+func ContextWithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	merged := maps.Clone(HeadersFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(headers))
+	}
+	maps.Copy(merged, headers)
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+// HeadersFromContext returns a copy of the headers carried by ctx, or nil
+func HeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(headersKey{}).(map[string]string)
+	return maps.Clone(headers)
+}
+
+// HeaderFromContext returns the value of a header carried by ctx
+func HeaderFromContext(ctx context.Context, key string) (string, bool) {
+	headers, _ := ctx.Value(headersKey{}).(map[string]string)
+	value, ok := headers[key]
+	return value, ok
+}
+
+// ContextWithEvent returns a copy of ctx carrying the priority and headers of an
+// event, for handlers whose results must be published like the event
+func ContextWithEvent(ctx context.Context, event *pb.AgentEvent) context.Context {
+	ctx = ContextWithPriority(ctx, event.GetRouting().GetPriority())
+	return ContextWithHeaders(ctx, event.GetRouting().GetHeaders())
+}
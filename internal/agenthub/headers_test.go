@@ -0,0 +1,77 @@
+package agenthub
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestA2ATaskSubscriber_Headers(t *testing.T) {
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The requester watches the task events routed back to it
+	requesterEvents := make(chan *pb.AgentEvent, 10)
+	service.agentMu.Lock()
+	service.taskSubscribers["requester"] = append(service.taskSubscribers["requester"], requesterEvents)
+	service.agentMu.Unlock()
+
+	handled := make(chan map[string]string, 1)
+	subscriber := NewA2ATaskSubscriber(client, "worker")
+	subscriber.RegisterTaskHandler("audit", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		handled <- HeadersFromContext(ctx)
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	})
+	go subscriber.SubscribeToTasks(ctx)
+	for {
+		service.agentMu.RLock()
+		subscribed := len(service.taskSubscribers["worker"]) > 0
+		service.agentMu.RUnlock()
+		if subscribed {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Request headers add to, and override, the headers carried by the context
+	publisher := NewA2ATaskPublisher(client, "test", "requester", nil)
+	req, err := NewTask("audit").WithText("check").From("requester").To("worker").WithHeader(HeaderCallerID, "alice").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	publishCtx := ContextWithHeaders(ctx, map[string]string{HeaderTenantID: "acme", HeaderCallerID: "nobody"})
+	if _, err := publisher.PublishTask(publishCtx, req); err != nil {
+		t.Fatalf("PublishTask failed: %v", err)
+	}
+
+	want := map[string]string{HeaderTenantID: "acme", HeaderCallerID: "alice"}
+	select {
+	case headers := <-handled:
+		if !maps.Equal(headers, want) {
+			t.Errorf("Expected the handler to see %v, got %v", want, headers)
+		}
+	case <-ctx.Done():
+		t.Fatal("Task was not handled")
+	}
+
+	// The completion travels back with the same headers
+	for {
+		select {
+		case event := <-requesterEvents:
+			if event.GetStatusUpdate() == nil {
+				continue
+			}
+			if got := event.GetRouting().GetHeaders(); !maps.Equal(got, want) {
+				t.Errorf("Expected the completion to carry %v, got %v", want, got)
+			}
+			return
+		case <-ctx.Done():
+			t.Fatal("Completion was not routed to the requester")
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// NewRegistrationHealthChecker returns a health checker that is unhealthy while
+// agentID is missing from the broker registry, e.g. after a broker restart
+func NewRegistrationHealthChecker(client *AgentHubClient, agentID string) observability.HealthChecker {
+	return observability.NewBasicHealthChecker("registration", func(ctx context.Context) error {
+		resp, err := client.Client.ListAgents(ctx, &pb.ListAgentsRequest{AgentId: agentID})
+		if err != nil {
+			return fmt.Errorf("failed to query broker registry: %w", err)
+		}
+		if len(resp.GetAgents()) == 0 {
+			return fmt.Errorf("agent %s is not registered with the broker", agentID)
+		}
+		return nil
+	})
+}
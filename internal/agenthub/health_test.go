@@ -0,0 +1,135 @@
+package agenthub
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+func TestAgentHubServer_ReadinessGate(t *testing.T) {
+	service := newTestAgentHubService()
+	server := service.Server
+
+	initialized := false
+	server.OnStart(func(ctx context.Context) error {
+		if server.HealthServer.IsReady() {
+			t.Error("Server should not be ready while initializing")
+		}
+		initialized = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = server.Start(ctx)
+	}()
+	defer server.Server.Stop()
+
+	select {
+	case <-server.Ready():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Server did not become ready")
+	}
+
+	if !initialized {
+		t.Error("Expected OnStart step to run before readiness")
+	}
+	if !server.HealthServer.IsReady() {
+		t.Error("Expected health server to report ready")
+	}
+}
+
+func TestAgentHubServer_ShutdownDrainTimeout(t *testing.T) {
+	service := newTestAgentHubService()
+	server := service.Server
+	pb.RegisterAgentHubServer(server.Server, service)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = server.Start(ctx)
+	}()
+	select {
+	case <-server.Ready():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Server did not become ready")
+	}
+
+	// An open subscription keeps the graceful stop from completing
+	conn, err := grpc.NewClient(server.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+	stream, err := pb.NewAgentHubClient(conn).SubscribeToAgentEvents(ctx, &pb.SubscribeToAgentEventsRequest{AgentId: "agent1"})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.agentMu.RLock()
+		subscribed := len(service.eventSubscribers["agent1"]) > 0
+		service.agentMu.RUnlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the subscription")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer shutdownCancel()
+	start := time.Now()
+	err = server.Shutdown(shutdownCtx)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Shutdown took %s, expected it to respect the deadline", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "grpc_drain") {
+		t.Errorf("Expected a grpc_drain error, got %v", err)
+	}
+	if server.HealthServer.IsReady() {
+		t.Error("Expected the server to stop reporting ready")
+	}
+}
+
+func TestRegistrationHealthChecker(t *testing.T) {
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+	ctx := context.Background()
+
+	checker := NewRegistrationHealthChecker(client, "worker")
+	if check := checker.Check(ctx); check.Status == observability.HealthStatusHealthy {
+		t.Fatal("Expected an unregistered agent to be unhealthy")
+	}
+
+	if _, err := client.Client.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentCard: &pb.AgentCard{Name: "worker"},
+	}); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	if check := checker.Check(ctx); check.Status != observability.HealthStatusHealthy {
+		t.Errorf("Expected a registered agent to be healthy, got %s: %s", check.Status, check.Message)
+	}
+
+	agents, err := client.ListAgents(ctx)
+	if err != nil || len(agents) != 1 || agents[0].GetName() != "worker" {
+		t.Errorf("Expected ListAgents to return the registered agent, got %v (err %v)", agents, err)
+	}
+}
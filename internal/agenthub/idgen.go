@@ -0,0 +1,57 @@
+package agenthub
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator mints the IDs of the events, messages and tasks created by the
+// broker and its clients. Each ID starts with the given prefix, which tells what
+// it identifies (e.g. "evt_<message ID>" or "task_greeting").
+type IDGenerator interface {
+	NewID(prefix string) string
+}
+
+// DefaultIDGenerator is used wherever no IDGenerator is injected
+var DefaultIDGenerator IDGenerator = UUIDGenerator{}
+
+// UUIDGenerator mints IDs made unique by a random UUID
+type UUIDGenerator struct{}
+
+// NewID returns prefix followed by a random UUID
+func (UUIDGenerator) NewID(prefix string) string {
+	return prefix + "_" + uuid.NewString()
+}
+
+// SequentialIDGenerator mints deterministic IDs numbered from 1, for tests
+type SequentialIDGenerator struct {
+	next atomic.Uint64
+}
+
+// NewSequentialIDGenerator creates a generator whose first ID is numbered 1
+func NewSequentialIDGenerator() *SequentialIDGenerator {
+	return &SequentialIDGenerator{}
+}
+
+// NewID returns prefix followed by the next sequence number
+func (g *SequentialIDGenerator) NewID(prefix string) string {
+	return prefix + "_" + strconv.FormatUint(g.next.Add(1), 10)
+}
+
+// WithIDGenerator sets the generator of the IDs minted by the broker, such as
+// event IDs, instead of DefaultIDGenerator
+func WithIDGenerator(ids IDGenerator) ServiceOption {
+	return func(s *AgentHubService) {
+		s.ids = idGeneratorOrDefault(ids)
+	}
+}
+
+// idGeneratorOrDefault returns ids, or DefaultIDGenerator when it is nil
+func idGeneratorOrDefault(ids IDGenerator) IDGenerator {
+	if ids == nil {
+		return DefaultIDGenerator
+	}
+	return ids
+}
@@ -0,0 +1,42 @@
+package agenthub
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestIDGenerators(t *testing.T) {
+	a, b := UUIDGenerator{}.NewID("evt"), UUIDGenerator{}.NewID("evt")
+	if a == b || !strings.HasPrefix(a, "evt_") {
+		t.Errorf("Expected distinct prefixed UUID IDs, got %q and %q", a, b)
+	}
+
+	// Injected generators make the minted IDs deterministic
+	service := newTestAgentHubService()
+	WithIDGenerator(NewSequentialIDGenerator())(service)
+	resp, err := service.PublishMessage(context.Background(), &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "msg", Role: pb.Role_ROLE_USER},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "a", EventType: "a2a.message"},
+	})
+	if err != nil || resp.GetEventId() != "evt_msg_1" {
+		t.Errorf("Expected event ID evt_msg_1, got %q (%v)", resp.GetEventId(), err)
+	}
+
+	publisher := &A2ATaskPublisher{IDs: NewSequentialIDGenerator()}
+	message, task := publisher.newTask(context.Background(), &A2APublishTaskRequest{TaskType: "greeting"})
+	if task.GetId() != "task_greeting_1" || message.GetMessageId() != "msg_greeting_2" || task.GetContextId() != "ctx_greeting_3" {
+		t.Errorf("Expected sequential task IDs, got %q, %q and %q", task.GetId(), message.GetMessageId(), task.GetContextId())
+	}
+
+	// Clients mint the IDs of the progress, completion and artifact messages they publish
+	client := &AgentHubClient{IDs: NewSequentialIDGenerator()}
+	if id := client.NewID("completion_task_1"); id != "completion_task_1_1" {
+		t.Errorf("Expected completion_task_1_1, got %q", id)
+	}
+	if id := (&AgentHubClient{}).NewID("progress"); !strings.HasPrefix(id, "progress_") || len(id) <= len("progress_") {
+		t.Errorf("Expected a default generated ID, got %q", id)
+	}
+}
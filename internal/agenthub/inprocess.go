@@ -0,0 +1,105 @@
+package agenthub
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// inProcessBufferSize is the buffer of the in-memory connection between client and broker
+const inProcessBufferSize = 1 << 20
+
+// InProcessBroker is a broker and a client connected to it through an in-memory
+// connection, without any network listener. Observability is a no-op on both sides.
+// It lets tests and embedded uses run agents against a working broker.
+type InProcessBroker struct {
+	Service *AgentHubService
+	Client  *AgentHubClient
+
+	cancel context.CancelFunc
+}
+
+// NewInProcessBroker starts a broker configured from the environment, like
+// StartBroker, and returns it with a connected client. Call Close to stop both.
+func NewInProcessBroker(opts ...ServiceOption) (*InProcessBroker, error) {
+	obs := observability.NewNoopObservability()
+	metricsManager, err := observability.NewMetricsManager(obs.Meter)
+	if err != nil {
+		return nil, err
+	}
+	traceManager := observability.NewTraceManagerWithTracer(obs.Tracer)
+
+	config := NewGRPCConfig("in_process")
+	config.HealthPort = "0"
+	listener := bufconn.Listen(inProcessBufferSize)
+
+	server := &AgentHubServer{
+		Server:         grpc.NewServer(messageSizeServerOptions(config)...),
+		Listener:       listener,
+		Observability:  obs,
+		TraceManager:   traceManager,
+		MetricsManager: metricsManager,
+		HealthServer:   observability.NewHealthServer(config.HealthPort, obs.Config.ServiceName, obs.Config.ServiceVersion),
+		Logger:         obs.Logger,
+		Config:         config,
+		ready:          make(chan struct{}),
+	}
+	routingRules, err := configuredRoutingRules(config)
+	if err != nil {
+		return nil, err
+	}
+	service := NewAgentHubService(server, append([]ServiceOption{routingRules}, opts...)...)
+	pb.RegisterAgentHubServer(server.Server, service)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go service.runContextPruner(ctx)
+	go service.runAgentReaper(ctx)
+	go service.runTaskPruner(ctx)
+	go service.runQueueDepthSampler(ctx, config.QueueDepthInterval)
+	go server.Server.Serve(listener)
+	close(server.ready)
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, messageSizeDialOptions(config)...)
+	conn, err := grpc.NewClient("passthrough:///in-process", dialOptions...)
+	if err != nil {
+		cancel()
+		server.Server.Stop()
+		return nil, err
+	}
+
+	client := &AgentHubClient{
+		Client:         pb.NewAgentHubClient(conn),
+		Connection:     conn,
+		Observability:  obs,
+		TraceManager:   traceManager,
+		MetricsManager: metricsManager,
+		HealthServer:   observability.NewHealthServer(config.HealthPort, obs.Config.ServiceName, obs.Config.ServiceVersion),
+		Logger:         obs.Logger,
+		Config:         config,
+	}
+
+	return &InProcessBroker{
+		Service: service,
+		Client:  client,
+		cancel:  cancel,
+	}, nil
+}
+
+// Close disconnects the client and stops the broker, ending open subscriptions
+func (b *InProcessBroker) Close() error {
+	err := b.Client.Connection.Close()
+	b.Service.Server.Server.Stop()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,128 @@
+package agenthub
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+func TestInProcessBroker(t *testing.T) {
+	broker, err := NewInProcessBroker()
+	if err != nil {
+		t.Fatalf("NewInProcessBroker failed: %v", err)
+	}
+	defer broker.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := broker.Client.Client.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentCard: &pb.AgentCard{Name: "in_process_agent", Description: "test"},
+	})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("RegisterAgent failed: %v %v", err, resp.GetError())
+	}
+	agents, err := broker.Client.ListAgents(ctx)
+	if err != nil || len(agents) != 1 || agents[0].GetName() != "in_process_agent" {
+		t.Fatalf("Expected the registered agent, got %v (err %v)", agents, err)
+	}
+
+	stream, err := broker.Client.Client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{AgentId: "in_process_agent"})
+	if err != nil {
+		t.Fatalf("SubscribeToMessages failed: %v", err)
+	}
+	// Wait for the broker to register the subscription
+	for subscribed := false; !subscribed; time.Sleep(10 * time.Millisecond) {
+		broker.Service.agentMu.RLock()
+		subscribed = len(broker.Service.messageSubscribers["in_process_agent"]) > 0
+		broker.Service.agentMu.RUnlock()
+	}
+
+	_, err = broker.Client.Client.PublishMessage(ctx, &pb.PublishMessageRequest{
+		Message: &pb.Message{
+			MessageId: "msg_in_process",
+			Role:      pb.Role_ROLE_USER,
+			Content:   []*pb.Part{{Part: &pb.Part_Text{Text: "hello"}}},
+		},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "sender", ToAgentId: "in_process_agent"},
+	})
+	if err != nil {
+		t.Fatalf("PublishMessage failed: %v", err)
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if event.GetMessage().GetMessageId() != "msg_in_process" {
+		t.Errorf("Expected msg_in_process, got %v", event)
+	}
+
+	if err := broker.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestNewAgentHubClient_InjectedObservability(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.Observability = observability.NewNoopObservability()
+
+	client, err := NewAgentHubClient(config)
+	if err != nil {
+		t.Fatalf("NewAgentHubClient failed: %v", err)
+	}
+	defer client.Connection.Close()
+
+	if client.Observability != config.Observability || client.Logger != config.Observability.Logger {
+		t.Error("Expected the client to use the injected observability")
+	}
+	_, span := client.TraceManager.StartSpan(context.Background(), "noop")
+	defer span.End()
+	if span.SpanContext().IsValid() {
+		t.Error("Expected spans of the no-op tracer not to be recorded")
+	}
+}
+
+func TestInProcessBroker_MaxMessageBytes(t *testing.T) {
+	// Above the 4MB gRPC default, so that the gRPC limits must follow the config
+	const limit = 5 << 20
+	t.Setenv("AGENTHUB_MAX_MESSAGE_BYTES", strconv.Itoa(limit))
+	broker, err := NewInProcessBroker()
+	if err != nil {
+		t.Fatalf("NewInProcessBroker failed: %v", err)
+	}
+	defer broker.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	publish := func(size int) error {
+		_, err := broker.Client.Client.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{
+				MessageId: "large-msg",
+				Role:      pb.Role_ROLE_USER,
+				Content:   []*pb.Part{{Part: &pb.Part_Text{Text: strings.Repeat("x", size)}}},
+			},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "test-requester"},
+		})
+		return err
+	}
+
+	if err := publish(limit - 1024); err != nil {
+		t.Fatalf("Expected a message within the limit to be accepted, got %v", err)
+	}
+	err = publish(limit + 1024)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "AGENTHUB_MAX_MESSAGE_BYTES") {
+		t.Errorf("Expected the error to name the limit, got %v", err)
+	}
+}
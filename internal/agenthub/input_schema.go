@@ -0,0 +1,77 @@
+package agenthub
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// ErrInvalidSchema is returned when a task input schema cannot be compiled
+var ErrInvalidSchema = errors.New("invalid input schema")
+
+// CompileInputSchema compiles the JSON Schema document declared for the input
+// of a task type
+func CompileInputSchema(taskType, schemaJSON string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schemaJSON))
+	if err != nil {
+		return nil, fmt.Errorf("%w for skill %s: %v", ErrInvalidSchema, taskType, err)
+	}
+
+	location := fmt.Sprintf("skill://%s/input.json", taskType)
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(location, doc); err != nil {
+		return nil, fmt.Errorf("%w for skill %s: %v", ErrInvalidSchema, taskType, err)
+	}
+
+	schema, err := compiler.Compile(location)
+	if err != nil {
+		return nil, fmt.Errorf("%w for skill %s: %v", ErrInvalidSchema, taskType, err)
+	}
+	return schema, nil
+}
+
+// ValidateDataParts validates every DataPart of the message against the schema.
+// It returns one human-readable entry per violation, or nil if the input is valid.
+func ValidateDataParts(schema *jsonschema.Schema, message *pb.Message) []string {
+	var violations []string
+	found := false
+
+	for i, part := range message.GetContent() {
+		dataPart := part.GetData()
+		if dataPart == nil {
+			continue
+		}
+		found = true
+
+		err := schema.Validate(dataPart.GetData().AsMap())
+		if err == nil {
+			continue
+		}
+
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			violations = append(violations, fmt.Sprintf("part %d: %v", i, err))
+			continue
+		}
+
+		for _, unit := range validationErr.BasicOutput().Errors {
+			if unit.Error == nil {
+				continue
+			}
+			location := unit.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+			violations = append(violations, fmt.Sprintf("part %d at %s: %s", i, location, unit.Error.String()))
+		}
+	}
+
+	if !found {
+		violations = append(violations, "message has no data part to validate")
+	}
+	return violations
+}
@@ -0,0 +1,69 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestA2ATaskSubscriber_InputSchema(t *testing.T) {
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+	publisher := NewA2ATaskPublisher(client, "test", "", nil)
+	ctx := context.Background()
+
+	const schema = `{"type": "object", "properties": {"n": {"type": "integer"}}, "required": ["n"]}`
+	handled := 0
+	subscriber := NewA2ATaskSubscriber(client, "worker")
+	if err := subscriber.RegisterTaskHandlerWithSchema("count", `{"type": 1}`, nil); !errors.Is(err, ErrInvalidSchema) {
+		t.Fatalf("Expected ErrInvalidSchema, got %v", err)
+	}
+	handler := func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		handled++
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	}
+	if err := subscriber.RegisterTaskHandlerWithSchema("count", schema, handler); err != nil {
+		t.Fatalf("RegisterTaskHandlerWithSchema failed: %v", err)
+	}
+	if subscriber.InputSchema("count") != schema {
+		t.Errorf("Expected the registered schema to be returned, got %q", subscriber.InputSchema("count"))
+	}
+
+	process := func(data map[string]any) *pb.Task {
+		payload, _ := structpb.NewStruct(data)
+		published, err := publisher.PublishTask(ctx, &A2APublishTaskRequest{
+			TaskType:         "count",
+			Content:          []*pb.Part{{Part: &pb.Part_Data{Data: &pb.DataPart{Data: payload}}}},
+			RequesterAgentID: "requester",
+			ResponderAgentID: "worker",
+		})
+		if err != nil {
+			t.Fatalf("PublishTask failed: %v", err)
+		}
+		task, err := service.GetTask(ctx, &pb.GetTaskRequest{TaskId: published.GetId()})
+		if err != nil {
+			t.Fatalf("GetTask failed: %v", err)
+		}
+		subscriber.processTask(ctx, task)
+		task, _ = service.GetTask(ctx, &pb.GetTaskRequest{TaskId: published.GetId()})
+		return task
+	}
+
+	// Invalid data fails the task without reaching the handler
+	task := process(map[string]any{"n": "three"})
+	if handled != 0 {
+		t.Error("Expected the handler not to run for invalid data")
+	}
+	if task.GetStatus().GetState() != pb.TaskState_TASK_STATE_FAILED {
+		t.Errorf("Expected the invalid task to fail, got %s", task.GetStatus().GetState())
+	}
+
+	task = process(map[string]any{"n": 3})
+	if handled != 1 || task.GetStatus().GetState() != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Errorf("Expected the valid task to be handled and completed, got %s", task.GetStatus().GetState())
+	}
+}
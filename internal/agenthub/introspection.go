@@ -0,0 +1,81 @@
+package agenthub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// BrokerSnapshot is a point-in-time view of the broker internals, for diagnosing
+// routing issues during incidents
+type BrokerSnapshot struct {
+	Time             time.Time                   `json:"time"`
+	RegisteredAgents int                         `json:"registered_agents"`
+	Subscribers      map[string]SubscriberCounts `json:"subscribers"` // By agent ID
+	TasksByState     map[string]int              `json:"tasks_by_state"`
+	Contexts         int                         `json:"contexts"`
+}
+
+// SubscriberCounts is the number of open subscriptions of an agent per stream type
+type SubscriberCounts struct {
+	Messages int `json:"messages"`
+	Tasks    int `json:"tasks"`
+	Events   int `json:"events"`
+}
+
+// Snapshot returns the current state of the broker: registered agents,
+// subscriptions per agent and stream type, tasks by state and retained contexts
+func (s *AgentHubService) Snapshot(ctx context.Context) (*BrokerSnapshot, error) {
+	snapshot := &BrokerSnapshot{
+		Time:         time.Now(),
+		Subscribers:  make(map[string]SubscriberCounts),
+		TasksByState: make(map[string]int),
+		Contexts:     s.contexts.len(),
+	}
+
+	s.agentsMu.RLock()
+	snapshot.RegisteredAgents = len(s.registeredAgents)
+	s.agentsMu.RUnlock()
+
+	s.agentMu.RLock()
+	for agentID, channels := range s.messageSubscribers {
+		counts := snapshot.Subscribers[agentID]
+		counts.Messages = len(channels)
+		snapshot.Subscribers[agentID] = counts
+	}
+	for agentID, channels := range s.taskSubscribers {
+		counts := snapshot.Subscribers[agentID]
+		counts.Tasks = len(channels)
+		snapshot.Subscribers[agentID] = counts
+	}
+	for agentID, subs := range s.eventSubscribers {
+		counts := snapshot.Subscribers[agentID]
+		counts.Events = len(subs)
+		snapshot.Subscribers[agentID] = counts
+	}
+	s.agentMu.RUnlock()
+
+	tasks, err := s.taskStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		snapshot.TasksByState[task.GetStatus().GetState().String()]++
+	}
+
+	return snapshot, nil
+}
+
+// DebugHandler serves the broker Snapshot as JSON
+func (s *AgentHubService) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snapshot, err := s.Snapshot(r.Context())
+		if err != nil {
+			http.Error(w, "failed to snapshot broker state: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(snapshot)
+	})
+}
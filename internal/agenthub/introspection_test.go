@@ -0,0 +1,72 @@
+package agenthub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_Snapshot(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	service.agentMu.Lock()
+	service.messageSubscribers["cortex"] = []chan *pb.AgentEvent{make(chan *pb.AgentEvent), make(chan *pb.AgentEvent)}
+	service.taskSubscribers["worker"] = []chan *pb.AgentEvent{make(chan *pb.AgentEvent)}
+	service.eventSubscribers["cortex"] = []*eventSubscription{{ch: make(chan *pb.AgentEvent)}}
+	service.agentMu.Unlock()
+
+	if _, err := service.RegisterAgent(ctx, &pb.RegisterAgentRequest{AgentCard: &pb.AgentCard{Name: "worker"}}); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	for _, task := range []*pb.Task{
+		{Id: "t1", Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_WORKING}},
+		{Id: "t2", Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_WORKING}},
+		{Id: "t3", Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED}},
+	} {
+		if err := service.taskStore.Put(ctx, task); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	service.recordContextMessage(ctx, &pb.Message{MessageId: "m1", ContextId: "ctx-1"})
+
+	rec := httptest.NewRecorder()
+	service.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/broker", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	var snapshot BrokerSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("Failed to decode snapshot: %v", err)
+	}
+
+	if snapshot.RegisteredAgents != 1 {
+		t.Errorf("Expected 1 registered agent, got %d", snapshot.RegisteredAgents)
+	}
+	if got := snapshot.Subscribers["cortex"]; got != (SubscriberCounts{Messages: 2, Events: 1}) {
+		t.Errorf("Unexpected cortex subscribers: %+v", got)
+	}
+	if got := snapshot.Subscribers["worker"]; got != (SubscriberCounts{Tasks: 1}) {
+		t.Errorf("Unexpected worker subscribers: %+v", got)
+	}
+	if snapshot.TasksByState["TASK_STATE_WORKING"] != 2 || snapshot.TasksByState["TASK_STATE_COMPLETED"] != 1 {
+		t.Errorf("Unexpected tasks by state: %v", snapshot.TasksByState)
+	}
+	if snapshot.Contexts != 1 {
+		t.Errorf("Expected 1 context, got %d", snapshot.Contexts)
+	}
+}
+
+func TestDebugEndpointConfig(t *testing.T) {
+	if NewGRPCConfig("broker").DebugEndpoint {
+		t.Error("Expected the debug endpoint to be disabled by default")
+	}
+	t.Setenv("AGENTHUB_DEBUG_ENDPOINT", "true")
+	if !NewGRPCConfig("broker").DebugEndpoint {
+		t.Error("Expected AGENTHUB_DEBUG_ENDPOINT to enable the debug endpoint")
+	}
+}
@@ -0,0 +1,54 @@
+package agenthub
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Default keepalive policy of broker connections
+const (
+	DefaultKeepaliveTime    = 30 * time.Second
+	DefaultKeepaliveTimeout = 10 * time.Second
+)
+
+// keepaliveEnforcementMinTime is the shortest ping interval the broker accepts from
+// agents. It is the shortest interval gRPC clients use, so that agents configured
+// with any KeepaliveTime are not disconnected for pinging too often.
+const keepaliveEnforcementMinTime = 10 * time.Second
+
+// keepaliveServerOptions makes the broker ping idle agents every KeepaliveTime and
+// close the connections that do not answer within KeepaliveTimeout, and accept the
+// pings of agents. A zero KeepaliveTime keeps the gRPC defaults.
+func keepaliveServerOptions(config *GRPCConfig) []grpc.ServerOption {
+	if config.KeepaliveTime <= 0 {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    config.KeepaliveTime,
+			Timeout: config.KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             keepaliveEnforcementMinTime,
+			PermitWithoutStream: config.KeepalivePermitWithoutStream,
+		}),
+	}
+}
+
+// keepaliveDialOptions makes agents ping the broker every KeepaliveTime, detecting
+// dead connections within KeepaliveTimeout and keeping idle connections open through
+// load balancers. A zero KeepaliveTime keeps the gRPC defaults.
+func keepaliveDialOptions(config *GRPCConfig) []grpc.DialOption {
+	if config.KeepaliveTime <= 0 {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                config.KeepaliveTime,
+			Timeout:             config.KeepaliveTimeout,
+			PermitWithoutStream: config.KeepalivePermitWithoutStream,
+		}),
+	}
+}
@@ -0,0 +1,24 @@
+package agenthub
+
+import "testing"
+
+func TestKeepaliveConfig(t *testing.T) {
+	config := NewGRPCConfig("test")
+	if config.KeepaliveTime != DefaultKeepaliveTime || config.KeepaliveTimeout != DefaultKeepaliveTimeout || !config.KeepalivePermitWithoutStream {
+		t.Errorf("Unexpected keepalive defaults: time %s, timeout %s, permit without stream %t",
+			config.KeepaliveTime, config.KeepaliveTimeout, config.KeepalivePermitWithoutStream)
+	}
+	if len(keepaliveServerOptions(config)) != 2 || len(keepaliveDialOptions(config)) != 1 {
+		t.Error("Expected keepalive options for the broker and agents")
+	}
+
+	t.Setenv("AGENTHUB_KEEPALIVE_TIME", "0")
+	t.Setenv("AGENTHUB_KEEPALIVE_PERMIT_WITHOUT_STREAM", "false")
+	config = NewGRPCConfig("test")
+	if config.KeepalivePermitWithoutStream {
+		t.Error("Expected permit without stream to be disabled")
+	}
+	if keepaliveServerOptions(config) != nil || keepaliveDialOptions(config) != nil {
+		t.Error("Expected a zero keepalive time to keep the gRPC defaults")
+	}
+}
@@ -0,0 +1,108 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DefaultHeartbeatInterval is how often agents report liveness to the broker
+const DefaultHeartbeatInterval = 10 * time.Second
+
+// Heartbeat records that a registered agent is still alive
+func (s *AgentHubService) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	agentID := req.GetAgentId()
+	if agentID == "" {
+		return &pb.HeartbeatResponse{
+			Success: false,
+			Error:   "agent_id is required",
+		}, nil
+	}
+
+	s.agentsMu.Lock()
+	_, registered := s.registeredAgents[agentID]
+	if registered {
+		s.agentLastSeen[agentID] = time.Now()
+	}
+	s.agentsMu.Unlock()
+
+	if !registered {
+		return &pb.HeartbeatResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent %q is not registered", agentID),
+		}, nil
+	}
+	return &pb.HeartbeatResponse{Success: true}, nil
+}
+
+// ListAgents retrieves the cards of every agent registered with the broker, sorted by name.
+// Orchestrators use it to discover agents registered before they subscribed to agent events.
+func (c *AgentHubClient) ListAgents(ctx context.Context) ([]*pb.AgentCard, error) {
+	resp, err := c.Client.ListAgents(ctx, &pb.ListAgentsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetAgents(), nil
+}
+
+// isStale reports whether an agent has been silent for longer than the staleness
+// threshold. Without a threshold, every registered agent is considered alive.
+// Callers must hold agentsMu.
+func (s *AgentHubService) isStale(agentID string, now time.Time) bool {
+	if s.staleThreshold <= 0 {
+		return false
+	}
+	return now.Sub(s.agentLastSeen[agentID]) > s.staleThreshold
+}
+
+// ReapStaleAgents deregisters the agents silent for longer than the staleness
+// threshold, broadcasting agent.deregistered for each, and returns how many were removed
+func (s *AgentHubService) ReapStaleAgents(ctx context.Context) int {
+	now := time.Now()
+	reaped := make(map[string]*pb.AgentCard)
+
+	s.agentsMu.Lock()
+	for agentID, card := range s.registeredAgents {
+		if s.isStale(agentID, now) {
+			reaped[agentID] = card
+			delete(s.registeredAgents, agentID)
+			delete(s.agentLastSeen, agentID)
+		}
+	}
+	s.agentsMu.Unlock()
+
+	for agentID, card := range reaped {
+		s.Server.Logger.WarnContext(ctx, "Deregistering stale agent",
+			"agent_id", agentID,
+			"stale_threshold", s.staleThreshold,
+		)
+		s.announceDeregistration(ctx, agentID, card)
+	}
+	return len(reaped)
+}
+
+// runAgentReaper calls ReapStaleAgents periodically until ctx is done.
+// It does nothing when no staleness threshold is configured.
+func (s *AgentHubService) runAgentReaper(ctx context.Context) {
+	if s.staleThreshold <= 0 {
+		return
+	}
+
+	interval := s.staleThreshold / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.ReapStaleAgents(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_ReapStaleAgents(t *testing.T) {
+	service := newTestAgentHubService()
+	service.staleThreshold = time.Minute
+	ctx := context.Background()
+
+	for _, name := range []string{"alive", "silent"} {
+		if _, err := service.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+			AgentCard: &pb.AgentCard{Name: name},
+		}); err != nil {
+			t.Fatalf("RegisterAgent failed: %v", err)
+		}
+	}
+	service.agentsMu.Lock()
+	service.agentLastSeen["alive"] = time.Now().Add(-2 * time.Minute)
+	service.agentLastSeen["silent"] = time.Now().Add(-2 * time.Minute)
+	service.agentsMu.Unlock()
+
+	if resp, _ := service.Heartbeat(ctx, &pb.HeartbeatRequest{AgentId: "alive"}); !resp.GetSuccess() {
+		t.Fatalf("Heartbeat failed: %s", resp.GetError())
+	}
+	list, _ := service.ListAgents(ctx, &pb.ListAgentsRequest{AliveOnly: true})
+	if len(list.GetAgents()) != 1 || list.GetAgents()[0].GetName() != "alive" {
+		t.Errorf("Expected only the alive agent, got %v", list.GetAgents())
+	}
+
+	if reaped := service.ReapStaleAgents(ctx); reaped != 1 {
+		t.Errorf("Expected 1 reaped agent, got %d", reaped)
+	}
+	list, _ = service.ListAgents(ctx, &pb.ListAgentsRequest{})
+	if len(list.GetAgents()) != 1 || list.GetAgents()[0].GetName() != "alive" {
+		t.Errorf("Expected the silent agent to be deregistered, got %v", list.GetAgents())
+	}
+	if resp, _ := service.Heartbeat(ctx, &pb.HeartbeatRequest{AgentId: "silent"}); resp.GetSuccess() {
+		t.Error("Expected a heartbeat from a reaped agent to fail")
+	}
+}
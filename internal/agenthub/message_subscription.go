@@ -0,0 +1,141 @@
+package agenthub
+
+import (
+	"context"
+	"io"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// eventStream is the receiving side of a broker subscription
+type eventStream interface {
+	Recv() (*pb.AgentEvent, error)
+}
+
+// MessageSubscription receives the events the broker streams to an agent and hands
+// them to a handler. When the stream ends or breaks, for instance when the broker
+// restarts, it re-subscribes according to the Reconnect policy instead of going
+// silent. Events routed while the agent was not subscribed are not redelivered.
+type MessageSubscription struct {
+	Client  *AgentHubClient
+	AgentID string
+	// Reconnect controls re-subscription when the stream ends
+	Reconnect ReconnectPolicy
+
+	kind      string
+	subscribe func(ctx context.Context) (eventStream, error)
+}
+
+// NewMessageSubscription creates a subscription to the messages sent to agentID
+func NewMessageSubscription(client *AgentHubClient, agentID string) *MessageSubscription {
+	return newEventSubscription(client, agentID, "messages", func(ctx context.Context) (eventStream, error) {
+		return client.Client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{AgentId: agentID})
+	})
+}
+
+// NewTaskEventSubscription creates a subscription to the task events sent to agentID
+func NewTaskEventSubscription(client *AgentHubClient, agentID string) *MessageSubscription {
+	return newEventSubscription(client, agentID, "tasks", func(ctx context.Context) (eventStream, error) {
+		return client.Client.SubscribeToTasks(ctx, &pb.SubscribeToTasksRequest{AgentId: agentID})
+	})
+}
+
+// NewAgentEventSubscription creates a subscription to the agent events of the given
+// types (all of them when none is given) sent to agentID
+func NewAgentEventSubscription(client *AgentHubClient, agentID string, eventTypes ...string) *MessageSubscription {
+	return newEventSubscription(client, agentID, "agent events", func(ctx context.Context) (eventStream, error) {
+		return client.Client.SubscribeToAgentEvents(ctx, &pb.SubscribeToAgentEventsRequest{AgentId: agentID, EventTypes: eventTypes})
+	})
+}
+
+func newEventSubscription(client *AgentHubClient, agentID, kind string, subscribe func(ctx context.Context) (eventStream, error)) *MessageSubscription {
+	return &MessageSubscription{
+		Client:    client,
+		AgentID:   agentID,
+		Reconnect: DefaultReconnectPolicy(),
+		kind:      kind,
+		subscribe: subscribe,
+	}
+}
+
+// Run subscribes and calls handle with each received event, in order, until ctx
+// is done or the reconnect retries are exhausted
+func (s *MessageSubscription) Run(ctx context.Context, handle func(ctx context.Context, event *pb.AgentEvent)) error {
+	return s.run(ctx, nil, handle)
+}
+
+// run drains the established stream, if any, then re-subscribes when the stream ends
+func (s *MessageSubscription) run(ctx context.Context, stream eventStream, handle func(ctx context.Context, event *pb.AgentEvent)) error {
+	return resubscribe(ctx, s.Reconnect, s.Client.Logger, "Subscription stream lost, re-subscribing",
+		[]any{"agent_id", s.AgentID, "subscription", s.kind},
+		func(ctx context.Context) (bool, error) {
+			if stream != nil {
+				established := stream
+				stream = nil
+				return s.drain(ctx, established, handle)
+			}
+			return s.receive(ctx, handle)
+		})
+}
+
+// Events runs the subscription in the background and delivers the received events
+// on the returned channel, which is closed once the subscription stops
+func (s *MessageSubscription) Events(ctx context.Context) <-chan *pb.AgentEvent {
+	events := make(chan *pb.AgentEvent)
+	go func() {
+		defer close(events)
+		s.Run(ctx, func(ctx context.Context, event *pb.AgentEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+// Start subscribes, returning the error if the broker refuses the subscription,
+// then handles events in the background like Run. Subscribing before publishing
+// requests guarantees that fast responses are not missed. done, when not nil, is
+// called with the error Run would return once the subscription stops.
+func (s *MessageSubscription) Start(ctx context.Context, handle func(ctx context.Context, event *pb.AgentEvent), done func(error)) error {
+	stream, err := s.subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := s.run(ctx, stream, handle)
+		if done != nil {
+			done(err)
+		}
+	}()
+	return nil
+}
+
+// receive runs a single subscription until its stream ends. It reports whether
+// the subscription was established, and why the stream ended.
+func (s *MessageSubscription) receive(ctx context.Context, handle func(ctx context.Context, event *pb.AgentEvent)) (bool, error) {
+	stream, err := s.subscribe(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.Client.Logger.ErrorContext(ctx, "Failed to subscribe", "agent_id", s.AgentID, "subscription", s.kind, "error", err)
+		}
+		return false, err
+	}
+	return s.drain(ctx, stream, handle)
+}
+
+// drain hands the events of an established stream to handle until it ends
+func (s *MessageSubscription) drain(ctx context.Context, stream eventStream, handle func(ctx context.Context, event *pb.AgentEvent)) (bool, error) {
+	s.Client.Logger.InfoContext(ctx, "Subscribed", "agent_id", s.AgentID, "subscription", s.kind)
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				s.Client.Logger.InfoContext(ctx, "Subscription stream ended", "agent_id", s.AgentID, "subscription", s.kind)
+			}
+			return true, err
+		}
+		handle(ctx, event)
+	}
+}
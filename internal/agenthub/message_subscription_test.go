@@ -0,0 +1,89 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestMessageSubscription_Resubscribes(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serve := func(addr string) *grpc.Server {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		server := grpc.NewServer()
+		pb.RegisterAgentHubServer(server, service)
+		go server.Serve(listener)
+		return server
+	}
+	// Reserve an address the broker can be restarted on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	brokerAddr := listener.Addr().String()
+	listener.Close()
+	server := serve(brokerAddr)
+
+	conn, err := grpc.NewClient(brokerAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoff.Config{BaseDelay: 10 * time.Millisecond, Multiplier: 1, MaxDelay: 10 * time.Millisecond}}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	client := &AgentHubClient{Client: pb.NewAgentHubClient(conn), Logger: service.Server.Logger}
+	subscription := NewMessageSubscription(client, "agent1")
+	subscription.Reconnect = ReconnectPolicy{MaxRetries: -1, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	events := subscription.Events(ctx)
+
+	// publish sends messages until one reaches the subscription
+	publish := func(id string) {
+		t.Helper()
+		deadline := time.After(5 * time.Second)
+		for i := 0; ; i++ {
+			service.PublishMessage(ctx, &pb.PublishMessageRequest{
+				Message: &pb.Message{MessageId: fmt.Sprintf("%s_%d", id, i), Role: pb.Role_ROLE_USER},
+				Routing: &pb.AgentEventMetadata{FromAgentId: "producer", ToAgentId: "agent1", EventType: "a2a.message"},
+			})
+			select {
+			case event := <-events:
+				if !strings.HasPrefix(event.GetMessage().GetMessageId(), id) {
+					t.Fatalf("Expected a %s message, got %s", id, event.GetMessage().GetMessageId())
+				}
+				return
+			case <-time.After(20 * time.Millisecond):
+			case <-deadline:
+				t.Fatalf("Expected %s to be delivered", id)
+			}
+		}
+	}
+
+	publish("before")
+
+	// The stream ends when the broker restarts, and the subscription comes back
+	server.Stop()
+	server = serve(brokerAddr)
+	defer server.Stop()
+	publish("after")
+
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,100 @@
+package agenthub
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MetadataValue looks up a nested metadata field by path, e.g. MetadataValue(md, "routing", "priority").
+// It reports false when the metadata is nil, a path element is missing, or an intermediate value is not a struct.
+func MetadataValue(metadata *structpb.Struct, path ...string) (*structpb.Value, bool) {
+	if metadata == nil || len(path) == 0 {
+		return nil, false
+	}
+
+	current := metadata
+	for i, key := range path {
+		value, ok := current.GetFields()[key]
+		if !ok || value == nil {
+			return nil, false
+		}
+		if i == len(path)-1 {
+			return value, true
+		}
+		current = value.GetStructValue()
+		if current == nil {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// MetadataString returns the string value of a nested metadata field.
+// It reports false when the field is absent or not a string.
+func MetadataString(metadata *structpb.Struct, path ...string) (string, bool) {
+	value, ok := MetadataValue(metadata, path...)
+	if !ok {
+		return "", false
+	}
+	if _, isString := value.GetKind().(*structpb.Value_StringValue); !isString {
+		return "", false
+	}
+	return value.GetStringValue(), true
+}
+
+// MetadataNumber returns the numeric value of a nested metadata field.
+// It reports false when the field is absent or not a number.
+func MetadataNumber(metadata *structpb.Struct, path ...string) (float64, bool) {
+	value, ok := MetadataValue(metadata, path...)
+	if !ok {
+		return 0, false
+	}
+	if _, isNumber := value.GetKind().(*structpb.Value_NumberValue); !isNumber {
+		return 0, false
+	}
+	return value.GetNumberValue(), true
+}
+
+// MetadataBool returns the boolean value of a nested metadata field.
+// It reports false when the field is absent or not a boolean.
+func MetadataBool(metadata *structpb.Struct, path ...string) (bool, bool) {
+	value, ok := MetadataValue(metadata, path...)
+	if !ok {
+		return false, false
+	}
+	if _, isBool := value.GetKind().(*structpb.Value_BoolValue); !isBool {
+		return false, false
+	}
+	return value.GetBoolValue(), true
+}
+
+// metadataDepth returns the nesting depth of a metadata struct. A flat struct has depth 1.
+func metadataDepth(metadata *structpb.Struct) int {
+	if metadata == nil {
+		return 0
+	}
+	depth := 0
+	for _, value := range metadata.GetFields() {
+		if d := valueDepth(value); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
+
+// valueDepth returns the nesting depth contributed by a metadata value
+func valueDepth(value *structpb.Value) int {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return metadataDepth(kind.StructValue)
+	case *structpb.Value_ListValue:
+		depth := 0
+		for _, item := range kind.ListValue.GetValues() {
+			if d := valueDepth(item); d > depth {
+				depth = d
+			}
+		}
+		return depth + 1
+	default:
+		return 0
+	}
+}
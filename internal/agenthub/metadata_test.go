@@ -0,0 +1,79 @@
+package agenthub
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_PublishMessage_MetadataLimits(t *testing.T) {
+	service := newTestAgentHubService()
+	service.Server.Config.MaxMetadataDepth = 2
+	service.Server.Config.MaxMetadataBytes = 64
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		wantErr  bool
+	}{
+		{name: "within limits", metadata: map[string]interface{}{"task_type": "echo", "opts": map[string]interface{}{"n": 1}}},
+		{name: "too deep", metadata: map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": 1}}}, wantErr: true},
+		{name: "too large", metadata: map[string]interface{}{"blob": strings.Repeat("x", 100)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := structpb.NewStruct(tt.metadata)
+			if err != nil {
+				t.Fatalf("failed to build metadata: %v", err)
+			}
+			_, err = service.PublishMessage(ctx, &pb.PublishMessageRequest{
+				Message: &pb.Message{
+					MessageId: "metadata-msg",
+					Role:      pb.Role_ROLE_USER,
+					Metadata:  metadata,
+				},
+				Routing: &pb.AgentEventMetadata{FromAgentId: "test-requester"},
+			})
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("Expected InvalidArgument, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMetadataString(t *testing.T) {
+	metadata, err := structpb.NewStruct(map[string]interface{}{
+		"task_type": "echo",
+		"routing":   map[string]interface{}{"target": "agent1", "priority": 3},
+	})
+	if err != nil {
+		t.Fatalf("failed to build metadata: %v", err)
+	}
+
+	if v, ok := MetadataString(metadata, "routing", "target"); !ok || v != "agent1" {
+		t.Errorf("Expected nested target agent1, got %q, %v", v, ok)
+	}
+	if _, ok := MetadataString(metadata, "routing", "priority"); ok {
+		t.Error("Expected non-string field to report false")
+	}
+	if _, ok := MetadataString(metadata, "task_type", "child"); ok {
+		t.Error("Expected path through a scalar to report false")
+	}
+	if _, ok := MetadataString(nil, "task_type"); ok {
+		t.Error("Expected nil metadata to report false")
+	}
+}
@@ -0,0 +1,60 @@
+package agenthub
+
+import (
+	"sync"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// orderingSlot identifies a stream of events that must be delivered in order:
+// all events sharing an ordering key, for a single subscriber channel.
+type orderingSlot struct {
+	ch  chan *pb.AgentEvent
+	key string
+}
+
+// orderedDispatcher serializes deliveries per (subscriber, ordering key) while
+// letting different keys and different subscribers proceed in parallel.
+type orderedDispatcher struct {
+	mu      sync.Mutex
+	pending map[orderingSlot][]*pb.AgentEvent
+}
+
+func newOrderedDispatcher() *orderedDispatcher {
+	return &orderedDispatcher{
+		pending: make(map[orderingSlot][]*pb.AgentEvent),
+	}
+}
+
+// dispatch queues the event for the slot and starts a drain goroutine if none is running.
+// The send function is called sequentially, in enqueue order, for a given slot.
+func (d *orderedDispatcher) dispatch(ch chan *pb.AgentEvent, key string, evt *pb.AgentEvent, send func(chan *pb.AgentEvent, *pb.AgentEvent)) {
+	slot := orderingSlot{ch: ch, key: key}
+
+	d.mu.Lock()
+	queue, running := d.pending[slot]
+	d.pending[slot] = append(queue, evt)
+	d.mu.Unlock()
+
+	if !running {
+		go d.drain(slot, send)
+	}
+}
+
+// drain delivers queued events for a slot until the queue is empty
+func (d *orderedDispatcher) drain(slot orderingSlot, send func(chan *pb.AgentEvent, *pb.AgentEvent)) {
+	for {
+		d.mu.Lock()
+		queue := d.pending[slot]
+		if len(queue) == 0 {
+			delete(d.pending, slot)
+			d.mu.Unlock()
+			return
+		}
+		evt := queue[0]
+		d.pending[slot] = queue[1:]
+		d.mu.Unlock()
+
+		send(slot.ch, evt)
+	}
+}
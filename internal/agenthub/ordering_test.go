@@ -0,0 +1,49 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_RouteEvent_OrderingKey(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	subChan := make(chan *pb.AgentEvent, 1)
+	service.agentMu.Lock()
+	service.messageSubscribers["agent1"] = append(service.messageSubscribers["agent1"], subChan)
+	service.agentMu.Unlock()
+
+	const count = 50
+	for i := 0; i < count; i++ {
+		event := &pb.AgentEvent{
+			EventId: fmt.Sprintf("evt_%d", i),
+			Payload: &pb.AgentEvent_Message{
+				Message: &pb.Message{MessageId: fmt.Sprintf("msg_%d", i)},
+			},
+			Routing: &pb.AgentEventMetadata{
+				ToAgentId:   "agent1",
+				EventType:   "a2a.message",
+				OrderingKey: "ctx_1",
+			},
+		}
+		if err := service.routeEvent(ctx, event); err != nil {
+			t.Fatalf("routeEvent failed: %v", err)
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		select {
+		case evt := <-subChan:
+			if want := fmt.Sprintf("evt_%d", i); evt.GetEventId() != want {
+				t.Fatalf("Expected %s, got %s", want, evt.GetEventId())
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for event %d", i)
+		}
+	}
+}
@@ -0,0 +1,527 @@
+package agenthub
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DefaultOutboxRetryInterval is how often the outbox retries the publishes the
+// broker has not acknowledged yet
+const DefaultOutboxRetryInterval = 5 * time.Second
+
+// Kinds of publish requests kept in an outbox
+const (
+	OutboxKindMessage      = "message"
+	OutboxKindTaskUpdate   = "task_update"
+	OutboxKindTaskArtifact = "task_artifact"
+)
+
+// OutboxEntry is a publish request kept by an Outbox until the broker acknowledges it.
+// Exactly one of the requests is set.
+type OutboxEntry struct {
+	ID        string
+	CreatedAt time.Time
+
+	Message      *pb.PublishMessageRequest
+	TaskUpdate   *pb.PublishTaskUpdateRequest
+	TaskArtifact *pb.PublishTaskArtifactRequest
+}
+
+// Kind returns the kind of the publish request of the entry
+func (e *OutboxEntry) Kind() string {
+	switch {
+	case e.Message != nil:
+		return OutboxKindMessage
+	case e.TaskUpdate != nil:
+		return OutboxKindTaskUpdate
+	case e.TaskArtifact != nil:
+		return OutboxKindTaskArtifact
+	default:
+		return ""
+	}
+}
+
+// request returns the publish request of the entry
+func (e *OutboxEntry) request() proto.Message {
+	switch {
+	case e.Message != nil:
+		return e.Message
+	case e.TaskUpdate != nil:
+		return e.TaskUpdate
+	default:
+		return e.TaskArtifact
+	}
+}
+
+// OutboxStore durably keeps the entries of an Outbox. Implementations must be safe
+// for concurrent use; an embedded database fits this interface as well as the
+// bundled FileOutboxStore.
+type OutboxStore interface {
+	// Append stores a new entry, and must not return before it is durable
+	Append(ctx context.Context, entry *OutboxEntry) error
+	// MarkSent records that the broker acknowledged an entry. Marking an unknown
+	// entry is not an error.
+	MarkSent(ctx context.Context, id string) error
+	// Pending returns the entries not marked sent, in append order
+	Pending(ctx context.Context) ([]*OutboxEntry, error)
+}
+
+// InMemoryOutboxStore is an OutboxStore backed by a slice. Entries are lost on
+// restart, so it only covers broker outages.
+type InMemoryOutboxStore struct {
+	mu      sync.Mutex
+	entries []*OutboxEntry
+}
+
+// NewInMemoryOutboxStore creates an empty in-memory outbox store
+func NewInMemoryOutboxStore() *InMemoryOutboxStore {
+	return &InMemoryOutboxStore{}
+}
+
+// Append adds the entry after the pending ones
+func (m *InMemoryOutboxStore) Append(ctx context.Context, entry *OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+// MarkSent forgets the entry
+func (m *InMemoryOutboxStore) MarkSent(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, entry := range m.entries {
+		if entry.ID == id {
+			m.entries = append(m.entries[:i:i], m.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Pending returns the entries not marked sent
+func (m *InMemoryOutboxStore) Pending(ctx context.Context) ([]*OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*OutboxEntry(nil), m.entries...), nil
+}
+
+// outboxRecord is a line of a FileOutboxStore file: either an appended entry,
+// or the acknowledgement of one
+type outboxRecord struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind,omitempty"`
+	Payload   []byte    `json:"payload,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	Sent      bool      `json:"sent,omitempty"`
+}
+
+// FileOutboxStore is an OutboxStore writing to an append-only file of JSON lines.
+// Appended entries and acknowledgements are synced to disk before returning. The
+// file is compacted when it is opened, and truncated once every entry is sent.
+type FileOutboxStore struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries []*OutboxEntry
+}
+
+// NewFileOutboxStore opens the outbox file at path, creating it and its directory
+// if needed, and loads the entries left unsent by a previous run
+func NewFileOutboxStore(path string) (*FileOutboxStore, error) {
+	entries, err := readOutboxFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	// Rewrite the file with the pending entries only, then keep appending to it
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".outbox-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compact outbox: %w", err)
+	}
+	for _, entry := range entries {
+		if err = writeOutboxRecord(tmp, entry); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("failed to compact outbox: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox: %w", err)
+	}
+	return &FileOutboxStore{file: file, entries: entries}, nil
+}
+
+// readOutboxFile returns the unsent entries of an outbox file. A truncated last
+// line, left by a crash while appending, is ignored.
+func readOutboxFile(path string) ([]*OutboxEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox: %w", err)
+	}
+	defer f.Close()
+
+	var entries []*OutboxEntry
+	reader := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outbox: %w", err)
+		}
+
+		var record outboxRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("invalid outbox record at %s:%d: %w", path, line, err)
+		}
+		if record.Sent {
+			for i, entry := range entries {
+				if entry.ID == record.ID {
+					entries = append(entries[:i:i], entries[i+1:]...)
+					break
+				}
+			}
+			continue
+		}
+		entry, err := record.entry()
+		if err != nil {
+			return nil, fmt.Errorf("invalid outbox record at %s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// entry decodes the entry appended by the record
+func (r *outboxRecord) entry() (*OutboxEntry, error) {
+	entry := &OutboxEntry{ID: r.ID, CreatedAt: r.CreatedAt}
+	var request proto.Message
+	switch r.Kind {
+	case OutboxKindMessage:
+		entry.Message = &pb.PublishMessageRequest{}
+		request = entry.Message
+	case OutboxKindTaskUpdate:
+		entry.TaskUpdate = &pb.PublishTaskUpdateRequest{}
+		request = entry.TaskUpdate
+	case OutboxKindTaskArtifact:
+		entry.TaskArtifact = &pb.PublishTaskArtifactRequest{}
+		request = entry.TaskArtifact
+	default:
+		return nil, fmt.Errorf("unknown entry kind %q", r.Kind)
+	}
+	if err := proto.Unmarshal(r.Payload, request); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// writeOutboxRecord appends the record of an entry to w
+func writeOutboxRecord(w io.Writer, entry *OutboxEntry) error {
+	payload, err := proto.Marshal(entry.request())
+	if err != nil {
+		return err
+	}
+	return writeOutboxLine(w, outboxRecord{ID: entry.ID, Kind: entry.Kind(), Payload: payload, CreatedAt: entry.CreatedAt})
+}
+
+func writeOutboxLine(w io.Writer, record outboxRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// Append writes the entry to the file and syncs it
+func (f *FileOutboxStore) Append(ctx context.Context, entry *OutboxEntry) error {
+	if entry.Kind() == "" {
+		return fmt.Errorf("outbox entry %s has no publish request", entry.ID)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := writeOutboxRecord(f.file, entry); err != nil {
+		return fmt.Errorf("failed to append to outbox: %w", err)
+	}
+	if err := f.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync outbox: %w", err)
+	}
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+// MarkSent writes the acknowledgement of the entry, or truncates the file when no
+// entry is left pending
+func (f *FileOutboxStore) MarkSent(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	found := false
+	for i, entry := range f.entries {
+		if entry.ID == id {
+			f.entries = append(f.entries[:i:i], f.entries[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	var err error
+	if len(f.entries) == 0 {
+		err = f.file.Truncate(0)
+	} else {
+		err = writeOutboxLine(f.file, outboxRecord{ID: id, Sent: true})
+	}
+	if err == nil {
+		err = f.file.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox entry sent: %w", err)
+	}
+	return nil
+}
+
+// Pending returns the entries not marked sent
+func (f *FileOutboxStore) Pending(ctx context.Context) ([]*OutboxEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*OutboxEntry(nil), f.entries...), nil
+}
+
+// Close closes the outbox file
+func (f *FileOutboxStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// Outbox makes publishes survive agent crashes and broker outages: each request is
+// stored before being sent, and retried until the broker acknowledges it. Entries
+// are sent in the order they were published; the broker deduplication window
+// (AGENTHUB_DEDUP_WINDOW) absorbs messages resent after a crash between the
+// acknowledgement and its recording.
+type Outbox struct {
+	// RetryInterval is how often Run retries unacknowledged entries
+	RetryInterval time.Duration
+	// IDs generates the entry IDs
+	IDs IDGenerator
+
+	store   OutboxStore
+	client  pb.AgentHubClient
+	logger  *slog.Logger
+	flushMu sync.Mutex
+	// wake tells Run that an entry was appended
+	wake chan struct{}
+}
+
+// NewOutbox creates an outbox storing entries in store and publishing them with client
+func NewOutbox(store OutboxStore, client pb.AgentHubClient, logger *slog.Logger) *Outbox {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Outbox{
+		RetryInterval: DefaultOutboxRetryInterval,
+		IDs:           DefaultIDGenerator,
+		store:         store,
+		client:        client,
+		logger:        logger,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// PublishMessage stores the message publish for Run to send. It returns the entry
+// ID once the request is stored, without waiting for the broker.
+func (o *Outbox) PublishMessage(ctx context.Context, req *pb.PublishMessageRequest) (string, error) {
+	return o.enqueue(ctx, &OutboxEntry{Message: req})
+}
+
+// PublishTaskUpdate stores the task status update for Run to publish
+func (o *Outbox) PublishTaskUpdate(ctx context.Context, req *pb.PublishTaskUpdateRequest) (string, error) {
+	return o.enqueue(ctx, &OutboxEntry{TaskUpdate: req})
+}
+
+// PublishTaskArtifact stores the task artifact for Run to publish
+func (o *Outbox) PublishTaskArtifact(ctx context.Context, req *pb.PublishTaskArtifactRequest) (string, error) {
+	return o.enqueue(ctx, &OutboxEntry{TaskArtifact: req})
+}
+
+func (o *Outbox) enqueue(ctx context.Context, entry *OutboxEntry) (string, error) {
+	entry.ID = idGeneratorOrDefault(o.IDs).NewID("outbox")
+	entry.CreatedAt = time.Now()
+	if err := o.store.Append(ctx, entry); err != nil {
+		return "", err
+	}
+	// Sending is left to Run, so that a backlog never delays the publisher
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return entry.ID, nil
+}
+
+// Flush publishes the pending entries in order, stopping at the first one the
+// broker fails to acknowledge. Entries the broker rejects as invalid or not
+// permitted are dropped, as retrying them cannot succeed. It returns the number of entries acknowledged.
+func (o *Outbox) Flush(ctx context.Context) (int, error) {
+	o.flushMu.Lock()
+	defer o.flushMu.Unlock()
+
+	entries, err := o.store.Pending(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, entry := range entries {
+		if err := o.send(ctx, entry); err != nil {
+			if !isPermanentPublishError(err) {
+				return sent, err
+			}
+			o.logger.ErrorContext(ctx, "Broker rejected outbox entry, dropping it",
+				"entry_id", entry.ID,
+				"kind", entry.Kind(),
+				"error", err,
+			)
+		} else {
+			sent++
+		}
+		if err := o.store.MarkSent(ctx, entry.ID); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// send publishes an entry, failing unless the broker reports success
+func (o *Outbox) send(ctx context.Context, entry *OutboxEntry) error {
+	var resp *pb.PublishResponse
+	var err error
+	switch {
+	case entry.Message != nil:
+		resp, err = o.client.PublishMessage(ctx, entry.Message)
+	case entry.TaskUpdate != nil:
+		resp, err = o.client.PublishTaskUpdate(ctx, entry.TaskUpdate)
+	case entry.TaskArtifact != nil:
+		resp, err = o.client.PublishTaskArtifact(ctx, entry.TaskArtifact)
+	default:
+		return status.Errorf(codes.InvalidArgument, "outbox entry %s has no publish request", entry.ID)
+	}
+	if err != nil {
+		return err
+	}
+	if !resp.GetSuccess() {
+		return fmt.Errorf("%w: %s", errPublishRejected, resp.GetError())
+	}
+	return nil
+}
+
+// errPublishRejected is returned for a publish the broker answered without success,
+// which it does when it cannot route the event, for instance while no agent with
+// the required skill is registered
+var errPublishRejected = errors.New("broker failed to publish")
+
+// isPermanentPublishError reports whether the broker refused a publish for a
+// reason that retrying cannot fix. A publish answered without success is retried:
+// the agent able to handle it may be restarting or not deployed yet.
+func isPermanentPublishError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.PermissionDenied:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run replays the entries left pending by a previous run, then sends new entries as
+// they are stored and retries unacknowledged ones every RetryInterval, until ctx is done
+func (o *Outbox) Run(ctx context.Context) {
+	interval := o.RetryInterval
+	if interval <= 0 {
+		interval = DefaultOutboxRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if sent, err := o.Flush(ctx); err != nil && ctx.Err() == nil {
+			o.logger.WarnContext(ctx, "Outbox flush failed, retrying later",
+				"sent", sent,
+				"retry_in", interval,
+				"error", err,
+			)
+		} else if sent > 0 {
+			o.logger.InfoContext(ctx, "Outbox flushed pending publishes", "sent", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.wake:
+		}
+	}
+}
+
+// Close waits for a running flush, and closes the store if it holds resources
+func (o *Outbox) Close() error {
+	o.flushMu.Lock()
+	defer o.flushMu.Unlock()
+	if closer, ok := o.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// publishTaskUpdate publishes a task status update, through the outbox when one is configured
+func (c *AgentHubClient) publishTaskUpdate(ctx context.Context, req *pb.PublishTaskUpdateRequest) error {
+	if c.Outbox != nil {
+		_, err := c.Outbox.PublishTaskUpdate(ctx, req)
+		return err
+	}
+	_, err := c.Client.PublishTaskUpdate(ctx, req)
+	return err
+}
+
+// publishTaskArtifact publishes a task artifact, through the outbox when one is configured
+func (c *AgentHubClient) publishTaskArtifact(ctx context.Context, req *pb.PublishTaskArtifactRequest) error {
+	if c.Outbox != nil {
+		_, err := c.Outbox.PublishTaskArtifact(ctx, req)
+		return err
+	}
+	_, err := c.Client.PublishTaskArtifact(ctx, req)
+	return err
+}
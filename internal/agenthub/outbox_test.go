@@ -0,0 +1,147 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// unavailableBroker fails every publish as if the broker were down
+type unavailableBroker struct {
+	pb.AgentHubClient
+}
+
+func (unavailableBroker) PublishMessage(ctx context.Context, req *pb.PublishMessageRequest, opts ...grpc.CallOption) (*pb.PublishResponse, error) {
+	return nil, status.Error(codes.Unavailable, "broker down")
+}
+
+func TestOutbox(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	received := make(chan *pb.AgentEvent, 4)
+	service.agentMu.Lock()
+	service.messageSubscribers["agent1"] = []chan *pb.AgentEvent{received}
+	service.agentMu.Unlock()
+
+	path := filepath.Join(t.TempDir(), "outbox", "outbox.jsonl")
+	store, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore failed: %v", err)
+	}
+
+	// Publishes made while the broker is down are kept
+	outbox := NewOutbox(store, unavailableBroker{}, service.Server.Logger)
+	outbox.IDs = NewSequentialIDGenerator()
+	routing := &pb.AgentEventMetadata{FromAgentId: "producer", ToAgentId: "agent1", EventType: "a2a.message"}
+	invalid := &pb.PublishMessageRequest{Message: &pb.Message{Role: pb.Role_ROLE_AGENT}, Routing: routing}
+	unroutable := &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "result_0", Role: pb.Role_ROLE_AGENT},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "producer", RequiredSkill: "missing", EventType: "a2a.message"},
+	}
+	valid := &pb.PublishMessageRequest{Message: &pb.Message{MessageId: "result_1", Role: pb.Role_ROLE_AGENT}, Routing: routing}
+	for _, req := range []*pb.PublishMessageRequest{invalid, unroutable, valid} {
+		if _, err := outbox.PublishMessage(ctx, req); err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+	if err := outbox.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// After a restart the unsent entries are replayed: the invalid one is dropped,
+	// and the flush stops at the entry no agent can handle yet
+	store, err = NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore failed: %v", err)
+	}
+	if pending, _ := store.Pending(ctx); len(pending) != 3 || pending[2].Message.GetMessage().GetMessageId() != "result_1" {
+		t.Fatalf("Expected the 3 unsent entries to be reloaded in order, got %v", pending)
+	}
+	outbox = NewOutbox(store, startTestBroker(t, service), service.Server.Logger)
+	sent, err := outbox.Flush(ctx)
+	if !errors.Is(err, errPublishRejected) || sent != 0 {
+		t.Fatalf("Expected the unroutable entry to stop the flush, got %d sent (%v)", sent, err)
+	}
+	if pending, _ := store.Pending(ctx); len(pending) != 2 || pending[0].Message.GetMessage().GetMessageId() != "result_0" {
+		t.Fatalf("Expected the unroutable and following entries to be kept, got %v", pending)
+	}
+
+	// Once an agent with the skill registers, the kept entries are delivered in order
+	if _, err := service.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentCard: &pb.AgentCard{Name: "agent1", Skills: []*pb.AgentSkill{{Id: "missing"}}},
+	}); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	sent, err = outbox.Flush(ctx)
+	if err != nil || sent != 2 {
+		t.Fatalf("Expected 2 entries sent, got %d (%v)", sent, err)
+	}
+	for _, want := range []string{"result_0", "result_1"} {
+		select {
+		case event := <-received:
+			if event.GetMessage().GetMessageId() != want {
+				t.Errorf("Expected %s, got %s", want, event.GetMessage().GetMessageId())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected the replayed message %s to be delivered", want)
+		}
+	}
+
+	// New entries are sent by Run, not by the publisher
+	runCtx, stopRun := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		outbox.Run(runCtx)
+		close(done)
+	}()
+	if _, err := outbox.PublishMessage(ctx, &pb.PublishMessageRequest{
+		Message: &pb.Message{MessageId: "result_2", Role: pb.Role_ROLE_AGENT},
+		Routing: routing,
+	}); err != nil {
+		t.Fatalf("PublishMessage failed: %v", err)
+	}
+	select {
+	case event := <-received:
+		if event.GetMessage().GetMessageId() != "result_2" {
+			t.Errorf("Expected result_2, got %s", event.GetMessage().GetMessageId())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Run to send the new entry without waiting for the retry interval")
+	}
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if pending, _ := store.Pending(ctx); len(pending) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the sent entry to be acknowledged")
+		}
+	}
+	stopRun()
+	<-done
+	if err := outbox.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Once every entry is acknowledged nothing is left to replay
+	if info, err := os.Stat(path); err != nil || info.Size() != 0 {
+		t.Errorf("Expected an empty outbox file, got %v (%v)", info, err)
+	}
+	store, err = NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore failed: %v", err)
+	}
+	defer store.Close()
+	if pending, _ := store.Pending(ctx); len(pending) != 0 {
+		t.Errorf("Expected no pending entries, got %d", len(pending))
+	}
+}
@@ -0,0 +1,116 @@
+package agenthub
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// PartKind identifies the type of a message content part
+type PartKind string
+
+// Content part kinds of the A2A Part oneof
+const (
+	PartKindText PartKind = "text"
+	PartKindData PartKind = "data"
+	PartKindFile PartKind = "file"
+)
+
+// MessageParts holds the content of a message grouped by part kind, in message order
+type MessageParts struct {
+	Texts []string
+	Data  []*structpb.Struct
+	Files []*pb.FilePart
+}
+
+// Count returns the number of parts of the given kind
+func (p MessageParts) Count(kind PartKind) int {
+	switch kind {
+	case PartKindText:
+		return len(p.Texts)
+	case PartKindData:
+		return len(p.Data)
+	case PartKindFile:
+		return len(p.Files)
+	default:
+		return 0
+	}
+}
+
+// ExtractParts groups the content parts of a message by kind. Empty parts are skipped.
+func ExtractParts(msg *pb.Message) MessageParts {
+	var parts MessageParts
+	for _, part := range msg.GetContent() {
+		switch p := part.GetPart().(type) {
+		case *pb.Part_Text:
+			parts.Texts = append(parts.Texts, p.Text)
+		case *pb.Part_Data:
+			parts.Data = append(parts.Data, p.Data.GetData())
+		case *pb.Part_File:
+			parts.Files = append(parts.Files, p.File)
+		}
+	}
+	return parts
+}
+
+// PartRequirement bounds how many parts of a kind a message may carry.
+// A zero Max means no upper bound.
+type PartRequirement struct {
+	Kind PartKind
+	Min  int
+	Max  int
+}
+
+// ExactlyParts requires exactly n parts of the given kind
+func ExactlyParts(kind PartKind, n int) PartRequirement {
+	return PartRequirement{Kind: kind, Min: n, Max: n}
+}
+
+// AtLeastParts requires n or more parts of the given kind
+func AtLeastParts(kind PartKind, n int) PartRequirement {
+	return PartRequirement{Kind: kind, Min: n}
+}
+
+func (r PartRequirement) String() string {
+	switch {
+	case r.Min == r.Max:
+		return fmt.Sprintf("exactly %d %s part(s)", r.Min, r.Kind)
+	case r.Max == 0:
+		return fmt.Sprintf("at least %d %s part(s)", r.Min, r.Kind)
+	default:
+		return fmt.Sprintf("%d to %d %s part(s)", r.Min, r.Max, r.Kind)
+	}
+}
+
+// ValidateParts checks the content parts of a message against the requirements.
+// Parts of a kind no requirement mentions are rejected. It returns nil when no
+// requirement is given.
+func ValidateParts(msg *pb.Message, requirements []PartRequirement) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	parts := ExtractParts(msg)
+	allowed := make(map[PartKind]bool, len(requirements))
+	var violations []string
+	for _, req := range requirements {
+		allowed[req.Kind] = true
+		count := parts.Count(req.Kind)
+		if count < req.Min || (req.Max > 0 && count > req.Max) {
+			violations = append(violations, fmt.Sprintf("expected %s, got %d", req, count))
+		}
+	}
+	for _, kind := range []PartKind{PartKindText, PartKindData, PartKindFile} {
+		if count := parts.Count(kind); count > 0 && !allowed[kind] {
+			violations = append(violations, fmt.Sprintf("unexpected %d %s part(s)", count, kind))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("message parts do not match: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
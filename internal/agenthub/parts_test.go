@@ -0,0 +1,62 @@
+package agenthub
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestExtractAndValidateParts(t *testing.T) {
+	data, _ := structpb.NewStruct(map[string]any{"n": 1})
+	msg := &pb.Message{
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: "hello"}},
+			{Part: &pb.Part_Data{Data: &pb.DataPart{Data: data}}},
+			{Part: &pb.Part_File{File: &pb.FilePart{Name: "a.png"}}},
+			{},
+		},
+	}
+
+	parts := ExtractParts(msg)
+	if len(parts.Texts) != 1 || parts.Texts[0] != "hello" {
+		t.Errorf("Expected one text part, got %v", parts.Texts)
+	}
+	if len(parts.Data) != 1 || parts.Data[0].GetFields()["n"].GetNumberValue() != 1 {
+		t.Errorf("Expected one data part, got %v", parts.Data)
+	}
+	if len(parts.Files) != 1 || parts.Files[0].GetName() != "a.png" {
+		t.Errorf("Expected one file part, got %v", parts.Files)
+	}
+
+	tests := []struct {
+		name         string
+		requirements []PartRequirement
+		wantErr      string
+	}{
+		{"no requirements", nil, ""},
+		{"all kinds allowed", []PartRequirement{
+			ExactlyParts(PartKindText, 1), AtLeastParts(PartKindData, 1), AtLeastParts(PartKindFile, 0),
+		}, ""},
+		{"too few", []PartRequirement{
+			ExactlyParts(PartKindText, 2), AtLeastParts(PartKindData, 0), AtLeastParts(PartKindFile, 0),
+		}, "expected exactly 2 text part(s), got 1"},
+		{"unexpected kinds", []PartRequirement{ExactlyParts(PartKindText, 1)}, "unexpected 1 data part(s); unexpected 1 file part(s)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateParts(msg, tt.requirements)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package agenthub
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// DefaultRedactedFields are payload keys whose values are never written to payload logs
+var DefaultRedactedFields = []string{"password", "secret", "token", "api_key", "authorization"}
+
+const redactedValue = "[REDACTED]"
+
+// logPayload writes the full serialized payload at TRACE level. It is a no-op
+// unless payload logging is enabled in the config and the logger accepts TRACE.
+func (s *AgentHubService) logPayload(ctx context.Context, msg string, payload proto.Message) {
+	config := s.Server.Config
+	if config == nil || !config.LogPayloads || !s.Server.Logger.Enabled(ctx, observability.LevelTrace) {
+		return
+	}
+
+	s.Server.Logger.Log(ctx, observability.LevelTrace, msg,
+		"payload", redactPayload(payload, config.RedactedFields),
+	)
+}
+
+// redactPayload serializes a payload to JSON, replacing the values of redacted keys
+func redactPayload(payload proto.Message, redactedFields []string) string {
+	raw, err := protojson.Marshal(payload)
+	if err != nil {
+		return "<unserializable payload: " + err.Error() + ">"
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "<unserializable payload: " + err.Error() + ">"
+	}
+
+	redacted, err := json.Marshal(redactValue(doc, redactedFields))
+	if err != nil {
+		return "<unserializable payload: " + err.Error() + ">"
+	}
+	return string(redacted)
+}
+
+// redactValue walks a decoded JSON document and masks the values of redacted keys
+func redactValue(value interface{}, redactedFields []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isRedactedField(key, redactedFields) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field, redactedFields)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, redactedFields)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isRedactedField reports whether a key matches a redaction rule (case-insensitive)
+func isRedactedField(key string, redactedFields []string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package agenthub
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestRedactPayload(t *testing.T) {
+	metadata, err := structpb.NewStruct(map[string]interface{}{
+		"api_key": "sk-123",
+		"nested":  map[string]interface{}{"Password": "hunter2", "user": "alice"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build metadata: %v", err)
+	}
+	msg := &pb.Message{MessageId: "msg-1", Metadata: metadata}
+
+	out := redactPayload(msg, DefaultRedactedFields)
+	for _, secret := range []string{"sk-123", "hunter2"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("payload log leaked %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "alice") || !strings.Contains(out, redactedValue) {
+		t.Errorf("unexpected redacted payload: %s", out)
+	}
+}
@@ -0,0 +1,84 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// ConnectionPool spreads publish calls across several broker connections so that
+// high fan-out publishers are not capped by the stream limit of a single HTTP/2
+// connection. Subscriptions and queries go through the embedded primary client.
+type ConnectionPool struct {
+	pb.AgentHubClient
+
+	conns   []*grpc.ClientConn
+	clients []pb.AgentHubClient
+	next    atomic.Uint64
+}
+
+// newConnectionPool builds a pool of size connections: the primary connection plus
+// size-1 additional ones opened with dial.
+func newConnectionPool(primary *grpc.ClientConn, size int, dial func() (*grpc.ClientConn, error)) (*ConnectionPool, error) {
+	if size < 1 {
+		size = 1
+	}
+
+	pool := &ConnectionPool{
+		AgentHubClient: pb.NewAgentHubClient(primary),
+		clients:        []pb.AgentHubClient{pb.NewAgentHubClient(primary)},
+	}
+	for i := 1; i < size; i++ {
+		conn, err := dial()
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to open pooled connection %d: %w", i, err)
+		}
+		pool.conns = append(pool.conns, conn)
+		pool.clients = append(pool.clients, pb.NewAgentHubClient(conn))
+	}
+	return pool, nil
+}
+
+// Size returns the number of connections in the pool, including the primary one
+func (p *ConnectionPool) Size() int {
+	return len(p.clients)
+}
+
+// pick returns the next client in round-robin order
+func (p *ConnectionPool) pick() pb.AgentHubClient {
+	return p.clients[(p.next.Add(1)-1)%uint64(len(p.clients))]
+}
+
+// PublishMessage publishes a message on the next pooled connection
+func (p *ConnectionPool) PublishMessage(ctx context.Context, in *pb.PublishMessageRequest, opts ...grpc.CallOption) (*pb.PublishResponse, error) {
+	return p.pick().PublishMessage(ctx, in, opts...)
+}
+
+// PublishTaskUpdate publishes a task update on the next pooled connection
+func (p *ConnectionPool) PublishTaskUpdate(ctx context.Context, in *pb.PublishTaskUpdateRequest, opts ...grpc.CallOption) (*pb.PublishResponse, error) {
+	return p.pick().PublishTaskUpdate(ctx, in, opts...)
+}
+
+// PublishTaskArtifact publishes a task artifact on the next pooled connection
+func (p *ConnectionPool) PublishTaskArtifact(ctx context.Context, in *pb.PublishTaskArtifactRequest, opts ...grpc.CallOption) (*pb.PublishResponse, error) {
+	return p.pick().PublishTaskArtifact(ctx, in, opts...)
+}
+
+// Close closes the additional pooled connections. The primary connection is owned
+// by the AgentHubClient and is left open.
+func (p *ConnectionPool) Close() error {
+	var errs []error
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	p.conns = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestConnectionPool_RoundRobin(t *testing.T) {
+	dials := 0
+	dial := func() (*grpc.ClientConn, error) {
+		dials++
+		return grpc.NewClient("passthrough:///pool-test", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	primary, _ := dial()
+	defer primary.Close()
+
+	pool, err := newConnectionPool(primary, 3, dial)
+	if err != nil {
+		t.Fatalf("newConnectionPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	if pool.Size() != 3 || dials != 3 {
+		t.Fatalf("Expected 3 pooled connections, got size %d with %d dials", pool.Size(), dials)
+	}
+	first := pool.pick()
+	pool.pick()
+	pool.pick()
+	if pool.pick() != first {
+		t.Error("Expected round-robin to wrap back to the first client")
+	}
+}
+
+// BenchmarkPublishMessage_ConnectionPool compares concurrent publish throughput
+// over a single connection and over a pool of connections.
+func BenchmarkPublishMessage_ConnectionPool(b *testing.B) {
+	service := newTestAgentHubService()
+	service.Server.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterAgentHubServer(server, service)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	dial := func() (*grpc.ClientConn, error) {
+		return grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	for _, size := range []int{1, 4} {
+		b.Run(fmt.Sprintf("connections=%d", size), func(b *testing.B) {
+			primary, err := dial()
+			if err != nil {
+				b.Fatalf("Failed to dial: %v", err)
+			}
+			defer primary.Close()
+			pool, err := newConnectionPool(primary, size, dial)
+			if err != nil {
+				b.Fatalf("newConnectionPool failed: %v", err)
+			}
+			defer pool.Close()
+
+			var seq atomic.Int64
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(pbench *testing.PB) {
+				ctx := context.Background()
+				for pbench.Next() {
+					_, err := pool.PublishMessage(ctx, &pb.PublishMessageRequest{
+						Message: &pb.Message{
+							MessageId: fmt.Sprintf("bench_%d", seq.Add(1)),
+							Role:      pb.Role_ROLE_USER,
+						},
+						Routing: &pb.AgentEventMetadata{FromAgentId: "bench", ToAgentId: "nobody"},
+					})
+					if err != nil {
+						b.Errorf("PublishMessage failed: %v", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package agenthub
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// ParsePriority parses a priority name as accepted by AGENTHUB_DEFAULT_PRIORITY:
+// low, medium, high or critical, optionally prefixed with PRIORITY_. An empty name is MEDIUM.
+func ParsePriority(name string) (pb.Priority, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	if normalized == "" {
+		return pb.Priority_PRIORITY_MEDIUM, nil
+	}
+	if !strings.HasPrefix(normalized, "PRIORITY_") {
+		normalized = "PRIORITY_" + normalized
+	}
+	value, ok := pb.Priority_value[normalized]
+	if !ok || pb.Priority(value) == pb.Priority_PRIORITY_UNSPECIFIED {
+		return pb.Priority_PRIORITY_MEDIUM, fmt.Errorf("unknown priority %q", name)
+	}
+	return pb.Priority(value), nil
+}
+
+// WithDefaultPriority sets the priority given to events published without one,
+// overriding GRPCConfig.DefaultPriority
+func WithDefaultPriority(priority pb.Priority) ServiceOption {
+	return func(s *AgentHubService) {
+		s.defaultPriority = priority
+	}
+}
+
+// applyDefaultPriority gives the configured default priority to an event
+// published without one
+func (s *AgentHubService) applyDefaultPriority(event *pb.AgentEvent) {
+	routing := event.GetRouting()
+	if routing.GetPriority() != pb.Priority_PRIORITY_UNSPECIFIED || s.defaultPriority == pb.Priority_PRIORITY_UNSPECIFIED {
+		return
+	}
+	prioritized := proto.Clone(routing).(*pb.AgentEventMetadata)
+	prioritized.Priority = s.defaultPriority
+	event.Routing = prioritized
+}
+
+// priorityKey is the context key of the priority of the event being handled
+type priorityKey struct{}
+
+// ContextWithPriority returns a copy of ctx carrying the priority of the event
+// being handled, so that the events it triggers are published with the same priority
+func ContextWithPriority(ctx context.Context, priority pb.Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority carried by ctx, or PRIORITY_UNSPECIFIED,
+// which lets the broker apply its default priority
+func PriorityFromContext(ctx context.Context) pb.Priority {
+	priority, _ := ctx.Value(priorityKey{}).(pb.Priority)
+	return priority
+}
+
+// eventPriority ranks an event for delivery; unspecified priority counts as MEDIUM
+func eventPriority(evt *pb.AgentEvent) pb.Priority {
+	if p := evt.GetRouting().GetPriority(); p != pb.Priority_PRIORITY_UNSPECIFIED {
+		return p
+	}
+	return pb.Priority_PRIORITY_MEDIUM
+}
+
+// deliveryQueue orders the events pending for one subscriber by priority,
+// highest first, keeping arrival order among events of the same priority.
+type deliveryQueue struct {
+	entries deliveryHeap
+	seq     uint64
+}
+
+type deliveryEntry struct {
+	event    *pb.AgentEvent
+	priority pb.Priority
+	seq      uint64
+}
+
+func (q *deliveryQueue) push(evt *pb.AgentEvent) {
+	q.seq++
+	heap.Push(&q.entries, deliveryEntry{event: evt, priority: eventPriority(evt), seq: q.seq})
+}
+
+// pop removes and returns the most urgent event, or nil when the queue is empty
+func (q *deliveryQueue) pop() *pb.AgentEvent {
+	if len(q.entries) == 0 {
+		return nil
+	}
+	return heap.Pop(&q.entries).(deliveryEntry).event
+}
+
+func (q *deliveryQueue) len() int {
+	return len(q.entries)
+}
+
+// deliveryHeap implements heap.Interface for deliveryQueue
+type deliveryHeap []deliveryEntry
+
+func (h deliveryHeap) Len() int { return len(h) }
+
+func (h deliveryHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h deliveryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *deliveryHeap) Push(x any) { *h = append(*h, x.(deliveryEntry)) }
+
+func (h *deliveryHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = deliveryEntry{}
+	*h = old[:len(old)-1]
+	return entry
+}
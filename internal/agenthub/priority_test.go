@@ -0,0 +1,90 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_StreamEvents_Priority(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subChan := make(chan *pb.AgentEvent, 10)
+	for _, evt := range []struct {
+		id       string
+		priority pb.Priority
+	}{
+		{"low-1", pb.Priority_PRIORITY_LOW},
+		{"low-2", pb.Priority_PRIORITY_LOW},
+		{"cancel", pb.Priority_PRIORITY_HIGH},
+		{"default", pb.Priority_PRIORITY_UNSPECIFIED},
+		{"low-3", pb.Priority_PRIORITY_LOW},
+	} {
+		subChan <- &pb.AgentEvent{EventId: evt.id, Routing: &pb.AgentEventMetadata{Priority: evt.priority}}
+	}
+
+	var sent []string
+	send := func(evt *pb.AgentEvent) error {
+		sent = append(sent, evt.GetEventId())
+		if len(sent) == 5 {
+			cancel()
+		}
+		return nil
+	}
+	if err := service.streamEvents(ctx, subscriptionTasks, "worker", nil, nil, subChan, send); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the stream to end with its context, got %v", err)
+	}
+
+	want := []string{"cancel", "default", "low-1", "low-2", "low-3"}
+	if fmt.Sprint(sent) != fmt.Sprint(want) {
+		t.Errorf("Expected delivery order %v, got %v", want, sent)
+	}
+}
+
+func TestAgentHubService_DefaultPriority(t *testing.T) {
+	if p, err := ParsePriority("high"); err != nil || p != pb.Priority_PRIORITY_HIGH {
+		t.Errorf("Expected high to parse as HIGH, got %s (%v)", p, err)
+	}
+	if p, err := ParsePriority("PRIORITY_LOW"); err != nil || p != pb.Priority_PRIORITY_LOW {
+		t.Errorf("Expected PRIORITY_LOW to parse as LOW, got %s (%v)", p, err)
+	}
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Error("Expected an unknown priority to be rejected")
+	}
+
+	service := newTestAgentHubService()
+	WithDefaultPriority(pb.Priority_PRIORITY_LOW)(service)
+	ctx := context.Background()
+
+	received := make(chan *pb.AgentEvent, 2)
+	service.agentMu.Lock()
+	service.messageSubscribers["agent1"] = []chan *pb.AgentEvent{received}
+	service.agentMu.Unlock()
+
+	for _, priority := range []pb.Priority{pb.Priority_PRIORITY_UNSPECIFIED, pb.Priority_PRIORITY_HIGH} {
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: "msg_" + priority.String(), Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "producer", ToAgentId: "agent1", EventType: "a2a.message", Priority: priority},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+
+	for _, want := range []pb.Priority{pb.Priority_PRIORITY_LOW, pb.Priority_PRIORITY_HIGH} {
+		select {
+		case event := <-received:
+			if got := event.GetRouting().GetPriority(); got != want {
+				t.Errorf("Expected priority %s for %s, got %s", want, event.GetMessage().GetMessageId(), got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the message to be delivered")
+		}
+	}
+}
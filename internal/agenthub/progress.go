@@ -0,0 +1,67 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// ReportProgress publishes a non-final TASK_STATE_WORKING status update carrying
+// a completion percentage (clamped to 0-100) and a human-readable message.
+// Trace context is propagated to the broker through ctx.
+func (c *AgentHubClient) ReportProgress(ctx context.Context, taskID string, percent int, message string) error {
+	if taskID == "" {
+		return fmt.Errorf("task ID is required to report progress")
+	}
+
+	percent = max(0, min(percent, 100))
+
+	ctx, span := c.TraceManager.StartPublishSpan(ctx, c.Config.ComponentName, "task_progress", "task_progress")
+	defer span.End()
+
+	progressMessage := &pb.Message{
+		MessageId: c.NewID("progress_" + taskID),
+		TaskId:    taskID,
+		Role:      pb.Role_ROLE_AGENT,
+		Content: []*pb.Part{
+			{Part: &pb.Part_Text{Text: message}},
+		},
+		Metadata: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"progress_percent": structpb.NewNumberValue(float64(percent)),
+				"progress_message": structpb.NewStringValue(message),
+			},
+		},
+	}
+
+	_, err := c.Client.PublishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
+		Update: &pb.TaskStatusUpdateEvent{
+			TaskId: taskID,
+			Status: &pb.TaskStatus{
+				State:     pb.TaskState_TASK_STATE_WORKING,
+				Update:    progressMessage,
+				Timestamp: timestamppb.Now(),
+			},
+			Final: false,
+		},
+		Routing: &pb.AgentEventMetadata{
+			FromAgentId: c.Config.ComponentName,
+			EventType:   "task_progress",
+			Priority:    pb.Priority_PRIORITY_LOW,
+			Headers:     HeadersFromContext(ctx),
+		},
+	})
+	if err != nil {
+		c.TraceManager.RecordError(span, err)
+		c.MetricsManager.IncrementEventErrors(ctx, "task_progress", c.Config.ComponentName, "grpc_error")
+		return fmt.Errorf("failed to report progress for task %s: %w", taskID, err)
+	}
+
+	c.MetricsManager.IncrementEventsPublished(ctx, "task_progress", "broker")
+	c.TraceManager.SetSpanSuccess(span)
+	return nil
+}
@@ -0,0 +1,156 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// taskPollInterval is how often PublishTaskAndWait re-reads the task from the broker,
+// in case a terminal update was routed before the subscription was in place
+const taskPollInterval = time.Second
+
+// TaskWaitTimeoutError is returned by PublishTaskAndWait when the task does not
+// reach a terminal state in time
+type TaskWaitTimeoutError struct {
+	TaskID    string
+	Timeout   time.Duration
+	LastState pb.TaskState
+}
+
+func (e *TaskWaitTimeoutError) Error() string {
+	return fmt.Sprintf("task %s did not finish within %s (last state %s)", e.TaskID, e.Timeout, e.LastState)
+}
+
+// IsTerminalTaskState reports whether a task in this state will not change anymore
+func IsTerminalTaskState(state pb.TaskState) bool {
+	switch state {
+	case pb.TaskState_TASK_STATE_COMPLETED,
+		pb.TaskState_TASK_STATE_FAILED,
+		pb.TaskState_TASK_STATE_CANCELLED,
+		pb.TaskState_TASK_STATE_REJECTED:
+		return true
+	default:
+		return false
+	}
+}
+
+// PublishTaskAndWait publishes a task and blocks until it reaches a terminal state
+// (COMPLETED, FAILED, CANCELLED or REJECTED) or the timeout expires. It returns the
+// final task with its artifacts, or a *TaskWaitTimeoutError.
+func (tp *A2ATaskPublisher) PublishTaskAndWait(ctx context.Context, req *A2APublishTaskRequest, timeout time.Duration) (*pb.Task, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Subscribe before publishing so that fast responders are not missed
+	stream, err := tp.Client.SubscribeToTasks(waitCtx, &pb.SubscribeToTasksRequest{
+		AgentId: req.RequesterAgentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to task updates: %w", err)
+	}
+
+	events := make(chan *pb.AgentEvent)
+	streamErr := make(chan error, 1)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				streamErr <- err
+				return
+			}
+			select {
+			case events <- event:
+			case <-waitCtx.Done():
+				return
+			}
+		}
+	}()
+
+	task, err := tp.PublishTask(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			applyTaskEvent(task, event)
+
+		case <-ticker.C:
+			if stored, err := tp.Client.GetTask(waitCtx, &pb.GetTaskRequest{TaskId: task.GetId()}); err == nil {
+				task = proto.Clone(stored).(*pb.Task)
+			}
+
+		case err := <-streamErr:
+			if waitCtx.Err() == nil {
+				return nil, fmt.Errorf("task update stream failed: %w", err)
+			}
+			// The deadline closed the stream; report it as a timeout below
+			streamErr = nil
+
+		case <-waitCtx.Done():
+			if errors.Is(waitCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+				return nil, &TaskWaitTimeoutError{
+					TaskID:    task.GetId(),
+					Timeout:   timeout,
+					LastState: task.GetStatus().GetState(),
+				}
+			}
+			return nil, ctx.Err()
+		}
+
+		if IsTerminalTaskState(task.GetStatus().GetState()) {
+			return tp.finalTask(ctx, task), nil
+		}
+	}
+}
+
+// applyTaskEvent folds a task event into the locally tracked task
+func applyTaskEvent(task *pb.Task, event *pb.AgentEvent) {
+	switch payload := event.GetPayload().(type) {
+	case *pb.AgentEvent_Task:
+		if payload.Task.GetId() == task.GetId() {
+			proto.Reset(task)
+			proto.Merge(task, payload.Task)
+		}
+	case *pb.AgentEvent_StatusUpdate:
+		if payload.StatusUpdate.GetTaskId() == task.GetId() {
+			task.Status = payload.StatusUpdate.GetStatus()
+		}
+	case *pb.AgentEvent_ArtifactUpdate:
+		update := payload.ArtifactUpdate
+		if update.GetTaskId() != task.GetId() {
+			return
+		}
+		for i, existing := range task.Artifacts {
+			if existing.GetArtifactId() == update.GetArtifact().GetArtifactId() {
+				if update.GetAppend() {
+					existing.Parts = append(existing.Parts, update.GetArtifact().GetParts()...)
+				} else {
+					task.Artifacts[i] = update.GetArtifact()
+				}
+				return
+			}
+		}
+		task.Artifacts = append(task.Artifacts, update.GetArtifact())
+	}
+}
+
+// finalTask returns the broker's copy of a finished task, which holds every artifact,
+// falling back to the locally tracked task
+func (tp *A2ATaskPublisher) finalTask(ctx context.Context, task *pb.Task) *pb.Task {
+	stored, err := tp.Client.GetTask(ctx, &pb.GetTaskRequest{TaskId: task.GetId()})
+	if err != nil || len(stored.GetArtifacts()) < len(task.GetArtifacts()) {
+		return task
+	}
+	return stored
+}
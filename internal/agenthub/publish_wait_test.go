@@ -0,0 +1,70 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestA2ATaskPublisher_PublishTaskAndWait(t *testing.T) {
+	service := newTestAgentHubService()
+	publisher := NewA2ATaskPublisher(newTestClient(t, service), "test", "", nil)
+	ctx := context.Background()
+
+	// Act as the responder: complete the task once the broker has it
+	go func() {
+		for {
+			var taskID string
+			tasks, _ := service.taskStore.List(ctx)
+			for _, task := range tasks {
+				taskID = task.GetId()
+			}
+			if taskID == "" {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			service.PublishTaskArtifact(ctx, &pb.PublishTaskArtifactRequest{
+				Artifact: &pb.TaskArtifactUpdateEvent{TaskId: taskID, Artifact: &pb.Artifact{ArtifactId: "result"}},
+				Routing:  &pb.AgentEventMetadata{FromAgentId: "responder"},
+			})
+			service.PublishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
+				Update: &pb.TaskStatusUpdateEvent{
+					TaskId: taskID,
+					Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED},
+					Final:  true,
+				},
+				Routing: &pb.AgentEventMetadata{FromAgentId: "responder"},
+			})
+			return
+		}
+	}()
+
+	task, err := publisher.PublishTaskAndWait(ctx, &A2APublishTaskRequest{
+		TaskType:         "echo",
+		RequesterAgentID: "requester",
+		ResponderAgentID: "responder",
+	}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("PublishTaskAndWait failed: %v", err)
+	}
+	if task.GetStatus().GetState() != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Errorf("Expected COMPLETED, got %s", task.GetStatus().GetState())
+	}
+	if len(task.GetArtifacts()) != 1 {
+		t.Errorf("Expected 1 artifact, got %d", len(task.GetArtifacts()))
+	}
+
+	// Nobody completes this one
+	_, err = publisher.PublishTaskAndWait(ctx, &A2APublishTaskRequest{
+		TaskType:         "never",
+		RequesterAgentID: "requester",
+		ResponderAgentID: "nobody",
+	}, 200*time.Millisecond)
+	var timeoutErr *TaskWaitTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected TaskWaitTimeoutError, got %v", err)
+	}
+}
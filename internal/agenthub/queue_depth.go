@@ -0,0 +1,84 @@
+package agenthub
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// DefaultQueueDepthInterval is how often the depth of subscriber channels is sampled
+const DefaultQueueDepthInterval = 10 * time.Second
+
+// queueKey identifies the subscriptions of one agent to one stream
+type queueKey struct {
+	subscription string
+	agentID      string
+}
+
+// queueDepths returns, for each agent and stream, the number of events waiting in
+// its fullest subscription channel
+func (s *AgentHubService) queueDepths() map[queueKey]int {
+	depths := make(map[queueKey]int)
+	record := func(subscription, agentID string, ch chan *pb.AgentEvent) {
+		key := queueKey{subscription: subscription, agentID: agentID}
+		if depth, seen := depths[key]; !seen || len(ch) > depth {
+			depths[key] = len(ch)
+		}
+	}
+
+	s.agentMu.RLock()
+	defer s.agentMu.RUnlock()
+	for agentID, channels := range s.messageSubscribers {
+		for _, ch := range channels {
+			record("messages", agentID, ch)
+		}
+	}
+	for agentID, channels := range s.taskSubscribers {
+		for _, ch := range channels {
+			record("tasks", agentID, ch)
+		}
+	}
+	for agentID, subs := range s.eventSubscribers {
+		for _, sub := range subs {
+			record("events", agentID, sub.ch)
+		}
+	}
+	return depths
+}
+
+// recordQueueDepths reports the subscriber queue depths. Subscriptions seen in the
+// previous sample but gone since are reported empty. It returns the keys reported.
+func (s *AgentHubService) recordQueueDepths(ctx context.Context, previous map[queueKey]int) map[queueKey]int {
+	depths := s.queueDepths()
+	for key, depth := range depths {
+		s.Server.MetricsManager.RecordSubscriberQueueDepth(ctx, key.subscription, key.agentID, depth, s.bufferSize)
+	}
+	for key := range previous {
+		if _, active := depths[key]; !active {
+			s.Server.MetricsManager.RecordSubscriberQueueDepth(ctx, key.subscription, key.agentID, 0, s.bufferSize)
+		}
+	}
+	return depths
+}
+
+// runQueueDepthSampler samples the subscriber queue depths every interval until
+// ctx is done. It does nothing when the interval is not positive.
+func (s *AgentHubService) runQueueDepthSampler(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous map[queueKey]int
+	for {
+		select {
+		case <-ticker.C:
+			previous = s.recordQueueDepths(ctx, previous)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_QueueDepths(t *testing.T) {
+	service := newTestAgentHubService()
+
+	quiet := make(chan *pb.AgentEvent, 4)
+	busy := make(chan *pb.AgentEvent, 4)
+	busy <- &pb.AgentEvent{}
+	busy <- &pb.AgentEvent{}
+	events := make(chan *pb.AgentEvent, 4)
+	events <- &pb.AgentEvent{}
+	service.messageSubscribers["agent1"] = []chan *pb.AgentEvent{quiet, busy}
+	service.eventSubscribers["agent2"] = []*eventSubscription{{ch: events}}
+
+	depths := service.queueDepths()
+	want := map[queueKey]int{
+		{subscription: "messages", agentID: "agent1"}: 2,
+		{subscription: "events", agentID: "agent2"}:   1,
+	}
+	if len(depths) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, depths)
+	}
+	for key, depth := range want {
+		if depths[key] != depth {
+			t.Errorf("Expected depth %d for %v, got %d", depth, key, depths[key])
+		}
+	}
+
+	// Departed subscribers are no longer sampled
+	previous := service.recordQueueDepths(context.Background(), nil)
+	delete(service.eventSubscribers, "agent2")
+	if sampled := service.recordQueueDepths(context.Background(), previous); len(sampled) != 1 {
+		t.Errorf("Expected only agent1 to be sampled, got %v", sampled)
+	}
+}
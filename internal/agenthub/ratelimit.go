@@ -0,0 +1,95 @@
+package agenthub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// maxIdleBuckets is the number of tracked agents above which refilled buckets are forgotten
+const maxIdleBuckets = 1024
+
+// publishLimiter rate limits publishes with one token bucket per sending agent
+type publishLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	buckets  map[string]*agentBucket
+}
+
+type agentBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newPublishLimiter returns a limiter allowing rate publishes per second and bursts
+// of burst publishes per agent, or nil (unlimited) when rate is not positive
+func newPublishLimiter(rate float64, burst int) *publishLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &publishLimiter{
+		rate:     rate,
+		capacity: float64(burst),
+		buckets:  make(map[string]*agentBucket),
+	}
+}
+
+// allow consumes a token from the agent's bucket if one is available
+func (l *publishLimiter) allow(agentID string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[agentID]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.forgetRefilled(now)
+		}
+		b = &agentBucket{tokens: l.capacity, last: now}
+		l.buckets[agentID] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.capacity {
+		b.tokens = l.capacity
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// forgetRefilled drops the buckets that are full again, which behave like new ones
+func (l *publishLimiter) forgetRefilled(now time.Time) {
+	for agentID, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.capacity {
+			delete(l.buckets, agentID)
+		}
+	}
+}
+
+// checkPublishRate rejects a publish exceeding the rate limit of its sending agent
+func (s *AgentHubService) checkPublishRate(ctx context.Context, routing *pb.AgentEventMetadata) error {
+	agentID := routing.GetFromAgentId()
+	if s.publishLimiter.allow(agentID, time.Now()) {
+		return nil
+	}
+
+	s.Server.MetricsManager.IncrementRateLimitRejections(ctx, agentID)
+	return status.Errorf(codes.ResourceExhausted, "publish rate limit exceeded for agent %q", agentID)
+}
@@ -0,0 +1,66 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_PublishRateLimit(t *testing.T) {
+	config := NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.ServerAddr = ":0"
+	config.PublishRateLimit = 0.001
+	config.PublishRateBurst = 2
+	server, err := NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	service := NewAgentHubService(server)
+	ctx := context.Background()
+
+	publish := func(agentID, messageID string) error {
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: messageID, Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: agentID},
+		})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := publish("noisy", fmt.Sprintf("msg-%d", i)); err != nil {
+			t.Fatalf("Publish within burst failed: %v", err)
+		}
+	}
+	if err := publish("noisy", "msg-over"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted over the limit, got %v", err)
+	}
+
+	// Limits are per agent
+	if err := publish("quiet", "msg-quiet"); err != nil {
+		t.Errorf("Expected another agent to be unaffected, got %v", err)
+	}
+}
+
+func TestPublishLimiter_Refill(t *testing.T) {
+	limiter := newPublishLimiter(10, 1)
+	now := time.Now()
+	if !limiter.allow("agent", now) {
+		t.Fatal("Expected first publish to be allowed")
+	}
+	if limiter.allow("agent", now) {
+		t.Fatal("Expected second immediate publish to be rejected")
+	}
+	if !limiter.allow("agent", now.Add(100*time.Millisecond)) {
+		t.Error("Expected a publish to be allowed once a token is refilled")
+	}
+	if !newPublishLimiter(0, 0).allow("agent", now) {
+		t.Error("Expected a zero rate to mean unlimited")
+	}
+}
@@ -0,0 +1,79 @@
+package agenthub
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ReconnectPolicy controls how a subscriber re-subscribes after its stream to
+// the broker breaks, for instance when the broker restarts
+type ReconnectPolicy struct {
+	// MaxRetries is the number of consecutive failed re-subscriptions tolerated
+	// before giving up (0 disables reconnection, negative retries forever)
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry, doubled after each failure
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+}
+
+// DefaultReconnectPolicy retries forever, backing off from 500ms up to 30s
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxRetries:     -1,
+		InitialBackoff: DefaultConnectInitialBackoff,
+		MaxBackoff:     DefaultConnectMaxBackoff,
+	}
+}
+
+// backoff returns the delays of the policy, with defaults for unset values
+func (p ReconnectPolicy) backoff() (initial, max time.Duration) {
+	initial, max = p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = DefaultConnectInitialBackoff
+	}
+	if max < initial {
+		max = initial
+	}
+	return initial, max
+}
+
+// resubscribe runs subscription attempts, each returning once its stream ends,
+// until ctx is done or the policy gives up. An attempt reports whether its
+// subscription was established, which resets the retry budget. Retries are
+// logged with message and the given attributes.
+func resubscribe(ctx context.Context, policy ReconnectPolicy, logger *slog.Logger, message string, attrs []any, attempt func(ctx context.Context) (bool, error)) error {
+	initialBackoff, maxBackoff := policy.backoff()
+	backoff := initialBackoff
+	failures := 0
+
+	for {
+		subscribed, err := attempt(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if subscribed {
+			// The stream was up: start over with a fresh retry budget
+			failures, backoff = 0, initialBackoff
+		}
+		failures++
+		if policy.MaxRetries >= 0 && failures > policy.MaxRetries {
+			return err
+		}
+
+		delay := jitteredDelay(backoff)
+		logger.WarnContext(ctx, message, append(attrs,
+			"attempt", failures,
+			"retry_in", delay,
+			"error", err,
+		)...)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff = nextBackoff(backoff, maxBackoff)
+	}
+}
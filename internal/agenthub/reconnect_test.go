@@ -0,0 +1,86 @@
+package agenthub
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestA2ATaskSubscriber_ResubscribesAfterBrokerRestart(t *testing.T) {
+	service := newTestAgentHubService()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	server := grpc.NewServer()
+	pb.RegisterAgentHubServer(server, service)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	client := &AgentHubClient{
+		Client:         pb.NewAgentHubClient(conn),
+		Connection:     conn,
+		TraceManager:   service.Server.TraceManager,
+		MetricsManager: service.Server.MetricsManager,
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	handled := make(chan string, 1)
+	subscriber := NewA2ATaskSubscriber(client, "worker")
+	subscriber.Reconnect.InitialBackoff = 20 * time.Millisecond
+	subscriber.RegisterTaskHandler("ping", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		handled <- task.GetId()
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go subscriber.SubscribeToTasks(ctx)
+
+	// Bounce the broker
+	time.Sleep(100 * time.Millisecond)
+	server.Stop()
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to listen again: %v", err)
+	}
+	server = grpc.NewServer()
+	pb.RegisterAgentHubServer(server, service)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	publisher := NewA2ATaskPublisher(client, "test", "", nil)
+
+	// Publish until the re-subscribed worker picks a task up
+	deadline := time.After(5 * time.Second)
+	for {
+		if _, err := publisher.PublishTask(ctx, &A2APublishTaskRequest{
+			TaskType:         "ping",
+			RequesterAgentID: "requester",
+			ResponderAgentID: "worker",
+		}); err != nil {
+			t.Logf("PublishTask failed: %v", err)
+		}
+		select {
+		case <-handled:
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Subscriber did not receive tasks after the broker restarted")
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package agenthub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// MetadataKeyCorrelationID is the message metadata field holding the correlation ID
+// SendAndReceive sets on its requests; responders may echo it in their response
+const MetadataKeyCorrelationID = "correlation_id"
+
+// DefaultReplyTimeout is how long SendAndReceive waits for a response when the call
+// has no deadline
+const DefaultReplyTimeout = 30 * time.Second
+
+// SendAndReceive publishes a message and waits for the first response to it, giving
+// clients a unary call for conversational turns. A response is a message published
+// through the broker whose correlation_id metadata is the one set on the request,
+// or whose original_message_id metadata is the request message ID. The response is
+// routed to subscribers as usual.
+func (s *AgentHubService) SendAndReceive(ctx context.Context, req *pb.SendAndReceiveRequest) (*pb.Message, error) {
+	ctx, span := s.Server.TraceManager.StartSpan(ctx, "broker.send_and_receive",
+		attribute.String("message.id", req.GetMessage().GetMessageId()),
+	)
+	defer span.End()
+
+	if req.GetMessage() == nil {
+		err := status.Error(codes.InvalidArgument, "message cannot be nil")
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+	if req.GetMessage().GetMessageId() == "" {
+		err := status.Error(codes.InvalidArgument, "message_id cannot be empty")
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultReplyTimeout)
+		defer cancel()
+	}
+
+	// Tag the request so that responders can be matched, and wait before publishing
+	// so that fast responses are not missed
+	correlationID := s.ids.NewID("corr")
+	message := proto.Clone(req.GetMessage()).(*pb.Message)
+	if message.Metadata == nil {
+		message.Metadata = &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+	}
+	message.Metadata.Fields[MetadataKeyCorrelationID] = structpb.NewStringValue(correlationID)
+	span.SetAttributes(attribute.String("correlation.id", correlationID))
+
+	waiter := s.replies.register(correlationID, message.GetMessageId())
+	defer s.replies.cancel(waiter)
+
+	resp, err := s.PublishMessage(ctx, &pb.PublishMessageRequest{Message: message, Routing: req.GetRouting()})
+	if err != nil {
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+	if !resp.GetSuccess() {
+		err := status.Errorf(codes.FailedPrecondition, "failed to publish message: %s", resp.GetError())
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+
+	select {
+	case response := <-waiter.response:
+		s.Server.TraceManager.SetSpanSuccess(span)
+		return response, nil
+	case <-ctx.Done():
+		err := status.FromContextError(ctx.Err()).Err()
+		if ctx.Err() == context.DeadlineExceeded {
+			err = status.Errorf(codes.DeadlineExceeded, "no response to message %s", message.GetMessageId())
+		}
+		s.Server.TraceManager.RecordError(span, err)
+		return nil, err
+	}
+}
+
+// replyWaiter is a one-shot wait for the response to a request message
+type replyWaiter struct {
+	correlationID string
+	requestID     string
+	response      chan *pb.Message
+}
+
+// replyWaiters indexes the pending SendAndReceive calls by correlation ID and by
+// request message ID
+type replyWaiters struct {
+	mu            sync.Mutex
+	byCorrelation map[string]*replyWaiter
+	byRequest     map[string]*replyWaiter
+}
+
+func newReplyWaiters() *replyWaiters {
+	return &replyWaiters{
+		byCorrelation: make(map[string]*replyWaiter),
+		byRequest:     make(map[string]*replyWaiter),
+	}
+}
+
+// register starts waiting for the response to a request
+func (r *replyWaiters) register(correlationID, requestID string) *replyWaiter {
+	w := &replyWaiter{correlationID: correlationID, requestID: requestID, response: make(chan *pb.Message, 1)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byCorrelation[correlationID] = w
+	r.byRequest[requestID] = w
+	return w
+}
+
+// cancel stops waiting, whether or not a response arrived
+func (r *replyWaiters) cancel(w *replyWaiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(w)
+}
+
+// remove forgets a waiter. Callers hold r.mu.
+func (r *replyWaiters) remove(w *replyWaiter) {
+	if r.byCorrelation[w.correlationID] == w {
+		delete(r.byCorrelation, w.correlationID)
+	}
+	if r.byRequest[w.requestID] == w {
+		delete(r.byRequest, w.requestID)
+	}
+}
+
+// resolve hands a published message to the call waiting for it, if it is a
+// response. It reports whether a waiter took the message.
+func (r *replyWaiters) resolve(message *pb.Message) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.byCorrelation) == 0 {
+		return false
+	}
+
+	var w *replyWaiter
+	if id, ok := MetadataString(message.GetMetadata(), MetadataKeyCorrelationID); ok {
+		w = r.byCorrelation[id]
+	}
+	if w == nil {
+		if id, ok := MetadataString(message.GetMetadata(), "original_message_id"); ok {
+			w = r.byRequest[id]
+		}
+	}
+	// The request carries its own correlation ID
+	if w == nil || w.requestID == message.GetMessageId() {
+		return false
+	}
+
+	r.remove(w)
+	w.response <- message
+	return true
+}
@@ -0,0 +1,70 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_SendAndReceive(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	requests := make(chan *pb.AgentEvent, 4)
+	service.agentMu.Lock()
+	service.messageSubscribers["responder"] = []chan *pb.AgentEvent{requests}
+	service.agentMu.Unlock()
+
+	// Act as the responder, answering by original message ID or by correlation ID
+	go func() {
+		for event := range requests {
+			request := event.GetMessage()
+			metadata := map[string]any{"original_message_id": request.GetMessageId()}
+			if request.GetMessageId() == "req_corr" {
+				correlationID, _ := MetadataString(request.GetMetadata(), MetadataKeyCorrelationID)
+				metadata = map[string]any{MetadataKeyCorrelationID: correlationID}
+			}
+			response := &pb.Message{MessageId: "resp_" + request.GetMessageId(), Role: pb.Role_ROLE_AGENT}
+			response.Metadata, _ = structpb.NewStruct(metadata)
+			service.PublishMessage(ctx, &pb.PublishMessageRequest{
+				Message: response,
+				Routing: &pb.AgentEventMetadata{FromAgentId: "responder", ToAgentId: "requester", EventType: "a2a.message.response"},
+			})
+		}
+	}()
+
+	for _, id := range []string{"req_original", "req_corr"} {
+		callCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		response, err := service.SendAndReceive(callCtx, &pb.SendAndReceiveRequest{
+			Message: &pb.Message{MessageId: id, Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "requester", ToAgentId: "responder", EventType: "a2a.message.request"},
+		})
+		cancel()
+		if err != nil {
+			t.Fatalf("SendAndReceive(%s) failed: %v", id, err)
+		}
+		if response.GetMessageId() != "resp_"+id {
+			t.Errorf("Expected resp_%s, got %s", id, response.GetMessageId())
+		}
+	}
+
+	// Without a response the call ends with the deadline
+	callCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err := service.SendAndReceive(callCtx, &pb.SendAndReceiveRequest{
+		Message: &pb.Message{MessageId: "req_unanswered", Role: pb.Role_ROLE_USER},
+		Routing: &pb.AgentEventMetadata{FromAgentId: "requester", ToAgentId: "nobody", EventType: "a2a.message.request"},
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if len(service.replies.byCorrelation) != 0 || len(service.replies.byRequest) != 0 {
+		t.Error("Expected no pending reply waiters")
+	}
+}
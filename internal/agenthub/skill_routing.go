@@ -0,0 +1,74 @@
+package agenthub
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// findAgentBySkill returns the ID of a registered agent advertising the given skill,
+// matched against the skill ID, name or tags.
+// When several agents match, the lowest agent ID is returned so routing is deterministic.
+func (s *AgentHubService) findAgentBySkill(skill string) (string, bool) {
+	s.agentsMu.RLock()
+	defer s.agentsMu.RUnlock()
+
+	var candidates []string
+	for agentID, card := range s.registeredAgents {
+		for _, agentSkill := range card.GetSkills() {
+			if skillMatches(agentSkill, skill) {
+				candidates = append(candidates, agentID)
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.Strings(candidates)
+	return candidates[0], true
+}
+
+// skillMatches reports whether an advertised skill answers to the given identifier
+func skillMatches(agentSkill *pb.AgentSkill, skill string) bool {
+	return agentSkill.GetId() == skill || agentSkill.GetName() == skill || slices.Contains(agentSkill.GetTags(), skill)
+}
+
+// resolveSkillRouting fills in the target agent of skill-routed metadata.
+// Metadata without a required skill, or with an explicit target, is returned unchanged.
+// When no agent provides the skill, the configured fallback agent is used; without one,
+// an error describing the missing handler is returned.
+func (s *AgentHubService) resolveSkillRouting(ctx context.Context, eventType string, routing *pb.AgentEventMetadata) (*pb.AgentEventMetadata, error) {
+	skill := routing.GetRequiredSkill()
+	if skill == "" || routing.GetToAgentId() != "" {
+		return routing, nil
+	}
+
+	resolved := proto.Clone(routing).(*pb.AgentEventMetadata)
+
+	if agentID, ok := s.findAgentBySkill(skill); ok {
+		resolved.ToAgentId = agentID
+		return resolved, nil
+	}
+
+	if fallback := s.Server.Config.FallbackAgentID; fallback != "" {
+		s.Server.MetricsManager.IncrementEventsUnroutable(ctx, eventType, skill, "fallback")
+		s.Server.Logger.WarnContext(ctx, "No agent provides required skill, using fallback agent",
+			"skill", skill,
+			"fallback_agent", fallback,
+		)
+		resolved.ToAgentId = fallback
+		return resolved, nil
+	}
+
+	s.Server.MetricsManager.IncrementEventsUnroutable(ctx, eventType, skill, "rejected")
+	s.Server.Logger.WarnContext(ctx, "No agent provides required skill",
+		"skill", skill,
+	)
+	return nil, fmt.Errorf("no handler available for skill %q", skill)
+}
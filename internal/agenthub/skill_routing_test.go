@@ -0,0 +1,88 @@
+package agenthub
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_PublishMessage_SkillRouting(t *testing.T) {
+	service := newTestAgentHubService()
+	ctx := context.Background()
+
+	_, err := service.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentCard: &pb.AgentCard{
+			Name:   "translator",
+			Skills: []*pb.AgentSkill{{Id: "translate"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	// Skills are also matched by name and tag, whatever their ID
+	_, err = service.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentCard: &pb.AgentCard{
+			Name:   "classifier",
+			Skills: []*pb.AgentSkill{{Id: "skill_0", Name: "Classify", Tags: []string{"classify"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+
+	translatorChan := make(chan *pb.AgentEvent, 1)
+	classifierChan := make(chan *pb.AgentEvent, 1)
+	fallbackChan := make(chan *pb.AgentEvent, 1)
+	service.agentMu.Lock()
+	service.messageSubscribers["translator"] = []chan *pb.AgentEvent{translatorChan}
+	service.messageSubscribers["classifier"] = []chan *pb.AgentEvent{classifierChan}
+	service.messageSubscribers["fallback"] = []chan *pb.AgentEvent{fallbackChan}
+	service.agentMu.Unlock()
+
+	publish := func(skill string) *pb.PublishResponse {
+		t.Helper()
+		resp, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: "msg_" + skill, Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "requester", RequiredSkill: skill},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+		return resp
+	}
+
+	expectDelivery := func(ch chan *pb.AgentEvent, name string) {
+		t.Helper()
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected event to be delivered to %s", name)
+		}
+	}
+
+	if resp := publish("translate"); !resp.GetSuccess() {
+		t.Fatalf("Expected success, got error %q", resp.GetError())
+	}
+	expectDelivery(translatorChan, "translator")
+
+	for _, skill := range []string{"classify", "Classify"} {
+		if resp := publish(skill); !resp.GetSuccess() {
+			t.Fatalf("Expected success for %s, got error %q", skill, resp.GetError())
+		}
+		expectDelivery(classifierChan, "classifier")
+	}
+
+	resp := publish("summarize")
+	if resp.GetSuccess() || !strings.Contains(resp.GetError(), "no handler available") {
+		t.Fatalf("Expected no handler failure, got %+v", resp)
+	}
+
+	service.Server.Config.FallbackAgentID = "fallback"
+	if resp := publish("summarize"); !resp.GetSuccess() {
+		t.Fatalf("Expected success with fallback, got error %q", resp.GetError())
+	}
+	expectDelivery(fallbackChan, "fallback")
+}
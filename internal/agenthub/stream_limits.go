@@ -0,0 +1,57 @@
+package agenthub
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// streamTracker counts open subscription streams per client connection
+type streamTracker struct {
+	mu     sync.Mutex
+	limit  int
+	counts map[string]int
+}
+
+// newStreamTracker creates a tracker allowing limit streams per connection (0 means unlimited)
+func newStreamTracker(limit int) *streamTracker {
+	return &streamTracker{
+		limit:  limit,
+		counts: make(map[string]int),
+	}
+}
+
+// connectionKey identifies the client connection of a stream by its peer address
+func connectionKey(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// acquire reserves a stream slot for the connection of ctx. The returned
+// function releases the slot and must be called when the stream ends.
+func (t *streamTracker) acquire(ctx context.Context) (func(), error) {
+	key := connectionKey(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limit > 0 && t.counts[key] >= t.limit {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent subscriptions on this connection, limit is %d", t.limit)
+	}
+	t.counts[key]++
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.counts[key]--
+		if t.counts[key] <= 0 {
+			delete(t.counts, key)
+		}
+	}, nil
+}
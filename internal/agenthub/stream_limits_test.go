@@ -0,0 +1,28 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamTracker_Limit(t *testing.T) {
+	tracker := newStreamTracker(1)
+	ctx := context.Background()
+
+	release, err := tracker.acquire(ctx)
+	if err != nil {
+		t.Fatalf("First acquire failed: %v", err)
+	}
+
+	if _, err := tracker.acquire(ctx); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted, got %v", err)
+	}
+
+	release()
+	if _, err := tracker.acquire(ctx); err != nil {
+		t.Errorf("Acquire after release failed: %v", err)
+	}
+}
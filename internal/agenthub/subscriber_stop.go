@@ -0,0 +1,45 @@
+package agenthub
+
+import (
+	"sync"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// subscriberStops signals the end of subscriptions to the goroutines delivering
+// events to them. Subscription channels are never closed, since deliveries run
+// concurrently with unsubscription: a delivery still waiting for room when the
+// subscription ends gives up on the stop signal instead of sending on a closed
+// channel. The zero value is ready to use.
+type subscriberStops struct {
+	mu    sync.Mutex
+	stops map[chan *pb.AgentEvent]chan struct{}
+}
+
+// add registers a subscription channel
+func (s *subscriberStops) add(ch chan *pb.AgentEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stops == nil {
+		s.stops = make(map[chan *pb.AgentEvent]chan struct{})
+	}
+	s.stops[ch] = make(chan struct{})
+}
+
+// stop releases the deliveries waiting on a subscription channel and forgets it
+func (s *subscriberStops) stop(ch chan *pb.AgentEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stop, ok := s.stops[ch]; ok {
+		close(stop)
+		delete(s.stops, ch)
+	}
+}
+
+// get returns the stop signal of a subscription channel. It is nil, and never
+// fires, for channels that were not registered or are already stopped.
+func (s *subscriberStops) get(ch chan *pb.AgentEvent) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stops[ch]
+}
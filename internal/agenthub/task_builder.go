@@ -0,0 +1,134 @@
+package agenthub
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// TaskBuilder assembles an A2APublishTaskRequest step by step, sparing callers the
+// nested Part literals:
+//
+//	req, err := agenthub.NewTask("translation").
+//		WithText("hello").
+//		WithData(map[string]any{"target_language": "fr"}).
+//		From("agent_publisher").
+//		To("agent_translator").
+//		Priority(pb.Priority_PRIORITY_HIGH).
+//		Build()
+//
+// Mistakes are collected along the way and reported by Build.
+type TaskBuilder struct {
+	req  A2APublishTaskRequest
+	errs []error
+}
+
+// NewTask starts building a task of the given type
+func NewTask(taskType string) *TaskBuilder {
+	return &TaskBuilder{req: A2APublishTaskRequest{TaskType: taskType}}
+}
+
+// WithText appends a text part
+func (b *TaskBuilder) WithText(text string) *TaskBuilder {
+	return b.WithPart(&pb.Part{Part: &pb.Part_Text{Text: text}})
+}
+
+// WithData appends a data part. The values must be representable as a
+// structpb.Struct: nil, numbers, strings, booleans, and maps and slices of them.
+func (b *TaskBuilder) WithData(data map[string]any) *TaskBuilder {
+	fields, err := structpb.NewStruct(data)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("invalid data part: %w", err))
+		return b
+	}
+	return b.WithPart(&pb.Part{Part: &pb.Part_Data{Data: &pb.DataPart{Data: fields}}})
+}
+
+// WithFile appends a file part
+func (b *TaskBuilder) WithFile(file *pb.FilePart) *TaskBuilder {
+	return b.WithPart(&pb.Part{Part: &pb.Part_File{File: file}})
+}
+
+// WithPart appends a content part
+func (b *TaskBuilder) WithPart(part *pb.Part) *TaskBuilder {
+	b.req.Content = append(b.req.Content, part)
+	return b
+}
+
+// From sets the agent requesting the task
+func (b *TaskBuilder) From(agentID string) *TaskBuilder {
+	b.req.RequesterAgentID = agentID
+	return b
+}
+
+// To addresses the task to an agent. Tasks addressed to no agent are broadcast.
+func (b *TaskBuilder) To(agentID string) *TaskBuilder {
+	b.req.ResponderAgentID = agentID
+	return b
+}
+
+// Priority sets the priority of the task
+func (b *TaskBuilder) Priority(priority pb.Priority) *TaskBuilder {
+	b.req.Priority = priority
+	return b
+}
+
+// InContext groups the task in a conversation context
+func (b *TaskBuilder) InContext(contextID string) *TaskBuilder {
+	b.req.ContextID = contextID
+	return b
+}
+
+// WithHeader sets a header carried with the task to its handler, such as HeaderTenantID
+func (b *TaskBuilder) WithHeader(key, value string) *TaskBuilder {
+	if b.req.Headers == nil {
+		b.req.Headers = make(map[string]string)
+	}
+	b.req.Headers[key] = value
+	return b
+}
+
+// Deadline sets the time the task must be handled by
+func (b *TaskBuilder) Deadline(deadline time.Time) *TaskBuilder {
+	b.req.Deadline = deadline
+	return b
+}
+
+// Build validates and returns the request. A task needs a type and at least one
+// content part; its priority must be a known one, and its deadline not passed.
+func (b *TaskBuilder) Build() (*A2APublishTaskRequest, error) {
+	errs := append([]error(nil), b.errs...)
+	if b.req.TaskType == "" {
+		errs = append(errs, errors.New("task type cannot be empty"))
+	}
+	if len(b.req.Content) == 0 {
+		errs = append(errs, errors.New("task needs at least one content part"))
+	}
+	for i, part := range b.req.Content {
+		if part.GetPart() == nil {
+			errs = append(errs, fmt.Errorf("content part %d is empty", i))
+		}
+	}
+	if _, ok := pb.Priority_name[int32(b.req.Priority)]; !ok {
+		errs = append(errs, fmt.Errorf("unknown priority %d", b.req.Priority))
+	}
+	if _, ok := b.req.Headers[""]; ok {
+		errs = append(errs, errors.New("header name cannot be empty"))
+	}
+	if !b.req.Deadline.IsZero() && time.Now().After(b.req.Deadline) {
+		errs = append(errs, fmt.Errorf("deadline %s has already passed", b.req.Deadline.Format(time.RFC3339)))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid %q task: %w", b.req.TaskType, err)
+	}
+
+	req := b.req
+	req.Content = append([]*pb.Part(nil), b.req.Content...)
+	req.Headers = maps.Clone(b.req.Headers)
+	return &req, nil
+}
@@ -0,0 +1,53 @@
+package agenthub
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestTaskBuilder(t *testing.T) {
+	req, err := NewTask("translation").
+		WithText("hello").
+		WithData(map[string]any{"target_language": "fr"}).
+		From("agent_publisher").
+		To("agent_translator").
+		Priority(pb.Priority_PRIORITY_HIGH).
+		InContext("ctx-1").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if req.TaskType != "translation" || req.RequesterAgentID != "agent_publisher" || req.ResponderAgentID != "agent_translator" ||
+		req.Priority != pb.Priority_PRIORITY_HIGH || req.ContextID != "ctx-1" {
+		t.Errorf("Unexpected request %+v", req)
+	}
+	parts := ExtractParts(&pb.Message{Content: req.Content})
+	if len(parts.Texts) != 1 || parts.Texts[0] != "hello" {
+		t.Errorf("Expected a text part, got %v", parts.Texts)
+	}
+	if len(parts.Data) != 1 || parts.Data[0].GetFields()["target_language"].GetStringValue() != "fr" {
+		t.Errorf("Expected a data part, got %v", parts.Data)
+	}
+
+	tests := []struct {
+		name    string
+		builder *TaskBuilder
+		want    string
+	}{
+		{name: "no type", builder: NewTask("").WithText("hello"), want: "task type cannot be empty"},
+		{name: "no content", builder: NewTask("translation"), want: "at least one content part"},
+		{name: "invalid data", builder: NewTask("translation").WithData(map[string]any{"ch": make(chan int)}), want: "invalid data part"},
+		{name: "unknown priority", builder: NewTask("translation").WithText("hello").Priority(pb.Priority(42)), want: "unknown priority"},
+		{name: "passed deadline", builder: NewTask("translation").WithText("hello").Deadline(time.Now().Add(-time.Minute)), want: "already passed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package agenthub
+
+import (
+	"context"
+	"time"
+)
+
+// WithTaskRetention drops tasks, and their artifacts, once they have been in a
+// terminal state for longer than retention, overriding GRPCConfig.TaskRetention.
+// A zero retention keeps tasks forever.
+func WithTaskRetention(retention time.Duration) ServiceOption {
+	return func(s *AgentHubService) {
+		s.taskRetention = retention
+	}
+}
+
+// WithTaskArchive sets a store that expired tasks are copied to before the broker
+// drops them. A task that cannot be archived is kept and retried at the next run.
+func WithTaskArchive(archive TaskStore) ServiceOption {
+	return func(s *AgentHubService) {
+		s.taskArchive = archive
+	}
+}
+
+// recordTaskFinished remembers when a task reached a terminal state, for the
+// retention policy. Callers hold tasksMu.
+func (s *AgentHubService) recordTaskFinished(taskID string, now time.Time) {
+	if s.taskRetention > 0 {
+		s.taskFinishedAt[taskID] = now
+	}
+}
+
+// PruneTasks drops the tasks that have been in a terminal state for longer than
+// the retention period, archiving them first when an archive is configured, and
+// returns how many were dropped. Tasks finished before the broker started are aged
+// from their status timestamp. It runs periodically once the broker starts, and can
+// also be called manually.
+func (s *AgentHubService) PruneTasks(ctx context.Context) int {
+	if s.taskRetention <= 0 {
+		return 0
+	}
+
+	s.tasksMu.Lock()
+	defer s.tasksMu.Unlock()
+
+	tasks, err := s.taskStore.List(ctx)
+	if err != nil {
+		s.Server.Logger.WarnContext(ctx, "Failed to list tasks for expiry", "error", err)
+		return 0
+	}
+
+	now := time.Now()
+	pruned := 0
+	for _, task := range tasks {
+		state := task.GetStatus().GetState()
+		if !IsTerminalTaskState(state) {
+			continue
+		}
+		finishedAt, ok := s.taskFinishedAt[task.GetId()]
+		if !ok {
+			if task.GetStatus().GetTimestamp() == nil {
+				// Unknown end time: start aging the task now
+				s.taskFinishedAt[task.GetId()] = now
+				continue
+			}
+			finishedAt = task.GetStatus().GetTimestamp().AsTime()
+		}
+		if now.Sub(finishedAt) < s.taskRetention {
+			continue
+		}
+
+		archived := false
+		if s.taskArchive != nil {
+			if err := s.taskArchive.Put(ctx, task); err != nil {
+				s.Server.Logger.WarnContext(ctx, "Failed to archive expired task, keeping it", "task_id", task.GetId(), "error", err)
+				continue
+			}
+			archived = true
+		}
+		if err := s.taskStore.Delete(ctx, task.GetId()); err != nil {
+			s.Server.Logger.WarnContext(ctx, "Failed to drop expired task", "task_id", task.GetId(), "error", err)
+			continue
+		}
+		delete(s.taskFinishedAt, task.GetId())
+		s.Server.MetricsManager.IncrementTasksEvicted(ctx, state.String(), archived)
+		pruned++
+	}
+
+	if pruned > 0 {
+		s.Server.Logger.DebugContext(ctx, "Pruned expired tasks",
+			"pruned_count", pruned,
+		)
+	}
+	return pruned
+}
+
+// runTaskPruner calls PruneTasks periodically until ctx is done.
+// It does nothing when no retention is configured.
+func (s *AgentHubService) runTaskPruner(ctx context.Context) {
+	if s.taskRetention <= 0 {
+		return
+	}
+
+	interval := s.taskGCInterval
+	if interval <= 0 {
+		interval = s.taskRetention / 2
+		if interval < time.Second {
+			interval = time.Second
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.PruneTasks(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_PruneTasks(t *testing.T) {
+	service := newTestAgentHubService()
+	archive := NewInMemoryTaskStore()
+	WithTaskRetention(time.Hour)(service)
+	WithTaskArchive(archive)(service)
+	ctx := context.Background()
+
+	for _, taskID := range []string{"task-done", "task-recent", "task-running"} {
+		_, err := service.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: "msg-" + taskID, TaskId: taskID, Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "tester"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+	for _, taskID := range []string{"task-done", "task-recent"} {
+		_, err := service.PublishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
+			Update: &pb.TaskStatusUpdateEvent{TaskId: taskID, Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED}, Final: true},
+		})
+		if err != nil {
+			t.Fatalf("PublishTaskUpdate failed: %v", err)
+		}
+	}
+
+	// Only task-done has outlived the retention period
+	service.tasksMu.Lock()
+	service.taskFinishedAt["task-done"] = time.Now().Add(-2 * time.Hour)
+	service.tasksMu.Unlock()
+
+	if pruned := service.PruneTasks(ctx); pruned != 1 {
+		t.Fatalf("Expected 1 expired task pruned, got %d", pruned)
+	}
+	if _, err := service.GetTask(ctx, &pb.GetTaskRequest{TaskId: "task-done"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected the expired task to be dropped, got %v", err)
+	}
+	if _, err := archive.Get(ctx, "task-done"); err != nil {
+		t.Errorf("Expected the expired task to be archived, got %v", err)
+	}
+	for _, taskID := range []string{"task-recent", "task-running"} {
+		if _, err := service.GetTask(ctx, &pb.GetTaskRequest{TaskId: taskID}); err != nil {
+			t.Errorf("Expected %s to be kept, got %v", taskID, err)
+		}
+	}
+}
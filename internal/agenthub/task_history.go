@@ -0,0 +1,151 @@
+package agenthub
+
+import (
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// taskHistoryWatch is a live tail of the messages added to a task's history
+type taskHistoryWatch struct {
+	messages chan *pb.Message
+	end      chan struct{} // closed once no more messages will be sent
+	err      error         // why the watch ended, set before end is closed
+}
+
+// watchTask starts following the history of a task. Callers hold tasksMu.
+func (s *AgentHubService) watchTask(taskID string) *taskHistoryWatch {
+	w := &taskHistoryWatch{
+		messages: make(chan *pb.Message, s.bufferSize),
+		end:      make(chan struct{}),
+	}
+	s.taskWatches[taskID] = append(s.taskWatches[taskID], w)
+	return w
+}
+
+// unwatchTask stops following a task, if the watch has not ended already
+func (s *AgentHubService) unwatchTask(taskID string, w *taskHistoryWatch) {
+	s.tasksMu.Lock()
+	defer s.tasksMu.Unlock()
+	s.removeTaskWatch(taskID, w)
+}
+
+// removeTaskWatch forgets a watch. Callers hold tasksMu.
+func (s *AgentHubService) removeTaskWatch(taskID string, w *taskHistoryWatch) {
+	watches := s.taskWatches[taskID]
+	for i, watch := range watches {
+		if watch == w {
+			watches = append(watches[:i:i], watches[i+1:]...)
+			break
+		}
+	}
+	if len(watches) == 0 {
+		delete(s.taskWatches, taskID)
+	} else {
+		s.taskWatches[taskID] = watches
+	}
+}
+
+// notifyTaskWatches hands a message added to a task's history to its followers.
+// Followers too slow to keep up are ended rather than blocking the publisher.
+// Callers hold tasksMu.
+func (s *AgentHubService) notifyTaskWatches(taskID string, message *pb.Message) {
+	for _, w := range s.taskWatches[taskID] {
+		select {
+		case w.messages <- message:
+		default:
+			s.endTaskWatch(taskID, w, status.Error(codes.ResourceExhausted, "task history follower fell behind"))
+		}
+	}
+}
+
+// endTaskWatches ends every follower of a task with err. Callers hold tasksMu.
+func (s *AgentHubService) endTaskWatches(taskID string, err error) {
+	for _, w := range s.taskWatches[taskID] {
+		w.err = err
+		close(w.end)
+	}
+	delete(s.taskWatches, taskID)
+}
+
+// endTaskWatch ends a single follower of a task with err. Callers hold tasksMu.
+func (s *AgentHubService) endTaskWatch(taskID string, w *taskHistoryWatch, err error) {
+	w.err = err
+	close(w.end)
+	s.removeTaskWatch(taskID, w)
+}
+
+// StreamTaskHistory streams the history of a task, oldest first. With follow set,
+// it then streams the messages added to the task until the task reaches a terminal
+// state. Unlike GetTask, the history is not truncated.
+func (s *AgentHubService) StreamTaskHistory(req *pb.StreamTaskHistoryRequest, stream grpc.ServerStreamingServer[pb.Message]) error {
+	ctx := stream.Context()
+	taskID := req.GetTaskId()
+	if taskID == "" {
+		return status.Error(codes.InvalidArgument, "task_id cannot be empty")
+	}
+
+	if req.GetFollow() {
+		release, err := s.streams.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	// Load the history and start following under the same lock, so that no message
+	// is missed or sent twice
+	s.tasksMu.Lock()
+	task, err := s.taskStore.Get(ctx, taskID)
+	if err != nil {
+		s.tasksMu.Unlock()
+		if errors.Is(err, ErrTaskNotFound) {
+			return status.Error(codes.NotFound, "task not found")
+		}
+		return status.Errorf(codes.Internal, "failed to load task: %v", err)
+	}
+	var watch *taskHistoryWatch
+	if req.GetFollow() && !IsTerminalTaskState(task.GetStatus().GetState()) {
+		watch = s.watchTask(taskID)
+	}
+	s.tasksMu.Unlock()
+	if watch != nil {
+		defer s.unwatchTask(taskID, watch)
+	}
+
+	for _, message := range task.GetHistory() {
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+	}
+	if watch == nil {
+		return nil
+	}
+
+	for {
+		select {
+		case message := <-watch.messages:
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		case <-watch.end:
+			// Send what was added before the watch ended
+			for {
+				select {
+				case message := <-watch.messages:
+					if err := stream.Send(message); err != nil {
+						return err
+					}
+				default:
+					return watch.err
+				}
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
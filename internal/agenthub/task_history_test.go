@@ -0,0 +1,84 @@
+package agenthub
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestAgentHubService_StreamTaskHistory(t *testing.T) {
+	service := newTestAgentHubService()
+	client := startTestBroker(t, service)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	publish := func(id string) {
+		t.Helper()
+		_, err := client.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: id, TaskId: "task-1", Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "tester"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+	}
+	recv := func(stream grpc.ServerStreamingClient[pb.Message], id string) {
+		t.Helper()
+		message, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Expected %s, got error %v", id, err)
+		}
+		if message.GetMessageId() != id {
+			t.Fatalf("Expected %s, got %s", id, message.GetMessageId())
+		}
+	}
+
+	publish("msg-0")
+	publish("msg-1")
+
+	// Without follow, the stream ends after the stored history
+	stream, err := client.StreamTaskHistory(ctx, &pb.StreamTaskHistoryRequest{TaskId: "task-1"})
+	if err != nil {
+		t.Fatalf("StreamTaskHistory failed: %v", err)
+	}
+	recv(stream, "msg-0")
+	recv(stream, "msg-1")
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Expected the stream to end, got %v", err)
+	}
+
+	// With follow, messages added later are streamed until the task is over
+	stream, err = client.StreamTaskHistory(ctx, &pb.StreamTaskHistoryRequest{TaskId: "task-1", Follow: true})
+	if err != nil {
+		t.Fatalf("StreamTaskHistory failed: %v", err)
+	}
+	recv(stream, "msg-0")
+	recv(stream, "msg-1")
+	publish("msg-2")
+	recv(stream, "msg-2")
+
+	_, err = client.PublishTaskUpdate(ctx, &pb.PublishTaskUpdateRequest{
+		Update: &pb.TaskStatusUpdateEvent{TaskId: "task-1", Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED}, Final: true},
+	})
+	if err != nil {
+		t.Fatalf("PublishTaskUpdate failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Expected the stream to end with the task, got %v", err)
+	}
+
+	stream, err = client.StreamTaskHistory(ctx, &pb.StreamTaskHistoryRequest{TaskId: "unknown"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown task, got %v", err)
+	}
+}
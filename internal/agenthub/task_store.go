@@ -0,0 +1,108 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// ErrTaskNotFound is returned by a TaskStore when no task has the requested ID
+var ErrTaskNotFound = errors.New("task not found")
+
+// TaskStore persists the tasks tracked by the broker.
+// Implementations must be safe for concurrent use. Tasks are exchanged by value:
+// changes to a task returned by Get or List only take effect once passed to Put.
+type TaskStore interface {
+	// Get returns the task with the given ID, or ErrTaskNotFound
+	Get(ctx context.Context, taskID string) (*pb.Task, error)
+	// Put creates or replaces a task
+	Put(ctx context.Context, task *pb.Task) error
+	// List returns every stored task, in no particular order
+	List(ctx context.Context) ([]*pb.Task, error)
+	// Delete removes a task. Deleting an unknown task is not an error.
+	Delete(ctx context.Context, taskID string) error
+	// UpdateStatus replaces the status of a task and returns the updated task, or ErrTaskNotFound
+	UpdateStatus(ctx context.Context, taskID string, status *pb.TaskStatus) (*pb.Task, error)
+}
+
+// InMemoryTaskStore is a TaskStore backed by a map. Tasks are lost on restart.
+type InMemoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[string]*pb.Task
+}
+
+// NewInMemoryTaskStore creates an empty in-memory task store
+func NewInMemoryTaskStore() *InMemoryTaskStore {
+	return &InMemoryTaskStore{
+		tasks: make(map[string]*pb.Task),
+	}
+}
+
+// Get returns a copy of the stored task
+func (m *InMemoryTaskStore) Get(ctx context.Context, taskID string) (*pb.Task, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	return proto.Clone(task).(*pb.Task), nil
+}
+
+// Put stores a copy of the task
+func (m *InMemoryTaskStore) Put(ctx context.Context, task *pb.Task) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tasks[task.GetId()] = proto.Clone(task).(*pb.Task)
+	return nil
+}
+
+// List returns copies of all stored tasks
+func (m *InMemoryTaskStore) List(ctx context.Context) ([]*pb.Task, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tasks := make([]*pb.Task, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		tasks = append(tasks, proto.Clone(task).(*pb.Task))
+	}
+	return tasks, nil
+}
+
+// Delete removes a task from the store
+func (m *InMemoryTaskStore) Delete(ctx context.Context, taskID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tasks, taskID)
+	return nil
+}
+
+// UpdateStatus replaces the status of a stored task
+func (m *InMemoryTaskStore) UpdateStatus(ctx context.Context, taskID string, status *pb.TaskStatus) (*pb.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	task.Status = proto.Clone(status).(*pb.TaskStatus)
+	return proto.Clone(task).(*pb.Task), nil
+}
+
+// WithTaskStore sets the store the broker keeps tasks in. A nil store keeps the
+// default InMemoryTaskStore.
+func WithTaskStore(store TaskStore) ServiceOption {
+	return func(s *AgentHubService) {
+		if store != nil {
+			s.taskStore = store
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestInMemoryTaskStore(t *testing.T) {
+	store := NewInMemoryTaskStore()
+	ctx := context.Background()
+
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
+	}
+	if _, err := store.UpdateStatus(ctx, "missing", &pb.TaskStatus{}); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound from UpdateStatus, got %v", err)
+	}
+
+	if err := store.Put(ctx, &pb.Task{Id: "task-1", Status: &pb.TaskStatus{State: pb.TaskState_TASK_STATE_SUBMITTED}}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Tasks are exchanged by value
+	task, _ := store.Get(ctx, "task-1")
+	task.ContextId = "changed"
+	if stored, _ := store.Get(ctx, "task-1"); stored.GetContextId() != "" {
+		t.Error("Expected changes to a returned task not to affect the store")
+	}
+
+	updated, err := store.UpdateStatus(ctx, "task-1", &pb.TaskStatus{State: pb.TaskState_TASK_STATE_COMPLETED})
+	if err != nil || updated.GetStatus().GetState() != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Fatalf("UpdateStatus failed: %v, %v", updated, err)
+	}
+
+	if err := store.Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if tasks, _ := store.List(ctx); len(tasks) != 0 {
+		t.Errorf("Expected an empty store after delete, got %d tasks", len(tasks))
+	}
+}
@@ -0,0 +1,33 @@
+package agenthub
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc/stats"
+)
+
+// DefaultTelemetryExcludedMethods lists the gRPC methods that are not traced or measured by default
+var DefaultTelemetryExcludedMethods = []string{
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+// isTelemetryExcluded reports whether a gRPC method is excluded from telemetry.
+// Entries match either the full method name ("/package.Service/Method") or the bare method name.
+func isTelemetryExcluded(excluded []string, fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, entry := range excluded {
+		if entry == fullMethod || entry == method {
+			return true
+		}
+	}
+	return false
+}
+
+// telemetryFilter builds an otelgrpc filter skipping the excluded methods
+func telemetryFilter(excluded []string) otelgrpc.Filter {
+	return func(info *stats.RPCTagInfo) bool {
+		return !isTelemetryExcluded(excluded, info.FullMethodName)
+	}
+}
@@ -0,0 +1,23 @@
+package agenthub
+
+import "testing"
+
+func TestIsTelemetryExcluded(t *testing.T) {
+	excluded := []string{"/grpc.health.v1.Health/Check", "GetAgentCard"}
+
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"/grpc.health.v1.Health/Check", true},
+		{"/eventbus.AgentHub/GetAgentCard", true},
+		{"/eventbus.AgentHub/PublishMessage", false},
+		{"/grpc.health.v1.Health/Watch", false},
+	}
+
+	for _, tt := range tests {
+		if got := isTelemetryExcluded(excluded, tt.method); got != tt.want {
+			t.Errorf("isTelemetryExcluded(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package agenthub
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// writeTestCertificates writes a CA and a CA-signed certificate valid for 127.0.0.1,
+// usable both as server and client certificate, and returns their paths
+func writeTestCertificates(t *testing.T) (caFile, certFile, keyFile string) {
+	dir := t.TempDir()
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "agenthub test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "agenthub"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return writePEM("ca.pem", "CERTIFICATE", caDER), writePEM("cert.pem", "CERTIFICATE", certDER), writePEM("key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func TestTransportCredentials_MutualTLS(t *testing.T) {
+	if _, err := serverTransportCredentials(&GRPCConfig{TLSEnabled: true}); err == nil {
+		t.Error("Expected an error when TLS is enabled without certificate files")
+	}
+
+	caFile, certFile, keyFile := writeTestCertificates(t)
+	config := &GRPCConfig{TLSEnabled: true, TLSCertFile: certFile, TLSKeyFile: keyFile, TLSCAFile: caFile}
+
+	serverCreds, err := serverTransportCredentials(config)
+	if err != nil {
+		t.Fatalf("serverTransportCredentials failed: %v", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.Creds(serverCreds))
+	pb.RegisterAgentHubServer(server, newTestAgentHubService())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	call := func(config *GRPCConfig) error {
+		creds, err := clientTransportCredentials(config)
+		if err != nil {
+			return err
+		}
+		conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, err = pb.NewAgentHubClient(conn).ListTasks(ctx, &pb.ListTasksRequest{})
+		return err
+	}
+
+	if err := call(config); err != nil {
+		t.Errorf("Expected mutual TLS call to succeed, got %v", err)
+	}
+	if err := call(&GRPCConfig{TLSEnabled: true, TLSCAFile: caFile}); err == nil {
+		t.Error("Expected call without a client certificate to be rejected")
+	}
+}
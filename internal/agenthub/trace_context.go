@@ -0,0 +1,49 @@
+package agenthub
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// MetadataKeyTraceHeaders is the message metadata field holding the trace context
+// headers (W3C traceparent and baggage) of the publisher, so that task spans
+// continue the publisher's trace across the broker
+const MetadataKeyTraceHeaders = "trace_headers"
+
+// injectTraceHeaders stores the trace context of ctx in metadata
+func injectTraceHeaders(ctx context.Context, traceManager *observability.TraceManager, metadata *structpb.Struct) {
+	headers := make(map[string]string)
+	traceManager.InjectTraceContext(ctx, headers)
+	if len(headers) == 0 {
+		return
+	}
+
+	fields := make(map[string]*structpb.Value, len(headers))
+	for key, value := range headers {
+		fields[key] = structpb.NewStringValue(value)
+	}
+	metadata.Fields[MetadataKeyTraceHeaders] = structpb.NewStructValue(&structpb.Struct{Fields: fields})
+}
+
+// extractTraceHeaders returns ctx carrying the trace context stored in metadata,
+// or ctx unchanged when metadata holds none
+func extractTraceHeaders(ctx context.Context, traceManager *observability.TraceManager, metadata *structpb.Struct) context.Context {
+	value, ok := MetadataValue(metadata, MetadataKeyTraceHeaders)
+	if !ok || value.GetStructValue() == nil {
+		return ctx
+	}
+
+	headers := make(map[string]string)
+	for key, field := range value.GetStructValue().GetFields() {
+		if _, isString := field.GetKind().(*structpb.Value_StringValue); isString {
+			headers[key] = field.GetStringValue()
+		}
+	}
+	if len(headers) == 0 {
+		return ctx
+	}
+	return traceManager.ExtractTraceContext(ctx, headers)
+}
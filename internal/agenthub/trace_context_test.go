@@ -0,0 +1,59 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/owulveryck/agenthub/internal/observability/observabilitytest"
+)
+
+func TestA2ATaskPublisher_PropagatesTraceContext(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	service := newTestAgentHubService()
+	traceManager, exporter := observabilitytest.NewInMemoryTraceManager(t)
+	client := newTestClient(t, service)
+	client.TraceManager = traceManager
+	publisher := NewA2ATaskPublisher(client, "test", "", nil)
+
+	ctx := context.Background()
+	task, err := publisher.PublishTask(ctx, &A2APublishTaskRequest{
+		TaskType:         "echo",
+		RequesterAgentID: "requester",
+		ResponderAgentID: "responder",
+	})
+	if err != nil {
+		t.Fatalf("PublishTask failed: %v", err)
+	}
+
+	spans := observabilitytest.FindSpans(exporter, "test.publish_event")
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 publish span, got %d", len(spans))
+	}
+	published := spans[0].SpanContext
+
+	// The subscriber reads the task the broker stored from the published message
+	stored, err := service.taskStore.Get(ctx, task.GetId())
+	if err != nil {
+		t.Fatalf("Failed to get stored task: %v", err)
+	}
+	for name, metadata := range map[string]*structpb.Struct{"returned": task.GetMetadata(), "stored": stored.GetMetadata()} {
+		extracted := trace.SpanContextFromContext(extractTraceHeaders(ctx, traceManager, metadata))
+		if extracted.TraceID() != published.TraceID() || extracted.SpanID() != published.SpanID() {
+			t.Errorf("Expected %s task to carry span %s/%s, got %s/%s", name,
+				published.TraceID(), published.SpanID(), extracted.TraceID(), extracted.SpanID())
+		}
+	}
+
+	// Metadata without trace headers leaves the context unchanged
+	if got := extractTraceHeaders(ctx, traceManager, &structpb.Struct{}); got != ctx {
+		t.Error("Expected context to be unchanged without trace headers")
+	}
+}
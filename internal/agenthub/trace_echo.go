@@ -0,0 +1,78 @@
+package agenthub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// traceContextHeaders are the W3C trace context headers echoed by EchoTraceContext
+var traceContextHeaders = []string{"traceparent", "tracestate"}
+
+// EchoTraceContext returns the trace context received with the call. The headers are
+// read from the gRPC metadata and parsed independently of the broker's own tracing,
+// which is reported separately, so that a broken propagation shows which side of the
+// connection is at fault.
+func (s *AgentHubService) EchoTraceContext(ctx context.Context, req *emptypb.Empty) (*pb.EchoTraceContextResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	headers := make(map[string]string, len(traceContextHeaders))
+	for _, key := range traceContextHeaders {
+		if values := md.Get(key); len(values) > 0 {
+			headers[key] = values[0]
+		}
+	}
+
+	resp := &pb.EchoTraceContextResponse{
+		Traceparent: headers["traceparent"],
+		Tracestate:  headers["tracestate"],
+	}
+	received := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier(headers)))
+	if received.IsValid() {
+		resp.TraceId = received.TraceID().String()
+		resp.SpanId = received.SpanID().String()
+		resp.Sampled = received.IsSampled()
+	}
+	if broker := trace.SpanContextFromContext(ctx); broker.IsValid() {
+		resp.BrokerTraceId = broker.TraceID().String()
+		resp.BrokerSpanId = broker.SpanID().String()
+	}
+
+	s.Server.Logger.DebugContext(ctx, "Echoing trace context",
+		"traceparent", resp.GetTraceparent(),
+		"trace_id", resp.GetTraceId(),
+		"broker_trace_id", resp.GetBrokerTraceId(),
+	)
+	return resp, nil
+}
+
+// VerifyTracePropagation asks the broker for the trace context it receives from this
+// client and checks that it continues the trace of ctx. It returns what the broker
+// received along with the error, for inspection. The span ID is not compared: the
+// client instrumentation sends the span of the RPC, a child of the span in ctx.
+func (c *AgentHubClient) VerifyTracePropagation(ctx context.Context) (*pb.EchoTraceContextResponse, error) {
+	local := trace.SpanContextFromContext(ctx)
+	if !local.IsValid() {
+		return nil, errors.New("context carries no span to propagate")
+	}
+
+	resp, err := c.Client.EchoTraceContext(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to echo trace context: %w", err)
+	}
+	switch {
+	case resp.GetTraceparent() == "":
+		return resp, errors.New("broker received no traceparent header")
+	case resp.GetTraceId() == "":
+		return resp, fmt.Errorf("broker received an invalid traceparent header %q", resp.GetTraceparent())
+	case resp.GetTraceId() != local.TraceID().String():
+		return resp, fmt.Errorf("broker received trace %s, expected %s", resp.GetTraceId(), local.TraceID())
+	}
+	return resp, nil
+}
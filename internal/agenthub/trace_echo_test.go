@@ -0,0 +1,49 @@
+package agenthub
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAgentHubClient_VerifyTracePropagation(t *testing.T) {
+	service := newTestAgentHubService()
+	client := newTestClient(t, service)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	if _, err := client.VerifyTracePropagation(context.Background()); err == nil {
+		t.Error("Expected an error for a context without span")
+	}
+
+	// The test connection has no instrumentation injecting the trace context
+	resp, err := client.VerifyTracePropagation(ctx)
+	if err == nil || resp.GetTraceparent() != "" {
+		t.Errorf("Expected a missing traceparent to be reported, got %v (err %v)", resp, err)
+	}
+
+	headers := make(map[string]string)
+	propagation.TraceContext{}.Inject(ctx, propagation.MapCarrier(headers))
+	resp, err = client.VerifyTracePropagation(metadata.AppendToOutgoingContext(ctx, "traceparent", headers["traceparent"]))
+	if err != nil {
+		t.Fatalf("Expected the trace context to propagate, got %v", err)
+	}
+	if resp.GetTraceId() != traceID.String() || resp.GetSpanId() != spanID.String() || !resp.GetSampled() {
+		t.Errorf("Unexpected echoed trace context %v", resp)
+	}
+
+	other := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	resp, err = client.VerifyTracePropagation(metadata.AppendToOutgoingContext(ctx, "traceparent", other))
+	if err == nil || resp.GetTraceId() != "0af7651916cd43dd8448eb211c80319c" {
+		t.Errorf("Expected a trace mismatch to be reported, got %v (err %v)", resp, err)
+	}
+}
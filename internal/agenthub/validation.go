@@ -0,0 +1,88 @@
+package agenthub
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// MessageLimitError describes which configured message limit was exceeded
+type MessageLimitError struct {
+	// Reason is a short machine-friendly identifier used as a metric label
+	Reason string
+	// Detail is the human-readable description returned to the caller
+	Detail string
+}
+
+func (e *MessageLimitError) Error() string {
+	return e.Detail
+}
+
+// validateRouting rejects routing metadata that subscribers cannot safely relay.
+// Event types are written as-is into line-based protocols, such as the
+// Server-Sent Events of the gateway, where a line break would forge fields.
+func validateRouting(routing *pb.AgentEventMetadata) error {
+	if eventType := routing.GetEventType(); strings.ContainsAny(eventType, "\r\n") {
+		return fmt.Errorf("event_type %q cannot contain line breaks", eventType)
+	}
+	return nil
+}
+
+// validateMessageLimits checks a message against the size, part-count and metadata limits of the config.
+// A zero limit disables the corresponding check.
+func validateMessageLimits(config *GRPCConfig, message *pb.Message) *MessageLimitError {
+	if config == nil {
+		return nil
+	}
+
+	if config.MaxMessageParts > 0 && len(message.GetContent()) > config.MaxMessageParts {
+		return &MessageLimitError{
+			Reason: "too_many_parts",
+			Detail: fmt.Sprintf("message has %d content parts, limit is %d", len(message.GetContent()), config.MaxMessageParts),
+		}
+	}
+
+	if config.MaxPartBytes > 0 {
+		for i, part := range message.GetContent() {
+			if size := proto.Size(part); size > config.MaxPartBytes {
+				return &MessageLimitError{
+					Reason: "part_too_large",
+					Detail: fmt.Sprintf("content part %d is %d bytes, limit is %d", i, size, config.MaxPartBytes),
+				}
+			}
+		}
+	}
+
+	if metadata := message.GetMetadata(); metadata != nil {
+		if config.MaxMetadataDepth > 0 {
+			if depth := metadataDepth(metadata); depth > config.MaxMetadataDepth {
+				return &MessageLimitError{
+					Reason: "metadata_too_deep",
+					Detail: fmt.Sprintf("message metadata is nested %d levels deep, limit is %d", depth, config.MaxMetadataDepth),
+				}
+			}
+		}
+		if config.MaxMetadataBytes > 0 {
+			if size := proto.Size(metadata); size > config.MaxMetadataBytes {
+				return &MessageLimitError{
+					Reason: "metadata_too_large",
+					Detail: fmt.Sprintf("message metadata is %d bytes, limit is %d", size, config.MaxMetadataBytes),
+				}
+			}
+		}
+	}
+
+	if config.MaxMessageBytes > 0 {
+		if size := proto.Size(message); size > config.MaxMessageBytes {
+			return &MessageLimitError{
+				Reason: "message_too_large",
+				Detail: fmt.Sprintf("message is %d bytes, exceeding the %d bytes limit (AGENTHUB_MAX_MESSAGE_BYTES)", size, config.MaxMessageBytes),
+			}
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // AppConfig holds all application configuration
@@ -13,10 +15,17 @@ type AppConfig struct {
 
 	// Observability Configuration
 	JaegerEndpoint   string
+	OTLPProtocol     string
 	PrometheusPort   string
 	GrafanaPort      string
 	AlertManagerPort string
 
+	// MetricsExporter selects how metrics leave the service: scraped from
+	// /metrics ("prometheus") or pushed to the OTLP endpoint ("otlp")
+	MetricsExporter string
+	// MetricsExportInterval is the push interval of the OTLP metrics exporter
+	MetricsExportInterval time.Duration
+
 	// Health Check Ports
 	BrokerHealthPort     string
 	PublisherHealthPort  string
@@ -35,17 +44,22 @@ type AppConfig struct {
 
 // Load loads configuration from environment variables with defaults
 func Load() *AppConfig {
+	otlpProtocol := OTLPProtocol()
 	return &AppConfig{
 		// AgentHub Core
-		BrokerAddr: getEnv("AGENTHUB_BROKER_ADDR", "localhost"),
+		BrokerAddr: BrokerAddr(),
 		BrokerPort: getEnv("AGENTHUB_BROKER_PORT", "50051"),
 
 		// Observability Stack
-		JaegerEndpoint:   getEnv("JAEGER_ENDPOINT", "127.0.0.1:4317"),
+		JaegerEndpoint:   getEnv("JAEGER_ENDPOINT", defaultOTLPEndpoint(otlpProtocol)),
+		OTLPProtocol:     otlpProtocol,
 		PrometheusPort:   getEnv("PROMETHEUS_PORT", "9090"),
 		GrafanaPort:      getEnv("GRAFANA_PORT", "3333"),
 		AlertManagerPort: getEnv("ALERTMANAGER_PORT", "9093"),
 
+		MetricsExporter:       MetricsExporter(),
+		MetricsExportInterval: MetricsExportInterval(),
+
 		// Health Check Ports
 		BrokerHealthPort:     getEnv("BROKER_HEALTH_PORT", "8080"),
 		PublisherHealthPort:  getEnv("PUBLISHER_HEALTH_PORT", "8081"),
@@ -58,19 +72,95 @@ func Load() *AppConfig {
 		// Service Configuration
 		ServiceName:    getEnv("SERVICE_NAME", "agenthub-service"),
 		ServiceVersion: getEnv("SERVICE_VERSION", "1.0.0"),
-		Environment:    getEnv("ENVIRONMENT", "development"),
+		Environment:    CurrentEnvironment(),
 		LogLevel:       getEnv("LOG_LEVEL", "INFO"),
 	}
 }
 
+// Environment variables switching telemetry signals independently, so that
+// deployments without a trace backend can keep their metrics, and vice versa.
+// Both are enabled unless set to false.
+const (
+	EnvTracingEnabled = "AGENTHUB_TRACING_ENABLED"
+	EnvMetricsEnabled = "AGENTHUB_METRICS_ENABLED"
+)
+
+// TracingEnabled reports whether traces are exported, from AGENTHUB_TRACING_ENABLED
+func TracingEnabled() bool {
+	return getEnvAsBool(EnvTracingEnabled, true)
+}
+
+// MetricsEnabled reports whether metrics are exported, from AGENTHUB_METRICS_ENABLED
+func MetricsEnabled() bool {
+	return getEnvAsBool(EnvMetricsEnabled, true)
+}
+
+// OTLP protocols used to export traces
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http"
+)
+
+// OTLPProtocol returns the trace export protocol from OTEL_EXPORTER_OTLP_PROTOCOL,
+// defaulting to gRPC. The standard "http/protobuf" value selects HTTP; other
+// values are returned lower-cased, for Validate to report.
+func OTLPProtocol() string {
+	return normalizeOTLPProtocol(getEnv("OTEL_EXPORTER_OTLP_PROTOCOL", OTLPProtocolGRPC))
+}
+
+// normalizeOTLPProtocol lower-cases an OTLP protocol and maps "http/protobuf" to HTTP
+func normalizeOTLPProtocol(protocol string) string {
+	protocol = strings.ToLower(protocol)
+	if protocol == "http/protobuf" {
+		return OTLPProtocolHTTP
+	}
+	return protocol
+}
+
+// Metrics exporters. Prometheus waits for a scraper to pull /metrics, while OTLP
+// pushes metrics to the collector receiving traces, at JAEGER_ENDPOINT over
+// OTEL_EXPORTER_OTLP_PROTOCOL.
+const (
+	MetricsExporterPrometheus = "prometheus"
+	MetricsExporterOTLP       = "otlp"
+)
+
+// DefaultMetricsExportInterval is the OpenTelemetry default push interval
+const DefaultMetricsExportInterval = time.Minute
+
+// MetricsExporter returns the metrics exporter from OTEL_METRICS_EXPORTER, defaulting
+// to Prometheus. Values are returned lower-cased, for Validate to report unknown ones.
+func MetricsExporter() string {
+	return strings.ToLower(getEnv("OTEL_METRICS_EXPORTER", MetricsExporterPrometheus))
+}
+
+// MetricsExportInterval returns the OTLP metrics push interval from
+// OTEL_METRIC_EXPORT_INTERVAL, in milliseconds as in the OpenTelemetry specification.
+// Unset, malformed or non-positive values give DefaultMetricsExportInterval.
+func MetricsExportInterval() time.Duration {
+	millis := getEnvAsInt("OTEL_METRIC_EXPORT_INTERVAL", 0)
+	if millis <= 0 {
+		return DefaultMetricsExportInterval
+	}
+	return time.Duration(millis) * time.Millisecond
+}
+
+// defaultOTLPEndpoint returns the local OTLP receiver address for the protocol
+func defaultOTLPEndpoint(protocol string) string {
+	if protocol == OTLPProtocolHTTP {
+		return "127.0.0.1:4318"
+	}
+	return "127.0.0.1:4317"
+}
+
 // GetBrokerAddress returns the full broker address
 func (c *AppConfig) GetBrokerAddress() string {
 	return c.BrokerAddr + ":" + c.BrokerPort
 }
 
-// GetHealthPort returns the health port for a given service type
-func (c *AppConfig) GetHealthPort(serviceType string) string {
-	switch serviceType {
+// GetHealthPort returns the health port of a service, see HealthPort
+func (c *AppConfig) GetHealthPort(serviceName string) string {
+	switch serviceName {
 	case "broker":
 		return c.BrokerHealthPort
 	case "publisher":
@@ -78,8 +168,51 @@ func (c *AppConfig) GetHealthPort(serviceType string) string {
 	case "subscriber":
 		return c.SubscriberHealthPort
 	default:
-		return "8080"
+		return HealthPort(serviceName)
+	}
+}
+
+// EphemeralHealthPort makes the health server listen on a free port picked by the OS
+const EphemeralHealthPort = "0"
+
+// DefaultHealthPorts are the health ports of the bundled services, each unique so
+// that they can run side by side on one host
+var DefaultHealthPorts = map[string]string{
+	"broker":         "8080",
+	"publisher":      "8081",
+	"subscriber":     "8082",
+	"chat_repl":      "8083",
+	"chat_responder": "8084",
+	"echo_agent":     "8085",
+	"cortex":         "8086",
+	"chat_cli":       "8087",
+	"gateway":        "8088",
+}
+
+// HealthPortEnvVar returns the environment variable setting the health port of a
+// service: its name upper-cased, with characters other than letters and digits
+// replaced by underscores, followed by _HEALTH_PORT (e.g. CHAT_CLI_HEALTH_PORT)
+func HealthPortEnvVar(serviceName string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, serviceName)
+	return strings.ToUpper(name) + "_HEALTH_PORT"
+}
+
+// HealthPort resolves the health port of any service. It checks the variable named
+// by HealthPortEnvVar, then DefaultHealthPorts. Other services get EphemeralHealthPort,
+// which never conflicts with another service.
+func HealthPort(serviceName string) string {
+	if port := os.Getenv(HealthPortEnvVar(serviceName)); port != "" {
+		return port
+	}
+	if port, ok := DefaultHealthPorts[serviceName]; ok {
+		return port
 	}
+	return EphemeralHealthPort
 }
 
 // GetJaegerWebURL returns the Jaeger web interface URL
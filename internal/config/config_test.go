@@ -0,0 +1,64 @@
+package config
+
+import "testing"
+
+func TestHealthPortEnvVar(t *testing.T) {
+	tests := map[string]string{
+		"broker":         "BROKER_HEALTH_PORT",
+		"chat_cli":       "CHAT_CLI_HEALTH_PORT",
+		"chat-responder": "CHAT_RESPONDER_HEALTH_PORT",
+		"my.agent v2":    "MY_AGENT_V2_HEALTH_PORT",
+	}
+	for name, want := range tests {
+		if got := HealthPortEnvVar(name); got != want {
+			t.Errorf("HealthPortEnvVar(%q) = %s, expected %s", name, got, want)
+		}
+	}
+}
+
+func TestHealthPort(t *testing.T) {
+	t.Setenv("CORTEX_HEALTH_PORT", "9186")
+	t.Setenv("CUSTOM_AGENT_HEALTH_PORT", "9200")
+
+	tests := []struct {
+		service string
+		want    string
+	}{
+		{"gateway", "8088"},
+		{"chat_cli", "8087"},
+		{"cortex", "9186"},
+		{"custom-agent", "9200"},
+		{"unknown_agent", EphemeralHealthPort},
+	}
+	for _, tt := range tests {
+		if got := HealthPort(tt.service); got != tt.want {
+			t.Errorf("HealthPort(%q) = %s, expected %s", tt.service, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultHealthPortsAreUnique(t *testing.T) {
+	seen := make(map[string]string, len(DefaultHealthPorts))
+	for service, port := range DefaultHealthPorts {
+		if other, ok := seen[port]; ok {
+			t.Errorf("%s and %s share the health port %s", service, other, port)
+		}
+		seen[port] = service
+	}
+}
+
+func TestAppConfig_GetHealthPort(t *testing.T) {
+	t.Setenv("BROKER_HEALTH_PORT", "9080")
+	t.Setenv("ECHO_AGENT_HEALTH_PORT", "9085")
+
+	c := Load()
+	if got := c.GetHealthPort("broker"); got != "9080" {
+		t.Errorf("Expected the broker health port 9080, got %s", got)
+	}
+	if got := c.GetHealthPort("subscriber"); got != "8082" {
+		t.Errorf("Expected the default subscriber health port 8082, got %s", got)
+	}
+	if got := c.GetHealthPort("echo_agent"); got != "9085" {
+		t.Errorf("Expected the echo_agent health port 9085, got %s", got)
+	}
+}
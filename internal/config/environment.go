@@ -0,0 +1,69 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DefaultEnvironment is used when ENVIRONMENT is unset
+const DefaultEnvironment = "development"
+
+var (
+	brokerAddrDefaultsMu sync.RWMutex
+	brokerAddrDefaults   = map[string]string{
+		DefaultEnvironment: "localhost",
+	}
+
+	// brokerAddrFallbackWarned records the environments already warned about
+	brokerAddrFallbackWarned sync.Map
+)
+
+// RegisterBrokerAddrDefault registers the broker host used in the given environment
+// when AGENTHUB_BROKER_ADDR is unset. Registering an empty address removes the default.
+func RegisterBrokerAddrDefault(environment, addr string) {
+	brokerAddrDefaultsMu.Lock()
+	defer brokerAddrDefaultsMu.Unlock()
+
+	environment = strings.ToLower(environment)
+	if addr == "" {
+		delete(brokerAddrDefaults, environment)
+		return
+	}
+	brokerAddrDefaults[environment] = addr
+}
+
+// CurrentEnvironment returns the deployment environment from ENVIRONMENT
+func CurrentEnvironment() string {
+	return getEnv("ENVIRONMENT", DefaultEnvironment)
+}
+
+// DefaultBrokerAddr returns the broker host to use when AGENTHUB_BROKER_ADDR is unset.
+// It checks AGENTHUB_BROKER_ADDR_<ENVIRONMENT> (e.g. AGENTHUB_BROKER_ADDR_PRODUCTION),
+// then defaults registered with RegisterBrokerAddrDefault, and finally falls back to localhost,
+// with a warning as an environment without default is usually not meant to reach a local broker.
+func DefaultBrokerAddr(environment string) string {
+	if addr := os.Getenv("AGENTHUB_BROKER_ADDR_" + strings.ToUpper(environment)); addr != "" {
+		return addr
+	}
+
+	brokerAddrDefaultsMu.RLock()
+	defer brokerAddrDefaultsMu.RUnlock()
+
+	if addr, ok := brokerAddrDefaults[strings.ToLower(environment)]; ok {
+		return addr
+	}
+	if _, warned := brokerAddrFallbackWarned.LoadOrStore(strings.ToLower(environment), true); !warned {
+		slog.Warn("No broker address configured for environment, falling back to localhost",
+			"environment", environment,
+			"hint", "set AGENTHUB_BROKER_ADDR or AGENTHUB_BROKER_ADDR_"+strings.ToUpper(environment),
+		)
+	}
+	return "localhost"
+}
+
+// BrokerAddr returns AGENTHUB_BROKER_ADDR, or the default for the current environment
+func BrokerAddr() string {
+	return getEnv("AGENTHUB_BROKER_ADDR", DefaultBrokerAddr(CurrentEnvironment()))
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureLogs redirects the default logger to a buffer for the duration of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestDefaultBrokerAddr(t *testing.T) {
+	RegisterBrokerAddrDefault("Staging", "broker.staging.internal")
+	t.Cleanup(func() { RegisterBrokerAddrDefault("staging", "") })
+	t.Setenv("AGENTHUB_BROKER_ADDR_QA", "broker.qa.internal")
+
+	tests := []struct {
+		environment string
+		want        string
+	}{
+		{"development", "localhost"},
+		{"staging", "broker.staging.internal"},
+		{"STAGING", "broker.staging.internal"},
+		{"qa", "broker.qa.internal"},
+	}
+	logs := captureLogs(t)
+	for _, tt := range tests {
+		if got := DefaultBrokerAddr(tt.environment); got != tt.want {
+			t.Errorf("DefaultBrokerAddr(%q) = %q, want %q", tt.environment, got, tt.want)
+		}
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected no warning for configured environments, got %s", logs)
+	}
+}
+
+func TestDefaultBrokerAddr_FallbackWarns(t *testing.T) {
+	logs := captureLogs(t)
+
+	if got := DefaultBrokerAddr("production"); got != "localhost" {
+		t.Errorf("Expected the localhost fallback, got %q", got)
+	}
+	if !strings.Contains(logs.String(), "falling back to localhost") || !strings.Contains(logs.String(), "AGENTHUB_BROKER_ADDR_PRODUCTION") {
+		t.Errorf("Expected a warning naming the variable to set, got %q", logs)
+	}
+
+	// The warning is logged once per environment
+	logs.Reset()
+	DefaultBrokerAddr("production")
+	if logs.Len() != 0 {
+		t.Errorf("Expected a single warning, got %q", logs)
+	}
+}
+
+func TestBrokerAddr(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "staging")
+	t.Setenv("AGENTHUB_BROKER_ADDR_STAGING", "broker.staging.internal")
+
+	t.Setenv("AGENTHUB_BROKER_ADDR", "")
+	if got := BrokerAddr(); got != "broker.staging.internal" {
+		t.Errorf("Expected the environment default, got %q", got)
+	}
+	t.Setenv("AGENTHUB_BROKER_ADDR", "broker.example.com")
+	if got := BrokerAddr(); got != "broker.example.com" {
+		t.Errorf("Expected AGENTHUB_BROKER_ADDR to win, got %q", got)
+	}
+}
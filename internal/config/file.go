@@ -0,0 +1,155 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileField binds a configuration file key to an AppConfig field and the
+// environment variables that take precedence over it
+type fileField struct {
+	key string
+	env []string
+	// set parses the value of the key into its field
+	set func(c *AppConfig, value string) error
+}
+
+// stringField sets a string field to the value as written in the file
+func stringField(field func(*AppConfig) *string) func(*AppConfig, string) error {
+	return func(c *AppConfig, value string) error {
+		*field(c) = value
+		return nil
+	}
+}
+
+// durationField sets a duration field from a Go duration such as "30s", or from
+// a number of milliseconds like the OpenTelemetry environment variables.
+// The duration must be positive.
+func durationField(field func(*AppConfig) *time.Duration) func(*AppConfig, string) error {
+	return func(c *AppConfig, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			millis, numErr := strconv.ParseFloat(value, 64)
+			if numErr != nil {
+				return fmt.Errorf("%q is neither a duration nor a number of milliseconds", value)
+			}
+			d = time.Duration(millis * float64(time.Millisecond))
+		}
+		if d <= 0 {
+			return fmt.Errorf("%q is not a positive duration", value)
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+var fileFields = []fileField{
+	{"broker_addr", []string{"AGENTHUB_BROKER_ADDR"}, stringField(func(c *AppConfig) *string { return &c.BrokerAddr })},
+	{"broker_port", []string{"AGENTHUB_BROKER_PORT"}, stringField(func(c *AppConfig) *string { return &c.BrokerPort })},
+	{"jaeger_endpoint", []string{"JAEGER_ENDPOINT"}, stringField(func(c *AppConfig) *string { return &c.JaegerEndpoint })},
+	{"otlp_protocol", []string{"OTEL_EXPORTER_OTLP_PROTOCOL"}, stringField(func(c *AppConfig) *string { return &c.OTLPProtocol })},
+	{"prometheus_port", []string{"PROMETHEUS_PORT"}, stringField(func(c *AppConfig) *string { return &c.PrometheusPort })},
+	{"grafana_port", []string{"GRAFANA_PORT"}, stringField(func(c *AppConfig) *string { return &c.GrafanaPort })},
+	{"alertmanager_port", []string{"ALERTMANAGER_PORT"}, stringField(func(c *AppConfig) *string { return &c.AlertManagerPort })},
+	{"metrics_exporter", []string{"OTEL_METRICS_EXPORTER"}, stringField(func(c *AppConfig) *string { return &c.MetricsExporter })},
+	{"metrics_export_interval", []string{"OTEL_METRIC_EXPORT_INTERVAL"}, durationField(func(c *AppConfig) *time.Duration { return &c.MetricsExportInterval })},
+	{"broker_health_port", []string{"BROKER_HEALTH_PORT"}, stringField(func(c *AppConfig) *string { return &c.BrokerHealthPort })},
+	{"publisher_health_port", []string{"PUBLISHER_HEALTH_PORT"}, stringField(func(c *AppConfig) *string { return &c.PublisherHealthPort })},
+	{"subscriber_health_port", []string{"SUBSCRIBER_HEALTH_PORT"}, stringField(func(c *AppConfig) *string { return &c.SubscriberHealthPort })},
+	{"otlp_grpc_port", []string{"OTLP_GRPC_PORT"}, stringField(func(c *AppConfig) *string { return &c.OTLPGRPCPort })},
+	{"otlp_http_port", []string{"OTLP_HTTP_PORT"}, stringField(func(c *AppConfig) *string { return &c.OTLPHTTPPort })},
+	{"service_name", []string{"SERVICE_NAME"}, stringField(func(c *AppConfig) *string { return &c.ServiceName })},
+	{"service_version", []string{"SERVICE_VERSION"}, stringField(func(c *AppConfig) *string { return &c.ServiceVersion })},
+	{"environment", []string{"ENVIRONMENT"}, stringField(func(c *AppConfig) *string { return &c.Environment })},
+	{"log_level", []string{"LOG_LEVEL"}, stringField(func(c *AppConfig) *string { return &c.LogLevel })},
+}
+
+// LoadFromFile loads configuration from a YAML (.yaml, .yml) or JSON (.json) file.
+// Keys use the snake_case form of the AppConfig fields (e.g. broker_addr, log_level).
+// Environment variables that are set still take precedence over the file, and
+// values missing from both fall back to the same defaults as Load.
+// Unknown keys are logged as warnings and otherwise ignored.
+func LoadFromFile(path string) (*AppConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q: expected .yaml, .yml or .json", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	config := Load()
+	known := make(map[string]bool, len(fileFields))
+	for _, f := range fileFields {
+		known[f.key] = true
+
+		raw, ok := values[f.key]
+		if !ok || raw == nil || envIsSet(f.env) {
+			continue
+		}
+		if err := f.set(config, fmt.Sprint(raw)); err != nil {
+			return nil, fmt.Errorf("invalid %s in config file %s: %w", f.key, path, err)
+		}
+	}
+
+	config.OTLPProtocol = normalizeOTLPProtocol(config.OTLPProtocol)
+	config.MetricsExporter = strings.ToLower(config.MetricsExporter)
+
+	// The default OTLP endpoint depends on the protocol, which the file may set
+	if !envIsSet([]string{"JAEGER_ENDPOINT"}) {
+		if _, inFile := values["jaeger_endpoint"]; !inFile {
+			config.JaegerEndpoint = defaultOTLPEndpoint(config.OTLPProtocol)
+		}
+	}
+
+	// The file may select another environment: resolve the broker address for it,
+	// letting AGENTHUB_BROKER_ADDR_<ENVIRONMENT> win over the file as well
+	if !envIsSet([]string{"AGENTHUB_BROKER_ADDR"}) {
+		_, inFile := values["broker_addr"]
+		if !inFile || envIsSet([]string{"AGENTHUB_BROKER_ADDR_" + strings.ToUpper(config.Environment)}) {
+			config.BrokerAddr = DefaultBrokerAddr(config.Environment)
+		}
+	}
+
+	var unknown []string
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		slog.Warn("Ignoring unknown configuration keys", "path", path, "keys", unknown)
+	}
+
+	return config, nil
+}
+
+// envIsSet reports whether any of the environment variables is set to a non-empty value
+func envIsSet(names []string) bool {
+	for _, name := range names {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
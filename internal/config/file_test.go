@@ -0,0 +1,184 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes content to a file with the given name in a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		env     map[string]string
+		check   func(t *testing.T, c *AppConfig)
+	}{
+		{
+			name:    "YAML",
+			file:    "agenthub.yaml",
+			content: "broker_addr: broker.example.com\nbroker_port: 6000\nlog_level: debug\n",
+			check: func(t *testing.T, c *AppConfig) {
+				if got := c.GetBrokerAddress(); got != "broker.example.com:6000" {
+					t.Errorf("Expected broker.example.com:6000, got %s", got)
+				}
+				if c.LogLevel != "debug" {
+					t.Errorf("Expected log level debug, got %s", c.LogLevel)
+				}
+			},
+		},
+		{
+			name:    "JSON",
+			file:    "agenthub.json",
+			content: `{"service_name": "from-file", "grafana_port": 4000}`,
+			check: func(t *testing.T, c *AppConfig) {
+				if c.ServiceName != "from-file" || c.GrafanaPort != "4000" {
+					t.Errorf("Expected the file values, got service %s and grafana port %s", c.ServiceName, c.GrafanaPort)
+				}
+				if c.BrokerPort != "50051" {
+					t.Errorf("Expected the default broker port, got %s", c.BrokerPort)
+				}
+			},
+		},
+		{
+			name:    "environment takes precedence",
+			file:    "agenthub.yml",
+			content: "broker_port: 6000\nlog_level: debug\n",
+			env:     map[string]string{"AGENTHUB_BROKER_PORT": "7000"},
+			check: func(t *testing.T, c *AppConfig) {
+				if c.BrokerPort != "7000" {
+					t.Errorf("Expected the environment broker port 7000, got %s", c.BrokerPort)
+				}
+				if c.LogLevel != "debug" {
+					t.Errorf("Expected log level debug from the file, got %s", c.LogLevel)
+				}
+			},
+		},
+		{
+			name:    "OTLP protocol selects the default endpoint",
+			file:    "agenthub.yaml",
+			content: "otlp_protocol: http/protobuf\n",
+			check: func(t *testing.T, c *AppConfig) {
+				if c.OTLPProtocol != OTLPProtocolHTTP || c.JaegerEndpoint != "127.0.0.1:4318" {
+					t.Errorf("Expected OTLP over HTTP to 127.0.0.1:4318, got %s to %s", c.OTLPProtocol, c.JaegerEndpoint)
+				}
+			},
+		},
+		{
+			name:    "OTLP endpoint from the file",
+			file:    "agenthub.json",
+			content: `{"otlp_protocol": "http", "jaeger_endpoint": "collector:4318"}`,
+			check: func(t *testing.T, c *AppConfig) {
+				if c.OTLPProtocol != OTLPProtocolHTTP || c.JaegerEndpoint != "collector:4318" {
+					t.Errorf("Expected OTLP over HTTP to collector:4318, got %s to %s", c.OTLPProtocol, c.JaegerEndpoint)
+				}
+			},
+		},
+		{
+			name:    "OTLP metrics push",
+			file:    "agenthub.yaml",
+			content: "metrics_exporter: OTLP\nmetrics_export_interval: 15s\n",
+			check: func(t *testing.T, c *AppConfig) {
+				if c.MetricsExporter != MetricsExporterOTLP || c.MetricsExportInterval != 15*time.Second {
+					t.Errorf("Expected OTLP metrics every 15s, got %s every %s", c.MetricsExporter, c.MetricsExportInterval)
+				}
+			},
+		},
+		{
+			name:    "export interval in milliseconds",
+			file:    "agenthub.json",
+			content: `{"metrics_export_interval": 2500000}`,
+			check: func(t *testing.T, c *AppConfig) {
+				if c.MetricsExportInterval != 2500*time.Second {
+					t.Errorf("Expected an interval of 2500s, got %s", c.MetricsExportInterval)
+				}
+			},
+		},
+		{
+			name:    "environment interval takes precedence",
+			file:    "agenthub.yaml",
+			content: "metrics_export_interval: 15s\n",
+			env:     map[string]string{"OTEL_METRIC_EXPORT_INTERVAL": "5000"},
+			check: func(t *testing.T, c *AppConfig) {
+				if c.MetricsExportInterval != 5*time.Second {
+					t.Errorf("Expected the environment interval of 5s, got %s", c.MetricsExportInterval)
+				}
+			},
+		},
+		{
+			name:    "environment from the file resolves the broker address",
+			file:    "agenthub.yaml",
+			content: "environment: qa\n",
+			env:     map[string]string{"AGENTHUB_BROKER_ADDR_QA": "broker.qa.internal"},
+			check: func(t *testing.T, c *AppConfig) {
+				if c.Environment != "qa" || c.BrokerAddr != "broker.qa.internal" {
+					t.Errorf("Expected the qa broker, got environment %s and address %s", c.Environment, c.BrokerAddr)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			c, err := LoadFromFile(writeConfigFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("Failed to load config file: %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadFromFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr string
+	}{
+		{"missing file", func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.yaml") }, "failed to read config file"},
+		{"unsupported extension", func(t *testing.T) string { return writeConfigFile(t, "agenthub.toml", "log_level = 'debug'") }, `unsupported config file extension ".toml"`},
+		{"invalid YAML", func(t *testing.T) string { return writeConfigFile(t, "agenthub.yaml", "log_level: [debug") }, "failed to parse config file"},
+		{"invalid interval", func(t *testing.T) string { return writeConfigFile(t, "agenthub.yaml", "metrics_export_interval: soon") }, "invalid metrics_export_interval"},
+		{"negative interval", func(t *testing.T) string { return writeConfigFile(t, "agenthub.yaml", "metrics_export_interval: -1s") }, "not a positive duration"},
+		{"invalid JSON", func(t *testing.T) string { return writeConfigFile(t, "agenthub.json", `{"log_level":`) }, "failed to parse config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFromFile(tt.path(t))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromFile_UnknownKeysWarn(t *testing.T) {
+	logs := captureLogs(t)
+
+	c, err := LoadFromFile(writeConfigFile(t, "agenthub.yaml", "log_level: warn\nbroker_adress: typo\n"))
+	if err != nil {
+		t.Fatalf("Failed to load config file: %v", err)
+	}
+	if c.LogLevel != "warn" {
+		t.Errorf("Expected the known keys to apply, got log level %s", c.LogLevel)
+	}
+	if out := logs.String(); !strings.Contains(out, "Ignoring unknown configuration keys") || !strings.Contains(out, "broker_adress") {
+		t.Errorf("Expected a warning naming the unknown key, got %q", out)
+	}
+}
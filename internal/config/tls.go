@@ -0,0 +1,17 @@
+package config
+
+// Environment variables configuring TLS for broker and agent connections.
+// They are read by agenthub.NewGRPCConfig.
+//
+//   - AGENTHUB_TLS_ENABLED: enables TLS when set to true (default false)
+//   - AGENTHUB_TLS_CERT: PEM certificate. The broker serves it; agents present it as a
+//     client certificate when the broker requires mutual TLS.
+//   - AGENTHUB_TLS_KEY: PEM private key matching AGENTHUB_TLS_CERT
+//   - AGENTHUB_TLS_CA: PEM CA bundle. Agents verify the broker certificate against it.
+//     When set on the broker, clients must present a certificate signed by it (mutual TLS).
+const (
+	EnvTLSEnabled  = "AGENTHUB_TLS_ENABLED"
+	EnvTLSCertFile = "AGENTHUB_TLS_CERT"
+	EnvTLSKeyFile  = "AGENTHUB_TLS_KEY"
+	EnvTLSCAFile   = "AGENTHUB_TLS_CA"
+)
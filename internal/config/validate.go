@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// validLogLevels are the LOG_LEVEL values understood by the observability setup
+var validLogLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "WARNING", "ERROR"}
+
+// LoadStrict loads the configuration like Load and validates it, so that
+// misconfiguration is reported at startup instead of deep inside gRPC or slog setup
+func LoadStrict() (*AppConfig, error) {
+	c := Load()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks the configuration and reports every problem found in one error,
+// naming the environment variable to fix
+func (c *AppConfig) Validate() error {
+	var errs []error
+
+	ports := []struct {
+		name, value string
+	}{
+		{"AGENTHUB_BROKER_PORT", c.BrokerPort},
+		{"PROMETHEUS_PORT", c.PrometheusPort},
+		{"GRAFANA_PORT", c.GrafanaPort},
+		{"ALERTMANAGER_PORT", c.AlertManagerPort},
+		{"OTLP_GRPC_PORT", c.OTLPGRPCPort},
+		{"OTLP_HTTP_PORT", c.OTLPHTTPPort},
+	}
+	for _, port := range ports {
+		if err := validatePort(port.value, 1); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", port.name, err))
+		}
+	}
+
+	// Health ports may be 0, letting the OS pick a free port
+	healthPorts := []struct {
+		name, value string
+	}{
+		{"BROKER_HEALTH_PORT", c.BrokerHealthPort},
+		{"PUBLISHER_HEALTH_PORT", c.PublisherHealthPort},
+		{"SUBSCRIBER_HEALTH_PORT", c.SubscriberHealthPort},
+	}
+	for _, port := range healthPorts {
+		if err := validatePort(port.value, 0); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", port.name, err))
+		}
+	}
+
+	if host, port, err := net.SplitHostPort(c.JaegerEndpoint); err != nil {
+		errs = append(errs, fmt.Errorf("JAEGER_ENDPOINT: %q is not a host:port address: %w", c.JaegerEndpoint, err))
+	} else if host == "" {
+		errs = append(errs, fmt.Errorf("JAEGER_ENDPOINT: %q has no host", c.JaegerEndpoint))
+	} else if err := validatePort(port, 1); err != nil {
+		errs = append(errs, fmt.Errorf("JAEGER_ENDPOINT: %w", err))
+	}
+
+	if c.OTLPProtocol != OTLPProtocolGRPC && c.OTLPProtocol != OTLPProtocolHTTP {
+		errs = append(errs, fmt.Errorf("OTEL_EXPORTER_OTLP_PROTOCOL: %q is not one of grpc, http, http/protobuf", c.OTLPProtocol))
+	}
+
+	if c.MetricsExporter != MetricsExporterPrometheus && c.MetricsExporter != MetricsExporterOTLP {
+		errs = append(errs, fmt.Errorf("OTEL_METRICS_EXPORTER: %q is not one of prometheus, otlp", c.MetricsExporter))
+	}
+
+	if !isValidLogLevel(c.LogLevel) {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL: %q is not one of %s", c.LogLevel, strings.Join(validLogLevels, ", ")))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validatePort checks that a port is a number between min and 65535
+func validatePort(value string, min int) error {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("port %q is not a number", value)
+	}
+	if port < min || port > 65535 {
+		return fmt.Errorf("port %d is out of range [%d, 65535]", port, min)
+	}
+	return nil
+}
+
+func isValidLogLevel(level string) bool {
+	for _, valid := range validLogLevels {
+		if strings.EqualFold(level, valid) {
+			return true
+		}
+	}
+	return false
+}
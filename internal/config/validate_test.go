@@ -0,0 +1,112 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAppConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*AppConfig)
+		wantErr []string
+	}{
+		{"defaults", func(c *AppConfig) {}, nil},
+		{"ephemeral health port", func(c *AppConfig) { c.BrokerHealthPort = "0" }, nil},
+		{"lower-case log level", func(c *AppConfig) { c.LogLevel = "debug" }, nil},
+		{"OTLP metrics over HTTP", func(c *AppConfig) {
+			c.MetricsExporter = MetricsExporterOTLP
+			c.OTLPProtocol = OTLPProtocolHTTP
+		}, nil},
+		{"port not a number", func(c *AppConfig) { c.BrokerPort = "grpc" }, []string{`AGENTHUB_BROKER_PORT: port "grpc" is not a number`}},
+		{"port zero", func(c *AppConfig) { c.PrometheusPort = "0" }, []string{"PROMETHEUS_PORT: port 0 is out of range [1, 65535]"}},
+		{"port too large", func(c *AppConfig) { c.SubscriberHealthPort = "70000" }, []string{"SUBSCRIBER_HEALTH_PORT: port 70000 is out of range [0, 65535]"}},
+		{"endpoint without port", func(c *AppConfig) { c.JaegerEndpoint = "collector" }, []string{`JAEGER_ENDPOINT: "collector" is not a host:port address`}},
+		{"endpoint without host", func(c *AppConfig) { c.JaegerEndpoint = ":4317" }, []string{`JAEGER_ENDPOINT: ":4317" has no host`}},
+		{"unknown protocol", func(c *AppConfig) { c.OTLPProtocol = "udp" }, []string{`OTEL_EXPORTER_OTLP_PROTOCOL: "udp" is not one of`}},
+		{"unknown metrics exporter", func(c *AppConfig) { c.MetricsExporter = "statsd" }, []string{`OTEL_METRICS_EXPORTER: "statsd" is not one of`}},
+		{"unknown log level", func(c *AppConfig) { c.LogLevel = "VERBOSE" }, []string{`LOG_LEVEL: "VERBOSE" is not one of TRACE, DEBUG`}},
+		{"every problem reported", func(c *AppConfig) {
+			c.GrafanaPort = "-1"
+			c.LogLevel = "loud"
+		}, []string{"GRAFANA_PORT", "LOG_LEVEL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validTestConfig()
+			tt.modify(c)
+			err := c.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Expected a valid configuration, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error containing %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected the error to contain %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+// validTestConfig returns the default configuration, independent of the environment
+func validTestConfig() *AppConfig {
+	return &AppConfig{
+		BrokerAddr:           "localhost",
+		BrokerPort:           "50051",
+		JaegerEndpoint:       "127.0.0.1:4317",
+		OTLPProtocol:         OTLPProtocolGRPC,
+		PrometheusPort:       "9090",
+		GrafanaPort:          "3333",
+		AlertManagerPort:     "9093",
+		MetricsExporter:      MetricsExporterPrometheus,
+		BrokerHealthPort:     "8080",
+		PublisherHealthPort:  "8081",
+		SubscriberHealthPort: "8082",
+		OTLPGRPCPort:         "4320",
+		OTLPHTTPPort:         "4321",
+		LogLevel:             "INFO",
+	}
+}
+
+func TestLoadStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"defaults", nil, ""},
+		{"valid overrides", map[string]string{"AGENTHUB_BROKER_PORT": "6000", "LOG_LEVEL": "warn", "OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf"}, ""},
+		{"invalid port", map[string]string{"AGENTHUB_BROKER_PORT": "99999"}, "AGENTHUB_BROKER_PORT"},
+		{"invalid log level", map[string]string{"LOG_LEVEL": "chatty"}, "LOG_LEVEL"},
+		{"invalid protocol", map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "thrift"}, "OTEL_EXPORTER_OTLP_PROTOCOL"},
+		{"invalid exporter", map[string]string{"OTEL_METRICS_EXPORTER": "none"}, "OTEL_METRICS_EXPORTER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			c, err := LoadStrict()
+			if tt.wantErr == "" {
+				if err != nil || c == nil {
+					t.Fatalf("Expected a valid configuration, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error naming %s, got %v", tt.wantErr, err)
+			}
+			if c != nil {
+				t.Error("Expected no configuration with an error")
+			}
+		})
+	}
+}
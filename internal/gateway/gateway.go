@@ -0,0 +1,264 @@
+// Package gateway exposes the AgentHub broker to HTTP clients such as browsers,
+// which cannot speak gRPC. Agent event streams are served as Server-Sent Events
+// and messages are published with plain JSON requests.
+//
+// The gateway does not authenticate its callers: anyone reaching it can read the
+// event streams of any agent and publish messages. Expose it behind a proxy that
+// authenticates users.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// DefaultAddr is the address the gateway listens on unless AGENTHUB_GATEWAY_ADDR is set
+const DefaultAddr = ":8090"
+
+// maxPublishBodyBytes bounds the JSON body accepted by POST /messages
+const maxPublishBodyBytes = 4 << 20
+
+// Gateway bridges HTTP requests to the AgentHub gRPC API:
+//
+//	GET  /agents/{id}/messages  streams SubscribeToMessages as Server-Sent Events
+//	GET  /agents/{id}/tasks     streams SubscribeToTasks as Server-Sent Events
+//	POST /messages              publishes a JSON PublishMessageRequest
+//
+// Events are sent as protobuf JSON, named after their routing event type. When the
+// broker retains event history, each event carries its resume cursor as SSE id, so
+// reconnecting browsers resume through the Last-Event-ID header. A failed
+// subscription ends with an "error" event.
+//
+// Messages are published on behalf of the gateway: their sender is the gateway
+// agent ID, so that HTTP callers can neither impersonate agents nor escape the
+// broker publish rate limit by changing the sender.
+type Gateway struct {
+	client       pb.AgentHubClient
+	agentID      string
+	traceManager *observability.TraceManager
+	logger       *slog.Logger
+}
+
+// New creates a gateway forwarding to the broker the client is connected to, and
+// publishing as the component name of the client
+func New(client *agenthub.AgentHubClient) *Gateway {
+	return &Gateway{
+		client:       client.Client,
+		agentID:      client.Config.ComponentName,
+		traceManager: client.TraceManager,
+		logger:       client.Logger,
+	}
+}
+
+// Handler returns the HTTP handler serving the gateway routes
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /agents/{id}/messages", g.handleSubscribeMessages)
+	mux.HandleFunc("GET /agents/{id}/tasks", g.handleSubscribeTasks)
+	mux.HandleFunc("POST /messages", g.handlePublishMessage)
+	return mux
+}
+
+// eventStream is the receiving side of a broker subscription
+type eventStream interface {
+	Recv() (*pb.AgentEvent, error)
+}
+
+func (g *Gateway) handleSubscribeMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := g.requestContext(r)
+	stream, err := g.client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{
+		AgentId:      r.PathValue("id"),
+		ResumeCursor: r.Header.Get("Last-Event-ID"),
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	g.streamEvents(ctx, w, r.PathValue("id"), stream)
+}
+
+func (g *Gateway) handleSubscribeTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := g.requestContext(r)
+	stream, err := g.client.SubscribeToTasks(ctx, &pb.SubscribeToTasksRequest{
+		AgentId:      r.PathValue("id"),
+		ResumeCursor: r.Header.Get("Last-Event-ID"),
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	g.streamEvents(ctx, w, r.PathValue("id"), stream)
+}
+
+// streamEvents writes the events of a subscription as Server-Sent Events until
+// the stream ends or the HTTP client goes away
+func (g *Gateway) streamEvents(ctx context.Context, w http.ResponseWriter, agentID string, stream eventStream) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	g.logger.InfoContext(ctx, "Gateway subscription opened", "agent_id", agentID)
+	defer g.logger.InfoContext(ctx, "Gateway subscription closed", "agent_id", agentID)
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				g.logger.WarnContext(ctx, "Gateway subscription failed",
+					"agent_id", agentID,
+					"error", err,
+				)
+				// The response is already committed: report the failure in-stream
+				fmt.Fprint(w, "event: error\n")
+				writeData(w, status.Convert(err).Message())
+				flusher.Flush()
+			}
+			return
+		}
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes one Server-Sent Event carrying the JSON encoded agent event
+func writeEvent(w io.Writer, event *pb.AgentEvent) error {
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if cursor := event.GetCursor(); cursor != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", singleLine(cursor)); err != nil {
+			return err
+		}
+	}
+	if eventType := singleLine(event.GetRouting().GetEventType()); eventType != "" {
+		if _, err := fmt.Fprintf(w, "event: %s\n", eventType); err != nil {
+			return err
+		}
+	}
+	return writeData(w, string(data))
+}
+
+// writeData writes the data of an event and ends it. Each line of the value is
+// sent as its own data field, which clients join back with line breaks, so
+// that the value cannot end the event or add fields to it.
+func writeData(w io.Writer, value string) error {
+	value = strings.ReplaceAll(strings.ReplaceAll(value, "\r\n", "\n"), "\r", "\n")
+	for _, line := range strings.Split(value, "\n") {
+		if _, err := fmt.Fprintf(w, "data: %s\n", line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
+	return err
+}
+
+// singleLine drops the line breaks of a single-line field value
+func singleLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+func (g *Gateway) handlePublishMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := g.requestContext(r)
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPublishBodyBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusRequestEntityTooLarge)
+		return
+	}
+	req := &pb.PublishMessageRequest{}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid PublishMessageRequest: %v", err), http.StatusBadRequest)
+		return
+	}
+	if from := req.GetRouting().GetFromAgentId(); from != "" && from != g.agentID {
+		g.logger.WarnContext(ctx, "Gateway rejected a message published as another agent",
+			"from_agent_id", from,
+		)
+		http.Error(w, fmt.Sprintf("messages are published as %q, routing.from_agent_id cannot be set to %q", g.agentID, from), http.StatusForbidden)
+		return
+	}
+	if req.Routing == nil {
+		req.Routing = &pb.AgentEventMetadata{}
+	}
+	req.Routing.FromAgentId = g.agentID
+
+	resp, err := g.client.PublishMessage(ctx, req)
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// requestContext returns the request context joined to the trace of the HTTP
+// caller, so that broker spans continue the browser-side trace
+func (g *Gateway) requestContext(r *http.Request) context.Context {
+	// Propagators look up lower-case keys such as "traceparent"
+	headers := make(map[string]string, len(r.Header))
+	for name := range r.Header {
+		headers[strings.ToLower(name)] = r.Header.Get(name)
+	}
+	return g.traceManager.ExtractTraceContext(r.Context(), headers)
+}
+
+func writeJSON(w http.ResponseWriter, code int, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// writeGRPCError reports a broker error with the matching HTTP status
+func writeGRPCError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	http.Error(w, st.Message(), httpStatus(st.Code()))
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
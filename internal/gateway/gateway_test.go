@@ -0,0 +1,194 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// newTestGateway serves a gateway in front of an in-process broker
+func newTestGateway(t *testing.T) *httptest.Server {
+	config := agenthub.NewGRPCConfig("test")
+	config.HealthPort = "0"
+	config.ServerAddr = ":0"
+	config.Observability = observability.NewNoopObservability()
+	server, err := agenthub.NewAgentHubServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create broker: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterAgentHubServer(grpcServer, agenthub.NewAgentHubService(server))
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	gw := &Gateway{
+		client:       pb.NewAgentHubClient(conn),
+		agentID:      "gateway",
+		traceManager: server.TraceManager,
+		logger:       server.Logger,
+	}
+	httpServer := httptest.NewServer(gw.Handler())
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestGateway_PublishAndStreamMessages(t *testing.T) {
+	srv := newTestGateway(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/agents/web-ui/messages", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", ct)
+	}
+
+	// The broker registers the subscription asynchronously: publish until it is delivered
+	events := make(chan []string, 1)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		var lines []string
+		for scanner.Scan() {
+			if scanner.Text() == "" {
+				events <- lines
+				return
+			}
+			lines = append(lines, scanner.Text())
+		}
+	}()
+
+	for i := 0; ; i++ {
+		body := fmt.Sprintf(`{
+			"message": {"messageId": "msg_%d", "role": "ROLE_AGENT", "content": [{"text": "hello"}]},
+			"routing": {"toAgentId": "web-ui", "eventType": "chat_response"}
+		}`, i)
+		publish, err := http.Post(srv.URL+"/messages", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+		publish.Body.Close()
+		if publish.StatusCode != http.StatusOK {
+			t.Fatalf("Expected publish status 200, got %d", publish.StatusCode)
+		}
+
+		select {
+		case lines := <-events:
+			if len(lines) != 2 || lines[0] != "event: chat_response" || !strings.HasPrefix(lines[1], "data: ") {
+				t.Fatalf("Unexpected event: %q", lines)
+			}
+			if !strings.Contains(lines[1], `"text":"hello"`) {
+				t.Errorf("Expected message content in event data, got %q", lines[1])
+			}
+			if !strings.Contains(lines[1], `"fromAgentId":"gateway"`) {
+				t.Errorf("Expected the gateway as sender, got %q", lines[1])
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for the streamed event")
+		}
+	}
+}
+
+func TestGateway_PublishInvalidJSON(t *testing.T) {
+	srv := newTestGateway(t)
+
+	resp, err := http.Post(srv.URL+"/messages", "application/json", strings.NewReader(`{"message": 42}`))
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestGateway_PublishAsAnotherAgent(t *testing.T) {
+	srv := newTestGateway(t)
+
+	body := `{
+		"message": {"messageId": "msg_1", "role": "ROLE_USER", "content": [{"text": "hello"}]},
+		"routing": {"fromAgentId": "cortex", "toAgentId": "web-ui"}
+	}`
+	resp, err := http.Post(srv.URL+"/messages", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 when impersonating an agent, got %d", resp.StatusCode)
+	}
+}
+
+func TestGateway_PublishEventTypeWithLineBreak(t *testing.T) {
+	srv := newTestGateway(t)
+
+	body := `{
+		"message": {"messageId": "msg_1", "role": "ROLE_USER", "content": [{"text": "hello"}]},
+		"routing": {"toAgentId": "web-ui", "eventType": "chat\nid: forged\ndata: {}"}
+	}`
+	resp, err := http.Post(srv.URL+"/messages", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an event type with a line break, got %d", resp.StatusCode)
+	}
+}
+
+func TestWriteEvent_LineBreaks(t *testing.T) {
+	var buf strings.Builder
+	err := writeEvent(&buf, &pb.AgentEvent{
+		Cursor:  "cursor\r\nretry: 1",
+		Routing: &pb.AgentEventMetadata{EventType: "chat\nid: forged\ndata: {}"},
+	})
+	if err != nil {
+		t.Fatalf("writeEvent failed: %v", err)
+	}
+
+	frames := strings.Split(buf.String(), "\n\n")
+	if len(frames) != 2 || frames[1] != "" {
+		t.Fatalf("Expected a single event, got %q", buf.String())
+	}
+	lines := strings.Split(frames[0], "\n")
+	if len(lines) != 3 || lines[0] != "id: cursorretry: 1" || lines[1] != "event: chatid: forgeddata: {}" || !strings.HasPrefix(lines[2], "data: {") {
+		t.Errorf("Expected the line breaks of single-line fields to be dropped, got %q", lines)
+	}
+
+	buf.Reset()
+	if err := writeData(&buf, "first\nsecond\r\n\rthird"); err != nil {
+		t.Fatalf("writeData failed: %v", err)
+	}
+	if want := "data: first\ndata: second\ndata: \ndata: third\n\n"; buf.String() != want {
+		t.Errorf("Expected one data field per line, got %q", buf.String())
+	}
+}
@@ -5,39 +5,82 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"math"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/owulveryck/agenthub/internal/config"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 )
 
+// LevelTrace is the most verbose log level, below DEBUG. It is used for
+// full payload dumps and enabled with LOG_LEVEL=TRACE.
+const LevelTrace = slog.Level(-8)
+
+// levelName returns the display name of a log level, including TRACE
+func levelName(level slog.Level) string {
+	if level == LevelTrace {
+		return "TRACE"
+	}
+	return level.String()
+}
+
 type Config struct {
 	ServiceName    string
 	ServiceVersion string
 	JaegerEndpoint string
 	PrometheusPort string
+	OTLPProtocol   string // OTLP exporter protocol: "grpc" (default) or "http"
 	Environment    string
 	LogLevel       string
+
+	// TraceSampleRatio is the fraction of new traces sampled, from 0 (none) to 1 (all).
+	// Spans continuing a remote trace follow the sampling decision of their parent.
+	TraceSampleRatio float64
+
+	// EnableTracing exports spans over OTLP. When false, a no-op tracer provider
+	// is installed and no exporter connects to the trace backend.
+	EnableTracing bool
+	// EnableMetrics exports metrics through MetricsExporter. When false,
+	// instruments are no-ops.
+	EnableMetrics bool
+	// MetricsExporter is "prometheus" (default), serving metrics for scraping, or
+	// "otlp", pushing them every MetricsExportInterval to the OTLP endpoint used for
+	// traces, for deployments where a collector receives telemetry and nothing scrapes.
+	MetricsExporter       string
+	MetricsExportInterval time.Duration
 }
 
+// DefaultTraceSampleRatio samples every trace
+const DefaultTraceSampleRatio = 1.0
+
 type Observability struct {
 	Config   Config
 	Tracer   trace.Tracer
 	Meter    metric.Meter
 	Logger   *slog.Logger
 	Handler  *ObservabilityHandler
+	level    *slog.LevelVar
 	shutdown func(context.Context) error
+
+	flushTraces  func(context.Context) error
+	flushMetrics func(context.Context) error
 }
 
 func NewObservability(config Config) (*Observability, error) {
@@ -61,27 +104,23 @@ func NewObservability(config Config) (*Observability, error) {
 		return nil, err
 	}
 
-	// Setup tracing with OTLP exporter
-	traceExporter, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithEndpoint(config.JaegerEndpoint),
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithTimeout(time.Second*10), // Add explicit timeout
-		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
-			Enabled:         true,
-			InitialInterval: time.Second,
-			MaxInterval:     time.Second * 5,
-			MaxElapsedTime:  time.Second * 30,
-		}),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create OTLP trace exporter for service %s (endpoint: %s): %w", config.ServiceName, config.JaegerEndpoint, err)
-	}
+	// Setup tracing with OTLP exporter, or record nothing when tracing is disabled
+	var tracerProvider trace.TracerProvider = tracenoop.NewTracerProvider()
+	shutdownTraces, flushTraces := noopFlush, noopFlush
+	if config.EnableTracing {
+		traceExporter, err := newTraceExporter(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter for service %s (endpoint: %s): %w", config.ServiceName, config.JaegerEndpoint, err)
+		}
 
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(traceExporter),
-		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-	)
+		sdkTracerProvider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(traceExporter),
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TraceSampleRatio))),
+		)
+		tracerProvider = sdkTracerProvider
+		shutdownTraces, flushTraces = sdkTracerProvider.Shutdown, sdkTracerProvider.ForceFlush
+	}
 
 	otel.SetTracerProvider(tracerProvider)
 
@@ -93,65 +132,60 @@ func NewObservability(config Config) (*Observability, error) {
 
 	tracer := otel.Tracer(config.ServiceName)
 
-	// Setup metrics
-	promExporter, err := prometheus.New()
-	if err != nil {
-		return nil, err
-	}
+	// Setup metrics with the configured exporter, or record nothing when metrics are disabled
+	var meterProvider metric.MeterProvider = metricnoop.NewMeterProvider()
+	shutdownMetrics, flushMetrics := noopFlush, noopFlush
+	if config.EnableMetrics {
+		metricReader, err := newMetricReader(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s metrics exporter for service %s: %w", config.MetricsExporter, config.ServiceName, err)
+		}
 
-	meterProvider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(promExporter),
-	)
+		sdkMeterProvider := sdkmetric.NewMeterProvider(
+			sdkmetric.WithResource(res),
+			sdkmetric.WithReader(metricReader),
+		)
+		meterProvider = sdkMeterProvider
+		shutdownMetrics, flushMetrics = sdkMeterProvider.Shutdown, sdkMeterProvider.ForceFlush
+	}
 
 	otel.SetMeterProvider(meterProvider)
 	meter := otel.Meter(config.ServiceName)
 
-	// Parse log level
-	var logLevel slog.Level
-	switch strings.ToUpper(config.LogLevel) {
-	case "DEBUG":
-		logLevel = slog.LevelDebug
-	case "INFO":
-		logLevel = slog.LevelInfo
-	case "WARN", "WARNING":
-		logLevel = slog.LevelWarn
-	case "ERROR":
-		logLevel = slog.LevelError
-	default:
+	// Parse log level, kept in a LevelVar so that SetLogLevel can change it at runtime
+	logLevel, err := ParseLogLevel(config.LogLevel)
+	if err != nil {
 		logLevel = slog.LevelInfo
 	}
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(logLevel)
 
 	// Create observability handler with log level
 	handlerOpts := HandlerOptions{
-		Level: logLevel,
+		Level: levelVar,
+	}
+	handler, err := NewObservabilityHandlerWithOptions(tracer, meter, config.ServiceName, handlerOpts)
+	if err != nil {
+		return nil, err
 	}
 
-	// If DEBUG level, also log to stdout
-	var logger *slog.Logger
-	if logLevel == slog.LevelDebug {
-		// Create a multi-writer: observability handler + stdout
-		stdoutHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		})
-
-		handler, err := NewObservabilityHandlerWithOptions(tracer, meter, config.ServiceName, handlerOpts)
-		if err != nil {
-			return nil, err
-		}
+	// At DEBUG or TRACE level, also log to stdout
+	stdoutHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: stdoutLevel{levelVar},
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && len(groups) == 0 {
+				if level, ok := a.Value.Any().(slog.Level); ok {
+					a.Value = slog.StringValue(levelName(level))
+				}
+			}
+			return a
+		},
+	})
 
-		// Create a combined handler that writes to both
-		logger = slog.New(&CombinedHandler{
-			handlers: []slog.Handler{handler, stdoutHandler},
-		})
-	} else {
-		// Only use observability handler
-		handler, err := NewObservabilityHandlerWithOptions(tracer, meter, config.ServiceName, handlerOpts)
-		if err != nil {
-			return nil, err
-		}
-		logger = slog.New(handler)
-	}
+	// Create a combined handler that writes to both
+	logger := slog.New(&CombinedHandler{
+		handlers: []slog.Handler{handler, stdoutHandler},
+	})
 
 	obs := &Observability{
 		Config:  config,
@@ -159,34 +193,225 @@ func NewObservability(config Config) (*Observability, error) {
 		Meter:   meter,
 		Logger:  logger,
 		Handler: nil, // Will be set below
+		level:   levelVar,
 		shutdown: func(ctx context.Context) error {
-			if err := tracerProvider.Shutdown(ctx); err != nil {
+			if err := shutdownTraces(ctx); err != nil {
 				return fmt.Errorf("failed to shutdown trace provider for service %s (OTLP endpoint: %s): %w", config.ServiceName, config.JaegerEndpoint, err)
 			}
-			if err := meterProvider.Shutdown(ctx); err != nil {
+			if err := shutdownMetrics(ctx); err != nil {
 				return fmt.Errorf("failed to shutdown meter provider for service %s: %w", config.ServiceName, err)
 			}
 			return nil
 		},
+		flushTraces:  flushTraces,
+		flushMetrics: flushMetrics,
 	}
 
 	return obs, nil
 }
 
+// noopFlush stands for the shutdown and flush of a disabled signal
+func noopFlush(context.Context) error {
+	return nil
+}
+
+// newTraceExporter creates the OTLP trace exporter for the configured protocol
+func newTraceExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(cfg.OTLPProtocol) {
+	case "", config.OTLPProtocolGRPC:
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(cfg.JaegerEndpoint),
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithTimeout(time.Second*10), // Add explicit timeout
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Second,
+				MaxInterval:     time.Second * 5,
+				MaxElapsedTime:  time.Second * 30,
+			}),
+		)
+	case config.OTLPProtocolHTTP:
+		return otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpoint(cfg.JaegerEndpoint),
+			otlptracehttp.WithInsecure(),
+			otlptracehttp.WithTimeout(time.Second*10),
+			otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Second,
+				MaxInterval:     time.Second * 5,
+				MaxElapsedTime:  time.Second * 30,
+			}),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q: use grpc or http", cfg.OTLPProtocol)
+	}
+}
+
+// newMetricReader creates the reader exporting metrics, pulled by Prometheus or
+// pushed periodically over OTLP
+func newMetricReader(ctx context.Context, cfg Config) (sdkmetric.Reader, error) {
+	var exporter sdkmetric.Exporter
+	switch strings.ToLower(cfg.MetricsExporter) {
+	case "", config.MetricsExporterPrometheus:
+		return prometheus.New()
+	case config.MetricsExporterOTLP:
+		switch strings.ToLower(cfg.OTLPProtocol) {
+		case "", config.OTLPProtocolGRPC:
+			grpcExporter, err := otlpmetricgrpc.New(ctx,
+				otlpmetricgrpc.WithEndpoint(cfg.JaegerEndpoint),
+				otlpmetricgrpc.WithInsecure(),
+				otlpmetricgrpc.WithTimeout(time.Second*10),
+			)
+			if err != nil {
+				return nil, err
+			}
+			exporter = grpcExporter
+		case config.OTLPProtocolHTTP:
+			httpExporter, err := otlpmetrichttp.New(ctx,
+				otlpmetrichttp.WithEndpoint(cfg.JaegerEndpoint),
+				otlpmetrichttp.WithInsecure(),
+				otlpmetrichttp.WithTimeout(time.Second*10),
+			)
+			if err != nil {
+				return nil, err
+			}
+			exporter = httpExporter
+		default:
+			return nil, fmt.Errorf("unsupported OTLP protocol %q: use grpc or http", cfg.OTLPProtocol)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported metrics exporter %q: use prometheus or otlp", cfg.MetricsExporter)
+	}
+
+	interval := cfg.MetricsExportInterval
+	if interval <= 0 {
+		interval = config.DefaultMetricsExportInterval
+	}
+	return sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval)), nil
+}
+
+// NewNoopObservability creates observability that records nothing: spans and
+// metrics are discarded and so are log records. No exporter is set up, which
+// suits tests and embedded brokers. Pair it with NewTraceManagerWithTracer(obs.Tracer).
+func NewNoopObservability() *Observability {
+	return &Observability{
+		Config: Config{ServiceName: "agenthub"},
+		Tracer: tracenoop.NewTracerProvider().Tracer("agenthub"),
+		Meter:  metricnoop.NewMeterProvider().Meter("agenthub"),
+		Logger: slog.New(slog.DiscardHandler),
+		level:  new(slog.LevelVar),
+		shutdown: func(ctx context.Context) error {
+			return nil
+		},
+	}
+}
+
 func (o *Observability) Shutdown(ctx context.Context) error {
 	return o.shutdown(ctx)
 }
 
+// FlushTraces exports the spans still buffered by the trace pipeline
+func (o *Observability) FlushTraces(ctx context.Context) error {
+	if o.flushTraces == nil {
+		return nil
+	}
+	return o.flushTraces(ctx)
+}
+
+// FlushMetrics collects and exports the pending metric readings
+func (o *Observability) FlushMetrics(ctx context.Context) error {
+	if o.flushMetrics == nil {
+		return nil
+	}
+	return o.flushMetrics(ctx)
+}
+
+// SetLogLevel changes the level of the logger at runtime. It accepts the
+// LOG_LEVEL values: TRACE, DEBUG, INFO, WARN, WARNING and ERROR.
+func (o *Observability) SetLogLevel(level string) error {
+	if o.level == nil {
+		return fmt.Errorf("log level of service %s cannot be changed", o.Config.ServiceName)
+	}
+	logLevel, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	o.level.Set(logLevel)
+	o.Logger.Info("Log level changed", "level", levelName(logLevel))
+	return nil
+}
+
+// LogLevel returns the current level of the logger
+func (o *Observability) LogLevel() string {
+	if o.level == nil {
+		return levelName(slog.LevelInfo)
+	}
+	return levelName(o.level.Level())
+}
+
+// ParseLogLevel parses a LOG_LEVEL value, ignoring case
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToUpper(level) {
+	case "TRACE":
+		return LevelTrace, nil
+	case "DEBUG":
+		return slog.LevelDebug, nil
+	case "INFO":
+		return slog.LevelInfo, nil
+	case "WARN", "WARNING":
+		return slog.LevelWarn, nil
+	case "ERROR":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q: use TRACE, DEBUG, INFO, WARN or ERROR", level)
+	}
+}
+
+// stdoutLevel enables stdout logging only while the level is DEBUG or more verbose
+type stdoutLevel struct {
+	level *slog.LevelVar
+}
+
+func (l stdoutLevel) Level() slog.Level {
+	if level := l.level.Level(); level <= slog.LevelDebug {
+		return level
+	}
+	return slog.LevelError + 1
+}
+
 func DefaultConfig(serviceName string) Config {
 	appConfig := config.Load()
 	return Config{
 		ServiceName:    serviceName,
 		ServiceVersion: appConfig.ServiceVersion,
 		JaegerEndpoint: appConfig.JaegerEndpoint,
+		OTLPProtocol:   appConfig.OTLPProtocol,
 		PrometheusPort: appConfig.PrometheusPort,
 		Environment:    appConfig.Environment,
 		LogLevel:       appConfig.LogLevel,
+
+		TraceSampleRatio: traceSampleRatioFromEnv(),
+
+		EnableTracing: config.TracingEnabled(),
+		EnableMetrics: config.MetricsEnabled(),
+
+		MetricsExporter:       appConfig.MetricsExporter,
+		MetricsExportInterval: appConfig.MetricsExportInterval,
+	}
+}
+
+// traceSampleRatioFromEnv reads OTEL_TRACES_SAMPLER_ARG, clamped to [0, 1].
+// Unset or malformed values sample every trace.
+func traceSampleRatioFromEnv() float64 {
+	value := os.Getenv("OTEL_TRACES_SAMPLER_ARG")
+	if value == "" {
+		return DefaultTraceSampleRatio
+	}
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return DefaultTraceSampleRatio
 	}
+	return math.Min(math.Max(ratio, 0), 1)
 }
 
 // CombinedHandler implements slog.Handler and forwards to multiple handlers
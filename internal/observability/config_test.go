@@ -0,0 +1,262 @@
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/owulveryck/agenthub/internal/config"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestNewTraceExporter(t *testing.T) {
+	tests := []struct {
+		protocol string
+		wantErr  bool
+	}{
+		{"", false},
+		{config.OTLPProtocolGRPC, false},
+		{"GRPC", false},
+		{config.OTLPProtocolHTTP, false},
+		{"thrift", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.protocol, func(t *testing.T) {
+			exporter, err := newTraceExporter(context.Background(), Config{JaegerEndpoint: "127.0.0.1:4317", OTLPProtocol: tt.protocol})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "unsupported OTLP protocol") {
+					t.Fatalf("Expected an unsupported protocol error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to create trace exporter: %v", err)
+			}
+			if exporter == nil {
+				t.Fatal("Expected an exporter")
+			}
+			exporter.Shutdown(context.Background())
+		})
+	}
+}
+
+func TestNewMetricReader(t *testing.T) {
+	tests := []struct {
+		name         string
+		exporter     string
+		protocol     string
+		wantPeriodic bool
+		wantErr      string
+	}{
+		{"default", "", "", false, ""},
+		{"prometheus", config.MetricsExporterPrometheus, "", false, ""},
+		{"OTLP over gRPC", config.MetricsExporterOTLP, config.OTLPProtocolGRPC, true, ""},
+		{"OTLP over HTTP", "OTLP", config.OTLPProtocolHTTP, true, ""},
+		{"OTLP over unknown protocol", config.MetricsExporterOTLP, "thrift", false, "unsupported OTLP protocol"},
+		{"unknown exporter", "statsd", "", false, "unsupported metrics exporter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := newMetricReader(context.Background(), Config{
+				JaegerEndpoint:  "127.0.0.1:4317",
+				OTLPProtocol:    tt.protocol,
+				MetricsExporter: tt.exporter,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to create metric reader: %v", err)
+			}
+			defer reader.Shutdown(context.Background())
+
+			switch reader.(type) {
+			case *sdkmetric.PeriodicReader:
+				if !tt.wantPeriodic {
+					t.Error("Expected a Prometheus reader, got a periodic reader")
+				}
+			case *prometheus.Exporter:
+				if tt.wantPeriodic {
+					t.Error("Expected a periodic reader, got a Prometheus reader")
+				}
+			default:
+				t.Errorf("Unexpected reader %T", reader)
+			}
+		})
+	}
+}
+
+// otlpCollector records the paths of the OTLP/HTTP export requests it receives
+type otlpCollector struct {
+	mu    sync.Mutex
+	paths map[string]int
+}
+
+func newOTLPCollector(t *testing.T) (*otlpCollector, string) {
+	t.Helper()
+	collector := &otlpCollector{paths: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		collector.mu.Lock()
+		collector.paths[r.URL.Path]++
+		collector.mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(server.Close)
+	return collector, strings.TrimPrefix(server.URL, "http://")
+}
+
+func (c *otlpCollector) received(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paths[path]
+}
+
+func TestNewObservability_Toggles(t *testing.T) {
+	tests := []struct {
+		name          string
+		enableTracing bool
+		enableMetrics bool
+	}{
+		{"disabled", false, false},
+		{"tracing only", true, false},
+		{"metrics only", false, true},
+		{"both", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector, endpoint := newOTLPCollector(t)
+			obs, err := NewObservability(Config{
+				ServiceName:      "toggle-test",
+				JaegerEndpoint:   endpoint,
+				OTLPProtocol:     config.OTLPProtocolHTTP,
+				TraceSampleRatio: DefaultTraceSampleRatio,
+				EnableTracing:    tt.enableTracing,
+				EnableMetrics:    tt.enableMetrics,
+				MetricsExporter:  config.MetricsExporterOTLP,
+				// Long enough that only the explicit flush exports
+				MetricsExportInterval: time.Hour,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create observability: %v", err)
+			}
+			ctx := context.Background()
+			defer obs.Shutdown(ctx)
+
+			_, span := obs.Tracer.Start(ctx, "toggle.span")
+			if span.IsRecording() != tt.enableTracing {
+				t.Errorf("Expected span recording to be %v", tt.enableTracing)
+			}
+			span.End()
+
+			counter, err := obs.Meter.Int64Counter("toggle_test_total")
+			if err != nil {
+				t.Fatalf("Failed to create counter: %v", err)
+			}
+			counter.Add(ctx, 1)
+
+			if err := obs.FlushTraces(ctx); err != nil {
+				t.Errorf("Failed to flush traces: %v", err)
+			}
+			if err := obs.FlushMetrics(ctx); err != nil {
+				t.Errorf("Failed to flush metrics: %v", err)
+			}
+
+			if got := collector.received("/v1/traces") > 0; got != tt.enableTracing {
+				t.Errorf("Expected traces exported: %v, got %v", tt.enableTracing, got)
+			}
+			if got := collector.received("/v1/metrics") > 0; got != tt.enableMetrics {
+				t.Errorf("Expected metrics pushed: %v, got %v", tt.enableMetrics, got)
+			}
+		})
+	}
+}
+
+func TestNewObservability_InvalidExporter(t *testing.T) {
+	_, err := NewObservability(Config{
+		ServiceName:     "invalid-test",
+		EnableMetrics:   true,
+		MetricsExporter: "statsd",
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to create statsd metrics exporter") {
+		t.Errorf("Expected a metrics exporter error, got %v", err)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"TRACE", LevelTrace, false},
+		{"debug", slog.LevelDebug, false},
+		{"Info", slog.LevelInfo, false},
+		{"WARN", slog.LevelWarn, false},
+		{"warning", slog.LevelWarn, false},
+		{"ERROR", slog.LevelError, false},
+		{"", slog.LevelInfo, true},
+		{"verbose", slog.LevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLogLevel(tt.level)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogLevel(%q) error = %v, expected error: %v", tt.level, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = %v, expected %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestObservability_SetLogLevel(t *testing.T) {
+	obs := NewNoopObservability()
+	if got := obs.LogLevel(); got != "INFO" {
+		t.Errorf("Expected the INFO level by default, got %s", got)
+	}
+
+	if err := obs.SetLogLevel("trace"); err != nil {
+		t.Fatalf("Failed to set log level: %v", err)
+	}
+	if got := obs.LogLevel(); got != "TRACE" {
+		t.Errorf("Expected the TRACE level, got %s", got)
+	}
+
+	if err := obs.SetLogLevel("loud"); err == nil {
+		t.Error("Expected an unknown level to be rejected")
+	}
+	if got := obs.LogLevel(); got != "TRACE" {
+		t.Errorf("Expected a rejected level to keep TRACE, got %s", got)
+	}
+
+	fixed := &Observability{Config: Config{ServiceName: "fixed"}}
+	if err := fixed.SetLogLevel("DEBUG"); err == nil {
+		t.Error("Expected an observability without a level variable to refuse changes")
+	}
+}
+
+func TestStdoutLevel(t *testing.T) {
+	levelVar := new(slog.LevelVar)
+	level := stdoutLevel{levelVar}
+
+	levelVar.Set(slog.LevelInfo)
+	if level.Level() <= slog.LevelError {
+		t.Errorf("Expected stdout logging off at INFO, got level %v", level.Level())
+	}
+	levelVar.Set(slog.LevelDebug)
+	if level.Level() != slog.LevelDebug {
+		t.Errorf("Expected stdout logging at DEBUG, got level %v", level.Level())
+	}
+}
@@ -37,7 +37,7 @@ type ObservabilityHandler struct {
 }
 
 type HandlerOptions struct {
-	Level       slog.Level
+	Level       slog.Leveler // A *slog.LevelVar lets the level change at runtime
 	Writer      io.Writer
 	ReplaceAttr func(groups []string, a slog.Attr) slog.Attr
 	BufferSize  int
@@ -74,6 +74,9 @@ func NewObservabilityHandlerWithOptions(tracer trace.Tracer, meter metric.Meter,
 	if opts.BufferSize <= 0 {
 		opts.BufferSize = 1000
 	}
+	if opts.Level == nil {
+		opts.Level = slog.LevelInfo
+	}
 
 	// Initialize metrics
 	eventCounter, err := meter.Int64Counter(
@@ -133,7 +136,7 @@ func NewObservabilityHandlerWithOptions(tracer trace.Tracer, meter metric.Meter,
 }
 
 func (h *ObservabilityHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return level >= h.opts.Level
+	return level >= h.opts.Level.Level()
 }
 
 func (h *ObservabilityHandler) Handle(ctx context.Context, r slog.Record) error {
@@ -222,14 +225,14 @@ func (h *ObservabilityHandler) processLogs() {
 func (h *ObservabilityHandler) processLogEntry(entry logEntry) {
 	// Update metrics
 	h.logCounter.Add(entry.ctx, 1, metric.WithAttributes(
-		attribute.String("level", entry.level.String()),
+		attribute.String("level", levelName(entry.level)),
 		attribute.String("service", h.serviceName),
 	))
 
 	// Convert to structured format for output
 	logData := map[string]interface{}{
 		"time":    entry.time.Format(time.RFC3339),
-		"level":   entry.level.String(),
+		"level":   levelName(entry.level),
 		"msg":     entry.msg,
 		"service": h.serviceName,
 	}
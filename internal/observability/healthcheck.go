@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -36,13 +38,45 @@ type HealthChecker interface {
 	Check(ctx context.Context) HealthCheck
 }
 
+// Probe selects the health endpoints a checker contributes to
+type Probe int
+
+const (
+	// ProbeReadiness checks whether the service can serve traffic (/readyz)
+	ProbeReadiness Probe = 1 << iota
+	// ProbeLiveness checks whether the process must be restarted (/livez)
+	ProbeLiveness
+)
+
+// probeChecker is a registered checker with the probes it contributes to
+type probeChecker struct {
+	checker HealthChecker
+	probes  Probe
+}
+
+// LogLevelController reads and changes the log level at runtime, as Observability does
+type LogLevelController interface {
+	LogLevel() string
+	SetLogLevel(level string) error
+}
+
 type HealthServer struct {
 	port        string
 	serviceName string
 	version     string
 	startTime   time.Time
-	checkers    map[string]HealthChecker
-	server      *http.Server
+	ready       atomic.Bool
+	logLevel    LogLevelController
+	handlers    map[string]http.Handler
+
+	// checkersMu guards checkers, which may be added while the server runs
+	checkersMu sync.RWMutex
+	checkers   map[string]probeChecker
+
+	// serverMu guards server, created by Start while Shutdown may already run
+	serverMu sync.Mutex
+	server   *http.Server
+	stopped  bool
 }
 
 func NewHealthServer(port, serviceName, version string) *HealthServer {
@@ -51,53 +85,134 @@ func NewHealthServer(port, serviceName, version string) *HealthServer {
 		serviceName: serviceName,
 		version:     version,
 		startTime:   time.Now(),
-		checkers:    make(map[string]HealthChecker),
+		checkers:    make(map[string]probeChecker),
 	}
 }
 
+// SetReady marks the service as ready (or not) to receive traffic.
+// Until it is marked ready, the readiness endpoints report unavailable.
+func (hs *HealthServer) SetReady(ready bool) {
+	hs.ready.Store(ready)
+}
+
+// IsReady reports whether the service has been marked ready
+func (hs *HealthServer) IsReady() bool {
+	return hs.ready.Load()
+}
+
+// AddChecker registers a readiness checker
 func (hs *HealthServer) AddChecker(name string, checker HealthChecker) {
-	hs.checkers[name] = checker
+	hs.AddProbeChecker(name, checker, ProbeReadiness)
+}
+
+// AddProbeChecker registers a checker run by the given probes, combined with |.
+// Liveness checkers should only fail when restarting the process would help.
+// Checkers can be added before or after Start.
+func (hs *HealthServer) AddProbeChecker(name string, checker HealthChecker, probes Probe) {
+	hs.checkersMu.Lock()
+	defer hs.checkersMu.Unlock()
+	hs.checkers[name] = probeChecker{checker: checker, probes: probes}
+}
+
+// probeCheckers returns the checkers run by a probe
+func (hs *HealthServer) probeCheckers(probe Probe) []HealthChecker {
+	hs.checkersMu.RLock()
+	defer hs.checkersMu.RUnlock()
+
+	checkers := make([]HealthChecker, 0, len(hs.checkers))
+	for _, registered := range hs.checkers {
+		if registered.probes&probe != 0 {
+			checkers = append(checkers, registered.checker)
+		}
+	}
+	return checkers
+}
+
+// SetLogLevelController serves the /loglevel endpoint, which reads (GET) and
+// changes (POST) the log level through the controller. Call it before Start.
+func (hs *HealthServer) SetLogLevelController(controller LogLevelController) {
+	hs.logLevel = controller
+}
+
+// Handle serves an additional endpoint, such as a debug view, next to the health
+// endpoints. The pattern follows http.ServeMux. Call it before Start.
+func (hs *HealthServer) Handle(pattern string, handler http.Handler) {
+	if hs.handlers == nil {
+		hs.handlers = make(map[string]http.Handler)
+	}
+	hs.handlers[pattern] = handler
 }
 
 func (hs *HealthServer) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 
-	// Health endpoint
-	mux.HandleFunc("/health", hs.healthHandler)
+	// Liveness endpoint
+	mux.HandleFunc("/livez", hs.livenessHandler)
 
-	// Ready endpoint (same as health for now)
+	// Readiness endpoint, also served as /health and /ready
+	mux.HandleFunc("/readyz", hs.readyHandler)
+	mux.HandleFunc("/health", hs.readyHandler)
 	mux.HandleFunc("/ready", hs.readyHandler)
 
 	// Metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
 
-	hs.server = &http.Server{
+	// Runtime log level endpoint
+	if hs.logLevel != nil {
+		mux.HandleFunc("GET /loglevel", hs.getLogLevelHandler)
+		mux.HandleFunc("POST /loglevel", hs.setLogLevelHandler)
+	}
+
+	// Additional endpoints
+	for pattern, handler := range hs.handlers {
+		mux.Handle(pattern, handler)
+	}
+
+	server := &http.Server{
 		Addr:    ":" + hs.port,
 		Handler: mux,
 	}
 
-	return hs.server.ListenAndServe()
+	hs.serverMu.Lock()
+	if hs.stopped {
+		hs.serverMu.Unlock()
+		return http.ErrServerClosed
+	}
+	hs.server = server
+	hs.serverMu.Unlock()
+
+	return server.ListenAndServe()
 }
 
+// Shutdown stops the server gracefully. Once called, a Start that has not yet
+// begun serving returns http.ErrServerClosed instead of listening.
 func (hs *HealthServer) Shutdown(ctx context.Context) error {
-	if hs.server != nil {
-		return hs.server.Shutdown(ctx)
+	hs.serverMu.Lock()
+	hs.stopped = true
+	server := hs.server
+	hs.serverMu.Unlock()
+
+	if server != nil {
+		return server.Shutdown(ctx)
 	}
 	return nil
 }
 
-func (hs *HealthServer) healthHandler(w http.ResponseWriter, r *http.Request) {
+// writeChecks runs the checkers of the probe and writes the response,
+// unavailable when a check fails
+func (hs *HealthServer) writeChecks(w http.ResponseWriter, r *http.Request, probe Probe) {
 	ctx := r.Context()
+	checkers := hs.probeCheckers(probe)
 
 	response := HealthResponse{
 		Status:  HealthStatusHealthy,
 		Version: hs.version,
 		Uptime:  time.Since(hs.startTime).String(),
-		Checks:  make([]HealthCheck, 0, len(hs.checkers)),
+		Checks:  make([]HealthCheck, 0, len(checkers)),
 	}
 
-	// Run all health checks
-	for _, checker := range hs.checkers {
+	// Run the health checks of the probe, without holding the lock while they run
+	for _, checker := range checkers {
 		check := checker.Check(ctx)
 		response.Checks = append(response.Checks, check)
 
@@ -118,10 +233,56 @@ func (hs *HealthServer) healthHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// livenessHandler reports the process alive, unless a liveness checker fails
+func (hs *HealthServer) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	hs.writeChecks(w, r, ProbeLiveness)
+}
+
 func (hs *HealthServer) readyHandler(w http.ResponseWriter, r *http.Request) {
-	// For now, ready is the same as health
-	// In production, you might want different checks for readiness vs liveness
-	hs.healthHandler(w, r)
+	// Not ready until the service finished initializing
+	if !hs.IsReady() {
+		response := HealthResponse{
+			Status:  HealthStatusUnhealthy,
+			Version: hs.version,
+			Uptime:  time.Since(hs.startTime).String(),
+			Checks: []HealthCheck{{
+				Name:        "readiness",
+				Status:      HealthStatusUnhealthy,
+				Message:     "service is initializing",
+				LastChecked: time.Now(),
+			}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Once ready, readiness follows the readiness checks
+	hs.writeChecks(w, r, ProbeReadiness)
+}
+
+// logLevelRequest is the body of /loglevel requests and responses
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+func (hs *HealthServer) getLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logLevelRequest{Level: hs.logLevel.LogLevel()})
+}
+
+func (hs *HealthServer) setLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := hs.logLevel.SetLogLevel(req.Level); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hs.getLogLevelHandler(w, r)
 }
 
 // Basic health checker implementations
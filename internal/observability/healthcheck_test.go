@@ -0,0 +1,59 @@
+package observability
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHealthServer_LogLevelHandlers(t *testing.T) {
+	obs := NewNoopObservability()
+	hs := NewHealthServer("0", "loglevel-test", "test")
+	hs.SetLogLevelController(obs)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantLevel  string
+	}{
+		{"set DEBUG", `{"level":"debug"}`, http.StatusOK, "DEBUG"},
+		{"set TRACE", `{"level":"TRACE"}`, http.StatusOK, "TRACE"},
+		{"unknown level", `{"level":"verbose"}`, http.StatusBadRequest, "TRACE"},
+		{"invalid body", `level=warn`, http.StatusBadRequest, "TRACE"},
+		{"set WARN", `{"level":"warning"}`, http.StatusOK, "WARN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			hs.setLogLevelHandler(rec, httptest.NewRequest(http.MethodPost, "/loglevel", strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp logLevelRequest
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if resp.Level != tt.wantLevel {
+					t.Errorf("Expected the response to report %s, got %s", tt.wantLevel, resp.Level)
+				}
+			}
+			if got := obs.LogLevel(); got != tt.wantLevel {
+				t.Errorf("Expected the log level %s, got %s", tt.wantLevel, got)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	hs.getLogLevelHandler(rec, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected a JSON response, got %s", ct)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `"level":"WARN"`) {
+		t.Errorf("Expected GET to report WARN, got %s", body)
+	}
+}
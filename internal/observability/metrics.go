@@ -17,6 +17,19 @@ type MetricsManager struct {
 	eventProcessingDuration metric.Float64Histogram
 	eventErrorsTotal        metric.Int64Counter
 	eventsPublishedTotal    metric.Int64Counter
+	eventsUnroutableTotal   metric.Int64Counter
+	eventsDroppedTotal      metric.Int64Counter
+	contextsEvictedTotal    metric.Int64Counter
+	tasksEvictedTotal       metric.Int64Counter
+	panicsRecoveredTotal    metric.Int64Counter
+	rateLimitRejections     metric.Int64Counter
+	eventsDeadLetteredTotal metric.Int64Counter
+	taskLifetime            metric.Float64Histogram
+	duplicateMessagesTotal  metric.Int64Counter
+	agentRegistrationsTotal metric.Int64Counter
+	subscriberQueueDepth    metric.Int64Gauge
+	subscriberQueueCapacity metric.Int64Gauge
+	circuitState            metric.Int64Gauge
 
 	// System metrics
 	processCPUSecondsTotal     metric.Float64Counter
@@ -28,6 +41,18 @@ type MetricsManager struct {
 	messageBrokerPublishDuration  metric.Float64Histogram
 	messageBrokerConsumeDuration  metric.Float64Histogram
 	messageBrokerConnectionErrors metric.Int64Counter
+	subscriptionConnected         metric.Int64UpDownCounter
+	taskWorkersActive             metric.Int64UpDownCounter
+
+	// Skill metrics
+	skillInvocationsTotal metric.Int64Counter
+	skillFailuresTotal    metric.Int64Counter
+	skillDuration         metric.Float64Histogram
+
+	// Orchestration metrics
+	cortexActionsTotal     metric.Int64Counter
+	cortexLLMRetriesTotal  metric.Int64Counter
+	cortexLLMFailuresTotal metric.Int64Counter
 }
 
 func NewMetricsManager(meter metric.Meter) (*MetricsManager, error) {
@@ -72,6 +97,123 @@ func NewMetricsManager(meter metric.Meter) (*MetricsManager, error) {
 		return nil, err
 	}
 
+	mm.eventsUnroutableTotal, err = meter.Int64Counter(
+		"events_unroutable_total",
+		metric.WithDescription("Total number of events with no agent providing the required skill"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.eventsDroppedTotal, err = meter.Int64Counter(
+		"events_dropped_total",
+		metric.WithDescription("Total number of events dropped before reaching a subscriber"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.contextsEvictedTotal, err = meter.Int64Counter(
+		"contexts_evicted_total",
+		metric.WithDescription("Total number of conversation contexts evicted from broker history"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.tasksEvictedTotal, err = meter.Int64Counter(
+		"tasks_evicted_total",
+		metric.WithDescription("Total number of finished tasks dropped from the broker after their retention period"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.panicsRecoveredTotal, err = meter.Int64Counter(
+		"panics_recovered_total",
+		metric.WithDescription("Total number of panics recovered by the broker"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.rateLimitRejections, err = meter.Int64Counter(
+		"ratelimit_rejections_total",
+		metric.WithDescription("Total number of publishes rejected by the broker rate limiter"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.eventsDeadLetteredTotal, err = meter.Int64Counter(
+		"events_deadlettered_total",
+		metric.WithDescription("Total number of events routed to no subscriber and dead-lettered"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.taskLifetime, err = meter.Float64Histogram(
+		"task_lifetime_seconds",
+		metric.WithDescription("Time from task creation to its terminal state in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.duplicateMessagesTotal, err = meter.Int64Counter(
+		"duplicate_messages_total",
+		metric.WithDescription("Total number of republished messages answered from the deduplication cache"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.agentRegistrationsTotal, err = meter.Int64Counter(
+		"agent_registrations_total",
+		metric.WithDescription("Total number of agent registrations, re-registrations and deregistrations"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.subscriberQueueDepth, err = meter.Int64Gauge(
+		"subscriber_queue_depth",
+		metric.WithDescription("Events waiting in the fullest subscription channel of an agent"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.subscriberQueueCapacity, err = meter.Int64Gauge(
+		"subscriber_queue_capacity",
+		metric.WithDescription("Capacity of the subscription channels of an agent"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.circuitState, err = meter.Int64Gauge(
+		"circuit_state",
+		metric.WithDescription("State of the delivery circuit breaker of a subscriber (0 closed, 1 open, 2 half-open)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// System metrics
 	mm.processCPUSecondsTotal, err = meter.Float64Counter(
 		"process_cpu_seconds_total",
@@ -137,6 +279,80 @@ func NewMetricsManager(meter metric.Meter) (*MetricsManager, error) {
 		return nil, err
 	}
 
+	mm.subscriptionConnected, err = meter.Int64UpDownCounter(
+		"subscription_connected",
+		metric.WithDescription("Whether a subscription stream to the broker is currently connected (1) or not (0)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.taskWorkersActive, err = meter.Int64UpDownCounter(
+		"task_workers_active",
+		metric.WithDescription("Number of task handlers an agent is currently running, by task type"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skill metrics
+	mm.skillInvocationsTotal, err = meter.Int64Counter(
+		"skill_invocations_total",
+		metric.WithDescription("Total number of tasks handed to an agent's skill handlers"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.skillFailuresTotal, err = meter.Int64Counter(
+		"skill_failures_total",
+		metric.WithDescription("Total number of tasks an agent's skill handlers did not complete"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.skillDuration, err = meter.Float64Histogram(
+		"skill_duration_seconds",
+		metric.WithDescription("Time an agent's skill handlers take to handle a task in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orchestration metrics
+	mm.cortexActionsTotal, err = meter.Int64Counter(
+		"cortex_actions_total",
+		metric.WithDescription("Total number of actions executed by Cortex"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.cortexLLMRetriesTotal, err = meter.Int64Counter(
+		"cortex_llm_retries_total",
+		metric.WithDescription("Total number of LLM calls retried by Cortex after a failure or timeout"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mm.cortexLLMFailuresTotal, err = meter.Int64Counter(
+		"cortex_llm_failures_total",
+		metric.WithDescription("Total number of LLM calls that failed after exhausting their retries"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return mm, nil
 }
 
@@ -171,6 +387,95 @@ func (mm *MetricsManager) IncrementEventsPublished(ctx context.Context, eventTyp
 	))
 }
 
+func (mm *MetricsManager) IncrementEventsUnroutable(ctx context.Context, eventType, skill, outcome string) {
+	mm.eventsUnroutableTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("event_type", eventType),
+		attribute.String("skill", skill),
+		attribute.String("outcome", outcome),
+	))
+}
+
+func (mm *MetricsManager) IncrementEventsDropped(ctx context.Context, eventType, reason string) {
+	mm.eventsDroppedTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("event_type", eventType),
+		attribute.String("reason", reason),
+	))
+}
+
+func (mm *MetricsManager) IncrementContextsEvicted(ctx context.Context, reason string, count int64) {
+	mm.contextsEvictedTotal.Add(ctx, count, metric.WithAttributes(
+		attribute.String("reason", reason),
+	))
+}
+
+func (mm *MetricsManager) IncrementTasksEvicted(ctx context.Context, state string, archived bool) {
+	mm.tasksEvictedTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("state", state),
+		attribute.Bool("archived", archived),
+	))
+}
+
+func (mm *MetricsManager) IncrementPanicsRecovered(ctx context.Context, operation string) {
+	mm.panicsRecoveredTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("operation", operation),
+	))
+}
+
+func (mm *MetricsManager) IncrementRateLimitRejections(ctx context.Context, agentID string) {
+	mm.rateLimitRejections.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("agent_id", agentID),
+	))
+}
+
+func (mm *MetricsManager) IncrementEventsDeadLettered(ctx context.Context, eventType string) {
+	mm.eventsDeadLetteredTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("event_type", eventType),
+	))
+}
+
+// RecordTaskLifetime records the end-to-end duration of a task that reached finalState
+func (mm *MetricsManager) RecordTaskLifetime(ctx context.Context, taskType, finalState string, d time.Duration) {
+	mm.taskLifetime.Record(ctx, d.Seconds(), metric.WithAttributes(
+		attribute.String("task_type", taskType),
+		attribute.String("final_state", finalState),
+	))
+}
+
+func (mm *MetricsManager) IncrementDuplicateMessages(ctx context.Context, agentID string) {
+	mm.duplicateMessagesTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("agent_id", agentID),
+	))
+}
+
+// IncrementAgentRegistrations counts a registration event of an agent: "registered",
+// "updated" when a registered agent registers again, or "deregistered"
+func (mm *MetricsManager) IncrementAgentRegistrations(ctx context.Context, agentID, eventType string) {
+	mm.agentRegistrationsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("agent_id", agentID),
+		attribute.String("event_type", eventType),
+	))
+}
+
+// RecordSubscriberQueueDepth records how many events wait in the fullest channel of an
+// agent's subscriptions, against the channel capacity
+func (mm *MetricsManager) RecordSubscriberQueueDepth(ctx context.Context, subscription, agentID string, depth, capacity int) {
+	attrs := metric.WithAttributes(
+		attribute.String("subscription", subscription),
+		attribute.String("agent_id", agentID),
+	)
+	mm.subscriberQueueDepth.Record(ctx, int64(depth), attrs)
+	mm.subscriberQueueCapacity.Record(ctx, int64(capacity), attrs)
+}
+
+// RecordCircuitState records the state of the delivery circuit breaker of an agent's
+// subscription: 0 closed, 1 open, 2 half-open
+func (mm *MetricsManager) RecordCircuitState(ctx context.Context, subscription, agentID string, state int) {
+	mm.circuitState.Record(ctx, int64(state), metric.WithAttributes(
+		attribute.String("subscription", subscription),
+		attribute.String("agent_id", agentID),
+	))
+}
+
 // System metrics methods
 func (mm *MetricsManager) UpdateSystemMetrics(ctx context.Context) {
 	var m runtime.MemStats
@@ -198,6 +503,64 @@ func (mm *MetricsManager) IncrementBrokerConnectionErrors(ctx context.Context) {
 	mm.messageBrokerConnectionErrors.Add(ctx, 1)
 }
 
+// SetSubscriptionConnected records a subscription stream connecting or disconnecting
+func (mm *MetricsManager) SetSubscriptionConnected(ctx context.Context, subscription, agentID string, connected bool) {
+	delta := int64(-1)
+	if connected {
+		delta = 1
+	}
+	mm.subscriptionConnected.Add(ctx, delta, metric.WithAttributes(
+		attribute.String("subscription", subscription),
+		attribute.String("agent_id", agentID),
+	))
+}
+
+// AddActiveTaskWorkers records task handlers of a type starting (delta > 0) or finishing (delta < 0)
+func (mm *MetricsManager) AddActiveTaskWorkers(ctx context.Context, agentID, taskType string, delta int64) {
+	mm.taskWorkersActive.Add(ctx, delta, metric.WithAttributes(
+		attribute.String("agent_id", agentID),
+		attribute.String("task_type", taskType),
+	))
+}
+
+// RecordSkillInvocation records a skill handler run: its duration, and a failure
+// labeled with the final state unless the task completed
+func (mm *MetricsManager) RecordSkillInvocation(ctx context.Context, agentID, skill, finalState string, completed bool, d time.Duration) {
+	attrs := metric.WithAttributes(
+		attribute.String("agent_id", agentID),
+		attribute.String("skill", skill),
+	)
+	mm.skillInvocationsTotal.Add(ctx, 1, attrs)
+	mm.skillDuration.Record(ctx, d.Seconds(), attrs)
+	if !completed {
+		mm.skillFailuresTotal.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("agent_id", agentID),
+			attribute.String("skill", skill),
+			attribute.String("state", finalState),
+		))
+	}
+}
+
+// Orchestration metrics methods
+func (mm *MetricsManager) IncrementCortexActions(ctx context.Context, actionType, targetAgent string) {
+	mm.cortexActionsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("action_type", actionType),
+		attribute.String("target_agent", targetAgent),
+	))
+}
+
+func (mm *MetricsManager) IncrementCortexLLMRetries(ctx context.Context, operation string) {
+	mm.cortexLLMRetriesTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("operation", operation),
+	))
+}
+
+func (mm *MetricsManager) IncrementCortexLLMFailures(ctx context.Context, operation string) {
+	mm.cortexLLMFailuresTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("operation", operation),
+	))
+}
+
 // Helper method to start timing an operation
 func (mm *MetricsManager) StartTimer() func(ctx context.Context, eventType, source string) {
 	start := time.Now()
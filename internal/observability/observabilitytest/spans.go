@@ -0,0 +1,76 @@
+// Package observabilitytest provides helpers to assert on the telemetry emitted
+// by AgentHub components in tests.
+package observabilitytest
+
+import (
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
+// NewInMemoryTraceManager returns a TraceManager whose spans are recorded
+// synchronously in the returned in-memory exporter.
+func NewInMemoryTraceManager(t testing.TB) (*observability.TraceManager, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() {
+		_ = provider.Shutdown(t.Context())
+	})
+
+	return observability.NewTraceManagerWithTracer(provider.Tracer("observabilitytest")), exporter
+}
+
+// FindSpans returns the ended spans with the given name, in end order
+func FindSpans(exporter *tracetest.InMemoryExporter, spanName string) tracetest.SpanStubs {
+	var spans tracetest.SpanStubs
+	for _, span := range exporter.GetSpans() {
+		if span.Name == spanName {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// AssertSpanHasAttributes fails the test unless at least one ended span named
+// spanName carries every expected attribute. Expected values are compared with
+// the attribute's string form, so integers and booleans are written as "3" or "true".
+func AssertSpanHasAttributes(t testing.TB, exporter *tracetest.InMemoryExporter, spanName string, expected map[string]string) {
+	t.Helper()
+
+	spans := FindSpans(exporter, spanName)
+	if len(spans) == 0 {
+		t.Errorf("no span named %q was recorded", spanName)
+		return
+	}
+
+	var lastMismatch string
+	for _, span := range spans {
+		actual := make(map[string]string, len(span.Attributes))
+		for _, attr := range span.Attributes {
+			actual[string(attr.Key)] = attr.Value.Emit()
+		}
+
+		lastMismatch = ""
+		for key, want := range expected {
+			got, ok := actual[key]
+			if !ok {
+				lastMismatch = "missing attribute " + key
+				break
+			}
+			if got != want {
+				lastMismatch = "attribute " + key + " is " + got + ", want " + want
+				break
+			}
+		}
+		if lastMismatch == "" {
+			return
+		}
+	}
+
+	t.Errorf("span %q does not have the expected attributes: %s", spanName, lastMismatch)
+}
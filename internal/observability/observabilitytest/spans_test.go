@@ -0,0 +1,24 @@
+package observabilitytest
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAssertSpanHasAttributes(t *testing.T) {
+	traceManager, exporter := NewInMemoryTraceManager(t)
+
+	_, span := traceManager.StartSpan(context.Background(), "agent.handle_task")
+	traceManager.AddA2ATaskAttributes(span, "task-1", "echo", "ctx-1", 2, 0)
+	span.End()
+
+	AssertSpanHasAttributes(t, exporter, "agent.handle_task", map[string]string{
+		"a2a.task.id":            "task-1",
+		"a2a.task.context_id":    "ctx-1",
+		"a2a.task.history_count": "2",
+	})
+
+	if spans := FindSpans(exporter, "missing"); len(spans) != 0 {
+		t.Errorf("Expected no spans named missing, got %d", len(spans))
+	}
+}
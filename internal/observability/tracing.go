@@ -20,6 +20,13 @@ func NewTraceManager(serviceName string) *TraceManager {
 	}
 }
 
+// NewTraceManagerWithTracer creates a TraceManager using the given tracer instead of the global provider
+func NewTraceManagerWithTracer(tracer trace.Tracer) *TraceManager {
+	return &TraceManager{
+		tracer: tracer,
+	}
+}
+
 func (tm *TraceManager) StartSpan(ctx context.Context, operationName string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
 	return tm.tracer.Start(ctx, operationName, trace.WithAttributes(attrs...))
 }
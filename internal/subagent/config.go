@@ -1,5 +1,12 @@
 package subagent
 
+import (
+	"time"
+
+	"github.com/owulveryck/agenthub/internal/agenthub"
+	"github.com/owulveryck/agenthub/internal/observability"
+)
+
 // Config holds the configuration for a SubAgent
 type Config struct {
 	// AgentID is the unique identifier for this agent
@@ -17,7 +24,16 @@ type Config struct {
 	// Version is the agent version (optional, defaults to "1.0.0")
 	Version string
 
-	// HealthPort is the port for the health check server (optional, defaults to "8080")
+	// URL is the address where the agent can be reached directly, advertised in its
+	// AgentCard for direct connections and discovery UIs (optional)
+	URL string
+
+	// PreferredTransport is the transport advertised with URL, such as "GRPC" or
+	// "JSONRPC" (optional, defaults to DefaultTransport when URL is set)
+	PreferredTransport string
+
+	// HealthPort is the port for the health check server (optional, defaults to
+	// <SERVICENAME>_HEALTH_PORT or the port of a bundled service, see config.HealthPort)
 	HealthPort string
 
 	// BrokerAddr is the address of the broker (optional, uses env AGENTHUB_BROKER_ADDR)
@@ -25,8 +41,31 @@ type Config struct {
 
 	// BrokerPort is the gRPC port of the broker (optional, uses env AGENTHUB_GRPC_PORT)
 	BrokerPort string
+
+	// HandlerTimeout bounds the execution time of every skill handler (optional, 0 means no timeout).
+	// A skill-specific timeout set with WithTimeout or SetSkillTimeout takes precedence.
+	HandlerTimeout time.Duration
+
+	// ShutdownTimeout bounds how long shutdown waits for in-flight tasks to complete
+	// before abandoning them (optional, defaults to DefaultShutdownTimeout)
+	ShutdownTimeout time.Duration
+
+	// Observability is used instead of setting up exporters (optional), e.g.
+	// observability.NewNoopObservability() in tests
+	Observability *observability.Observability
+
+	// Client is used instead of connecting to the broker (optional), e.g. the client
+	// of an agenthub.InProcessBroker in tests. It must be connected already, and is
+	// left open when the agent stops.
+	Client *agenthub.AgentHubClient
 }
 
+// DefaultShutdownTimeout is the default time given to in-flight tasks on shutdown
+const DefaultShutdownTimeout = 30 * time.Second
+
+// DefaultTransport is the transport advertised for an agent URL when none is configured
+const DefaultTransport = "GRPC"
+
 // WithDefaults returns a new Config with default values applied for optional fields
 func (c *Config) WithDefaults() *Config {
 	config := *c
@@ -39,8 +78,12 @@ func (c *Config) WithDefaults() *Config {
 		config.Version = "1.0.0"
 	}
 
-	if config.HealthPort == "" {
-		config.HealthPort = "8080"
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
+	if config.URL != "" && config.PreferredTransport == "" {
+		config.PreferredTransport = DefaultTransport
 	}
 
 	return &config
@@ -0,0 +1,39 @@
+package subagent
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// HandlerMiddleware decorates a skill handler, e.g. to add authorization checks or custom metrics
+type HandlerMiddleware func(TaskHandler) TaskHandler
+
+// Use registers middleware applied to every skill handler. Middleware runs in
+// registration order, the first registered being the outermost, directly around
+// the skill handler and inside the built-in timeout, validation, rate limiting
+// and observability wrappers. It must be called before Run.
+func (s *SubAgent) Use(middleware ...HandlerMiddleware) {
+	s.middleware = append(s.middleware, middleware...)
+}
+
+// applyMiddleware wraps handler with the registered middleware
+func (s *SubAgent) applyMiddleware(handler TaskHandler) TaskHandler {
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		handler = s.middleware[i](handler)
+	}
+	return handler
+}
+
+// RecoverMiddleware converts a handler panic into a FAILED task carrying the panic message
+func RecoverMiddleware(next TaskHandler) TaskHandler {
+	return func(ctx context.Context, task *pb.Task, message *pb.Message) (artifact *pb.Artifact, state pb.TaskState, errorMsg string) {
+		defer func() {
+			if r := recover(); r != nil {
+				artifact, state, errorMsg = nil, pb.TaskState_TASK_STATE_FAILED, fmt.Sprintf("handler panicked: %v", r)
+			}
+		}()
+		return next(ctx, task, message)
+	}
+}
@@ -0,0 +1,88 @@
+package subagent
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
+)
+
+// dataMessage returns a task message carrying a single data part
+func dataMessage(t *testing.T, data map[string]any) *pb.Message {
+	t.Helper()
+	fields, err := structpb.NewStruct(data)
+	if err != nil {
+		t.Fatalf("NewStruct failed: %v", err)
+	}
+	return &pb.Message{Content: []*pb.Part{{Part: &pb.Part_Data{Data: &pb.DataPart{Data: fields}}}}}
+}
+
+func TestSubAgent_WrapSkillHandler(t *testing.T) {
+	agent := newTestSubAgent(t)
+	agent.client = newTestBroker(t).Client
+
+	var reached atomic.Int32
+	agent.Use(func(next TaskHandler) TaskHandler {
+		return func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+			reached.Add(1)
+			return next(ctx, task, message)
+		}
+	})
+	handler := func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		if agenthub.ExtractParts(message).Data[0].GetFields()["n"].GetNumberValue() < 0 {
+			<-ctx.Done()
+		}
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	}
+	if err := agent.AddSkill("count", "Counts", handler,
+		WithTimeout(50*time.Millisecond),
+		WithExpectedParts(agenthub.ExactlyParts(agenthub.PartKindData, 1)),
+		WithRateLimit(0.001, 4),
+	); err != nil {
+		t.Fatalf("AddSkill failed: %v", err)
+	}
+	if err := agent.SetSkillInputSchema("count", `{"type": "object", "required": ["n"]}`); err != nil {
+		t.Fatalf("SetSkillInputSchema failed: %v", err)
+	}
+	wrapped := agent.wrapSkillHandler("count", agent.skills["count"])
+
+	// The limits are checked first, so that every task, even invalid, consumes a token
+	tests := []struct {
+		name        string
+		message     *pb.Message
+		wantState   pb.TaskState
+		wantError   string
+		wantReached int32
+	}{
+		{"parts", &pb.Message{Content: []*pb.Part{{Part: &pb.Part_Text{Text: "1"}}}}, pb.TaskState_TASK_STATE_FAILED, "message parts do not match", 0},
+		{"schema", dataMessage(t, map[string]any{"m": 1}), pb.TaskState_TASK_STATE_FAILED, "missing property 'n'", 0},
+		{"timeout", dataMessage(t, map[string]any{"n": -1}), pb.TaskState_TASK_STATE_FAILED, "handler timed out", 1},
+		{"valid", dataMessage(t, map[string]any{"n": 1}), pb.TaskState_TASK_STATE_COMPLETED, "", 2},
+		{"throttled", dataMessage(t, map[string]any{"n": 1}), pb.TaskState_TASK_STATE_FAILED, "rate limit", 2},
+	}
+	for _, tt := range tests {
+		_, state, errorMsg := wrapped(context.Background(), &pb.Task{Id: "task_" + tt.name}, tt.message)
+		if state != tt.wantState || !strings.Contains(errorMsg, tt.wantError) {
+			t.Errorf("%s: expected %s with %q, got %s with %q", tt.name, tt.wantState, tt.wantError, state, errorMsg)
+		}
+		if got := reached.Load(); got != tt.wantReached {
+			t.Errorf("%s: expected the middleware to be reached %d times, got %d", tt.name, tt.wantReached, got)
+		}
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	handler := RecoverMiddleware(func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		panic("boom")
+	})
+	_, state, errorMsg := handler(context.Background(), &pb.Task{}, &pb.Message{})
+	if state != pb.TaskState_TASK_STATE_FAILED || errorMsg != "handler panicked: boom" {
+		t.Errorf("Expected a failed task reporting the panic, got %s with %q", state, errorMsg)
+	}
+}
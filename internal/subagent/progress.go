@@ -0,0 +1,47 @@
+package subagent
+
+import (
+	"context"
+
+	"github.com/owulveryck/agenthub/internal/agenthub"
+)
+
+// progressKey is the context key of the Progress of a task handler
+type progressKey struct{}
+
+// Progress reports the progress of the task a skill handler is working on.
+// Requesters subscribed to task events receive each report as a non-final
+// TASK_STATE_WORKING status update.
+type Progress struct {
+	ctx    context.Context
+	client *agenthub.AgentHubClient
+	taskID string
+}
+
+// ProgressFromContext returns the progress reporter of the task handled with ctx.
+// Handlers not run by a SubAgent get a nil Progress, whose reports are dropped.
+func ProgressFromContext(ctx context.Context) *Progress {
+	progress, _ := ctx.Value(progressKey{}).(*Progress)
+	return progress
+}
+
+// contextWithProgress returns a copy of ctx carrying the progress reporter of a task
+func contextWithProgress(ctx context.Context, client *agenthub.AgentHubClient, taskID string) context.Context {
+	return context.WithValue(ctx, progressKey{}, &Progress{ctx: ctx, client: client, taskID: taskID})
+}
+
+// Report publishes the completion percentage (clamped to 0-100) of the task
+// with a human-readable message. Failing to report does not affect the task.
+func (p *Progress) Report(percent int, message string) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.client.ReportProgress(p.ctx, p.taskID, percent, message); err != nil {
+		p.client.Logger.WarnContext(p.ctx, "Failed to report task progress",
+			"task_id", p.taskID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package subagent
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
+)
+
+func TestProgress_Report(t *testing.T) {
+	broker := newTestBroker(t)
+	agent := newTestSubAgent(t)
+	agent.client = broker.Client
+
+	ctx := context.Background()
+	task, err := agenthub.NewA2ATaskPublisher(broker.Client, "test", "requester", nil).PublishTask(ctx, &agenthub.A2APublishTaskRequest{
+		TaskType:         "report",
+		Content:          []*pb.Part{{Part: &pb.Part_Text{Text: "work"}}},
+		RequesterAgentID: "requester",
+		ResponderAgentID: "agent_test",
+	})
+	if err != nil {
+		t.Fatalf("PublishTask failed: %v", err)
+	}
+
+	agent.MustAddSkill("report", "Reports progress", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		if err := ProgressFromContext(ctx).Report(150, "almost there"); err != nil {
+			return nil, pb.TaskState_TASK_STATE_FAILED, err.Error()
+		}
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	})
+	if _, state, errorMsg := agent.wrapSkillHandler("report", agent.skills["report"])(ctx, task, &pb.Message{}); state != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Fatalf("Expected the handler to complete, got %s with %q", state, errorMsg)
+	}
+
+	got, err := broker.Client.Client.GetTask(ctx, &pb.GetTaskRequest{TaskId: task.GetId()})
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	fields := got.GetStatus().GetUpdate().GetMetadata().GetFields()
+	if got.GetStatus().GetState() != pb.TaskState_TASK_STATE_WORKING || fields["progress_percent"].GetNumberValue() != 100 {
+		t.Errorf("Expected a WORKING status at 100%%, got %v", got.GetStatus())
+	}
+	if fields["progress_message"].GetStringValue() != "almost there" {
+		t.Errorf("Expected the progress message, got %v", fields["progress_message"])
+	}
+
+	// Handlers not run by a SubAgent report nowhere
+	if err := ProgressFromContext(ctx).Report(50, "ignored"); err != nil {
+		t.Errorf("Expected a nil Progress to drop reports, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package subagent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+// SkillOption configures a skill at registration time
+type SkillOption func(*Skill)
+
+// WithRateLimit limits a skill to perSecond task invocations, allowing bursts of up to burst tasks.
+// Tasks over the limit are failed as throttled without invoking the handler.
+func WithRateLimit(perSecond float64, burst int) SkillOption {
+	return func(skill *Skill) {
+		skill.RateLimit = perSecond
+		skill.RateBurst = burst
+	}
+}
+
+// WithMaxConcurrency caps the number of tasks a skill handles at the same time.
+// Tasks arriving while the cap is reached are failed as throttled without invoking the handler.
+func WithMaxConcurrency(n int) SkillOption {
+	return func(skill *Skill) {
+		skill.MaxConcurrency = n
+	}
+}
+
+// tokenBucket is a minimal token-bucket rate limiter
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// newTokenBucket creates a full bucket refilled at rate tokens per second
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:     rate,
+		capacity: float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// allow consumes a token if one is available
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// wrapHandlerWithLimits enforces the rate limit and concurrency cap of a skill before invoking the handler
+func (s *SubAgent) wrapHandlerWithLimits(skill *Skill, handler TaskHandler) TaskHandler {
+	var bucket *tokenBucket
+	if skill.RateLimit > 0 {
+		bucket = newTokenBucket(skill.RateLimit, skill.RateBurst)
+	}
+	var slots chan struct{}
+	if skill.MaxConcurrency > 0 {
+		slots = make(chan struct{}, skill.MaxConcurrency)
+	}
+
+	throttle := func(ctx context.Context, task *pb.Task, reason string) (*pb.Artifact, pb.TaskState, string) {
+		s.client.MetricsManager.IncrementEventErrors(ctx, "a2a_task", s.config.AgentID, "throttled")
+		s.client.Logger.WarnContext(ctx, "Task throttled",
+			"task_id", task.GetId(),
+			"skill", skill.Name,
+			"reason", reason,
+		)
+		return nil, pb.TaskState_TASK_STATE_FAILED, fmt.Sprintf("throttled: %s", reason)
+	}
+
+	return func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		if bucket != nil && !bucket.allow() {
+			return throttle(ctx, task, fmt.Sprintf("skill %s rate limit of %g/s exceeded", skill.Name, skill.RateLimit))
+		}
+
+		if slots != nil {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			default:
+				return throttle(ctx, task, fmt.Sprintf("skill %s is at its concurrency limit of %d", skill.Name, skill.MaxConcurrency))
+			}
+		}
+
+		return handler(ctx, task, message)
+	}
+}
@@ -0,0 +1,68 @@
+package subagent
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+)
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(10, 2)
+	if !bucket.allow() || !bucket.allow() {
+		t.Fatal("Expected the burst to be allowed")
+	}
+	if bucket.allow() {
+		t.Fatal("Expected the bucket to be empty after the burst")
+	}
+
+	// A token is refilled every 100ms
+	bucket.last = bucket.last.Add(-150 * time.Millisecond)
+	if !bucket.allow() {
+		t.Error("Expected a refilled token to be allowed")
+	}
+	if bucket.allow() {
+		t.Error("Expected a single token to be refilled")
+	}
+}
+
+func TestSubAgent_MaxConcurrency(t *testing.T) {
+	agent := newTestSubAgent(t)
+	agent.client = newTestBroker(t).Client
+
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		started <- struct{}{}
+		<-release
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	}
+	if err := agent.AddSkill("slow", "Takes its time", handler, WithMaxConcurrency(1)); err != nil {
+		t.Fatalf("AddSkill failed: %v", err)
+	}
+	wrapped := agent.wrapSkillHandler("slow", agent.skills["slow"])
+
+	done := make(chan pb.TaskState, 1)
+	go func() {
+		_, state, _ := wrapped(context.Background(), &pb.Task{Id: "task_1"}, &pb.Message{})
+		done <- state
+	}()
+	<-started
+
+	_, state, errorMsg := wrapped(context.Background(), &pb.Task{Id: "task_2"}, &pb.Message{})
+	if state != pb.TaskState_TASK_STATE_FAILED || !strings.Contains(errorMsg, "concurrency limit of 1") {
+		t.Errorf("Expected the second task to be throttled, got %s with %q", state, errorMsg)
+	}
+
+	close(release)
+	if state := <-done; state != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Errorf("Expected the first task to complete, got %s", state)
+	}
+
+	// The slot is freed once the handler returns
+	go func() { <-started }()
+	if _, state, _ := wrapped(context.Background(), &pb.Task{Id: "task_3"}, &pb.Message{}); state != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Errorf("Expected a task after the first one to run, got %s", state)
+	}
+}
@@ -2,15 +2,18 @@ package subagent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"time"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
 	"github.com/owulveryck/agenthub/internal/agenthub"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // SubAgent encapsulates the common functionality for building agents
@@ -19,6 +22,7 @@ type SubAgent struct {
 	client         *agenthub.AgentHubClient
 	taskSubscriber *agenthub.A2ATaskSubscriber
 	skills         map[string]*Skill
+	middleware     []HandlerMiddleware
 	agentCard      *pb.AgentCard
 	running        bool
 }
@@ -37,28 +41,85 @@ func New(config *Config) (*SubAgent, error) {
 	}, nil
 }
 
-// AddSkill registers a new skill with the agent
-func (s *SubAgent) AddSkill(name, description string, handler TaskHandler) error {
+// AddSkill registers a new skill with the agent, accepting and producing text/plain
+func (s *SubAgent) AddSkill(name, description string, handler TaskHandler, opts ...SkillOption) error {
+	return s.AddSkillWithModes(name, description, nil, nil, handler, opts...)
+}
+
+// AddSkillWithModes registers a new skill with the media types it accepts and produces
+// (e.g. "application/json"). Empty modes default to DefaultSkillModes.
+func (s *SubAgent) AddSkillWithModes(name, description string, inputModes, outputModes []string, handler TaskHandler, opts ...SkillOption) error {
 	if _, exists := s.skills[name]; exists {
 		return fmt.Errorf("%w: %s", ErrDuplicateSkill, name)
 	}
 
-	s.skills[name] = &Skill{
+	if len(inputModes) == 0 {
+		inputModes = DefaultSkillModes
+	}
+	if len(outputModes) == 0 {
+		outputModes = DefaultSkillModes
+	}
+
+	skill := &Skill{
 		Name:        name,
 		Description: description,
 		Handler:     handler,
+		InputModes:  append([]string(nil), inputModes...),
+		OutputModes: append([]string(nil), outputModes...),
+	}
+	for _, opt := range opts {
+		opt(skill)
 	}
+	s.skills[name] = skill
 
 	return nil
 }
 
 // MustAddSkill is like AddSkill but panics on error (for cleaner initialization code)
-func (s *SubAgent) MustAddSkill(name, description string, handler TaskHandler) {
-	if err := s.AddSkill(name, description, handler); err != nil {
+func (s *SubAgent) MustAddSkill(name, description string, handler TaskHandler, opts ...SkillOption) {
+	if err := s.AddSkill(name, description, handler, opts...); err != nil {
 		panic(err)
 	}
 }
 
+// SetSkillTimeout sets a handler timeout for a single skill, overriding Config.HandlerTimeout
+func (s *SubAgent) SetSkillTimeout(name string, timeout time.Duration) error {
+	skill, exists := s.skills[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownSkill, name)
+	}
+
+	skill.Timeout = timeout
+	return nil
+}
+
+// WithTimeout sets the handler timeout of a skill at registration, overriding Config.HandlerTimeout.
+// Handlers still running when it fires see their context cancelled and the task is failed.
+func WithTimeout(timeout time.Duration) SkillOption {
+	return func(skill *Skill) {
+		skill.Timeout = timeout
+	}
+}
+
+// SetSkillInputSchema declares a JSON Schema that the DataPart payloads of incoming
+// tasks must satisfy. Invalid tasks are failed with the list of violations before
+// the handler runs.
+func (s *SubAgent) SetSkillInputSchema(name, schemaJSON string) error {
+	skill, exists := s.skills[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownSkill, name)
+	}
+
+	schema, err := agenthub.CompileInputSchema(name, schemaJSON)
+	if err != nil {
+		return err
+	}
+
+	skill.InputSchema = schemaJSON
+	skill.inputSchema = schema
+	return nil
+}
+
 // Run starts the agent and blocks until the context is cancelled
 // It handles the full lifecycle: setup, registration, subscription, and graceful shutdown
 func (s *SubAgent) Run(ctx context.Context) error {
@@ -74,8 +135,13 @@ func (s *SubAgent) Run(ctx context.Context) error {
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	// Tasks run under their own context so that they can complete after the
+	// shutdown signal; it is cancelled only when they are abandoned
+	taskCtx, abandonTasks := context.WithCancel(context.WithoutCancel(ctx))
+	defer abandonTasks()
+
 	// Initialize the agent
-	if err := s.initialize(ctx); err != nil {
+	if err := s.initialize(ctx, taskCtx); err != nil {
 		return fmt.Errorf("failed to initialize agent: %w", err)
 	}
 
@@ -84,8 +150,11 @@ func (s *SubAgent) Run(ctx context.Context) error {
 		s.running = false
 	}()
 
-	// Ensure cleanup happens
+	// Ensure cleanup happens, leaving an injected client to its owner
 	defer func() {
+		if s.config.Client != nil {
+			return
+		}
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer shutdownCancel()
 		if err := s.client.Shutdown(shutdownCtx); err != nil {
@@ -93,6 +162,9 @@ func (s *SubAgent) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Report liveness until shutdown
+	go s.sendHeartbeats(ctx)
+
 	s.client.Logger.InfoContext(ctx, "Agent started successfully",
 		"agent_id", s.config.AgentID,
 		"name", s.config.Name,
@@ -106,14 +178,124 @@ func (s *SubAgent) Run(ctx context.Context) error {
 		"agent_id", s.config.AgentID,
 	)
 
+	// Stop receiving new work, then let in-flight tasks finish
+	s.deregister()
+	s.drainTasks(abandonTasks)
+
 	return nil
 }
 
+// sendHeartbeats reports liveness to the broker every HeartbeatInterval until ctx is done.
+// An agent the broker no longer knows, e.g. after a broker restart, registers again.
+func (s *SubAgent) sendHeartbeats(ctx context.Context) {
+	interval := s.client.Config.HeartbeatInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		resp, err := s.client.Client.Heartbeat(ctx, &pb.HeartbeatRequest{AgentId: s.config.AgentID})
+		if err != nil {
+			s.client.Logger.WarnContext(ctx, "Failed to send heartbeat",
+				"agent_id", s.config.AgentID,
+				"error", err,
+			)
+			continue
+		}
+		if resp.GetSuccess() {
+			continue
+		}
+
+		s.client.Logger.WarnContext(ctx, "Broker no longer knows the agent, registering again",
+			"agent_id", s.config.AgentID,
+			"error", resp.GetError(),
+		)
+		if _, err := s.client.Client.RegisterAgent(ctx, &pb.RegisterAgentRequest{AgentCard: s.agentCard}); err != nil {
+			s.client.Logger.WarnContext(ctx, "Failed to register agent again",
+				"agent_id", s.config.AgentID,
+				"error", err,
+			)
+		}
+	}
+}
+
+// deregister removes the agent card from the broker so that no new tasks are routed here
+func (s *SubAgent) deregister() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.client.Client.DeregisterAgent(ctx, &pb.DeregisterAgentRequest{
+		AgentId: s.config.AgentID,
+	})
+	if err == nil && !resp.GetSuccess() {
+		err = errors.New(resp.GetError())
+	}
+	if err != nil {
+		s.client.Logger.WarnContext(ctx, "Failed to deregister agent",
+			"agent_id", s.config.AgentID,
+			"error", err,
+		)
+		return
+	}
+
+	s.client.Logger.InfoContext(ctx, "Agent deregistered",
+		"agent_id", s.config.AgentID,
+	)
+}
+
+// drainTasks waits up to ShutdownTimeout for in-flight tasks, then abandons the rest
+func (s *SubAgent) drainTasks(abandonTasks context.CancelFunc) {
+	inFlight := s.taskSubscriber.InFlight()
+	if inFlight == 0 {
+		return
+	}
+
+	s.client.Logger.Info("Waiting for in-flight tasks",
+		"agent_id", s.config.AgentID,
+		"in_flight", inFlight,
+		"timeout", s.config.ShutdownTimeout,
+	)
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+	abandoned := s.taskSubscriber.Drain(drainCtx)
+	abandonTasks()
+
+	logLevel := slog.LevelInfo
+	if abandoned > 0 {
+		logLevel = slog.LevelWarn
+	}
+	s.client.Logger.Log(context.Background(), logLevel, "In-flight tasks drained",
+		"agent_id", s.config.AgentID,
+		"completed", max(inFlight-abandoned, 0),
+		"abandoned", abandoned,
+	)
+}
+
 // initialize sets up the AgentHub client, registers the agent card, and starts task subscription
-func (s *SubAgent) initialize(ctx context.Context) error {
+// The subscription stops with ctx, while its tasks are processed under taskCtx.
+func (s *SubAgent) initialize(ctx, taskCtx context.Context) error {
+	if s.config.Client != nil {
+		s.client = s.config.Client
+		s.client.HealthServer.AddChecker("registration", agenthub.NewRegistrationHealthChecker(s.client, s.config.AgentID))
+		return s.register(ctx, taskCtx)
+	}
+
 	// Create gRPC configuration using ServiceName (defaults to AgentID)
 	grpcConfig := agenthub.NewGRPCConfig(s.config.ServiceName)
-	grpcConfig.HealthPort = s.config.HealthPort
+	if s.config.HealthPort != "" {
+		grpcConfig.HealthPort = s.config.HealthPort
+	}
+	grpcConfig.Observability = s.config.Observability
 
 	if s.config.BrokerAddr != "" {
 		if err := os.Setenv("AGENTHUB_BROKER_ADDR", s.config.BrokerAddr); err != nil {
@@ -134,53 +316,76 @@ func (s *SubAgent) initialize(ctx context.Context) error {
 	}
 	s.client = client
 
+	// Report unhealthy whenever the broker does not know this agent, including
+	// until its card is registered below
+	s.client.HealthServer.AddChecker("registration", agenthub.NewRegistrationHealthChecker(s.client, s.config.AgentID))
+
 	// Start the client
 	if err := client.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start client: %w", err)
 	}
 
+	return s.register(ctx, taskCtx)
+}
+
+// register publishes the agent card and subscribes to the tasks of the skills
+func (s *SubAgent) register(ctx, taskCtx context.Context) error {
 	// Build and register agent card
 	if err := s.buildAndRegisterAgentCard(ctx); err != nil {
 		return fmt.Errorf("failed to register agent card: %w", err)
 	}
 
 	// Setup task subscription with handlers
-	if err := s.setupTaskSubscription(ctx); err != nil {
+	if err := s.setupTaskSubscription(ctx, taskCtx); err != nil {
 		return fmt.Errorf("failed to setup task subscription: %w", err)
 	}
 
 	return nil
 }
 
-// buildAndRegisterAgentCard creates the agent card from registered skills and publishes it
-func (s *SubAgent) buildAndRegisterAgentCard(ctx context.Context) error {
-	// Build skills for agent card
-	cardSkills := make([]*pb.AgentSkill, 0, len(s.skills))
-	skillIndex := 0
-	for skillName, skill := range s.skills {
+// buildAgentCard creates the agent card from registered skills. Each skill is
+// identified by its name, and skills are listed in name order, so that the card
+// is the same on every registration.
+func (s *SubAgent) buildAgentCard() *pb.AgentCard {
+	skillNames := make([]string, 0, len(s.skills))
+	for skillName := range s.skills {
+		skillNames = append(skillNames, skillName)
+	}
+	sort.Strings(skillNames)
+
+	cardSkills := make([]*pb.AgentSkill, 0, len(skillNames))
+	for _, skillName := range skillNames {
+		skill := s.skills[skillName]
 		cardSkills = append(cardSkills, &pb.AgentSkill{
-			Id:          fmt.Sprintf("skill_%d", skillIndex),
+			Id:          skillName,
 			Name:        skill.Name,
 			Description: skill.Description,
 			Tags:        []string{skillName}, // Use skill name as tag for routing
-			InputModes:  []string{"text/plain"},
-			OutputModes: []string{"text/plain"},
+			InputModes:  skill.InputModes,
+			OutputModes: skill.OutputModes,
+			InputSchema: skill.InputSchema,
 		})
-		skillIndex++
 	}
 
 	// Create agent card with required A2A fields
-	s.agentCard = &pb.AgentCard{
-		ProtocolVersion: "0.2.9",
-		Name:            s.config.AgentID,
-		Description:     s.config.Description,
-		Version:         s.config.Version,
-		Skills:          cardSkills,
+	return &pb.AgentCard{
+		ProtocolVersion:    "0.2.9",
+		Name:               s.config.AgentID,
+		Description:        s.config.Description,
+		Url:                s.config.URL,
+		PreferredTransport: s.config.PreferredTransport,
+		Version:            s.config.Version,
+		Skills:             cardSkills,
 		Capabilities: &pb.AgentCapabilities{
 			Streaming:         false,
 			PushNotifications: false,
 		},
 	}
+}
+
+// buildAndRegisterAgentCard creates the agent card from registered skills and publishes it
+func (s *SubAgent) buildAndRegisterAgentCard(ctx context.Context) error {
+	s.agentCard = s.buildAgentCard()
 
 	// Register agent card with broker
 	_, err := s.client.Client.RegisterAgent(ctx, &pb.RegisterAgentRequest{
@@ -194,31 +399,24 @@ func (s *SubAgent) buildAndRegisterAgentCard(ctx context.Context) error {
 	s.client.Logger.InfoContext(ctx, "Agent card registered",
 		"agent_id", s.config.AgentID,
 		"name", s.config.Name,
-		"skills", len(cardSkills),
+		"skills", len(s.agentCard.GetSkills()),
 	)
 
 	return nil
 }
 
 // setupTaskSubscription creates the task subscriber and registers all skill handlers
-func (s *SubAgent) setupTaskSubscription(ctx context.Context) error {
-	// Create task subscriber
+func (s *SubAgent) setupTaskSubscription(ctx, taskCtx context.Context) error {
+	// Create task subscriber, processing tasks beyond the subscription lifetime
 	s.taskSubscriber = agenthub.NewA2ATaskSubscriber(s.client, s.config.AgentID)
+	s.taskSubscriber.TaskContext = taskCtx
 
 	// Register handlers for each skill
 	for skillName, skill := range s.skills {
-		// Capture variables for closure
-		handlerName := skillName
-		handlerFunc := skill.Handler
-
-		// Wrap the handler with observability
-		wrappedHandler := s.wrapHandlerWithObservability(handlerName, handlerFunc)
-
-		// Register with task subscriber
-		s.taskSubscriber.RegisterTaskHandler(handlerName, wrappedHandler)
+		s.taskSubscriber.RegisterTaskHandler(skillName, s.wrapSkillHandler(skillName, skill))
 
 		s.client.Logger.DebugContext(ctx, "Registered task handler",
-			"skill", handlerName,
+			"skill", skillName,
 		)
 	}
 
@@ -239,6 +437,40 @@ func (s *SubAgent) setupTaskSubscription(ctx context.Context) error {
 	return nil
 }
 
+// wrapSkillHandler wraps the handler of a skill with its middleware, then with the
+// timeout, input schema, part validation, limits and observability wrappers, the
+// last being the outermost
+func (s *SubAgent) wrapSkillHandler(skillName string, skill *Skill) agenthub.A2ATaskHandler {
+	handlerFunc := s.applyMiddleware(skill.Handler)
+
+	// Enforce the handler timeout, if any
+	timeout := skill.Timeout
+	if timeout == 0 {
+		timeout = s.config.HandlerTimeout
+	}
+	if timeout > 0 {
+		handlerFunc = s.wrapHandlerWithTimeout(skillName, timeout, handlerFunc)
+	}
+
+	// Validate the input against the skill schema, if any
+	if skill.inputSchema != nil {
+		handlerFunc = s.wrapHandlerWithInputValidation(skillName, skill.inputSchema, handlerFunc)
+	}
+
+	// Check the message parts against the skill expectations, if any
+	if len(skill.ExpectedParts) > 0 {
+		handlerFunc = s.wrapHandlerWithPartValidation(skillName, skill.ExpectedParts, handlerFunc)
+	}
+
+	// Enforce the skill rate limit and concurrency cap, if any
+	if skill.RateLimit > 0 || skill.MaxConcurrency > 0 {
+		handlerFunc = s.wrapHandlerWithLimits(skill, handlerFunc)
+	}
+
+	// Wrap the handler with observability
+	return s.wrapHandlerWithObservability(skillName, handlerFunc)
+}
+
 // wrapHandlerWithObservability wraps a task handler with automatic tracing and logging
 func (s *SubAgent) wrapHandlerWithObservability(skillName string, handler TaskHandler) agenthub.A2ATaskHandler {
 	return func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
@@ -257,6 +489,9 @@ func (s *SubAgent) wrapHandlerWithObservability(skillName string, handler TaskHa
 			len(task.GetArtifacts()),
 		)
 		s.client.TraceManager.AddComponentAttribute(taskSpan, s.config.AgentID)
+		if deadline, ok := agenthub.TaskDeadline(task); ok {
+			taskSpan.SetAttributes(attribute.Int64("a2a.task.deadline_remaining_ms", time.Until(deadline).Milliseconds()))
+		}
 
 		// Log task processing start
 		s.client.Logger.InfoContext(taskCtx, "Processing task",
@@ -265,8 +500,8 @@ func (s *SubAgent) wrapHandlerWithObservability(skillName string, handler TaskHa
 			"context_id", task.GetContextId(),
 		)
 
-		// Call the actual handler
-		artifact, state, errorMsg := handler(taskCtx, task, message)
+		// Call the actual handler, which may report progress through ProgressFromContext
+		artifact, state, errorMsg := handler(contextWithProgress(taskCtx, s.client, task.GetId()), task, message)
 
 		// Record results in trace
 		if state == pb.TaskState_TASK_STATE_COMPLETED {
@@ -293,6 +528,53 @@ func (s *SubAgent) wrapHandlerWithObservability(skillName string, handler TaskHa
 	}
 }
 
+// wrapHandlerWithTimeout cancels the handler context after timeout and reports the task as failed
+// if the handler has not returned by then
+func (s *SubAgent) wrapHandlerWithTimeout(skillName string, timeout time.Duration, handler TaskHandler) TaskHandler {
+	type handlerResult struct {
+		artifact *pb.Artifact
+		state    pb.TaskState
+		errorMsg string
+	}
+
+	return func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		handlerCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// Buffered so a late handler never blocks once we stop waiting for it
+		resultChan := make(chan handlerResult, 1)
+		go func() {
+			artifact, state, errorMsg := handler(handlerCtx, task, message)
+			resultChan <- handlerResult{artifact: artifact, state: state, errorMsg: errorMsg}
+		}()
+
+		select {
+		case result := <-resultChan:
+			return result.artifact, result.state, result.errorMsg
+		case <-handlerCtx.Done():
+			if handlerCtx.Err() != context.DeadlineExceeded {
+				return nil, pb.TaskState_TASK_STATE_FAILED, fmt.Sprintf("handler cancelled: %v", handlerCtx.Err())
+			}
+			s.client.MetricsManager.IncrementEventErrors(ctx, "a2a_task", s.config.AgentID, "handler_timeout")
+			s.client.Logger.WarnContext(ctx, "Task handler timed out",
+				"task_id", task.GetId(),
+				"skill", skillName,
+				"timeout", timeout,
+			)
+			return nil, pb.TaskState_TASK_STATE_FAILED, fmt.Sprintf("handler timed out after %s", timeout)
+		}
+	}
+}
+
+// ReportProgress publishes a progress update for a task being handled by this agent.
+// It is meant to be called from long-running skill handlers.
+func (s *SubAgent) ReportProgress(ctx context.Context, taskID string, percent int, message string) error {
+	if s.client == nil {
+		return ErrAgentNotStarted
+	}
+	return s.client.ReportProgress(ctx, taskID, percent, message)
+}
+
 // GetLogger returns the agent's logger for custom logging needs
 func (s *SubAgent) GetLogger() *slog.Logger {
 	if s.client == nil {
@@ -306,6 +588,12 @@ func (s *SubAgent) GetClient() *agenthub.AgentHubClient {
 	return s.client
 }
 
+// GetAgentCard returns the agent card built from the skills added so far, as it is
+// registered with the broker
+func (s *SubAgent) GetAgentCard() *pb.AgentCard {
+	return s.buildAgentCard()
+}
+
 // GetConfig returns the agent configuration
 func (s *SubAgent) GetConfig() *Config {
 	return s.config
@@ -0,0 +1,268 @@
+package subagent
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
+)
+
+func echoHandler(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+	return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+}
+
+// newTestBroker starts an in-process broker closed at the end of the test
+func newTestBroker(t *testing.T) *agenthub.InProcessBroker {
+	t.Helper()
+	broker, err := agenthub.NewInProcessBroker()
+	if err != nil {
+		t.Fatalf("NewInProcessBroker failed: %v", err)
+	}
+	t.Cleanup(func() { broker.Close() })
+	return broker
+}
+
+func newTestSubAgent(t *testing.T, skills ...string) *SubAgent {
+	t.Helper()
+	agent, err := New(&Config{AgentID: "agent_test", Name: "Test Agent", Description: "Agent under test"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	for _, skill := range skills {
+		if err := agent.AddSkill(skill, "Handles "+skill, echoHandler); err != nil {
+			t.Fatalf("AddSkill(%q) failed: %v", skill, err)
+		}
+	}
+	return agent
+}
+
+func TestSubAgent_BuildAgentCard_StableSkillIDs(t *testing.T) {
+	agent := newTestSubAgent(t, "translate", "summarize", "classify")
+
+	card := agent.buildAgentCard()
+	var ids []string
+	for _, skill := range card.GetSkills() {
+		if skill.GetId() != skill.GetName() {
+			t.Errorf("Expected skill %q to be identified by its name, got %q", skill.GetName(), skill.GetId())
+		}
+		ids = append(ids, skill.GetId())
+	}
+	if want := []string{"classify", "summarize", "translate"}; !slices.Equal(ids, want) {
+		t.Fatalf("Expected skills %v, got %v", want, ids)
+	}
+	if !proto.Equal(card, agent.buildAgentCard()) {
+		t.Fatal("Expected the same card on every build")
+	}
+}
+
+func TestSubAgent_BuildAgentCard_ModesAndURL(t *testing.T) {
+	agent, err := New(&Config{AgentID: "agent_test", Name: "Test Agent", Description: "Agent under test", URL: "agent.example.com:9000"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	agent.MustAddSkill("echo", "Echoes text", echoHandler)
+	if err := agent.AddSkillWithModes("render", "Renders charts", []string{"application/json"}, []string{"image/png"}, echoHandler); err != nil {
+		t.Fatalf("AddSkillWithModes failed: %v", err)
+	}
+
+	card := agent.GetAgentCard()
+	if card.GetUrl() != "agent.example.com:9000" || card.GetPreferredTransport() != DefaultTransport {
+		t.Errorf("Expected the URL advertised over %s, got %q over %q", DefaultTransport, card.GetUrl(), card.GetPreferredTransport())
+	}
+	echo, render := card.GetSkills()[0], card.GetSkills()[1]
+	if !slices.Equal(echo.GetInputModes(), DefaultSkillModes) || !slices.Equal(echo.GetOutputModes(), DefaultSkillModes) {
+		t.Errorf("Expected the default modes, got %v and %v", echo.GetInputModes(), echo.GetOutputModes())
+	}
+	if !slices.Equal(render.GetInputModes(), []string{"application/json"}) || !slices.Equal(render.GetOutputModes(), []string{"image/png"}) {
+		t.Errorf("Expected the configured modes, got %v and %v", render.GetInputModes(), render.GetOutputModes())
+	}
+}
+
+func TestSubAgent_AgentCard_DiffOnNewSkill(t *testing.T) {
+	agent := newTestSubAgent(t, "translate", "classify")
+	previous := agent.GetAgentCard()
+
+	if diff := agenthub.DiffAgentCards(previous, agent.GetAgentCard()); len(diff.GetChangedSkills()) != 0 || len(diff.GetAddedSkills()) != 0 {
+		t.Fatalf("Expected no change when rebuilding the card, got %v", diff)
+	}
+
+	// A skill sorted between the existing ones must not shift their identity
+	if err := agent.AddSkill("summarize", "Handles summarize", echoHandler); err != nil {
+		t.Fatalf("AddSkill failed: %v", err)
+	}
+	diff := agenthub.DiffAgentCards(previous, agent.GetAgentCard())
+	if len(diff.GetAddedSkills()) != 1 || diff.GetAddedSkills()[0].GetId() != "summarize" {
+		t.Errorf("Expected summarize to be added, got %v", diff.GetAddedSkills())
+	}
+	if len(diff.GetChangedSkills()) != 0 || len(diff.GetRemovedSkills()) != 0 || len(diff.GetChangedFields()) != 0 {
+		t.Errorf("Expected only an added skill, got %v", diff)
+	}
+}
+
+func TestSubAgent_AgentCard_SkillRouting(t *testing.T) {
+	client := newTestBroker(t).Client.Client
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	agent := newTestSubAgent(t, "translate", "summarize")
+	if _, err := client.RegisterAgent(ctx, &pb.RegisterAgentRequest{AgentCard: agent.buildAgentCard()}); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+
+	stream, err := client.SubscribeToMessages(ctx, &pb.SubscribeToMessagesRequest{AgentId: agent.config.AgentID})
+	if err != nil {
+		t.Fatalf("SubscribeToMessages failed: %v", err)
+	}
+
+	// The subscription is registered asynchronously, retry until it is delivered to
+	received := make(chan *pb.AgentEvent, 1)
+	go func() {
+		if event, err := stream.Recv(); err == nil {
+			received <- event
+		}
+	}()
+	for {
+		resp, err := client.PublishMessage(ctx, &pb.PublishMessageRequest{
+			Message: &pb.Message{MessageId: "msg_summarize", Role: pb.Role_ROLE_USER},
+			Routing: &pb.AgentEventMetadata{FromAgentId: "requester", RequiredSkill: "summarize"},
+		})
+		if err != nil {
+			t.Fatalf("PublishMessage failed: %v", err)
+		}
+		if !resp.GetSuccess() {
+			t.Fatalf("Expected the summarize skill to be routed, got error %q", resp.GetError())
+		}
+		select {
+		case event := <-received:
+			if got := event.GetRouting().GetToAgentId(); got != agent.config.AgentID {
+				t.Fatalf("Expected message routed to %s, got %s", agent.config.AgentID, got)
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for the routed message")
+		}
+	}
+}
+
+// runTestSubAgent runs the agent against the broker until the returned function
+// stops it, which returns the error of Run
+func runTestSubAgent(t *testing.T, agent *SubAgent) (stop func() error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- agent.Run(ctx) }()
+	return func() error {
+		cancel()
+		select {
+		case err := <-result:
+			return err
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timed out waiting for Run to return")
+			return nil
+		}
+	}
+}
+
+// publishUntilStarted publishes tasks of the skill to the agent until a handler
+// reports it started, as the agent subscribes asynchronously
+func publishUntilStarted(t *testing.T, broker *agenthub.InProcessBroker, agentID, skill string, started <-chan string) string {
+	t.Helper()
+	publisher := agenthub.NewA2ATaskPublisher(broker.Client, "test", "requester", nil)
+	deadline := time.After(5 * time.Second)
+	for {
+		if _, err := publisher.PublishTask(context.Background(), &agenthub.A2APublishTaskRequest{
+			TaskType:         skill,
+			Content:          []*pb.Part{{Part: &pb.Part_Text{Text: "work"}}},
+			RequesterAgentID: "requester",
+			ResponderAgentID: agentID,
+		}); err != nil {
+			t.Fatalf("PublishTask failed: %v", err)
+		}
+		select {
+		case taskID := <-started:
+			return taskID
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Timed out waiting for a task to start")
+		}
+	}
+}
+
+func TestSubAgent_Run_DrainsTasksOnShutdown(t *testing.T) {
+	broker := newTestBroker(t)
+	agent, err := New(&Config{AgentID: "agent_drain", Name: "Drain", Description: "Drains on shutdown", Client: broker.Client})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	started, release := make(chan string, 10), make(chan struct{})
+	agent.MustAddSkill("slow", "Takes its time", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		started <- task.GetId()
+		<-release
+		return nil, pb.TaskState_TASK_STATE_COMPLETED, ""
+	})
+
+	stop := runTestSubAgent(t, agent)
+	taskID := publishUntilStarted(t, broker, "agent_drain", "slow", started)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- stop() }()
+	select {
+	case <-stopped:
+		t.Fatal("Expected Run to wait for the in-flight task")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-stopped; err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	task, err := broker.Client.Client.GetTask(context.Background(), &pb.GetTaskRequest{TaskId: taskID})
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if state := task.GetStatus().GetState(); state != pb.TaskState_TASK_STATE_COMPLETED {
+		t.Errorf("Expected the drained task to be completed, got %s", state)
+	}
+	if resp, err := broker.Client.Client.Heartbeat(context.Background(), &pb.HeartbeatRequest{AgentId: "agent_drain"}); err != nil || resp.GetSuccess() {
+		t.Errorf("Expected the agent to be deregistered on shutdown, got %v (%v)", resp, err)
+	}
+}
+
+func TestSubAgent_Run_AbandonsTasksAfterShutdownTimeout(t *testing.T) {
+	broker := newTestBroker(t)
+	agent, err := New(&Config{
+		AgentID:         "agent_abandon",
+		Name:            "Abandon",
+		Description:     "Abandons stuck tasks",
+		ShutdownTimeout: 100 * time.Millisecond,
+		Client:          broker.Client,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	started, cancelled := make(chan string, 10), make(chan struct{}, 10)
+	agent.MustAddSkill("stuck", "Never finishes", func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		started <- task.GetId()
+		<-ctx.Done()
+		cancelled <- struct{}{}
+		return nil, pb.TaskState_TASK_STATE_FAILED, ctx.Err().Error()
+	})
+
+	stop := runTestSubAgent(t, agent)
+	publishUntilStarted(t, broker, "agent_abandon", "stuck", started)
+	if err := stop(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the abandoned task context to be cancelled")
+	}
+}
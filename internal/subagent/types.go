@@ -3,8 +3,12 @@ package subagent
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
 
 	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
 )
 
 // TaskHandler is the function signature for handling tasks
@@ -12,11 +16,27 @@ import (
 // It returns an artifact (optional), task state, and error message (if failed)
 type TaskHandler func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string)
 
+// DefaultSkillModes are the input and output modes of skills registered without explicit modes
+var DefaultSkillModes = []string{"text/plain"}
+
 // Skill represents a capability that the agent can perform
 type Skill struct {
 	Name        string
 	Description string
 	Handler     TaskHandler
+	Timeout     time.Duration // Overrides Config.HandlerTimeout when non-zero
+	InputSchema string        // Optional JSON Schema that DataPart payloads must satisfy
+	InputModes  []string      // Media types the skill accepts, advertised in the AgentCard
+	OutputModes []string      // Media types the skill produces, advertised in the AgentCard
+
+	// ExpectedParts, when set, are the content parts a task message must carry
+	ExpectedParts []agenthub.PartRequirement
+
+	RateLimit      float64 // Maximum task invocations per second (0 means unlimited)
+	RateBurst      int     // Burst size allowed above RateLimit
+	MaxConcurrency int     // Maximum concurrent task invocations (0 means unlimited)
+
+	inputSchema *jsonschema.Schema
 }
 
 // Common errors
@@ -26,6 +46,8 @@ var (
 	ErrMissingDescription  = errors.New("agent description is required")
 	ErrNoSkills            = errors.New("at least one skill must be registered")
 	ErrDuplicateSkill      = errors.New("skill with this name already registered")
+	ErrUnknownSkill        = errors.New("skill is not registered")
+	ErrInvalidSchema       = agenthub.ErrInvalidSchema
 	ErrAgentNotStarted     = errors.New("agent has not been started")
 	ErrAgentAlreadyRunning = errors.New("agent is already running")
 )
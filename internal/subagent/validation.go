@@ -0,0 +1,52 @@
+package subagent
+
+import (
+	"context"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	pb "github.com/owulveryck/agenthub/events/a2a"
+	"github.com/owulveryck/agenthub/internal/agenthub"
+)
+
+// wrapHandlerWithInputValidation rejects tasks whose DataPart payloads do not match the skill schema
+func (s *SubAgent) wrapHandlerWithInputValidation(skillName string, schema *jsonschema.Schema, handler TaskHandler) TaskHandler {
+	return func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		if violations := agenthub.ValidateDataParts(schema, message); len(violations) > 0 {
+			s.client.MetricsManager.IncrementEventErrors(ctx, "a2a_task", s.config.AgentID, "input_validation")
+			s.client.Logger.WarnContext(ctx, "Task input failed schema validation",
+				"task_id", task.GetId(),
+				"skill", skillName,
+				"violations", violations,
+			)
+			return nil, pb.TaskState_TASK_STATE_FAILED, "input validation failed: " + strings.Join(violations, "; ")
+		}
+		return handler(ctx, task, message)
+	}
+}
+
+// WithExpectedParts declares the content parts a skill expects, e.g.
+// WithExpectedParts(agenthub.ExactlyParts(agenthub.PartKindText, 1)).
+// Tasks whose message does not match are failed before the handler runs.
+func WithExpectedParts(requirements ...agenthub.PartRequirement) SkillOption {
+	return func(skill *Skill) {
+		skill.ExpectedParts = append([]agenthub.PartRequirement(nil), requirements...)
+	}
+}
+
+// wrapHandlerWithPartValidation rejects tasks whose message parts do not match the skill expectations
+func (s *SubAgent) wrapHandlerWithPartValidation(skillName string, requirements []agenthub.PartRequirement, handler TaskHandler) TaskHandler {
+	return func(ctx context.Context, task *pb.Task, message *pb.Message) (*pb.Artifact, pb.TaskState, string) {
+		if err := agenthub.ValidateParts(message, requirements); err != nil {
+			s.client.MetricsManager.IncrementEventErrors(ctx, "a2a_task", s.config.AgentID, "part_validation")
+			s.client.Logger.WarnContext(ctx, "Task message parts rejected",
+				"task_id", task.GetId(),
+				"skill", skillName,
+				"error", err,
+			)
+			return nil, pb.TaskState_TASK_STATE_FAILED, "input validation failed: " + err.Error()
+		}
+		return handler(ctx, task, message)
+	}
+}